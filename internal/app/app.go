@@ -260,18 +260,10 @@ type ConfigServer struct {
 // Init initializes the app. This function must be called when the app starts.
 // Its name is not *init* so that the function is not executed during the tests.
 func Init() {
-	dir, err := os.UserConfigDir()
+	err := InitPaths()
 	if err != nil {
 		panic(err)
 	}
-	baseDir := filepath.Join(dir, "Recipya")
-
-	BackupPath = filepath.Join(baseDir, "Backup")
-	DBBasePath = filepath.Join(baseDir, "Database")
-	ImagesDir = filepath.Join(baseDir, "Images")
-	LogsDir = filepath.Join(baseDir, "Logs")
-	ThumbnailsDir = filepath.Join(ImagesDir, "Thumbnails")
-	VideosDir = filepath.Join(baseDir, "Videos")
 
 	xs := []string{BackupPath, DBBasePath, ImagesDir, ThumbnailsDir, LogsDir, VideosDir}
 	for _, s := range xs {
@@ -282,14 +274,7 @@ func Init() {
 	}
 
 	setup()
-
-	f, err := os.Open(filepath.Join(baseDir, "config.json"))
-	if err != nil {
-		NewConfig(nil)
-	} else {
-		NewConfig(f)
-	}
-	defer f.Close()
+	LoadConfig()
 
 	places := []string{
 		"\t- Backups: %s",
@@ -301,6 +286,37 @@ func Init() {
 	fmt.Printf("File locations:\n"+strings.Join(places, "\n"), BackupPath, DBBasePath, ImagesDir, LogsDir, VideosDir)
 }
 
+// LoadConfig initializes the global Config from the configuration file, or from the environment
+// variables when there is none. Unlike Init, the user is never prompted to create the file.
+func LoadConfig() {
+	f, err := os.Open(filepath.Join(filepath.Dir(DBBasePath), "config.json"))
+	if err != nil {
+		NewConfig(nil)
+		return
+	}
+	defer f.Close()
+
+	NewConfig(f)
+}
+
+// InitPaths sets the directories where the application stores its data. Unlike Init,
+// the directories are neither created nor is the application set up.
+func InitPaths() error {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return err
+	}
+	baseDir := filepath.Join(dir, "Recipya")
+
+	BackupPath = filepath.Join(baseDir, "Backup")
+	DBBasePath = filepath.Join(baseDir, "Database")
+	ImagesDir = filepath.Join(baseDir, "Images")
+	LogsDir = filepath.Join(baseDir, "Logs")
+	ThumbnailsDir = filepath.Join(ImagesDir, "Thumbnails")
+	VideosDir = filepath.Join(baseDir, "Videos")
+	return nil
+}
+
 // NewConfig initializes the global Config. It can either be populated from environment variables or the configuration file.
 func NewConfig(r io.Reader) {
 	if r == nil {
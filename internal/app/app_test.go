@@ -1,11 +1,15 @@
 package app_test
 
 import (
+	"bufio"
 	"bytes"
+	"database/sql"
 	"encoding/json"
 	"io"
+	"net"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
@@ -216,3 +220,208 @@ func TestAzureDI_PrepareRequest(t *testing.T) {
 		}
 	}
 }
+
+func TestDiagnose(t *testing.T) {
+	dir := t.TempDir()
+	app.BackupPath = filepath.Join(dir, "Backup")
+	app.DBBasePath = filepath.Join(dir, "Database")
+	app.ImagesDir = filepath.Join(dir, "Images")
+	app.VideosDir = filepath.Join(dir, "Videos")
+	for _, d := range []string{app.BackupPath, app.DBBasePath, app.ImagesDir} {
+		err := os.MkdirAll(d, os.ModePerm)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	find := func(diagnostics []app.Diagnostic, name string) app.Diagnostic {
+		t.Helper()
+		for _, d := range diagnostics {
+			if d.Name == name {
+				return d
+			}
+		}
+		t.Fatalf("no diagnostic named %q", name)
+		return app.Diagnostic{}
+	}
+
+	writeFDC := func(t *testing.T, inserts ...string) {
+		t.Helper()
+		path := filepath.Join(app.DBBasePath, app.FdcDB)
+		_ = os.Remove(path)
+		t.Cleanup(func() { _ = os.Remove(path) })
+
+		db, err := sql.Open("sqlite", "file:"+path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer db.Close()
+
+		for _, stmt := range append([]string{"CREATE TABLE food (id INTEGER)", "CREATE TABLE nutrient (id INTEGER)"}, inserts...) {
+			_, err = db.Exec(stmt)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	t.Run("problems are reported with hints", func(t *testing.T) {
+		_ = os.WriteFile(filepath.Join(app.DBBasePath, app.FdcDB), []byte("not a database"), os.ModePerm)
+		defer os.Remove(filepath.Join(app.DBBasePath, app.FdcDB))
+		app.Config = app.ConfigFile{Integrations: app.ConfigIntegrations{AzureDI: app.AzureDI{Endpoint: "https://di.azure.com"}}}
+
+		got := app.Diagnose()
+
+		for _, name := range []string{"FDC database", "Videos directory", "Azure AI Document Intelligence"} {
+			d := find(got, name)
+			if d.Status != app.DiagnosticFail || d.Hint == "" {
+				t.Errorf("%s: got %+v", name, d)
+			}
+		}
+
+		if d := find(got, "SMTP server"); d.Status != app.DiagnosticSkip {
+			t.Errorf("SMTP server: got %+v", d)
+		}
+	})
+
+	t.Run("healthy environment", func(t *testing.T) {
+		_ = os.MkdirAll(app.VideosDir, os.ModePerm)
+		writeFDC(t, "INSERT INTO food VALUES (1)", "INSERT INTO nutrient VALUES (1)")
+		app.Config = app.ConfigFile{Integrations: app.ConfigIntegrations{AzureDI: app.AzureDI{Endpoint: "https://di.azure.com", Key: "key"}}}
+
+		got := app.Diagnose()
+
+		for _, name := range []string{"FDC database", "Backup directory", "Database directory", "Images directory", "Videos directory", "Azure AI Document Intelligence"} {
+			if d := find(got, name); d.Status != app.DiagnosticPass {
+				t.Errorf("%s: got %+v", name, d)
+			}
+		}
+	})
+
+	t.Run("FDC database", func(t *testing.T) {
+		testcases := []struct {
+			name       string
+			write      func(t *testing.T)
+			wantStatus app.DiagnosticStatus
+		}{
+			{
+				name: "only the header of an SQLite database",
+				write: func(t *testing.T) {
+					path := filepath.Join(app.DBBasePath, app.FdcDB)
+					_ = os.WriteFile(path, []byte("SQLite format 3\x00..."), os.ModePerm)
+					t.Cleanup(func() { _ = os.Remove(path) })
+				},
+				wantStatus: app.DiagnosticFail,
+			},
+			{
+				name:       "no foods",
+				write:      func(t *testing.T) { writeFDC(t) },
+				wantStatus: app.DiagnosticFail,
+			},
+			{
+				name:       "valid",
+				write:      func(t *testing.T) { writeFDC(t, "INSERT INTO food VALUES (1)", "INSERT INTO nutrient VALUES (1)") },
+				wantStatus: app.DiagnosticPass,
+			},
+		}
+		for _, tc := range testcases {
+			t.Run(tc.name, func(t *testing.T) {
+				tc.write(t)
+
+				if d := find(app.Diagnose(), "FDC database"); d.Status != tc.wantStatus {
+					t.Fatalf("got %+v but want status %s", d, tc.wantStatus)
+				}
+			})
+		}
+	})
+
+	t.Run("SMTP server", func(t *testing.T) {
+		newSMTPServer := func(t *testing.T, greeting, ehlo string) string {
+			t.Helper()
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = ln.Close() })
+
+			go func() {
+				conn, err := ln.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+
+				_, _ = io.WriteString(conn, greeting)
+				r := bufio.NewReader(conn)
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+
+					switch {
+					case strings.HasPrefix(line, "EHLO"):
+						_, _ = io.WriteString(conn, ehlo)
+					case strings.HasPrefix(line, "QUIT"):
+						_, _ = io.WriteString(conn, "221 Bye\r\n")
+						return
+					default:
+						_, _ = io.WriteString(conn, "502 Command not implemented\r\n")
+					}
+				}
+			}()
+			return ln.Addr().String()
+		}
+
+		closed, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		closedAddr := closed.Addr().String()
+		_ = closed.Close()
+
+		testcases := []struct {
+			name        string
+			host        func(t *testing.T) string
+			wantStatus  app.DiagnosticStatus
+			wantDetails string
+		}{
+			{
+				name: "answers EHLO with STARTTLS",
+				host: func(t *testing.T) string {
+					return newSMTPServer(t, "220 smtp.test ESMTP\r\n", "250-smtp.test\r\n250 STARTTLS\r\n")
+				},
+				wantStatus:  app.DiagnosticPass,
+				wantDetails: "answered EHLO",
+			},
+			{
+				name:        "no STARTTLS",
+				host:        func(t *testing.T) string { return newSMTPServer(t, "220 smtp.test ESMTP\r\n", "250 smtp.test\r\n") },
+				wantStatus:  app.DiagnosticFail,
+				wantDetails: "does not support STARTTLS",
+			},
+			{
+				name:        "not an SMTP server",
+				host:        func(t *testing.T) string { return newSMTPServer(t, "HTTP/1.1 400 Bad Request\r\n\r\n", "") },
+				wantStatus:  app.DiagnosticFail,
+				wantDetails: "is not an SMTP server",
+			},
+			{
+				name:       "nothing listening",
+				host:       func(_ *testing.T) string { return closedAddr },
+				wantStatus: app.DiagnosticFail,
+			},
+		}
+		for _, tc := range testcases {
+			t.Run(tc.name, func(t *testing.T) {
+				app.Config = app.ConfigFile{Email: app.ConfigEmail{Host: tc.host(t)}}
+				defer func() { app.Config = app.ConfigFile{} }()
+
+				d := find(app.Diagnose(), "SMTP server")
+				if d.Status != tc.wantStatus || !strings.Contains(d.Details, tc.wantDetails) {
+					t.Fatalf("got %+v but want status %s with details containing %q", d, tc.wantStatus, tc.wantDetails)
+				}
+			})
+		}
+	})
+}
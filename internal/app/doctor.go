@@ -0,0 +1,279 @@
+package app
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	_ "modernc.org/sqlite" // Blank import to initialize the SQL driver.
+)
+
+// These constants define the outcomes of a Diagnostic.
+const (
+	DiagnosticFail DiagnosticStatus = "FAIL"
+	DiagnosticPass DiagnosticStatus = "PASS"
+	DiagnosticSkip DiagnosticStatus = "SKIP"
+)
+
+// DiagnosticStatus is the outcome of a Diagnostic.
+type DiagnosticStatus string
+
+// Diagnostic holds the outcome of checking one aspect of the environment the application runs in.
+type Diagnostic struct {
+	Name    string
+	Status  DiagnosticStatus
+	Details string
+	Hint    string
+}
+
+// Diagnose checks the environment the application runs in: FFmpeg, the FDC database, the configuration,
+// the data directories, the SMTP server and the Azure AI Document Intelligence integration.
+// InitPaths must be called beforehand. The Config is loaded when it is valid.
+func Diagnose() []Diagnostic {
+	diagnostics := []Diagnostic{
+		diagnoseFFmpeg(),
+		diagnoseFDC(),
+		diagnoseConfig(),
+	}
+
+	dirs := []struct {
+		name string
+		path string
+	}{
+		{name: "Backup", path: BackupPath},
+		{name: "Database", path: DBBasePath},
+		{name: "Images", path: ImagesDir},
+		{name: "Videos", path: VideosDir},
+	}
+	for _, dir := range dirs {
+		diagnostics = append(diagnostics, diagnoseDirWritable(dir.name, dir.path))
+	}
+
+	return append(diagnostics, diagnoseSMTP(), diagnoseAzureDI())
+}
+
+func diagnoseFFmpeg() Diagnostic {
+	d := Diagnostic{Name: "FFmpeg"}
+
+	err := exec.Command("ffmpeg", "-version").Run()
+	Info.IsFFmpegInstalled = err == nil
+	if Info.IsFFmpegInstalled {
+		d.Status = DiagnosticPass
+		d.Details = "installed"
+		return d
+	}
+
+	d.Status = DiagnosticFail
+	d.Details = "not found in the PATH; videos cannot be converted"
+	switch runtime.GOOS {
+	case "darwin":
+		d.Hint = "brew install ffmpeg"
+	case "windows":
+		d.Hint = `winget install "FFmpeg (Essentials Build)"`
+	default:
+		d.Hint = "install ffmpeg with your package manager"
+	}
+	return d
+}
+
+func diagnoseFDC() Diagnostic {
+	d := Diagnostic{
+		Name: "FDC database",
+		Hint: "delete " + FdcDB + " and start Recipya to download it again",
+	}
+
+	path := filepath.Join(DBBasePath, FdcDB)
+	_, err := os.Stat(path)
+	if err != nil {
+		d.Status = DiagnosticFail
+		d.Details = err.Error()
+		if errors.Is(err, os.ErrNotExist) {
+			d.Hint = "start Recipya to download it"
+		}
+		return d
+	}
+
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		d.Status = DiagnosticFail
+		d.Details = err.Error()
+		return d
+	}
+	defer db.Close()
+
+	var numFoods, numNutrients int64
+	err = db.QueryRow("SELECT (SELECT COUNT(*) FROM food), (SELECT COUNT(*) FROM nutrient)").Scan(&numFoods, &numNutrients)
+	if err != nil {
+		d.Status = DiagnosticFail
+		d.Details = "not a valid FDC database: " + err.Error()
+		return d
+	} else if numFoods == 0 || numNutrients == 0 {
+		d.Status = DiagnosticFail
+		d.Details = "the foods or the nutrients are missing"
+		return d
+	}
+
+	d.Status = DiagnosticPass
+	d.Details = fmt.Sprintf("%s (%d foods)", path, numFoods)
+	d.Hint = ""
+	return d
+}
+
+func diagnoseConfig() Diagnostic {
+	if isRunningInDocker() {
+		d := Diagnostic{Name: "Configuration", Details: "environment variables"}
+		if os.Getenv("RECIPYA_SERVER_PORT") == "" {
+			d.Status = DiagnosticFail
+			d.Details = "RECIPYA_SERVER_PORT is not set"
+			d.Hint = "set the RECIPYA_SERVER_PORT environment variable"
+			return d
+		}
+
+		NewConfig(nil)
+		d.Status = DiagnosticPass
+		return d
+	}
+
+	path := filepath.Join(filepath.Dir(DBBasePath), "config.json")
+	d := Diagnostic{Name: "Configuration", Details: path}
+
+	xb, err := os.ReadFile(path)
+	if err != nil {
+		d.Status = DiagnosticFail
+		d.Details = err.Error()
+		d.Hint = "start Recipya to create the configuration file"
+		return d
+	}
+
+	var c ConfigFile
+	err = json.Unmarshal(xb, &c)
+	if err != nil {
+		d.Status = DiagnosticFail
+		d.Details = "invalid JSON: " + err.Error()
+		d.Hint = "fix " + path + " or delete it and start Recipya to create it again"
+		return d
+	}
+
+	Config = c
+	d.Status = DiagnosticPass
+	return d
+}
+
+func diagnoseDirWritable(name, dir string) Diagnostic {
+	d := Diagnostic{Name: name + " directory", Details: dir}
+
+	_, err := os.Stat(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		d.Status = DiagnosticFail
+		d.Details = dir + " does not exist"
+		d.Hint = "start Recipya to create it"
+		return d
+	}
+
+	f, err := os.CreateTemp(dir, ".recipya-doctor-*")
+	if err != nil {
+		d.Status = DiagnosticFail
+		d.Details = err.Error()
+		d.Hint = "make " + dir + " writable by the user running Recipya"
+		return d
+	}
+	_ = f.Close()
+	_ = os.Remove(f.Name())
+
+	d.Status = DiagnosticPass
+	return d
+}
+
+func diagnoseSMTP() Diagnostic {
+	d := Diagnostic{Name: "SMTP server"}
+
+	host := Config.Email.Host
+	if host == "" {
+		d.Status = DiagnosticSkip
+		d.Details = "not configured; emails will not be sent"
+		d.Hint = "set the email section of the configuration"
+		return d
+	}
+
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(host, "587")
+	}
+
+	d.Hint = "verify the SMTP host and that outgoing connections to it are allowed"
+
+	conn, err := net.DialTimeout("tcp", host, 5*time.Second)
+	if err != nil {
+		d.Status = DiagnosticFail
+		d.Details = err.Error()
+		return d
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	serverName, _, _ := net.SplitHostPort(host)
+	c, err := smtp.NewClient(conn, serverName)
+	if err != nil {
+		d.Status = DiagnosticFail
+		d.Details = host + " is not an SMTP server: " + err.Error()
+		return d
+	}
+	defer c.Close()
+
+	err = c.Hello("localhost")
+	if err != nil {
+		d.Status = DiagnosticFail
+		d.Details = host + " refused EHLO: " + err.Error()
+		return d
+	}
+
+	// The emails are sent over a mandatory STARTTLS connection.
+	if ok, _ := c.Extension("STARTTLS"); !ok {
+		d.Status = DiagnosticFail
+		d.Details = host + " does not support STARTTLS"
+		d.Hint = "use the submission port of an SMTP server supporting STARTTLS, usually 587"
+		return d
+	}
+	_ = c.Quit()
+
+	d.Status = DiagnosticPass
+	d.Details = host + " answered EHLO"
+	d.Hint = ""
+	return d
+}
+
+func diagnoseAzureDI() Diagnostic {
+	d := Diagnostic{Name: "Azure AI Document Intelligence"}
+
+	di := Config.Integrations.AzureDI
+	if di.Endpoint == "" && di.Key == "" {
+		d.Status = DiagnosticSkip
+		d.Details = "not configured; OCR is disabled"
+		d.Hint = "set the integrations section of the configuration"
+		return d
+	}
+
+	u, err := url.ParseRequestURI(di.Endpoint)
+	switch {
+	case err != nil || u.Host == "":
+		d.Status = DiagnosticFail
+		d.Details = fmt.Sprintf("invalid endpoint %q", di.Endpoint)
+		d.Hint = "copy the endpoint from the resource's Keys and Endpoint page in Azure"
+	case di.Key == "":
+		d.Status = DiagnosticFail
+		d.Details = "the key is missing"
+		d.Hint = "copy a key from the resource's Keys and Endpoint page in Azure"
+	default:
+		d.Status = DiagnosticPass
+		d.Details = di.Endpoint
+	}
+	return d
+}
@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// APITokenPrefix prefixes every personal API access token so that they are easy to recognize.
+const APITokenPrefix = "rcp_"
+
+// GenerateAPIToken creates a personal API access token. It returns the token to give
+// to the user once, along with the selector and the hashed validator to store.
+func GenerateAPIToken() (token, selector, hashValidator string) {
+	selectorBytes := make([]byte, 12)
+	validatorBytes := make([]byte, 32)
+
+	_, _ = rand.Read(selectorBytes)
+	_, _ = rand.Read(validatorBytes)
+
+	selector = base64.RawURLEncoding.EncodeToString(selectorBytes)
+	validator := base64.RawURLEncoding.EncodeToString(validatorBytes)
+
+	return APITokenPrefix + selector + "." + validator, selector, HashAPITokenValidator(validator)
+}
+
+// HashAPITokenValidator hashes the validator part of an API token using SHA-256.
+func HashAPITokenValidator(validator string) string {
+	hash := sha256.Sum256([]byte(validator))
+	return hex.EncodeToString(hash[:])
+}
+
+// ParseAPIToken splits an API token into its selector and validator.
+func ParseAPIToken(token string) (selector, validator string, err error) {
+	rest, ok := strings.CutPrefix(token, APITokenPrefix)
+	if !ok {
+		return "", "", errors.New("not an API token")
+	}
+
+	selector, validator, ok = strings.Cut(rest, ".")
+	if !ok || selector == "" || validator == "" {
+		return "", "", errors.New("malformed API token")
+	}
+	return selector, validator, nil
+}
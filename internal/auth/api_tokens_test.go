@@ -0,0 +1,48 @@
+package auth_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/auth"
+)
+
+func TestAPITokens(t *testing.T) {
+	t.Run("generated token can be parsed back", func(t *testing.T) {
+		token, selector, hashValidator := auth.GenerateAPIToken()
+		if !strings.HasPrefix(token, auth.APITokenPrefix) {
+			t.Fatalf("token %q does not have the prefix", token)
+		}
+
+		gotSelector, gotValidator, err := auth.ParseAPIToken(token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gotSelector != selector {
+			t.Fatalf("got selector %q but want %q", gotSelector, selector)
+		}
+		if auth.HashAPITokenValidator(gotValidator) != hashValidator {
+			t.Fatal("hashed validators differ")
+		}
+	})
+
+	t.Run("tokens are unique", func(t *testing.T) {
+		seen := make(map[string]struct{})
+		for range 1000 {
+			token, _, _ := auth.GenerateAPIToken()
+			if _, ok := seen[token]; ok {
+				t.Fatalf("duplicate token %q", token)
+			}
+			seen[token] = struct{}{}
+		}
+	})
+
+	t.Run("invalid tokens", func(t *testing.T) {
+		for _, token := range []string{"", "abc.def", "rcp_", "rcp_abc", "rcp_.def", "rcp_abc."} {
+			_, _, err := auth.ParseAPIToken(token)
+			if err == nil {
+				t.Fatalf("expected error for %q", token)
+			}
+		}
+	})
+}
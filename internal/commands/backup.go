@@ -0,0 +1,273 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/services"
+	"github.com/urfave/cli/v2"
+)
+
+// Backup is the command to manage the backups outside the scheduled job and the settings page.
+// The global backups are used unless a user is specified.
+func Backup() *cli.Command {
+	backupUserFlag := &cli.StringFlag{
+		Name:    "user",
+		Aliases: []string{"u"},
+		Usage:   "email address of the user whose backups to use instead of the global ones",
+	}
+
+	dateArg := func(c *cli.Context) (string, error) {
+		if c.NArg() != 1 {
+			return "", errors.New("the date of the backup is required, e.g. " + time.Now().Format(time.DateOnly))
+		}
+
+		date := c.Args().First()
+		_, err := time.Parse(time.DateOnly, date)
+		if err != nil {
+			return "", fmt.Errorf("invalid date %q: expected YYYY-MM-DD", date)
+		}
+		return date, nil
+	}
+
+	return &cli.Command{
+		Name:  "backup",
+		Usage: "creates, lists, verifies and restores backups",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "create",
+				Usage: "backs up the data now",
+				Flags: []cli.Flag{backupUserFlag},
+				Action: func(c *cli.Context) error {
+					repo, err := openRepository()
+					if err != nil {
+						return err
+					}
+					return createBackup(c.App.Writer, repo, services.NewFilesService(), c.String("user"))
+				},
+			},
+			{
+				Name:  "list",
+				Usage: "lists the available backups, most recent first",
+				Flags: []cli.Flag{backupUserFlag},
+				Action: func(c *cli.Context) error {
+					id, err := backupUserID(c.String("user"))
+					if err != nil {
+						return err
+					}
+
+					listBackups(c.App.Writer, services.NewFilesService(), id)
+					return nil
+				},
+			},
+			{
+				Name:      "verify",
+				Usage:     "checks that the SQL and the images of a backup are consistent",
+				ArgsUsage: "<date>",
+				Flags:     []cli.Flag{backupUserFlag},
+				Action: func(c *cli.Context) error {
+					date, err := dateArg(c)
+					if err != nil {
+						return err
+					}
+
+					id, err := backupUserID(c.String("user"))
+					if err != nil {
+						return err
+					}
+
+					return verifyBackup(c.App.Writer, services.NewFilesService(), date, id)
+				},
+			},
+			{
+				Name:      "restore",
+				Usage:     "restores a backup after backing up the current data; the server must be stopped",
+				ArgsUsage: "<date>",
+				Flags:     []cli.Flag{backupUserFlag},
+				Action: func(c *cli.Context) error {
+					date, err := dateArg(c)
+					if err != nil {
+						return err
+					}
+
+					files := services.NewFilesService()
+					email := c.String("user")
+					if email == "" {
+						err = initApp()
+						if err != nil {
+							return err
+						}
+
+						err = restoreGlobalBackup(c.App.Writer, files, date)
+						if err != nil {
+							return err
+						}
+
+						notifyWebhooks(c.App.Writer, services.NewSQLiteService(), models.WebhookEventBackupRestored, 0, map[string]any{"scope": "global", "date": date})
+						return nil
+					}
+
+					repo, err := openRepository()
+					if err != nil {
+						return err
+					}
+					return restoreUserBackup(c.App.Writer, repo, files, date, email)
+				},
+			},
+		},
+	}
+}
+
+// backupUserID fetches the ID of the user whose backups are managed. The ID is 0 for the global backups.
+func backupUserID(email string) (int64, error) {
+	if email == "" {
+		return 0, initApp()
+	}
+
+	repo, err := openRepository()
+	if err != nil {
+		return -1, err
+	}
+	return userID(repo, email)
+}
+
+func createBackup(w io.Writer, repo *services.SQLiteService, files services.FilesService, email string) error {
+	if email != "" {
+		id, err := userID(repo, email)
+		if err != nil {
+			return err
+		}
+
+		err = files.BackupUserData(repo, id)
+		if err != nil {
+			return fmt.Errorf("could not back up the data of %s: %w", email, err)
+		}
+
+		fmt.Fprintf(w, "Backed up the data of %s.\n", email)
+		notifyWebhooks(w, repo, models.WebhookEventBackupCompleted, id, map[string]any{"scope": "user"})
+		return nil
+	}
+
+	// The backup omits the write-ahead log, so its content must be in the database file.
+	_, err := repo.DB.Exec("PRAGMA wal_checkpoint(TRUNCATE)")
+	if err != nil {
+		return fmt.Errorf("could not checkpoint the database: %w", err)
+	}
+
+	err = files.BackupGlobal()
+	if err != nil {
+		return err
+	}
+
+	err = files.BackupUsersData(repo)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, "Backed up the application and the data of every user.")
+
+	notifyWebhooks(w, repo, models.WebhookEventBackupCompleted, 0, map[string]any{"scope": "global"})
+	for _, user := range repo.Users() {
+		notifyWebhooks(w, repo, models.WebhookEventBackupCompleted, user.ID, map[string]any{"scope": "user"})
+	}
+	return nil
+}
+
+func listBackups(w io.Writer, files services.FilesService, userID int64) {
+	backups := files.Backups(userID)
+	if len(backups) == 0 {
+		fmt.Fprintln(w, "No backups found.")
+		return
+	}
+
+	for _, date := range backups {
+		fmt.Fprintln(w, date.Format(time.DateOnly))
+	}
+}
+
+func verifyBackup(w io.Writer, files services.FilesService, date string, userID int64) error {
+	v, err := files.VerifyBackup(date, userID)
+	if err != nil {
+		return fmt.Errorf("could not verify the backup: %w", err)
+	}
+
+	printVerification(w, v)
+	if !v.IsValid() {
+		return fmt.Errorf("backup %s has %d problem(s)", date, len(v.Problems))
+	}
+	return nil
+}
+
+func printVerification(w io.Writer, v models.BackupVerification) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Date:\t%s\n", v.Date.Format(time.DateOnly))
+	fmt.Fprintf(tw, "Recipes:\t%d\n", v.Recipes)
+	fmt.Fprintf(tw, "Images:\t%d\n", v.Images)
+	_ = tw.Flush()
+
+	if v.IsValid() {
+		fmt.Fprintln(w, "The backup is consistent.")
+		return
+	}
+
+	fmt.Fprintln(w, "Problems:")
+	for _, p := range v.Problems {
+		fmt.Fprintf(w, "  - %s\n", p)
+	}
+}
+
+func restoreGlobalBackup(w io.Writer, files services.FilesService, date string) error {
+	err := verifyBackup(w, files, date, 0)
+	if err != nil {
+		return err
+	}
+
+	err = files.RestoreGlobalBackup(date)
+	if err != nil {
+		return fmt.Errorf("could not restore the backup: %w", err)
+	}
+
+	fmt.Fprintf(w, "Restored the global backup of %s. The previous data was backed up beforehand.\n", date)
+	return nil
+}
+
+func restoreUserBackup(w io.Writer, repo services.RepositoryService, files services.FilesService, date, email string) error {
+	id, err := userID(repo, email)
+	if err != nil {
+		return err
+	}
+
+	err = verifyBackup(w, files, date, id)
+	if err != nil {
+		return err
+	}
+
+	// Backing up today would overwrite the backup being restored.
+	if date != time.Now().Format(time.DateOnly) {
+		err = files.BackupUserData(repo, id)
+		if err != nil {
+			return fmt.Errorf("could not back up the current data: %w", err)
+		}
+	}
+
+	backup, err := files.ExtractUserBackup(date, id)
+	if err != nil {
+		return fmt.Errorf("could not extract the backup: %w", err)
+	}
+	defer os.RemoveAll(filepath.Dir(backup.ImagesPath))
+
+	err = repo.RestoreUserBackup(backup)
+	if err != nil {
+		return fmt.Errorf("could not restore the backup: %w", err)
+	}
+
+	fmt.Fprintf(w, "Restored the backup of %s for %s.\n", date, email)
+	notifyWebhooks(w, repo, models.WebhookEventBackupRestored, id, map[string]any{"scope": "user", "date": date})
+	return nil
+}
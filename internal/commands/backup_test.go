@@ -0,0 +1,222 @@
+package commands
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/reaper47/recipya/internal/app"
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/services"
+)
+
+func newTestBackupRepository(t *testing.T) *services.SQLiteService {
+	t.Helper()
+	repo := newTestRepository(t)
+
+	image := uuid.New()
+	err := os.WriteFile(filepath.Join(app.ImagesDir, image.String()+app.ImageExt), bytes.Repeat([]byte("x"), 1<<12), os.ModePerm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recipes := models.Recipes{
+		{Category: "breakfast", Images: []uuid.UUID{image}, Ingredients: []string{"2 eggs"}, Instructions: []string{"Cook the eggs."}, Name: "Eggs", Yield: 2},
+		{Category: "breakfast", Ingredients: []string{"2 eggs"}, Instructions: []string{"Scramble the eggs."}, Name: "Scrambled eggs", Yield: 2},
+	}
+	_, _, err = repo.AddRecipes(recipes, 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = repo.AddCookbook("Breakfast's best", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return repo
+}
+
+func TestCreateBackup(t *testing.T) {
+	testcases := []struct {
+		name       string
+		email      string
+		userID     int64
+		wantErr    string
+		wantOut    string
+		wantEvents []models.WebhookEvent
+	}{
+		{
+			name:       "global",
+			wantOut:    "Backed up the application and the data of every user.\n",
+			wantEvents: []models.WebhookEvent{models.WebhookEventBackupCompleted},
+		},
+		{
+			name:       "user",
+			email:      "admin@recipya.com",
+			userID:     1,
+			wantOut:    "Backed up the data of admin@recipya.com.\n",
+			wantEvents: []models.WebhookEvent{models.WebhookEventBackupCompleted},
+		},
+		{
+			name:    "user who does not exist",
+			email:   "ghost@recipya.com",
+			wantErr: `user "ghost@recipya.com" not found`,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				repo  = newTestBackupRepository(t)
+				files = services.NewFilesService()
+				out   bytes.Buffer
+			)
+			received := newTestWebhook(t, repo, models.WebhookEventBackupCompleted)
+
+			err := createBackup(&out, repo, files, tc.email)
+
+			if got := received(); !slices.Equal(got, tc.wantEvents) {
+				t.Fatalf("got events %v but want %v", got, tc.wantEvents)
+			}
+
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("got error %v but want %q", err, tc.wantErr)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if out.String() != tc.wantOut {
+				t.Fatalf("got output %q but want %q", out.String(), tc.wantOut)
+			}
+
+			backups := files.Backups(tc.userID)
+			if len(backups) != 1 || backups[0].Format(time.DateOnly) != time.Now().Format(time.DateOnly) {
+				t.Fatalf("got backups %v but want today's", backups)
+			}
+
+			v, err := files.VerifyBackup(time.Now().Format(time.DateOnly), tc.userID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !v.IsValid() || v.Recipes != 2 || v.Images != 1 {
+				t.Fatalf("unexpected verification %+v", v)
+			}
+		})
+	}
+}
+
+func TestVerifyBackup(t *testing.T) {
+	today := time.Now().Format(time.DateOnly)
+
+	writeUserBackup := func(t *testing.T, files map[string]string) {
+		t.Helper()
+		dir := filepath.Join(app.BackupPath, "users", "1")
+		err := os.MkdirAll(dir, os.ModePerm)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		f, err := os.Create(filepath.Join(dir, "recipya."+today+".zip"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+
+		zw := zip.NewWriter(f)
+		for name, content := range files {
+			w, err := zw.Create(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, _ = w.Write([]byte(content))
+		}
+
+		err = zw.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testcases := []struct {
+		name    string
+		date    string
+		userID  int64
+		setup   func(t *testing.T, repo *services.SQLiteService)
+		wantErr string
+		wantOut []string
+		notWant string
+	}{
+		{
+			name: "consistent global backup",
+			date: today,
+			setup: func(t *testing.T, repo *services.SQLiteService) {
+				err := createBackup(&bytes.Buffer{}, repo, services.NewFilesService(), "")
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantOut: []string{"Recipes:  2", "Images:   1", "The backup is consistent."},
+		},
+		{
+			name:   "consistent user backup",
+			date:   today,
+			userID: 1,
+			setup: func(t *testing.T, repo *services.SQLiteService) {
+				err := createBackup(&bytes.Buffer{}, repo, services.NewFilesService(), "admin@recipya.com")
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantOut: []string{"Recipes:  2", "Images:   1", "The backup is consistent."},
+		},
+		{
+			name:   "user backup without recipes",
+			date:   today,
+			userID: 1,
+			setup: func(t *testing.T, _ *services.SQLiteService) {
+				writeUserBackup(t, map[string]string{"backup-deletes.sql": ""})
+			},
+			wantErr: "backup " + today + " has 2 problem(s)",
+			wantOut: []string{"Problems:", "  - backup-deletes.sql is empty", "  - recipes.zip is missing"},
+			notWant: "The backup is consistent.",
+		},
+		{
+			name:    "backup does not exist",
+			date:    "2006-01-02",
+			wantErr: "could not verify the backup",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := newTestBackupRepository(t)
+			if tc.setup != nil {
+				tc.setup(t, repo)
+			}
+			var out bytes.Buffer
+
+			err := verifyBackup(&out, services.NewFilesService(), tc.date, tc.userID)
+
+			if tc.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)) {
+				t.Fatalf("got error %v but want %q", err, tc.wantErr)
+			}
+			for _, want := range tc.wantOut {
+				if !strings.Contains(out.String(), want) {
+					t.Fatalf("output does not contain %q:\n%s", want, out.String())
+				}
+			}
+			if tc.notWant != "" && strings.Contains(out.String(), tc.notWant) {
+				t.Fatalf("output should not contain %q:\n%s", tc.notWant, out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/reaper47/recipya/internal/app"
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/services"
+	"github.com/urfave/cli/v2"
+)
+
+var userFlag = &cli.StringFlag{
+	Name:     "user",
+	Aliases:  []string{"u"},
+	Usage:    "email address of the user",
+	Required: true,
+}
+
+// initApp initializes the application without opening the database. Like the doctor command,
+// it neither sets the application up nor prompts for the configuration so that the commands
+// can run unattended, e.g. from a cron job. The logs are discarded because the commands
+// report their results on the terminal.
+func initApp() error {
+	err := app.InitPaths()
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range []string{app.BackupPath, app.DBBasePath, app.ImagesDir, app.ThumbnailsDir, app.VideosDir} {
+		err = os.MkdirAll(dir, os.ModePerm)
+		if err != nil {
+			return err
+		}
+	}
+
+	app.LoadConfig()
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	return nil
+}
+
+// openRepository initializes the application and opens the database.
+func openRepository() (*services.SQLiteService, error) {
+	err := initApp()
+	if err != nil {
+		return nil, err
+	}
+	return services.NewSQLiteService(), nil
+}
+
+// userID fetches the ID of the user with the given email address.
+func userID(repo services.RepositoryService, email string) (int64, error) {
+	id := repo.UserID(email)
+	if id == -1 {
+		return -1, fmt.Errorf("user %q not found", email)
+	}
+	return id, nil
+}
+
+// notifyWebhooks delivers the event to the webhooks subscribed to it and reports the failed deliveries.
+// A userID of 0 denotes an application-wide event which only global webhooks receive.
+func notifyWebhooks(w io.Writer, repo services.RepositoryService, event models.WebhookEvent, userID int64, data any) {
+	webhooks, err := repo.WebhooksForEvent(event, userID)
+	if err != nil {
+		fmt.Fprintf(w, "Could not fetch the webhooks: %s\n", err)
+		return
+	}
+
+	if len(webhooks) == 0 {
+		return
+	}
+
+	var (
+		payload  = models.NewWebhookPayload(event, userID, data)
+		notifier = services.NewWebhooksService(nil)
+	)
+
+	for _, webhook := range webhooks {
+		delivery := notifier.Deliver(webhook, payload)
+		if !delivery.IsSuccess() {
+			fmt.Fprintf(w, "Could not notify the webhook %s: %s\n", webhook.URL, delivery.Error)
+		}
+
+		err = repo.UpdateWebhookDelivery(webhook.ID, delivery)
+		if err != nil {
+			fmt.Fprintf(w, "Could not record the delivery to the webhook %s: %s\n", webhook.URL, err)
+		}
+	}
+}
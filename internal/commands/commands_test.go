@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/app"
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/services"
+)
+
+// newTestRepository opens a database in a temporary directory. Its first user,
+// admin@recipya.com, is the administrator.
+func newTestRepository(t *testing.T) *services.SQLiteService {
+	t.Helper()
+
+	paths := []*string{&app.BackupPath, &app.DBBasePath, &app.ImagesDir, &app.ThumbnailsDir, &app.VideosDir}
+	original := make([]string, len(paths))
+	for i, p := range paths {
+		original[i] = *p
+	}
+
+	dir := filepath.Join(t.TempDir(), "Recipya")
+	app.BackupPath = filepath.Join(dir, "Backup")
+	app.DBBasePath = filepath.Join(dir, "Database")
+	app.ImagesDir = filepath.Join(dir, "Images")
+	app.ThumbnailsDir = filepath.Join(dir, "Images", "Thumbnails")
+	app.VideosDir = filepath.Join(dir, "Videos")
+
+	for _, p := range paths {
+		err := os.MkdirAll(*p, os.ModePerm)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	repo := services.NewSQLiteService()
+	t.Cleanup(func() {
+		_ = repo.DB.Close()
+		_ = repo.FdcDB.Close()
+		for i, p := range paths {
+			*p = original[i]
+		}
+	})
+
+	_, err := repo.Register("admin@recipya.com", "hashed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return repo
+}
+
+// newTestWebhook registers a webhook of the administrator subscribed to the events. The returned
+// function lists the events of the payloads the webhook received.
+func newTestWebhook(t *testing.T, repo *services.SQLiteService, events ...models.WebhookEvent) func() []models.WebhookEvent {
+	t.Helper()
+
+	var (
+		mu       sync.Mutex
+		received []models.WebhookEvent
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload models.WebhookPayload
+		err := json.NewDecoder(r.Body).Decode(&payload)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		received = append(received, payload.Event)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(srv.Close)
+
+	_, err := repo.AddWebhook(models.Webhook{Events: events, URL: srv.URL, UserID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return func() []models.WebhookEvent {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Clone(received)
+	}
+}
+
+func TestOpenRepository(t *testing.T) {
+	paths := []*string{&app.BackupPath, &app.DBBasePath, &app.ImagesDir, &app.LogsDir, &app.ThumbnailsDir, &app.VideosDir}
+	original := make([]string, len(paths))
+	for i, p := range paths {
+		original[i] = *p
+	}
+	t.Cleanup(func() {
+		for i, p := range paths {
+			*p = original[i]
+		}
+	})
+
+	dir := t.TempDir()
+	t.Setenv("AppData", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	repo, err := openRepository()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = repo.DB.Close()
+		_ = repo.FdcDB.Close()
+	}()
+
+	for _, p := range []string{app.BackupPath, app.ImagesDir, app.ThumbnailsDir, app.VideosDir} {
+		_, err = os.Stat(p)
+		if err != nil {
+			t.Errorf("directory %s must be created: %s", p, err)
+		}
+	}
+
+	_, err = os.Stat(filepath.Join(app.DBBasePath, app.FdcDB))
+	if !os.IsNotExist(err) {
+		t.Fatal("an empty FDC database must not be created in place of the one the server downloads")
+	}
+
+	_, err = os.Stat(filepath.Join(filepath.Dir(app.DBBasePath), "config.json"))
+	if !os.IsNotExist(err) {
+		t.Fatal("the configuration file must not be created")
+	}
+}
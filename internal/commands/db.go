@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/reaper47/recipya/internal/services"
+	"github.com/urfave/cli/v2"
+)
+
+// DB is the command to manage the migrations of the database. Unlike the server, it never
+// applies the pending migrations unless asked to.
+func DB() *cli.Command {
+	withMigrations := func(fn func(c *cli.Context, m *services.Migrations) error) cli.ActionFunc {
+		return func(c *cli.Context) error {
+			err := initApp()
+			if err != nil {
+				return err
+			}
+
+			m, err := services.NewMigrationsService()
+			if err != nil {
+				return err
+			}
+			defer m.Close()
+
+			return fn(c, m)
+		}
+	}
+
+	return &cli.Command{
+		Name:  "db",
+		Usage: "manages the migrations of the database; the server must be stopped",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "status",
+				Usage: "lists the migrations and whether they are applied",
+				Action: withMigrations(func(c *cli.Context, m *services.Migrations) error {
+					return printMigrationsStatus(c.App.Writer, m)
+				}),
+			},
+			{
+				Name:  "up",
+				Usage: "applies the pending migrations",
+				Action: withMigrations(func(c *cli.Context, m *services.Migrations) error {
+					applied, err := m.Up()
+					for _, migration := range applied {
+						fmt.Fprintf(c.App.Writer, "Applied %s\n", migration.Name)
+					}
+
+					if err != nil {
+						return err
+					} else if len(applied) == 0 {
+						fmt.Fprintln(c.App.Writer, "The database is up to date.")
+					}
+					return nil
+				}),
+			},
+			{
+				Name:      "down-to",
+				Usage:     "rolls back the migrations newer than the version",
+				ArgsUsage: "<version>",
+				Action: withMigrations(func(c *cli.Context, m *services.Migrations) error {
+					if c.NArg() != 1 {
+						return errors.New("the version to roll back to is required")
+					}
+
+					version, err := strconv.ParseInt(c.Args().First(), 10, 64)
+					if err != nil || version < 0 {
+						return fmt.Errorf("invalid version %q", c.Args().First())
+					}
+
+					rolledBack, err := m.DownTo(version)
+					for _, migration := range rolledBack {
+						fmt.Fprintf(c.App.Writer, "Rolled back %s\n", migration.Name)
+					}
+					return err
+				}),
+			},
+			{
+				Name:  "verify",
+				Usage: "detects schema drift, such as missing triggers or full-text search tables, and pending migrations",
+				Action: withMigrations(func(c *cli.Context, m *services.Migrations) error {
+					problems, err := m.Verify()
+					if err != nil {
+						return err
+					}
+
+					if len(problems) == 0 {
+						fmt.Fprintln(c.App.Writer, "The schema matches the migrations.")
+						return nil
+					}
+
+					fmt.Fprintln(c.App.Writer, "Problems:")
+					for _, p := range problems {
+						fmt.Fprintf(c.App.Writer, "  - %s\n", p)
+					}
+					return fmt.Errorf("the database has %d problem(s)", len(problems))
+				}),
+			},
+		},
+	}
+}
+
+func printMigrationsStatus(w io.Writer, m *services.Migrations) error {
+	migrations, err := m.Status()
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tMIGRATION\tAPPLIED AT")
+	for _, migration := range migrations {
+		appliedAt := "pending"
+		if migration.IsApplied {
+			appliedAt = migration.AppliedAt.Local().Format(time.DateTime)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\n", migration.Version, migration.Name, appliedAt)
+	}
+	return tw.Flush()
+}
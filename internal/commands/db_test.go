@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/services"
+)
+
+func TestPrintMigrationsStatus(t *testing.T) {
+	testcases := []struct {
+		name        string
+		downTo      int64
+		wantPending []string
+	}{
+		{
+			name: "every migration applied",
+		},
+		{
+			name:        "migrations rolled back",
+			downTo:      20261018121300,
+			wantPending: []string{"20261018121400_recipe_yield_unit.sql", "20261018121500_cookbook_recipes_delete_updated_at.sql"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			_ = newTestRepository(t)
+
+			m, err := services.NewMigrationsService()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer m.Close()
+
+			if tc.downTo > 0 {
+				_, err = m.DownTo(tc.downTo)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			var out bytes.Buffer
+
+			err = printMigrationsStatus(&out, m)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			if !strings.HasPrefix(lines[0], "VERSION") || !strings.Contains(lines[0], "APPLIED AT") {
+				t.Fatalf("unexpected header %q", lines[0])
+			}
+			if !strings.HasPrefix(lines[1], "20221227180503  20221227180503_init.sql") {
+				t.Fatalf("the oldest migration should come first: %q", lines[1])
+			}
+
+			var pending []string
+			for _, line := range lines[1:] {
+				fields := strings.Fields(line)
+				if len(fields) < 3 {
+					t.Fatalf("unexpected line %q", line)
+				}
+
+				if fields[2] == "pending" {
+					pending = append(pending, fields[1])
+				}
+			}
+
+			if strings.Join(pending, ",") != strings.Join(tc.wantPending, ",") {
+				t.Fatalf("got pending migrations %v but want %v", pending, tc.wantPending)
+			}
+		})
+	}
+}
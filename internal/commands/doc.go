@@ -0,0 +1,2 @@
+// Package commands implements the application's command-line subcommands other than serve.
+package commands
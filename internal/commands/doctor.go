@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"text/tabwriter"
+
+	"github.com/reaper47/recipya/internal/app"
+	"github.com/urfave/cli/v2"
+)
+
+// Doctor is the command to diagnose the environment the application runs in.
+// Unlike the other commands, it does not set up the application so that it works on broken installations.
+func Doctor() *cli.Command {
+	return &cli.Command{
+		Name:  "doctor",
+		Usage: "checks the environment and suggests fixes for common problems",
+		Action: func(c *cli.Context) error {
+			err := app.InitPaths()
+			if err != nil {
+				return err
+			}
+			slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			return printDiagnostics(c.App.Writer, app.Diagnose())
+		},
+	}
+}
+
+func printDiagnostics(w io.Writer, diagnostics []app.Diagnostic) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STATUS\tCHECK\tDETAILS")
+	for _, d := range diagnostics {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", d.Status, d.Name, d.Details)
+	}
+	_ = tw.Flush()
+
+	var (
+		numFailed int
+		hasHints  bool
+	)
+
+	for _, d := range diagnostics {
+		if d.Status == app.DiagnosticFail {
+			numFailed++
+		}
+
+		if d.Status == app.DiagnosticPass || d.Hint == "" {
+			continue
+		}
+
+		if !hasHints {
+			fmt.Fprintln(w, "\nHints:")
+			hasHints = true
+		}
+		fmt.Fprintf(w, "  - %s: %s\n", d.Name, d.Hint)
+	}
+
+	if numFailed > 0 {
+		return fmt.Errorf("%d check(s) failed", numFailed)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/app"
+)
+
+func TestPrintDiagnostics(t *testing.T) {
+	testcases := []struct {
+		name        string
+		diagnostics []app.Diagnostic
+		wantErr     string
+		wantOut     string
+	}{
+		{
+			name: "every check passes",
+			diagnostics: []app.Diagnostic{
+				{Name: "FFmpeg", Status: app.DiagnosticPass, Details: "installed"},
+				{Name: "SMTP server", Status: app.DiagnosticPass, Details: "smtp.gmail.com:587 answered EHLO", Hint: "ignored"},
+			},
+			wantOut: "STATUS  CHECK        DETAILS\n" +
+				"PASS    FFmpeg       installed\n" +
+				"PASS    SMTP server  smtp.gmail.com:587 answered EHLO\n",
+		},
+		{
+			name: "skipped checks have hints",
+			diagnostics: []app.Diagnostic{
+				{Name: "FFmpeg", Status: app.DiagnosticPass, Details: "installed"},
+				{Name: "SMTP server", Status: app.DiagnosticSkip, Details: "not configured", Hint: "set the email section"},
+			},
+			wantOut: "STATUS  CHECK        DETAILS\n" +
+				"PASS    FFmpeg       installed\n" +
+				"SKIP    SMTP server  not configured\n" +
+				"\nHints:\n" +
+				"  - SMTP server: set the email section\n",
+		},
+		{
+			name: "failed checks",
+			diagnostics: []app.Diagnostic{
+				{Name: "FFmpeg", Status: app.DiagnosticFail, Details: "not found", Hint: "brew install ffmpeg"},
+				{Name: "FDC database", Status: app.DiagnosticFail, Details: "not a valid FDC database"},
+			},
+			wantErr: "2 check(s) failed",
+			wantOut: "STATUS  CHECK         DETAILS\n" +
+				"FAIL    FFmpeg        not found\n" +
+				"FAIL    FDC database  not a valid FDC database\n" +
+				"\nHints:\n" +
+				"  - FFmpeg: brew install ffmpeg\n",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			err := printDiagnostics(&out, tc.diagnostics)
+
+			if tc.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if tc.wantErr != "" && (err == nil || err.Error() != tc.wantErr) {
+				t.Fatalf("got error %v but want %q", err, tc.wantErr)
+			}
+			if out.String() != tc.wantOut {
+				t.Fatalf("got output:\n%s\nbut want:\n%s", out.String(), tc.wantOut)
+			}
+		})
+	}
+}
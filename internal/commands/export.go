@@ -0,0 +1,207 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/services"
+	"github.com/urfave/cli/v2"
+)
+
+// Export is the command to export a user's recipes, or a single cookbook, to a file.
+// It is the terminal counterpart of the export section of the settings.
+func Export() *cli.Command {
+	return &cli.Command{
+		Name:  "export",
+		Usage: "exports a user's recipes or one of their cookbooks to a file",
+		Flags: []cli.Flag{
+			userFlag,
+			&cli.StringFlag{
+				Name:    "format",
+				Aliases: []string{"f"},
+				Usage:   "export format, either json or pdf",
+				Value:   "json",
+			},
+			&cli.StringFlag{
+				Name:    "out",
+				Aliases: []string{"o"},
+				Usage:   "path of the file to write; defaults to recipes_<format>.zip, or the cookbook's title",
+			},
+			&cli.Int64Flag{
+				Name:  "cookbook",
+				Usage: "ID of the cookbook to export instead of the whole collection",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			fileType := models.NewFileType(c.String("format"))
+			if fileType != models.JSON && fileType != models.PDF {
+				return fmt.Errorf("unsupported format %q: must be json or pdf", c.String("format"))
+			}
+
+			repo, err := openRepository()
+			if err != nil {
+				return err
+			}
+
+			id, err := userID(repo, c.String("user"))
+			if err != nil {
+				return err
+			}
+
+			opts := exportOptions{
+				cookbookID: c.Int64("cookbook"),
+				fileType:   fileType,
+				out:        c.String("out"),
+			}
+			return exportRecipes(c.App.Writer, repo, services.NewFilesService(), id, opts)
+		},
+	}
+}
+
+var fileNameReplacer = strings.NewReplacer(" ", "_", "/", "_", "\\", "_")
+
+type exportOptions struct {
+	cookbookID int64
+	fileType   models.FileType
+	out        string
+}
+
+func exportRecipes(w io.Writer, repo services.RepositoryService, files services.FilesService, userID int64, opts exportOptions) error {
+	var (
+		data      io.Reader
+		recipes   models.Recipes
+		cookbooks []models.Cookbook
+		name      = "recipes_" + strings.TrimPrefix(opts.fileType.Ext(), ".") + ".zip"
+	)
+
+	if opts.cookbookID > 0 {
+		cookbook, err := repo.Cookbook(opts.cookbookID, userID)
+		if err != nil {
+			return fmt.Errorf("cookbook %d not found: %w", opts.cookbookID, err)
+		}
+
+		cookbook.Recipes, err = cookbook.Recipes.ExpandSubRecipesFunc(func(id int64) (*models.Recipe, error) {
+			return repo.Recipe(id, userID)
+		})
+		if err != nil {
+			fmt.Fprintf(w, "Some sub-recipes could not be expanded: %v\n", err)
+		}
+
+		if opts.fileType == models.PDF {
+			notes, err := repo.RecipesNotes(userID)
+			if err != nil {
+				return fmt.Errorf("could not fetch the recipe notes: %w", err)
+			}
+
+			tempName, err := files.ExportCookbook(cookbook, notes.GroupByRecipe(), opts.fileType)
+			if err != nil {
+				return fmt.Errorf("could not export the cookbook: %w", err)
+			}
+
+			path := filepath.Join(os.TempDir(), tempName)
+			defer os.Remove(path)
+
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			data = f
+			name = fileNameReplacer.Replace(cookbook.Title) + opts.fileType.Ext()
+		} else {
+			recipes = cookbook.Recipes
+			cookbooks = []models.Cookbook{cookbook}
+			name = fileNameReplacer.Replace(cookbook.Title) + "_json.zip"
+		}
+	} else {
+		var err error
+		recipes, err = repo.RecipesAll(userID).ExpandSubRecipes()
+		if err != nil {
+			fmt.Fprintf(w, "Some sub-recipes could not be expanded: %v\n", err)
+		}
+
+		cookbooks, err = repo.CookbooksUser(userID)
+		if err != nil {
+			return fmt.Errorf("could not fetch the cookbooks: %w", err)
+		}
+	}
+
+	if data == nil {
+		if len(recipes) == 0 {
+			return errors.New("no recipes to export")
+		}
+
+		var notes map[int64]models.RecipeNotes
+		if opts.fileType == models.PDF {
+			all, err := repo.RecipesNotes(userID)
+			if err != nil {
+				return fmt.Errorf("could not fetch the recipe notes: %w", err)
+			}
+			notes = all.GroupByRecipe()
+		}
+
+		buf, err := files.ExportRecipes(recipes, cookbooks, notes, opts.fileType, nil)
+		if err != nil {
+			return fmt.Errorf("could not export the recipes: %w", err)
+		}
+		data = buf
+	}
+
+	out := opts.out
+	if out == "" {
+		out = name
+	}
+
+	n, err := writeFileAtomic(out, data)
+	if err != nil {
+		return err
+	}
+
+	if len(recipes) > 0 {
+		fmt.Fprintf(w, "Exported %d recipes to %s (%.2f MB).\n", len(recipes), out, float64(n)/(1<<20))
+	} else {
+		fmt.Fprintf(w, "Exported the cookbook to %s (%.2f MB).\n", out, float64(n)/(1<<20))
+	}
+	return nil
+}
+
+// writeFileAtomic writes the data to a temporary file next to the destination before renaming it,
+// so that a scheduled job never leaves a truncated archive behind.
+func writeFileAtomic(path string, data io.Reader) (int64, error) {
+	dir := filepath.Dir(path)
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return 0, err
+	}
+
+	f, err := os.CreateTemp(dir, ".recipya-*.tmp")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(f.Name())
+
+	n, err := io.Copy(f, data)
+	if err != nil {
+		_ = f.Close()
+		return 0, err
+	}
+
+	// CreateTemp makes the file readable only by its owner, unlike a file written with os.Create.
+	err = f.Chmod(0o644)
+	if err != nil {
+		_ = f.Close()
+		return 0, err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return 0, err
+	}
+	return n, os.Rename(f.Name(), path)
+}
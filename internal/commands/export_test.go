@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/services"
+)
+
+func TestExportRecipes(t *testing.T) {
+	testcases := []struct {
+		name       string
+		isEmpty    bool
+		opts       exportOptions
+		wantErr    string
+		wantOut    string
+		wantInZip  []string
+		wantPrefix string
+	}{
+		{
+			name:      "collection as json",
+			opts:      exportOptions{fileType: models.JSON, out: "recipes.zip"},
+			wantOut:   "Exported 2 recipes to ",
+			wantInZip: []string{"Eggs/recipe.json", "Toast/recipe.json"},
+		},
+		{
+			name:      "collection as pdf",
+			opts:      exportOptions{fileType: models.PDF, out: "recipes.zip"},
+			wantOut:   "Exported 2 recipes to ",
+			wantInZip: []string{"Eggs.pdf", "Toast.pdf"},
+		},
+		{
+			name:      "cookbook as json",
+			opts:      exportOptions{cookbookID: 1, fileType: models.JSON, out: "cookbook.zip"},
+			wantOut:   "Exported 1 recipes to ",
+			wantInZip: []string{"Eggs/recipe.json"},
+		},
+		{
+			name:       "cookbook as pdf",
+			opts:       exportOptions{cookbookID: 1, fileType: models.PDF, out: "cookbook.pdf"},
+			wantOut:    "Exported the cookbook to ",
+			wantPrefix: "%PDF",
+		},
+		{
+			name:    "cookbook does not exist",
+			opts:    exportOptions{cookbookID: 99, fileType: models.JSON, out: "cookbook.zip"},
+			wantErr: "cookbook 99 not found",
+		},
+		{
+			name:    "no recipes",
+			isEmpty: true,
+			opts:    exportOptions{fileType: models.JSON, out: "recipes.zip"},
+			wantErr: "no recipes to export",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := newTestRepository(t)
+			if !tc.isEmpty {
+				recipes := models.Recipes{
+					{Category: "breakfast", Ingredients: []string{"2 eggs"}, Instructions: []string{"Cook the eggs."}, Name: "Eggs", Yield: 2},
+					{Category: "breakfast", Ingredients: []string{"1 slice of bread"}, Instructions: []string{"Toast the bread."}, Name: "Toast", Yield: 1},
+				}
+				_, _, err := repo.AddRecipes(recipes, 1, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				cookbookID, err := repo.AddCookbook("Breakfasts", 1)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				err = repo.AddCookbookRecipe(cookbookID, 1, 1)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var out bytes.Buffer
+			tc.opts.out = filepath.Join(t.TempDir(), "exports", tc.opts.out)
+
+			err := exportRecipes(&out, repo, services.NewFilesService(), 1, tc.opts)
+
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("got error %v but want %q", err, tc.wantErr)
+				}
+				if _, err := os.Stat(tc.opts.out); !os.IsNotExist(err) {
+					t.Fatalf("the file should not have been written: %v", err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !strings.HasPrefix(out.String(), tc.wantOut+tc.opts.out) {
+				t.Fatalf("got output %q but want %q", out.String(), tc.wantOut+tc.opts.out)
+			}
+
+			info, err := os.Stat(tc.opts.out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Mode().Perm() != 0o644 {
+				t.Fatalf("got mode %v but want %v", info.Mode().Perm(), os.FileMode(0o644))
+			}
+
+			data, err := os.ReadFile(tc.opts.out)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tc.wantPrefix != "" && !bytes.HasPrefix(data, []byte(tc.wantPrefix)) {
+				t.Fatalf("the file does not start with %q", tc.wantPrefix)
+			}
+
+			if len(tc.wantInZip) > 0 {
+				zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var names []string
+				for _, f := range zr.File {
+					names = append(names, f.Name)
+				}
+
+				for _, want := range tc.wantInZip {
+					if !slices.Contains(names, want) {
+						t.Fatalf("the archive does not contain %q: %v", want, names)
+					}
+				}
+			}
+		})
+	}
+}
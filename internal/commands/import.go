@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/services"
+	"github.com/urfave/cli/v2"
+)
+
+// Import is the command to import recipes from local files into a user's collection.
+// It accepts the same formats as the import form of the web application without its upload size limit.
+func Import() *cli.Command {
+	return &cli.Command{
+		Name:      "import",
+		Usage:     "imports recipes from local files, archives and directories into a user's collection",
+		ArgsUsage: "<paths...>",
+		Flags:     []cli.Flag{userFlag},
+		Action: func(c *cli.Context) error {
+			if c.NArg() == 0 {
+				return errors.New("at least one path is required")
+			}
+
+			repo, err := openRepository()
+			if err != nil {
+				return err
+			}
+
+			id, err := userID(repo, c.String("user"))
+			if err != nil {
+				return err
+			}
+
+			return importRecipes(c.App.Writer, repo, services.NewFilesService(), id, c.Args().Slice())
+		},
+	}
+}
+
+func importRecipes(w io.Writer, repo services.RepositoryService, files services.FilesService, userID int64, paths []string) error {
+	headers, skipped, cleanup, err := services.NewFileHeadersFromPaths(paths)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	for _, path := range skipped {
+		fmt.Fprintf(w, "Skipping unsupported file %s\n", path)
+	}
+
+	if len(headers) == 0 {
+		return errors.New("no file to import")
+	}
+
+	fmt.Fprintf(w, "Extracting recipes from %d files...\n", len(headers))
+	now := time.Now()
+
+	recipes := files.ExtractRecipes(headers)
+	if len(recipes) == 0 {
+		fmt.Fprintln(w, "No recipes found.")
+		return nil
+	}
+
+	var (
+		progress  = make(chan models.Progress)
+		report    = models.NewReport(models.ImportReportType)
+		recipeIDs []int64
+		addErr    error
+	)
+
+	go func() {
+		defer close(progress)
+		recipeIDs, report.Logs, addErr = repo.AddRecipes(recipes, userID, progress)
+	}()
+
+	for p := range progress {
+		fmt.Fprintf(w, "\rImporting %d/%d", p.Value+1, p.Total)
+	}
+	fmt.Fprintln(w)
+
+	if addErr != nil {
+		return fmt.Errorf("could not add the recipes: %w", addErr)
+	}
+
+	if len(recipeIDs) > 0 {
+		notifyWebhooks(w, repo, models.WebhookEventRecipeCreated, userID, map[string]any{"recipeIDs": recipeIDs})
+	}
+
+	report.ExecTime = time.Since(now)
+	repo.AddReport(report, userID)
+	notifyWebhooks(w, repo, models.WebhookEventImportFinished, userID, models.NewImportFinishedData(report, len(recipeIDs), len(recipes)))
+
+	printReportLogs(w, report.Logs)
+	fmt.Fprintf(w, "Imported %d recipes. %d skipped. Took %s.\n", len(recipeIDs), len(recipes)-len(recipeIDs), report.ExecTime.Round(time.Millisecond))
+	return nil
+}
+
+func printReportLogs(w io.Writer, logs []models.ReportLog) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STATUS\tRECIPE\tDETAILS")
+	for _, l := range logs {
+		status := "OK"
+		details := l.Action
+		if l.IsError {
+			status = "ERROR"
+			details = l.Error
+		} else if l.IsWarning {
+			status = "SKIPPED"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", status, l.Title, details)
+	}
+	_ = tw.Flush()
+}
@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/services"
+)
+
+func TestImportRecipes(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		err := os.MkdirAll(filepath.Dir(path), os.ModePerm)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = os.WriteFile(path, []byte(content), os.ModePerm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	recipeJSON := func(name string) string {
+		return `{"@context":"https://schema.org","@type":"Recipe","name":"` + name + `","recipeCategory":"breakfast","recipeIngredient":["2 eggs"],"recipeInstructions":[{"@type":"HowToStep","text":"Cook the eggs."}],"recipeYield":"2 servings"}`
+	}
+
+	eggs := writeFile("eggs.json", recipeJSON("Eggs"))
+	writeFile("folder/toast.json", recipeJSON("Toast"))
+	writeFile("folder/notes.exe", "binary")
+	unsupported := writeFile("cover.bmp", "image")
+	empty := writeFile("empty.json", "{}")
+
+	const numMany = 1001
+	for i := range numMany {
+		writeFile(filepath.Join("many", strconv.Itoa(i)+".json"), recipeJSON("Recipe "+strconv.Itoa(i)))
+	}
+
+	testcases := []struct {
+		name        string
+		paths       []string
+		wantErr     string
+		wantRecipes int
+		wantOut     []string
+	}{
+		{
+			name:        "file",
+			paths:       []string{eggs},
+			wantRecipes: 1,
+			wantOut:     []string{"Extracting recipes from 1 files...", "Imported 1 recipes. 0 skipped."},
+		},
+		{
+			name:        "directory",
+			paths:       []string{filepath.Join(dir, "folder")},
+			wantRecipes: 1,
+			wantOut:     []string{"Skipping unsupported file " + filepath.Join(dir, "folder", "notes.exe"), "Imported 1 recipes. 0 skipped."},
+		},
+		{
+			name:        "files and directories",
+			paths:       []string{eggs, filepath.Join(dir, "folder")},
+			wantRecipes: 2,
+			wantOut:     []string{"Extracting recipes from 2 files...", "Imported 2 recipes. 0 skipped."},
+		},
+		{
+			name:        "more files than a multipart form holds",
+			paths:       []string{filepath.Join(dir, "many")},
+			wantRecipes: numMany,
+			wantOut:     []string{"Extracting recipes from 1001 files...", "Imported 1001 recipes. 0 skipped."},
+		},
+		{
+			name:    "only unsupported files",
+			paths:   []string{unsupported},
+			wantErr: "no file to import",
+			wantOut: []string{"Skipping unsupported file " + unsupported},
+		},
+		{
+			name:    "no recipes in the files",
+			paths:   []string{empty},
+			wantOut: []string{"No recipes found."},
+		},
+		{
+			name:    "path does not exist",
+			paths:   []string{filepath.Join(dir, "missing.json")},
+			wantErr: "no such file or directory",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := newTestRepository(t)
+			var out bytes.Buffer
+
+			err := importRecipes(&out, repo, services.NewFilesService(), 1, tc.paths)
+
+			if tc.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)) {
+				t.Fatalf("got error %v but want %q", err, tc.wantErr)
+			}
+			if got := len(repo.RecipesAll(1)); got != tc.wantRecipes {
+				t.Fatalf("got %d recipes but want %d", got, tc.wantRecipes)
+			}
+			for _, want := range tc.wantOut {
+				if !strings.Contains(out.String(), want) {
+					t.Fatalf("output does not contain %q:\n%s", want, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestImportRecipesWebhooks(t *testing.T) {
+	repo := newTestRepository(t)
+	received := newTestWebhook(t, repo, models.WebhookEventRecipeCreated, models.WebhookEventImportFinished)
+
+	path := filepath.Join(t.TempDir(), "eggs.json")
+	err := os.WriteFile(path, []byte(`{"@context":"https://schema.org","@type":"Recipe","name":"Eggs","recipeCategory":"breakfast","recipeIngredient":["2 eggs"],"recipeInstructions":[{"@type":"HowToStep","text":"Cook the eggs."}],"recipeYield":"2 servings"}`), os.ModePerm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	err = importRecipes(&out, repo, services.NewFilesService(), 1, []string{path})
+	if err != nil {
+		t.Fatalf("unexpected error: %v\n%s", err, out.String())
+	}
+
+	want := []models.WebhookEvent{models.WebhookEventRecipeCreated, models.WebhookEventImportFinished}
+	if got := received(); !slices.Equal(got, want) {
+		t.Fatalf("got events %v but want %v\n%s", got, want, out.String())
+	}
+}
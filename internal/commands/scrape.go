@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"os"
+	"time"
+
+	"github.com/reaper47/recipya/internal/scraper"
+	"github.com/urfave/cli/v2"
+)
+
+// Scrape is the command to debug the extraction of a recipe from a website. It prints the
+// RecipeSchema the scraper produces along with the path taken, without storing anything.
+func Scrape() *cli.Command {
+	return &cli.Command{
+		Name:      "scrape",
+		Usage:     "prints the recipe extracted from a website to debug the scraper",
+		ArgsUsage: "<url>",
+		Flags: []cli.Flag{
+			&cli.PathFlag{
+				Name:  "html",
+				Usage: "saved copy of the page to parse instead of fetching the URL; no request is made",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			if c.NArg() != 1 {
+				return errors.New("the URL of the recipe is required")
+			}
+
+			var html io.Reader
+			if name := c.Path("html"); name != "" {
+				f, err := os.Open(name)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				html = f
+			}
+
+			jar, err := cookiejar.New(nil)
+			if err != nil {
+				return err
+			}
+
+			s := scraper.NewScraper(&http.Client{Jar: jar, Timeout: 30 * time.Second})
+			return scrape(c.App.Writer, s, c.Args().First(), html)
+		},
+	}
+}
+
+func scrape(w io.Writer, s *scraper.Scraper, rawURL string, html io.Reader) error {
+	trace, err := s.Trace(rawURL, html)
+
+	fmt.Fprintf(w, "Host: %s\n", trace.Host)
+	if trace.Path != "" {
+		fmt.Fprintf(w, "Path: %s\n", trace.Path)
+	}
+
+	if err != nil {
+		return fmt.Errorf("could not scrape %s: %w", rawURL, err)
+	}
+
+	xb, err := json.MarshalIndent(trace.Schema, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "%s\n", xb)
+	return err
+}
@@ -0,0 +1,279 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/reaper47/recipya/internal/auth"
+	"github.com/reaper47/recipya/internal/services"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+// Users is the command to administer the users when the web interface cannot help,
+// e.g. when someone is locked out and no SMTP server is configured.
+func Users() *cli.Command {
+	emailArg := func(c *cli.Context) (string, error) {
+		if c.NArg() != 1 {
+			return "", errors.New("the email address of the user is required")
+		}
+		return strings.TrimSpace(c.Args().First()), nil
+	}
+
+	return &cli.Command{
+		Name:  "users",
+		Usage: "administers the users of the application",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "lists the users",
+				Action: func(c *cli.Context) error {
+					repo, err := openRepository()
+					if err != nil {
+						return err
+					}
+
+					listUsers(c.App.Writer, repo)
+					return nil
+				},
+			},
+			{
+				Name:      "add",
+				Usage:     "adds a confirmed user; the password is read from the terminal or the standard input",
+				ArgsUsage: "<email>",
+				Action: func(c *cli.Context) error {
+					email, err := emailArg(c)
+					if err != nil {
+						return err
+					}
+
+					password, err := readPassword(c.App.Writer, os.Stdin)
+					if err != nil {
+						return err
+					}
+
+					repo, err := openRepository()
+					if err != nil {
+						return err
+					}
+					return addUser(c.App.Writer, repo, email, password)
+				},
+			},
+			{
+				Name:      "delete",
+				Usage:     "deletes a user along with all of their data",
+				ArgsUsage: "<email>",
+				Action: func(c *cli.Context) error {
+					email, err := emailArg(c)
+					if err != nil {
+						return err
+					}
+
+					repo, err := openRepository()
+					if err != nil {
+						return err
+					}
+					return deleteUser(c.App.Writer, repo, email)
+				},
+			},
+			{
+				Name:      "set-password",
+				Usage:     "sets a user's password; it is read from the terminal or the standard input",
+				ArgsUsage: "<email>",
+				Action: func(c *cli.Context) error {
+					email, err := emailArg(c)
+					if err != nil {
+						return err
+					}
+
+					password, err := readPassword(c.App.Writer, os.Stdin)
+					if err != nil {
+						return err
+					}
+
+					repo, err := openRepository()
+					if err != nil {
+						return err
+					}
+					return setUserPassword(c.App.Writer, repo, email, password)
+				},
+			},
+			{
+				Name:      "confirm",
+				Usage:     "confirms a user's account without the confirmation email",
+				ArgsUsage: "<email>",
+				Action: func(c *cli.Context) error {
+					email, err := emailArg(c)
+					if err != nil {
+						return err
+					}
+
+					repo, err := openRepository()
+					if err != nil {
+						return err
+					}
+					return confirmUser(c.App.Writer, repo, email)
+				},
+			},
+		},
+	}
+}
+
+func listUsers(w io.Writer, repo services.RepositoryService) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tEMAIL\tROLE")
+	for _, u := range repo.Users() {
+		role := "user"
+		if u.ID == 1 {
+			role = "admin"
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\n", u.ID, u.Email, role)
+	}
+	_ = tw.Flush()
+}
+
+func addUser(w io.Writer, repo services.RepositoryService, email, password string) error {
+	_, err := mail.ParseAddress(email)
+	if err != nil {
+		return fmt.Errorf("invalid email address %q", email)
+	}
+
+	if repo.UserID(email) != -1 {
+		return fmt.Errorf("user %q already exists", email)
+	}
+
+	hashPassword, err := auth.HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("could not hash the password: %w", err)
+	}
+
+	id, err := repo.Register(email, hashPassword)
+	if err != nil {
+		return fmt.Errorf("could not add the user: %w", err)
+	}
+
+	err = repo.Confirm(id)
+	if err != nil {
+		return fmt.Errorf("could not confirm the user: %w", err)
+	}
+
+	fmt.Fprintf(w, "Added user %s with ID %d.\n", email, id)
+	return nil
+}
+
+func deleteUser(w io.Writer, repo services.RepositoryService, email string) error {
+	id, err := userID(repo, email)
+	if err != nil {
+		return err
+	}
+
+	if id == 1 {
+		return errors.New("the administrator cannot be deleted")
+	}
+
+	err = repo.DeleteUser(id)
+	if err != nil {
+		return fmt.Errorf("could not delete the user: %w", err)
+	}
+
+	fmt.Fprintf(w, "Deleted user %s.\n", email)
+	return nil
+}
+
+func setUserPassword(w io.Writer, repo services.RepositoryService, email, password string) error {
+	id, err := userID(repo, email)
+	if err != nil {
+		return err
+	}
+
+	hashPassword, err := auth.HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("could not hash the password: %w", err)
+	}
+
+	err = repo.UpdatePassword(id, hashPassword)
+	if err != nil {
+		return fmt.Errorf("could not update the password: %w", err)
+	}
+
+	// The sessions remembered and the API tokens created with the previous password must not outlive it.
+	err = repo.DeleteAuthToken(id)
+	if err != nil {
+		return fmt.Errorf("could not revoke the remembered sessions: %w", err)
+	}
+
+	tokens, err := repo.APITokens(id)
+	if err != nil {
+		return fmt.Errorf("could not fetch the API tokens: %w", err)
+	}
+
+	for _, token := range tokens {
+		err = repo.DeleteAPIToken(token.ID, id)
+		if err != nil {
+			return fmt.Errorf("could not revoke the API token %q: %w", token.Name, err)
+		}
+	}
+
+	fmt.Fprintf(w, "Updated the password of %s.\n", email)
+	return nil
+}
+
+func confirmUser(w io.Writer, repo services.RepositoryService, email string) error {
+	id, err := userID(repo, email)
+	if err != nil {
+		return err
+	}
+
+	err = repo.Confirm(id)
+	if err != nil {
+		return fmt.Errorf("could not confirm the user: %w", err)
+	}
+
+	fmt.Fprintf(w, "Confirmed %s.\n", email)
+	return nil
+}
+
+// readPassword prompts for a password twice when the standard input is a terminal.
+// Otherwise, the first line of the input is used so that the command can be scripted.
+func readPassword(w io.Writer, in *os.File) (string, error) {
+	fd := int(in.Fd())
+	if !term.IsTerminal(fd) {
+		line, err := bufio.NewReader(in).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", err
+		}
+
+		password := strings.TrimRight(line, "\r\n")
+		if password == "" {
+			return "", errors.New("the password cannot be empty")
+		}
+		return password, nil
+	}
+
+	fmt.Fprint(w, "Password: ")
+	password, err := term.ReadPassword(fd)
+	fmt.Fprintln(w)
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Fprint(w, "Confirm password: ")
+	confirm, err := term.ReadPassword(fd)
+	fmt.Fprintln(w)
+	if err != nil {
+		return "", err
+	}
+
+	if len(password) == 0 {
+		return "", errors.New("the password cannot be empty")
+	} else if string(password) != string(confirm) {
+		return "", errors.New("the passwords do not match")
+	}
+	return string(password), nil
+}
@@ -0,0 +1,171 @@
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/services"
+)
+
+func TestUsers(t *testing.T) {
+	isConfirmed := func(t *testing.T, repo *services.SQLiteService, id int64) bool {
+		t.Helper()
+		var confirmed bool
+		err := repo.DB.QueryRow("SELECT is_confirmed FROM users WHERE id = ?", id).Scan(&confirmed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return confirmed
+	}
+
+	testcases := []struct {
+		name    string
+		run     func(t *testing.T, repo *services.SQLiteService, w *bytes.Buffer) error
+		check   func(t *testing.T, repo *services.SQLiteService)
+		wantErr string
+		wantOut string
+	}{
+		{
+			name: "add a user",
+			run: func(_ *testing.T, repo *services.SQLiteService, w *bytes.Buffer) error {
+				return addUser(w, repo, "cook@recipya.com", "secret")
+			},
+			check: func(t *testing.T, repo *services.SQLiteService) {
+				id := repo.UserID("cook@recipya.com")
+				if id != 2 || !isConfirmed(t, repo, id) || !repo.IsUserPassword(id, "secret") {
+					t.Fatalf("the user should have been added confirmed with their password: id %d", id)
+				}
+			},
+			wantOut: "Added user cook@recipya.com with ID 2.",
+		},
+		{
+			name: "add a user who exists",
+			run: func(_ *testing.T, repo *services.SQLiteService, w *bytes.Buffer) error {
+				return addUser(w, repo, "admin@recipya.com", "secret")
+			},
+			wantErr: `user "admin@recipya.com" already exists`,
+		},
+		{
+			name: "add a user with an invalid email",
+			run: func(_ *testing.T, repo *services.SQLiteService, w *bytes.Buffer) error {
+				return addUser(w, repo, "not an email", "secret")
+			},
+			check: func(t *testing.T, repo *services.SQLiteService) {
+				if len(repo.Users()) != 1 {
+					t.Fatal("the user should not have been added")
+				}
+			},
+			wantErr: `invalid email address "not an email"`,
+		},
+		{
+			name: "delete a user",
+			run: func(t *testing.T, repo *services.SQLiteService, w *bytes.Buffer) error {
+				_, err := repo.Register("cook@recipya.com", "hashed")
+				if err != nil {
+					t.Fatal(err)
+				}
+				return deleteUser(w, repo, "cook@recipya.com")
+			},
+			check: func(t *testing.T, repo *services.SQLiteService) {
+				if repo.UserID("cook@recipya.com") != -1 {
+					t.Fatal("the user should have been deleted")
+				}
+			},
+			wantOut: "Deleted user cook@recipya.com.",
+		},
+		{
+			name: "delete the administrator",
+			run: func(_ *testing.T, repo *services.SQLiteService, w *bytes.Buffer) error {
+				return deleteUser(w, repo, "admin@recipya.com")
+			},
+			wantErr: "the administrator cannot be deleted",
+		},
+		{
+			name: "delete a user who does not exist",
+			run: func(_ *testing.T, repo *services.SQLiteService, w *bytes.Buffer) error {
+				return deleteUser(w, repo, "ghost@recipya.com")
+			},
+			wantErr: `user "ghost@recipya.com" not found`,
+		},
+		{
+			name: "set the password",
+			run: func(t *testing.T, repo *services.SQLiteService, w *bytes.Buffer) error {
+				err := repo.AddAuthToken("selector", "validator", 1)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				_, err = repo.AddAPIToken(models.APIToken{Name: "script", Scopes: []models.APIScope{models.APIScopeRead}, UserID: 1}, "api-selector", "hash")
+				if err != nil {
+					t.Fatal(err)
+				}
+				return setUserPassword(w, repo, "admin@recipya.com", "new password")
+			},
+			check: func(t *testing.T, repo *services.SQLiteService) {
+				if !repo.IsUserPassword(1, "new password") {
+					t.Fatal("the password should have been updated")
+				}
+				if _, err := repo.GetAuthToken("selector", "validator"); err == nil {
+					t.Fatal("the remember-me tokens should have been revoked")
+				}
+				if tokens, _ := repo.APITokens(1); len(tokens) > 0 {
+					t.Fatalf("the API tokens should have been revoked: %v", tokens)
+				}
+			},
+			wantOut: "Updated the password of admin@recipya.com.",
+		},
+		{
+			name: "set the password of a user who does not exist",
+			run: func(_ *testing.T, repo *services.SQLiteService, w *bytes.Buffer) error {
+				return setUserPassword(w, repo, "ghost@recipya.com", "new password")
+			},
+			wantErr: `user "ghost@recipya.com" not found`,
+		},
+		{
+			name: "confirm a user",
+			run: func(_ *testing.T, repo *services.SQLiteService, w *bytes.Buffer) error {
+				return confirmUser(w, repo, "admin@recipya.com")
+			},
+			check: func(t *testing.T, repo *services.SQLiteService) {
+				if !isConfirmed(t, repo, 1) {
+					t.Fatal("the user should have been confirmed")
+				}
+			},
+			wantOut: "Confirmed admin@recipya.com.",
+		},
+		{
+			name: "list the users",
+			run: func(t *testing.T, repo *services.SQLiteService, w *bytes.Buffer) error {
+				_, err := repo.Register("cook@recipya.com", "hashed")
+				if err != nil {
+					t.Fatal(err)
+				}
+				listUsers(w, repo)
+				return nil
+			},
+			wantOut: "ID  EMAIL              ROLE\n1   admin@recipya.com  admin\n2   cook@recipya.com   user\n",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := newTestRepository(t)
+			var out bytes.Buffer
+
+			err := tc.run(t, repo, &out)
+
+			if tc.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if tc.wantErr != "" && (err == nil || err.Error() != tc.wantErr) {
+				t.Fatalf("got error %v but want %q", err, tc.wantErr)
+			}
+			if !strings.Contains(out.String(), tc.wantOut) {
+				t.Fatalf("got output %q but want %q", out.String(), tc.wantOut)
+			}
+			if tc.check != nil {
+				tc.check(t, repo)
+			}
+		})
+	}
+}
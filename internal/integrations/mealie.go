@@ -70,14 +70,11 @@ func (m *MealieRecipe) Schema() models.RecipeSchema {
 		category = m.RecipeCategory[0].Name
 	}
 
-	var yield int16
-	if m.RecipeYieldQuantity > 0 {
-		v, _ := strconv.ParseInt(m.RecipeYield, 10, 16)
-		yield = int16(v)
-	} else if m.RecipeYieldQuantity > 0 {
-		yield = int16(m.RecipeYieldQuantity)
-	} else if m.RecipeServings > 0 {
-		yield = int16(m.RecipeServings)
+	yield := models.NewYield(m.RecipeYield)
+	if yield.Value == 0 && m.RecipeYieldQuantity > 0 {
+		yield = models.NewYield(strconv.FormatFloat(m.RecipeYieldQuantity, 'f', -1, 64) + " " + m.RecipeYield)
+	} else if yield.Value == 0 && m.RecipeServings > 0 {
+		yield.Value = m.RecipeServings
 	}
 
 	extractTimeMinutes := func(s string) int64 {
@@ -162,7 +159,7 @@ func (m *MealieRecipe) Schema() models.RecipeSchema {
 		PrepTime:        m.PrepTime,
 		Tools:           &models.Tools{Values: tools},
 		TotalTime:       m.TotalTime,
-		Yield:           &models.Yield{Value: yield},
+		Yield:           &yield,
 		URL:             m.OrgURL,
 	}
 }
@@ -860,13 +857,7 @@ func MealieImport(baseURL, username, password string, client *http.Client, uploa
 				dateModified, _ = time.Parse(time.DateOnly, before)
 			}
 
-			var yield int16
-			for _, s := range strings.Split(m.RecipeYield, " ") {
-				parsed, err := strconv.ParseInt(s, 10, 16)
-				if err == nil {
-					yield = int16(parsed)
-				}
-			}
+			yield := models.NewYield(m.RecipeYield)
 
 			normalizeTime := func(s string) string {
 				s = strings.TrimPrefix(s, "PT")
@@ -973,7 +964,8 @@ func MealieImport(baseURL, username, password string, client *http.Client, uploa
 				Tools:     m.Tools,
 				UpdatedAt: dateModified,
 				URL:       source,
-				Yield:     yield,
+				Yield:     yield.Value,
+				YieldUnit: yield.Unit,
 			}
 
 			mu.Lock()
@@ -221,9 +221,13 @@ func TandoorImport(baseURL, username, password string, client *http.Client, uplo
 		}
 		_ = res.Body.Close()
 
-		yield := int16(t.Servings)
-		if yield == 0 {
-			yield = 1
+		yield := models.Yield{Value: float64(t.Servings)}
+		if yield.Value == 0 {
+			yield.Value = 1
+		}
+
+		if models.NewYield(t.ServingsText).Value == 0 {
+			yield.Unit = models.NewYield("1 " + t.ServingsText).Unit
 		}
 
 		var images []uuid.UUID
@@ -294,7 +298,8 @@ func TandoorImport(baseURL, username, password string, client *http.Client, uplo
 			},
 			UpdatedAt: t.UpdatedAt.UTC(),
 			URL:       src,
-			Yield:     yield,
+			Yield:     yield.Value,
+			YieldUnit: yield.Unit,
 		})
 	}
 	return recipes, nil
@@ -22,10 +22,10 @@ import (
 //
 // - Send queued emails
 //
-// - Backup data
+// - Backup data: The onBackup function is called once all backups succeeded.
 //
 // - Check for a new release
-func ScheduleCronJobs(repo services.RepositoryService, files services.FilesService, email services.EmailService) {
+func ScheduleCronJobs(repo services.RepositoryService, files services.FilesService, email services.EmailService, onBackup func()) {
 	scheduler := gocron.NewScheduler(time.UTC)
 
 	// Clean Images
@@ -70,6 +70,9 @@ func ScheduleCronJobs(repo services.RepositoryService, files services.FilesServi
 		}
 
 		slog.Info("Backup successful")
+		if onBackup != nil {
+			onBackup()
+		}
 	})
 
 	// Check for a new release
@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"slices"
+	"strings"
+	"time"
+)
 
 // NewAuthToken creates a new AuthToken.
 func NewAuthToken(id int64, selector, hashValidator string, expiresSeconds, userID int64) *AuthToken {
@@ -26,3 +30,57 @@ type AuthToken struct {
 func (a *AuthToken) IsExpired() bool {
 	return time.Now().After(a.Expires)
 }
+
+// APIScope is a permission granted to an APIToken.
+type APIScope string
+
+// These constants define the scopes an APIToken may be granted.
+const (
+	APIScopeRead  APIScope = "read"
+	APIScopeWrite APIScope = "write"
+)
+
+// NewAPIScopes parses a comma-separated list of scopes. Unknown scopes are ignored.
+func NewAPIScopes(s string) []APIScope {
+	scopes := make([]APIScope, 0, 2)
+	for _, part := range strings.Split(s, ",") {
+		scope := APIScope(strings.ToLower(strings.TrimSpace(part)))
+		switch scope {
+		case APIScopeRead, APIScopeWrite:
+			if !slices.Contains(scopes, scope) {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	return scopes
+}
+
+// APIToken holds details on a personal API access token. The secret part of the token is never stored.
+type APIToken struct {
+	ID         int64
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	LastUsedAt time.Time
+	Name       string
+	Scopes     []APIScope
+	UserID     int64
+}
+
+// HasScope verifies whether the token was granted the scope.
+func (a *APIToken) HasScope(scope APIScope) bool {
+	return slices.Contains(a.Scopes, scope)
+}
+
+// IsExpired verifies whether the API token is expired. A token without an expiry date never expires.
+func (a *APIToken) IsExpired() bool {
+	return !a.ExpiresAt.IsZero() && time.Now().After(a.ExpiresAt)
+}
+
+// ScopesString returns the token's scopes as a comma-separated list.
+func (a *APIToken) ScopesString() string {
+	xs := make([]string, len(a.Scopes))
+	for i, scope := range a.Scopes {
+		xs[i] = string(scope)
+	}
+	return strings.Join(xs, ",")
+}
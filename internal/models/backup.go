@@ -1,10 +1,31 @@
 package models
 
-// UserBackup holds components related to a user backup.
+import "time"
+
+// BackupVerification holds the outcome of checking a backup's consistency.
+type BackupVerification struct {
+	Date     time.Time
+	Images   int
+	Problems []string
+	Recipes  int
+}
+
+// IsValid verifies whether the backup can be restored safely.
+func (b BackupVerification) IsValid() bool {
+	return len(b.Problems) == 0
+}
+
+// UserBackup holds components related to a user backup. The data of the recipes is keyed by the
+// position of the recipe in Recipes because neither the IDs nor the names identify a recipe of the backup.
 type UserBackup struct {
+	CookLog    map[int]CookLog // CookLog holds the cooks of the recipes.
 	DeleteSQL  string
 	ImagesPath string
 	InsertSQL  string
+	Notes      map[int]RecipeNotes // Notes holds the private notes of the recipes.
 	Recipes    Recipes
+	Revisions  map[int][]RecipeRevision // Revisions are the revisions of the recipes.
+	SubRecipes map[int][]SubRecipeLink  // SubRecipes are the ingredients referring to other recipes.
 	UserID     int64
+	Variants   map[int]int // Variants are the positions of the recipes the variants derive from.
 }
@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"maps"
 	"slices"
+	"strconv"
 	"sync"
 	"time"
 
@@ -122,6 +123,21 @@ func (b *Broker) Monitor() {
 	}()
 }
 
+// SendRecipeUpdated notifies the user's open views that the recipe was updated so that they can refresh.
+func (b *Broker) SendRecipeUpdated(recipeID, userID int64) {
+	b.send(userID, Message{Type: "recipeUpdated", Data: strconv.FormatInt(recipeID, 10)})
+}
+
+// SendTimerFinished rings the user's devices when a cook mode timer of the recipe has finished.
+// The timer identifies it so that the device that ran the timer does not ring twice.
+func (b *Broker) SendTimerFinished(recipeID int64, timer, label string, userID int64) {
+	b.send(userID, Message{
+		Type:  "timerFinished",
+		Data:  timer,
+		Toast: NewInfoToast("Timer finished", label, fmt.Sprintf("View /recipes/%d/cook", recipeID)),
+	})
+}
+
 // SendToast sends a toast notification to the user.
 func (b *Broker) SendToast(toast Toast, userID int64) {
 	userIDAttr := slog.Int64("userID", userID)
@@ -245,3 +261,17 @@ func (b *Broker) SendProgressStatus(title string, isToastVisible bool, value, nu
 		}
 	}
 }
+
+// send writes the message to every connection of the user. Users without an open
+// connection are skipped silently because they have no view to update.
+func (b *Broker) send(userID int64, msg Message) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	for i, c := range b.subscribers[userID] {
+		err := wsjson.Write(ctx, c, msg)
+		if err != nil {
+			slog.Error("Failed to send websocket message", "userID", userID, "type", msg.Type, "i", i, "error", err)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrConflict is the error for when an entity was modified elsewhere since the user loaded it.
+var ErrConflict = errors.New("modified since it was loaded")
+
+// FieldChange holds the saved and submitted values of a field that differ.
+type FieldChange struct {
+	Field     string
+	Saved     string
+	Submitted string
+}
+
+// RecipeChanges lists the fields of the submitted recipe that differ from the saved one.
+// It is used to show the user what changed when their edit conflicts with another one.
+func RecipeChanges(saved, submitted Recipe) []FieldChange {
+	tools := func(r Recipe) string {
+		xs := make([]string, 0, len(r.Tools))
+		for _, t := range r.Tools {
+			xs = append(xs, t.StringQuantity())
+		}
+		return strings.Join(xs, "\n")
+	}
+
+	fields := []FieldChange{
+		{Field: "Title", Saved: saved.Name, Submitted: submitted.Name},
+		{Field: "Category", Saved: saved.Category, Submitted: submitted.Category},
+		{Field: "Description", Saved: saved.Description, Submitted: submitted.Description},
+		{Field: "Yield", Saved: saved.YieldText(), Submitted: submitted.YieldText()},
+		{Field: "Source", Saved: saved.URL, Submitted: submitted.URL},
+		{Field: "Preparation time", Saved: saved.Times.Prep.String(), Submitted: submitted.Times.Prep.String()},
+		{Field: "Cooking time", Saved: saved.Times.Cook.String(), Submitted: submitted.Times.Cook.String()},
+		{Field: "Keywords", Saved: strings.Join(saved.Keywords, ", "), Submitted: strings.Join(submitted.Keywords, ", ")},
+		{Field: "Tools", Saved: tools(saved), Submitted: tools(submitted)},
+		{Field: "Ingredients", Saved: strings.Join(saved.Ingredients, "\n"), Submitted: strings.Join(submitted.Ingredients, "\n")},
+		{Field: "Instructions", Saved: strings.Join(saved.Instructions, "\n"), Submitted: strings.Join(submitted.Instructions, "\n")},
+	}
+
+	changes := make([]FieldChange, 0, len(fields))
+	for _, f := range fields {
+		if strings.TrimSpace(f.Saved) != strings.TrimSpace(f.Submitted) {
+			changes = append(changes, f)
+		}
+	}
+	return changes
+}
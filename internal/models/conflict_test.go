@@ -0,0 +1,43 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestRecipeChanges(t *testing.T) {
+	saved := models.Recipe{
+		Category:     "dinner",
+		Ingredients:  []string{"2 eggs", "1 cup milk"},
+		Instructions: []string{"Mix", "Bake"},
+		Name:         "Pancakes",
+		Times:        models.Times{Cook: 10 * time.Minute},
+		Yield:        4,
+	}
+
+	t.Run("same recipe", func(t *testing.T) {
+		submitted := saved
+		submitted.Name = " Pancakes "
+
+		if got := models.RecipeChanges(saved, submitted); len(got) > 0 {
+			t.Fatalf("got changes %+v but want none", got)
+		}
+	})
+
+	t.Run("lists the differing fields", func(t *testing.T) {
+		submitted := saved
+		submitted.Ingredients = []string{"3 eggs", "1 cup milk"}
+		submitted.Yield = 6
+
+		want := []models.FieldChange{
+			{Field: "Yield", Saved: "4 servings", Submitted: "6 servings"},
+			{Field: "Ingredients", Saved: "2 eggs\n1 cup milk", Submitted: "3 eggs\n1 cup milk"},
+		}
+		if got := models.RecipeChanges(saved, submitted); !cmp.Equal(got, want) {
+			t.Fatal(cmp.Diff(got, want))
+		}
+	})
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"cmp"
 	"slices"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -18,11 +19,12 @@ type Cookbooks struct {
 
 // Cookbook is the struct that holds information on a cookbook.
 type Cookbook struct {
-	ID      int64     `toml:"id"`
-	Count   int64     `toml:"count"`
-	Image   uuid.UUID `toml:"image"`
-	Recipes Recipes   `toml:"recipes"`
-	Title   string    `toml:"title"`
+	ID        int64     `toml:"id"`
+	Count     int64     `toml:"count"`
+	Image     uuid.UUID `toml:"image"`
+	Recipes   Recipes   `toml:"recipes"`
+	Title     string    `toml:"title"`
+	UpdatedAt time.Time `toml:"-"`
 }
 
 // DominantCategories returns the `n` most common categories of recipes in the cookbook.
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// MaxCookRating is the highest rating a user can give when logging a cook.
+const MaxCookRating = 5
+
+// CookLogEntry records a user cooking a recipe on a given day.
+type CookLogEntry struct {
+	CookedAt time.Time
+	ID       int64
+	Notes    string
+	Rating   int // Rating is from 1 to MaxCookRating. Zero means the cook was not rated.
+	RecipeID int64
+}
+
+// CookLog holds the cooks of a recipe, the most recent first.
+type CookLog []CookLogEntry
+
+// AverageRating computes the mean of the rated cooks. It returns 0 when no cook is rated.
+func (c CookLog) AverageRating() float64 {
+	var (
+		sum   int
+		count int
+	)
+
+	for _, e := range c {
+		if e.Rating > 0 {
+			sum += e.Rating
+			count++
+		}
+	}
+
+	if count == 0 {
+		return 0
+	}
+	return float64(sum) / float64(count)
+}
+
+// LastCookedAt returns the day the recipe was last cooked. It is zero when it was never cooked.
+func (c CookLog) LastCookedAt() time.Time {
+	var last time.Time
+	for _, e := range c {
+		if e.CookedAt.After(last) {
+			last = e.CookedAt
+		}
+	}
+	return last
+}
@@ -0,0 +1,48 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestCookLog_AverageRating(t *testing.T) {
+	testcases := []struct {
+		name string
+		in   models.CookLog
+		want float64
+	}{
+		{name: "empty", in: models.CookLog{}, want: 0},
+		{name: "no ratings", in: models.CookLog{{Rating: 0}, {Rating: 0}}, want: 0},
+		{name: "unrated cooks are ignored", in: models.CookLog{{Rating: 4}, {Rating: 0}, {Rating: 5}}, want: 4.5},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.in.AverageRating(); got != tc.want {
+				t.Fatalf("got %v but want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCookLog_LastCookedAt(t *testing.T) {
+	t.Run("never cooked", func(t *testing.T) {
+		if got := (models.CookLog{}).LastCookedAt(); !got.IsZero() {
+			t.Fatalf("got %v but want zero", got)
+		}
+	})
+
+	t.Run("most recent cook", func(t *testing.T) {
+		last := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+		cooks := models.CookLog{
+			{CookedAt: last.AddDate(0, -1, 0)},
+			{CookedAt: last},
+			{CookedAt: last.AddDate(-1, 0, 0)},
+		}
+
+		if got := cooks.LastCookedAt(); !got.Equal(last) {
+			t.Fatalf("got %v but want %v", got, last)
+		}
+	})
+}
@@ -0,0 +1,128 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/reaper47/recipya/internal/utils/duration"
+)
+
+// Timer is a duration written in an instruction of a recipe, e.g. "25-30 minutes" in "Bake for 25-30 minutes".
+type Timer struct {
+	Duration    time.Duration
+	DurationMax time.Duration // DurationMax is greater than Duration when the instruction gives a range.
+	Step        int           // Step is the index of the instruction.
+	Text        string        // Text is the duration as written in the instruction.
+}
+
+// NewTimers detects the timers of the instruction at the given index.
+func NewTimers(step int, instruction string) []Timer {
+	spans := duration.FindSpans(instruction)
+	if len(spans) == 0 {
+		return nil
+	}
+
+	timers := make([]Timer, 0, len(spans))
+	for _, span := range spans {
+		timers = append(timers, Timer{
+			Duration:    span.Min,
+			DurationMax: span.Max,
+			Step:        step,
+			Text:        span.Text,
+		})
+	}
+	return timers
+}
+
+// Label formats the duration of the timer, e.g. "1 h 30 min" or "25 min – 30 min".
+func (t Timer) Label() string {
+	if t.DurationMax <= t.Duration {
+		return formatTimer(t.Duration)
+	}
+	return formatTimer(t.Duration) + " – " + formatTimer(t.DurationMax)
+}
+
+// formatTimer formats a duration in hours, minutes and seconds, omitting the empty parts.
+func formatTimer(d time.Duration) string {
+	d = d.Round(time.Second)
+	h := d / time.Hour
+	m := (d % time.Hour) / time.Minute
+	s := (d % time.Minute) / time.Second
+
+	var parts []string
+	if h > 0 {
+		parts = append(parts, fmt.Sprintf("%d h", h))
+	}
+
+	if m > 0 {
+		parts = append(parts, fmt.Sprintf("%d min", m))
+	}
+
+	if s > 0 || len(parts) == 0 {
+		parts = append(parts, fmt.Sprintf("%d s", s))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Timers detects the timers written in the instructions of the recipe.
+func (r *Recipe) Timers() []Timer {
+	var timers []Timer
+	for i, ins := range r.Instructions {
+		timers = append(timers, NewTimers(i, ins)...)
+	}
+	return timers
+}
+
+// CookStep is an instruction of a recipe as presented in cook mode.
+type CookStep struct {
+	Ingredients []string // Ingredients are the ingredients the instruction mentions.
+	Instruction string
+	Number      int
+	Section     string // Section is the name of the section the instruction belongs to, if any.
+	Timers      []Timer
+}
+
+// CookSteps breaks the recipe down into the steps followed in cook mode, one per instruction.
+func (r *Recipe) CookSteps() []CookStep {
+	ingredients := NewIngredients(r.Ingredients, r.StructuredIngredients)
+
+	steps := make([]CookStep, 0, len(r.Instructions))
+	var section string
+	for i, ins := range r.Instructions {
+		if name := r.InstructionSections.At(i); name != "" {
+			section = name
+		}
+
+		steps = append(steps, CookStep{
+			Ingredients: mentionedIngredients(ins, ingredients),
+			Instruction: ins,
+			Number:      i + 1,
+			Section:     section,
+			Timers:      NewTimers(i, ins),
+		})
+	}
+	return steps
+}
+
+// mentionedIngredients returns the text of the ingredients the instruction mentions. An ingredient
+// is mentioned when the last word of its name is in the instruction, e.g. "flour" for "all-purpose flour".
+func mentionedIngredients(instruction string, ingredients []Ingredient) []string {
+	words := strings.FieldsFunc(strings.ToLower(instruction), func(r rune) bool { return !unicode.IsLetter(r) })
+
+	var mentioned []string
+	for _, in := range ingredients {
+		name := strings.FieldsFunc(strings.ToLower(in.Name), func(r rune) bool { return !unicode.IsLetter(r) })
+		if len(name) == 0 {
+			continue
+		}
+
+		last := name[len(name)-1]
+		if slices.ContainsFunc(words, func(w string) bool { return isSameIngredient(w, last) }) {
+			mentioned = append(mentioned, in.Text)
+		}
+	}
+	return mentioned
+}
@@ -0,0 +1,85 @@
+package models_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestTimer_Label(t *testing.T) {
+	testcases := []struct {
+		name string
+		in   models.Timer
+		want string
+	}{
+		{name: "minutes", in: models.Timer{Duration: 10 * time.Minute}, want: "10 min"},
+		{name: "hours and minutes", in: models.Timer{Duration: 90 * time.Minute}, want: "1 h 30 min"},
+		{name: "seconds", in: models.Timer{Duration: 45 * time.Second}, want: "45 s"},
+		{name: "range", in: models.Timer{Duration: 25 * time.Minute, DurationMax: 30 * time.Minute}, want: "25 min – 30 min"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.in.Label(); got != tc.want {
+				t.Fatalf("got %q but want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRecipe_Timers(t *testing.T) {
+	r := models.Recipe{Instructions: []string{
+		"Preheat the oven to 350 F.",
+		"Bake for 25–30 minutes.",
+		"Let rest 1 hour, then glaze and chill 10 mins.",
+	}}
+
+	got := r.Timers()
+
+	want := []models.Timer{
+		{Duration: 25 * time.Minute, DurationMax: 30 * time.Minute, Step: 1, Text: "25–30 minutes"},
+		{Duration: time.Hour, DurationMax: time.Hour, Step: 2, Text: "1 hour"},
+		{Duration: 10 * time.Minute, DurationMax: 10 * time.Minute, Step: 2, Text: "10 mins"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v but want %+v", got, want)
+	}
+}
+
+func TestRecipe_CookSteps(t *testing.T) {
+	r := models.Recipe{
+		Ingredients: []string{"2 eggs", "1 cup all-purpose flour, sifted", "2 tomatoes", "salt"},
+		Instructions: []string{
+			"Whisk the egg with the flour.",
+			"Add the tomato and cook 5 minutes.",
+			"Serve.",
+		},
+		InstructionSections: models.Sections{{Index: 1, Name: "Sauce"}},
+	}
+
+	got := r.CookSteps()
+
+	want := []models.CookStep{
+		{
+			Ingredients: []string{"2 eggs", "1 cup all-purpose flour, sifted"},
+			Instruction: "Whisk the egg with the flour.",
+			Number:      1,
+		},
+		{
+			Ingredients: []string{"2 tomatoes"},
+			Instruction: "Add the tomato and cook 5 minutes.",
+			Number:      2,
+			Section:     "Sauce",
+			Timers:      []models.Timer{{Duration: 5 * time.Minute, DurationMax: 5 * time.Minute, Step: 1, Text: "5 minutes"}},
+		},
+		{
+			Instruction: "Serve.",
+			Number:      3,
+			Section:     "Sauce",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v but want %+v", got, want)
+	}
+}
@@ -0,0 +1,311 @@
+package models
+
+import (
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/reaper47/recipya/internal/units"
+	"github.com/reaper47/recipya/internal/utils/extensions"
+)
+
+// commonFractions are the fractions in which the quantities of ingredients are written.
+var commonFractions = []struct {
+	value float64
+	text  string
+}{
+	{value: 1.0 / 8, text: "1/8"},
+	{value: 1.0 / 4, text: "1/4"},
+	{value: 1.0 / 3, text: "1/3"},
+	{value: 3.0 / 8, text: "3/8"},
+	{value: 1.0 / 2, text: "1/2"},
+	{value: 5.0 / 8, text: "5/8"},
+	{value: 2.0 / 3, text: "2/3"},
+	{value: 3.0 / 4, text: "3/4"},
+	{value: 7.0 / 8, text: "7/8"},
+}
+
+var (
+	ingredientQuantityRegex = regexp.MustCompile(`^(\d+\s+\d+/\d+|\d+/\d+|\d+(?:[.,]\d+)?)(?:\s*(?:-|–|to)\s*(\d+\s+\d+/\d+|\d+/\d+|\d+(?:[.,]\d+)?))?\s*`)
+	ingredientNoteRegex     = regexp.MustCompile(`\s*\(([^)]*)\)`)
+	ingredientOptionalRegex = regexp.MustCompile(`(?i)\boptional\b[,:]?`)
+)
+
+// Ingredient is an ingredient of a recipe broken down into its parts, e.g.
+// "2-3 cups all-purpose flour, sifted (optional)" has a quantity of 2 to 3 cups of
+// all-purpose flour, a "sifted" preparation note and is optional.
+type Ingredient struct {
+	IsOptional  bool
+	Name        string
+	Note        string
+	Quantity    float64
+	QuantityMax float64
+	RecipeID    int64 // RecipeID is the ID of the recipe the ingredient refers to, if any. The quantity is then in servings of that recipe.
+	Text        string
+	Unit        units.Unit
+}
+
+// NewIngredient parses the text of an ingredient into its parts.
+// The parsed text is kept in the Text field.
+func NewIngredient(text string) Ingredient {
+	text = strings.Join(strings.Fields(text), " ")
+	in := Ingredient{Text: text}
+
+	var notes []string
+	s := ingredientNoteRegex.ReplaceAllStringFunc(units.ReplaceVulgarFractions(text), func(match string) string {
+		notes = append(notes, ingredientNoteRegex.FindStringSubmatch(match)[1])
+		return ""
+	})
+
+	s, note, _ := strings.Cut(s, ",")
+	notes = append([]string{note}, notes...)
+	for i, note := range notes {
+		var isOptional bool
+		notes[i], isOptional = stripOptional(note)
+		in.IsOptional = in.IsOptional || isOptional
+	}
+	in.Note = strings.Join(slices.DeleteFunc(notes, func(note string) bool { return note == "" }), ", ")
+
+	s, isOptional := stripOptional(s)
+	in.IsOptional = in.IsOptional || isOptional
+
+	s = strings.TrimSpace(s)
+	if matches := ingredientQuantityRegex.FindStringSubmatch(s); matches != nil {
+		in.Quantity = parseIngredientQuantity(matches[1])
+		in.QuantityMax = parseIngredientQuantity(matches[2])
+		s = s[len(matches[0]):]
+	}
+
+	if in.Quantity > 0 {
+		in.Unit, s = parseIngredientUnit(s)
+	}
+
+	s = strings.TrimPrefix(s, "of ")
+	in.Name = strings.TrimSpace(s)
+	return in
+}
+
+// NewIngredients parses the texts of the ingredients of a recipe. An already parsed
+// ingredient whose text has not changed is reused so that manual edits to its parts are kept.
+func NewIngredients(texts []string, parsed []Ingredient) []Ingredient {
+	known := make(map[string]Ingredient, len(parsed))
+	for _, in := range parsed {
+		known[in.Text] = in
+	}
+
+	ingredients := make([]Ingredient, 0, len(texts))
+	for _, text := range texts {
+		in, ok := known[text]
+		if !ok {
+			in = NewIngredient(text)
+		}
+		ingredients = append(ingredients, in)
+	}
+	return ingredients
+}
+
+// stripOptional removes the word "optional" from the text and reports whether it was found.
+func stripOptional(s string) (string, bool) {
+	isOptional := ingredientOptionalRegex.MatchString(s)
+	if isOptional {
+		s = ingredientOptionalRegex.ReplaceAllString(s, "")
+	}
+	return strings.Trim(strings.Join(strings.Fields(s), " "), " ,;:"), isOptional
+}
+
+// parseIngredientQuantity parses quantities such as 2, 1.5, 1,5, 1/2 and 1 1/2.
+func parseIngredientQuantity(s string) float64 {
+	var total float64
+	for _, part := range strings.Fields(strings.ReplaceAll(s, ",", ".")) {
+		num, den, isFraction := strings.Cut(part, "/")
+		n, err := strconv.ParseFloat(num, 64)
+		if err != nil {
+			return 0
+		}
+
+		if isFraction {
+			d, err := strconv.ParseFloat(den, 64)
+			if err != nil || d == 0 {
+				return 0
+			}
+			n /= d
+		}
+		total += n
+	}
+	return total
+}
+
+// parseIngredientUnit extracts the unit at the beginning of the text, if any. Units
+// of two words, e.g. fl oz, are tried first.
+func parseIngredientUnit(s string) (units.Unit, string) {
+	words := strings.Fields(s)
+	for n := min(2, len(words)); n > 0; n-- {
+		u := units.NewUnit(strings.Join(words[:n], " "))
+		if u != units.Invalid {
+			return u, strings.Join(words[n:], " ")
+		}
+	}
+	return units.Invalid, s
+}
+
+// Measurement returns the quantity of the ingredient. The upper bound of a range is ignored.
+func (i Ingredient) Measurement() units.Measurement {
+	return units.Measurement{Quantity: i.Quantity, Unit: i.Unit}
+}
+
+// Scale scales the quantity of the ingredient by the multiplier. The unit may change
+// to a more readable one, e.g. 16 tbsp becomes 1 cup.
+func (i Ingredient) Scale(multiplier float64) Ingredient {
+	if i.Quantity == 0 {
+		return i
+	}
+
+	if i.Unit == units.Invalid {
+		i.Quantity *= multiplier
+		i.QuantityMax *= multiplier
+	} else {
+		m := i.Measurement().Scale(multiplier)
+		if i.QuantityMax > 0 {
+			maxM, err := units.Measurement{Quantity: i.QuantityMax * multiplier, Unit: i.Unit}.Convert(m.Unit)
+			if err == nil {
+				i.QuantityMax = maxM.Quantity
+			}
+		}
+		i.Quantity = m.Quantity
+		i.Unit = m.Unit
+	}
+
+	i.Text = i.rewrite()
+	return i
+}
+
+// ConvertSystem converts the quantity of the ingredient to the units.System.
+// The ingredient is unchanged when it has no unit.
+func (i Ingredient) ConvertSystem(to units.System) Ingredient {
+	if i.Quantity == 0 || i.Unit == units.Invalid {
+		return i
+	}
+
+	m := i.Measurement().ConvertSystem(to)
+	if m.Unit == units.Invalid || m.Unit == i.Unit {
+		return i
+	}
+
+	if i.QuantityMax > 0 {
+		maxM, err := units.Measurement{Quantity: i.QuantityMax, Unit: i.Unit}.Convert(m.Unit)
+		if err == nil {
+			i.QuantityMax = maxM.Quantity
+		}
+	}
+	i.Quantity = m.Quantity
+	i.Unit = m.Unit
+
+	i.Text = i.rewrite()
+	return i
+}
+
+// withText parses the ingredient anew when its text changed. The recipe it refers to is kept.
+func (i Ingredient) withText(text string) Ingredient {
+	if text == i.Text {
+		return i
+	}
+
+	in := NewIngredient(text)
+	in.RecipeID = i.RecipeID
+	return in
+}
+
+// rewrite writes the quantity and the unit of the ingredient in place of those at the beginning of
+// its text, so that the rest of the text stays as written. The ingredient is assembled back into text
+// when the rest of the text no longer describes it.
+func (i Ingredient) rewrite() string {
+	parsed := NewIngredient(i.Text)
+	if parsed.Name != i.Name || parsed.Note != i.Note || parsed.IsOptional != i.IsOptional {
+		return i.String()
+	}
+
+	s := units.ReplaceVulgarFractions(i.Text)
+	matches := ingredientQuantityRegex.FindStringSubmatch(s)
+	if matches == nil {
+		return i.String()
+	}
+
+	rest := s[len(matches[0]):]
+	if parsed.Unit != units.Invalid {
+		_, rest = parseIngredientUnit(rest)
+	}
+	return strings.TrimSpace(i.measurement() + " " + rest)
+}
+
+// measurement writes the quantity and the unit of the ingredient, e.g. 1-1 1/2 cups.
+func (i Ingredient) measurement() string {
+	if i.Quantity == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(formatIngredientQuantity(i.Quantity))
+	if i.QuantityMax > i.Quantity {
+		sb.WriteString("-" + formatIngredientQuantity(i.QuantityMax))
+	}
+
+	if i.Unit != units.Invalid {
+		_, unit, _ := strings.Cut(units.Measurement{Quantity: max(i.Quantity, i.QuantityMax), Unit: i.Unit}.String(), " ")
+		sb.WriteString(" " + unit)
+	}
+	return sb.String()
+}
+
+// formatIngredientQuantity writes the quantity with a fraction when it has a common one, e.g. 1 1/2.
+func formatIngredientQuantity(q float64) string {
+	whole, frac := math.Modf(q)
+	for _, f := range commonFractions {
+		if math.Abs(frac-f.value) < 0.01 {
+			if whole == 0 {
+				return f.text
+			}
+			return strconv.FormatFloat(whole, 'f', 0, 64) + " " + f.text
+		}
+	}
+	return extensions.FloatToString(q, "%.2f")
+}
+
+// Schema converts the Ingredient to its IngredientSchema. The recipe it refers to is omitted
+// because its ID is specific to the database.
+func (i Ingredient) Schema() IngredientSchema {
+	var unit string
+	if i.Unit != units.Invalid {
+		unit = i.Unit.String()
+	}
+
+	return IngredientSchema{
+		IsOptional:  i.IsOptional,
+		Name:        i.Name,
+		Note:        i.Note,
+		Quantity:    i.Quantity,
+		QuantityMax: i.QuantityMax,
+		Text:        i.Text,
+		Unit:        unit,
+	}
+}
+
+// String assembles the parts of the ingredient back into text.
+func (i Ingredient) String() string {
+	var sb strings.Builder
+	if m := i.measurement(); m != "" {
+		sb.WriteString(m + " ")
+	}
+
+	sb.WriteString(i.Name)
+
+	if i.Note != "" {
+		sb.WriteString(", " + i.Note)
+	}
+
+	if i.IsOptional {
+		sb.WriteString(" (optional)")
+	}
+	return strings.TrimSpace(sb.String())
+}
@@ -0,0 +1,158 @@
+package models_test
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/units"
+)
+
+func TestNewIngredient(t *testing.T) {
+	testcases := []struct {
+		in   string
+		want models.Ingredient
+	}{
+		{
+			in:   "2 cups all-purpose flour",
+			want: models.Ingredient{Name: "all-purpose flour", Quantity: 2, Unit: units.Cup},
+		},
+		{
+			in:   "2-3 cups all-purpose flour, sifted (optional)",
+			want: models.Ingredient{IsOptional: true, Name: "all-purpose flour", Note: "sifted", Quantity: 2, QuantityMax: 3, Unit: units.Cup},
+		},
+		{
+			in:   "1½ tbsp. of olive oil",
+			want: models.Ingredient{Name: "olive oil", Quantity: 1.5, Unit: units.Tablespoon},
+		},
+		{
+			in:   "200g butter (softened)",
+			want: models.Ingredient{Name: "butter", Note: "softened", Quantity: 200, Unit: units.Gram},
+		},
+		{
+			in:   "1 to 2 fl oz cream",
+			want: models.Ingredient{Name: "cream", Quantity: 1, QuantityMax: 2, Unit: units.FlOz},
+		},
+		{
+			in:   "3 large eggs",
+			want: models.Ingredient{Name: "large eggs", Quantity: 3},
+		},
+		{
+			in:   "1 (14 oz) can diced tomatoes",
+			want: models.Ingredient{Name: "can diced tomatoes", Note: "14 oz", Quantity: 1},
+		},
+		{
+			in:   "Salt and pepper, to taste",
+			want: models.Ingredient{Name: "Salt and pepper", Note: "to taste"},
+		},
+		{
+			in:   "fresh parsley (optional, for garnish)",
+			want: models.Ingredient{IsOptional: true, Name: "fresh parsley", Note: "for garnish"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			tc.want.Text = tc.in
+
+			got := models.NewIngredient(tc.in)
+
+			if got != tc.want {
+				t.Fatalf("got %+v but want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewIngredients(t *testing.T) {
+	edited := models.Ingredient{Name: "cake flour", Quantity: 2, Text: "2 cups flour", Unit: units.Cup}
+
+	got := models.NewIngredients([]string{"1 egg", "2 cups flour"}, []models.Ingredient{edited})
+
+	want := []models.Ingredient{
+		{Name: "egg", Quantity: 1, Text: "1 egg"},
+		edited,
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %+v but want %+v", got, want)
+	}
+}
+
+func TestIngredient_Scale(t *testing.T) {
+	testcases := []struct {
+		name       string
+		in         string
+		multiplier float64
+		want       string
+	}{
+		{name: "unit", in: "1 cup milk", multiplier: 2, want: "2 cups milk"},
+		{name: "range", in: "2-3 cups flour, sifted (optional)", multiplier: 0.5, want: "1-1 1/2 cups flour, sifted (optional)"},
+		{name: "no unit", in: "3 eggs", multiplier: 2, want: "6 eggs"},
+		{name: "no quantity", in: "Salt, to taste", multiplier: 4, want: "Salt, to taste"},
+		{name: "keeps the wording", in: "1 tablespoon adobo sauce (from canned chipotle peppers)", multiplier: 2, want: "2 tbsp adobo sauce (from canned chipotle peppers)"},
+		{name: "smaller unit", in: "1 1/2 cups sugar", multiplier: 1.0 / 3, want: "8 tbsp sugar"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := models.NewIngredient(tc.in).Scale(tc.multiplier)
+
+			if got.Text != tc.want {
+				t.Fatalf("got %q but want %q", got.Text, tc.want)
+			}
+		})
+	}
+}
+
+func TestIngredient_ConvertSystem(t *testing.T) {
+	testcases := []struct {
+		name string
+		in   string
+		to   units.System
+		want models.Ingredient
+	}{
+		{
+			name: "imperial to metric",
+			in:   "2 cups milk, warm",
+			to:   units.MetricSystem,
+			want: models.Ingredient{Name: "milk", Note: "warm", Quantity: 4.73176, Text: "4.73 dl milk, warm", Unit: units.Decilitre},
+		},
+		{
+			name: "metric to imperial",
+			in:   "500 g flour",
+			to:   units.ImperialSystem,
+			want: models.Ingredient{Name: "flour", Quantity: 1.10231, Text: "1.1 lb flour", Unit: units.Pound},
+		},
+		{
+			name: "same system",
+			in:   "500 g flour",
+			to:   units.MetricSystem,
+			want: models.Ingredient{Name: "flour", Quantity: 500, Text: "500 g flour", Unit: units.Gram},
+		},
+		{
+			name: "no unit",
+			in:   "3 eggs",
+			to:   units.MetricSystem,
+			want: models.Ingredient{Name: "eggs", Quantity: 3, Text: "3 eggs"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := models.NewIngredient(tc.in).ConvertSystem(tc.to)
+
+			if got.Text != tc.want.Text || got.Unit != tc.want.Unit || math.Abs(got.Quantity-tc.want.Quantity) > 0.001 {
+				t.Fatalf("got %+v but want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIngredient_Schema(t *testing.T) {
+	in := models.Ingredient{IsOptional: true, Name: "cake flour", Note: "sifted", Quantity: 2, QuantityMax: 3, RecipeID: 4, Text: "2-3 cups flour", Unit: units.Cup}
+
+	got := in.Schema().Ingredient()
+
+	in.RecipeID = 0
+	if got != in {
+		t.Fatalf("got %+v but want %+v", got, in)
+	}
+}
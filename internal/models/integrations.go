@@ -3,7 +3,6 @@ package models
 import (
 	"github.com/google/uuid"
 	"github.com/reaper47/recipya/internal/utils/duration"
-	"strconv"
 	"strings"
 	"time"
 )
@@ -62,15 +61,7 @@ func (p PaprikaRecipe) Recipe(image uuid.UUID) Recipe {
 	var dateCreated time.Time
 	dateCreated, _ = time.Parse(time.DateTime, p.Created)
 
-	var yield int16
-	parts := strings.Split(p.Servings, " ")
-	for _, part := range parts {
-		parsed, err := strconv.ParseInt(part, 10, 16)
-		if err == nil {
-			yield = int16(parsed)
-			break
-		}
-	}
+	yield := NewYield(p.Servings)
 
 	source := p.SourceURL
 	if source == "" {
@@ -104,6 +95,7 @@ func (p PaprikaRecipe) Recipe(image uuid.UUID) Recipe {
 		Tools:     make([]HowToItem, 0),
 		UpdatedAt: dateCreated,
 		URL:       source,
-		Yield:     yield,
+		Yield:     yield.Value,
+		YieldUnit: yield.Unit,
 	}
 }
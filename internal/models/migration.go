@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// Migration holds information on a migration of the database's schema.
+type Migration struct {
+	AppliedAt time.Time
+	IsApplied bool
+	Name      string
+	Version   int64
+}
@@ -0,0 +1,174 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NoteAnchor is the part of a recipe a private note is attached to.
+type NoteAnchor string
+
+// These constants define the parts of a recipe a note can be attached to.
+const (
+	NoteAnchorRecipe      NoteAnchor = "recipe"
+	NoteAnchorIngredient  NoteAnchor = "ingredient"
+	NoteAnchorInstruction NoteAnchor = "instruction"
+)
+
+// RecipeNote is a private note a user attached to one of their recipes. It is kept apart
+// from the recipe so that it is never shared with other users.
+type RecipeNote struct {
+	Anchor   NoteAnchor
+	ID       int64
+	Index    int // Index is the position of the ingredient or instruction the note is attached to.
+	RecipeID int64
+	Text     string
+}
+
+// NewRecipeNote creates a note attached to the target of the recipe. The target is either
+// "recipe" or the anchor followed by the index of the item, e.g. "ingredient:2".
+func NewRecipeNote(recipe *Recipe, target, text string) (RecipeNote, error) {
+	note := RecipeNote{
+		Anchor:   NoteAnchorRecipe,
+		RecipeID: recipe.ID,
+		Text:     strings.TrimSpace(text),
+	}
+
+	if note.Text == "" {
+		return RecipeNote{}, errors.New("the note is empty")
+	}
+
+	if target == "" || target == string(NoteAnchorRecipe) {
+		return note, nil
+	}
+
+	before, after, ok := strings.Cut(target, ":")
+	if !ok {
+		return RecipeNote{}, fmt.Errorf("invalid note target %q", target)
+	}
+
+	index, err := strconv.Atoi(after)
+	if err != nil || index < 0 {
+		return RecipeNote{}, fmt.Errorf("invalid note target %q", target)
+	}
+
+	var n int
+	switch NoteAnchor(before) {
+	case NoteAnchorIngredient:
+		n = len(recipe.Ingredients)
+	case NoteAnchorInstruction:
+		n = len(recipe.Instructions)
+	default:
+		return RecipeNote{}, fmt.Errorf("invalid note target %q", target)
+	}
+
+	if index >= n {
+		return RecipeNote{}, fmt.Errorf("the recipe has no %s %d", before, index+1)
+	}
+
+	note.Anchor = NoteAnchor(before)
+	note.Index = index
+	return note, nil
+}
+
+// Label describes what the note is attached to. It is empty for notes about the whole recipe.
+func (n RecipeNote) Label() string {
+	switch n.Anchor {
+	case NoteAnchorIngredient:
+		return "Ingredient " + strconv.Itoa(n.Index+1)
+	case NoteAnchorInstruction:
+		return "Step " + strconv.Itoa(n.Index+1)
+	default:
+		return ""
+	}
+}
+
+// RecipeNotes holds the private notes of a recipe.
+type RecipeNotes []RecipeNote
+
+// General returns the notes about the whole recipe along with the notes whose ingredient or
+// instruction no longer exists because the recipe was edited since.
+func (n RecipeNotes) General(numIngredients, numInstructions int) RecipeNotes {
+	var notes RecipeNotes
+	for _, note := range n {
+		switch {
+		case note.Anchor == NoteAnchorIngredient && note.Index < numIngredients,
+			note.Anchor == NoteAnchorInstruction && note.Index < numInstructions:
+			continue
+		default:
+			notes = append(notes, note)
+		}
+	}
+	return notes
+}
+
+// GroupByRecipe groups the notes by the ID of their recipe.
+func (n RecipeNotes) GroupByRecipe() map[int64]RecipeNotes {
+	groups := make(map[int64]RecipeNotes)
+	for _, note := range n {
+		groups[note.RecipeID] = append(groups[note.RecipeID], note)
+	}
+	return groups
+}
+
+// Remap moves the notes attached to the items of the anchor to the position their item has in
+// updated, the items of the recipe once edited. The notes whose item was edited or removed become
+// notes about the whole recipe. It returns the notes that changed.
+func (n RecipeNotes) Remap(anchor NoteAnchor, old, updated []string) RecipeNotes {
+	positions := make(map[string][]int)
+	for i, item := range updated {
+		positions[item] = append(positions[item], i)
+	}
+
+	moved := make(map[int]int, len(old))
+	for i, item := range old {
+		if len(positions[item]) == 0 {
+			moved[i] = -1
+			continue
+		}
+		moved[i] = positions[item][0]
+		positions[item] = positions[item][1:]
+	}
+
+	var changed RecipeNotes
+	for _, note := range n {
+		if note.Anchor != anchor {
+			continue
+		}
+
+		index, ok := moved[note.Index]
+		if ok && index == note.Index {
+			continue
+		}
+
+		if !ok || index == -1 {
+			note.Anchor = NoteAnchorRecipe
+			index = 0
+		}
+		note.Index = index
+		changed = append(changed, note)
+	}
+	return changed
+}
+
+// Ingredient returns the notes attached to the ingredient at the index.
+func (n RecipeNotes) Ingredient(index int) RecipeNotes {
+	return n.anchoredTo(NoteAnchorIngredient, index)
+}
+
+// Instruction returns the notes attached to the instruction at the index.
+func (n RecipeNotes) Instruction(index int) RecipeNotes {
+	return n.anchoredTo(NoteAnchorInstruction, index)
+}
+
+func (n RecipeNotes) anchoredTo(anchor NoteAnchor, index int) RecipeNotes {
+	var notes RecipeNotes
+	for _, note := range n {
+		if note.Anchor == anchor && note.Index == index {
+			notes = append(notes, note)
+		}
+	}
+	return notes
+}
@@ -0,0 +1,143 @@
+package models_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestNewRecipeNote(t *testing.T) {
+	recipe := &models.Recipe{
+		ID:           3,
+		Ingredients:  []string{"1 cup flour", "2 eggs"},
+		Instructions: []string{"Mix", "Bake"},
+	}
+
+	valid := []struct {
+		name   string
+		target string
+		want   models.RecipeNote
+	}{
+		{name: "no target", target: "", want: models.RecipeNote{Anchor: models.NoteAnchorRecipe, RecipeID: 3, Text: "Great"}},
+		{name: "whole recipe", target: "recipe", want: models.RecipeNote{Anchor: models.NoteAnchorRecipe, RecipeID: 3, Text: "Great"}},
+		{name: "ingredient", target: "ingredient:1", want: models.RecipeNote{Anchor: models.NoteAnchorIngredient, Index: 1, RecipeID: 3, Text: "Great"}},
+		{name: "instruction", target: "instruction:0", want: models.RecipeNote{Anchor: models.NoteAnchorInstruction, RecipeID: 3, Text: "Great"}},
+	}
+	for _, tc := range valid {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := models.NewRecipeNote(recipe, tc.target, "  Great ")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tc.want {
+				t.Fatalf("got %+v but want %+v", got, tc.want)
+			}
+		})
+	}
+
+	invalid := []struct {
+		name   string
+		target string
+		text   string
+	}{
+		{name: "empty text", target: "recipe", text: "  "},
+		{name: "unknown anchor", target: "tool:0", text: "Great"},
+		{name: "missing index", target: "ingredient", text: "Great"},
+		{name: "negative index", target: "ingredient:-1", text: "Great"},
+		{name: "index out of range", target: "instruction:2", text: "Great"},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := models.NewRecipeNote(recipe, tc.target, tc.text)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestRecipeNote_Label(t *testing.T) {
+	testcases := []struct {
+		in   models.RecipeNote
+		want string
+	}{
+		{in: models.RecipeNote{Anchor: models.NoteAnchorRecipe}, want: ""},
+		{in: models.RecipeNote{Anchor: models.NoteAnchorIngredient, Index: 0}, want: "Ingredient 1"},
+		{in: models.RecipeNote{Anchor: models.NoteAnchorInstruction, Index: 4}, want: "Step 5"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.want, func(t *testing.T) {
+			if got := tc.in.Label(); got != tc.want {
+				t.Fatalf("got %q but want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRecipeNotes(t *testing.T) {
+	notes := models.RecipeNotes{
+		{ID: 1, Anchor: models.NoteAnchorRecipe, RecipeID: 1},
+		{ID: 2, Anchor: models.NoteAnchorIngredient, Index: 0, RecipeID: 1},
+		{ID: 3, Anchor: models.NoteAnchorInstruction, Index: 1, RecipeID: 1},
+		{ID: 4, Anchor: models.NoteAnchorIngredient, Index: 5, RecipeID: 1},
+		{ID: 5, Anchor: models.NoteAnchorIngredient, Index: 0, RecipeID: 2},
+	}
+
+	ids := func(notes models.RecipeNotes) []int64 {
+		xi := make([]int64, 0, len(notes))
+		for _, n := range notes {
+			xi = append(xi, n.ID)
+		}
+		return xi
+	}
+
+	t.Run("general includes notes of items that no longer exist", func(t *testing.T) {
+		got := ids(notes.General(2, 2))
+		if want := []int64{1, 4}; !slices.Equal(got, want) {
+			t.Fatalf("got %v but want %v", got, want)
+		}
+	})
+
+	t.Run("ingredient", func(t *testing.T) {
+		got := ids(notes.Ingredient(0))
+		if want := []int64{2, 5}; !slices.Equal(got, want) {
+			t.Fatalf("got %v but want %v", got, want)
+		}
+	})
+
+	t.Run("instruction", func(t *testing.T) {
+		got := ids(notes.Instruction(1))
+		if want := []int64{3}; !slices.Equal(got, want) {
+			t.Fatalf("got %v but want %v", got, want)
+		}
+	})
+
+	t.Run("group by recipe", func(t *testing.T) {
+		got := notes.GroupByRecipe()
+		if len(got) != 2 || !slices.Equal(ids(got[1]), []int64{1, 2, 3, 4}) || !slices.Equal(ids(got[2]), []int64{5}) {
+			t.Fatalf("got %v", got)
+		}
+	})
+
+	t.Run("remap moves the notes along with their item", func(t *testing.T) {
+		notes := models.RecipeNotes{
+			{ID: 1, Anchor: models.NoteAnchorRecipe},
+			{ID: 2, Anchor: models.NoteAnchorIngredient, Index: 0},
+			{ID: 3, Anchor: models.NoteAnchorIngredient, Index: 1},
+			{ID: 4, Anchor: models.NoteAnchorIngredient, Index: 2},
+			{ID: 5, Anchor: models.NoteAnchorInstruction, Index: 0},
+		}
+
+		got := notes.Remap(models.NoteAnchorIngredient, []string{"eggs", "milk", "flour"}, []string{"salt", "eggs", "flour"})
+
+		want := models.RecipeNotes{
+			{ID: 2, Anchor: models.NoteAnchorIngredient, Index: 1},
+			{ID: 3, Anchor: models.NoteAnchorRecipe, Index: 0},
+		}
+		if !slices.Equal(got, want) {
+			t.Fatalf("got %v but want %v", got, want)
+		}
+	})
+}
@@ -0,0 +1,132 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/reaper47/recipya/internal/units"
+)
+
+// FoodFDC is a food of the FDC database.
+type FoodFDC struct {
+	ID          int64
+	Description string
+}
+
+// NutritionPin overrides what the nutrition calculation matched for an ingredient of a recipe.
+// A zero FdcID keeps the automatic match and a zero Grams keeps the parsed weight.
+type NutritionPin struct {
+	FdcID      int64
+	Grams      float64
+	Ingredient string
+}
+
+// IsEmpty verifies whether the pin overrides nothing.
+func (n NutritionPin) IsEmpty() bool {
+	return n.FdcID == 0 && n.Grams == 0
+}
+
+// NutritionPins maps the pins of a recipe to their ingredient.
+type NutritionPins map[string]NutritionPin
+
+// NewNutritionPins creates the map of the pins of a recipe.
+func NewNutritionPins(pins []NutritionPin) NutritionPins {
+	m := make(NutritionPins, len(pins))
+	for _, p := range pins {
+		m[NutritionPinKey(p.Ingredient)] = p
+	}
+	return m
+}
+
+// NutritionPinKey normalizes the ingredient so that a pin survives whitespace and case edits.
+func NutritionPinKey(ingredient string) string {
+	return strings.ToLower(strings.Join(strings.Fields(ingredient), " "))
+}
+
+// Get returns the pin of the ingredient, if any.
+func (n NutritionPins) Get(ingredient string) (NutritionPin, bool) {
+	p, ok := n[NutritionPinKey(ingredient)]
+	return p, ok
+}
+
+// IngredientNutrition is the contribution of an ingredient to the nutrition facts of its recipe.
+// The macronutrients are in grams.
+type IngredientNutrition struct {
+	Calories      float64
+	Carbohydrates float64
+	Fat           float64
+	Food          FoodFDC
+	Grams         float64
+	Ingredient    string
+	IsPinned      bool
+	Nutrients     NutrientsFDC
+	Protein       float64
+}
+
+// NewIngredientNutrition computes the contribution of the ingredient from the nutrients of the
+// matched food, which are scaled to the reference measurement.
+func NewIngredientNutrition(ingredient string, food FoodFDC, reference units.Measurement, nutrients NutrientsFDC) IngredientNutrition {
+	in := IngredientNutrition{
+		Food:       food,
+		Ingredient: ingredient,
+		Nutrients:  make(NutrientsFDC, 0, len(nutrients)),
+	}
+
+	m, err := reference.Convert(units.Gram)
+	if err != nil {
+		m, err = reference.Convert(units.Millilitre)
+	}
+	if err == nil {
+		in.Grams = m.Quantity
+	}
+
+	for _, n := range nutrients {
+		n.Reference = reference
+		in.Nutrients = append(in.Nutrients, n)
+
+		v := n.Value()
+		switch n.Name {
+		case "Carbohydrate, by difference", "Carbohydrates":
+			in.Carbohydrates += v
+		case "Energy":
+			if n.UnitName == "KCAL" {
+				in.Calories += v
+			}
+		case "Fatty acids, total monounsaturated", "Fatty acids, total polyunsaturated", "Fatty acids, total trans", "Fatty acids, total saturated":
+			in.Fat += v
+		case "Protein":
+			in.Protein += v
+		}
+	}
+	return in
+}
+
+// IsMatched verifies whether a food of the FDC database was found for the ingredient.
+func (i IngredientNutrition) IsMatched() bool {
+	return i.Food.ID > 0
+}
+
+// IngredientsNutrition holds the contribution of every ingredient of a recipe.
+type IngredientsNutrition []IngredientNutrition
+
+// NutritionFact calculates the nutrition facts of the recipe from its ingredients.
+func (xs IngredientsNutrition) NutritionFact() Nutrition {
+	var (
+		nutrients NutrientsFDC
+		weight    float64
+	)
+
+	for _, x := range xs {
+		nutrients = append(nutrients, x.Nutrients...)
+		weight += x.Grams
+	}
+	return nutrients.NutritionFact(weight)
+}
+
+// TotalCalories sums the calories of the ingredients.
+func (xs IngredientsNutrition) TotalCalories() float64 {
+	var total float64
+	for _, x := range xs {
+		total += x.Calories
+	}
+	return total
+}
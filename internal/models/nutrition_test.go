@@ -0,0 +1,81 @@
+package models_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/units"
+)
+
+func TestNewIngredientNutrition(t *testing.T) {
+	food := models.FoodFDC{ID: 1, Description: "Flour, wheat"}
+	nutrients := models.NutrientsFDC{
+		{Name: "Energy", Amount: 364, UnitName: "KCAL"},
+		{Name: "Energy", Amount: 1520, UnitName: "KJ"},
+		{Name: "Carbohydrate, by difference", Amount: 76, UnitName: "G"},
+		{Name: "Protein", Amount: 10, UnitName: "G"},
+		{Name: "Fatty acids, total saturated", Amount: 500, UnitName: "MG"},
+		{Name: "Fatty acids, total polyunsaturated", Amount: 0.5, UnitName: "G"},
+	}
+
+	got := models.NewIngredientNutrition("200 g flour", food, units.Measurement{Quantity: 200, Unit: units.Gram}, nutrients)
+
+	assertFloat := func(t *testing.T, name string, got, want float64) {
+		t.Helper()
+		if math.Abs(got-want) > 1e-6 {
+			t.Errorf("%s: got %f but want %f", name, got, want)
+		}
+	}
+	assertFloat(t, "grams", got.Grams, 200)
+	assertFloat(t, "calories", got.Calories, 728)
+	assertFloat(t, "carbohydrates", got.Carbohydrates, 152)
+	assertFloat(t, "protein", got.Protein, 20)
+	assertFloat(t, "fat", got.Fat, 2)
+	if !got.IsMatched() {
+		t.Fatal("ingredient must be matched")
+	}
+	for _, n := range got.Nutrients {
+		if n.Reference.Quantity != 200 {
+			t.Fatalf("nutrient %q must reference the ingredient's weight", n.Name)
+		}
+	}
+}
+
+func TestNutritionPins_Get(t *testing.T) {
+	pins := models.NewNutritionPins([]models.NutritionPin{
+		{FdcID: 7, Ingredient: "2 cups  Flour"},
+	})
+
+	testcases := []struct {
+		in     string
+		wantOK bool
+	}{
+		{in: "2 cups  Flour", wantOK: true},
+		{in: " 2 CUPS flour ", wantOK: true},
+		{in: "2 cups sugar", wantOK: false},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			pin, ok := pins.Get(tc.in)
+			if ok != tc.wantOK {
+				t.Fatalf("got %v but want %v", ok, tc.wantOK)
+			}
+			if ok && pin.FdcID != 7 {
+				t.Fatalf("got pin %+v", pin)
+			}
+		})
+	}
+}
+
+func TestIngredientsNutrition_TotalCalories(t *testing.T) {
+	xs := models.IngredientsNutrition{
+		{Calories: 120.5, Ingredient: "1 egg"},
+		{Ingredient: "salt"},
+		{Calories: 79.5, Ingredient: "1 tbsp butter"},
+	}
+
+	if got := xs.TotalCalories(); got != 200 {
+		t.Fatalf("got %f but want 200", got)
+	}
+}
@@ -0,0 +1,155 @@
+package models
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/reaper47/recipya/internal/units"
+)
+
+// PantryItem holds an ingredient the user has at home.
+type PantryItem struct {
+	ID        int64
+	ExpiresAt time.Time
+	Name      string
+	Quantity  float64
+	Unit      units.Unit
+}
+
+// NewPantryItem creates a PantryItem from an ingredient such as "2 kg flour".
+// The expiry date is optional.
+func NewPantryItem(ingredient string, expiresAt time.Time) PantryItem {
+	name, m := parseIngredient(ingredient)
+	return PantryItem{
+		ExpiresAt: expiresAt,
+		Name:      name,
+		Quantity:  m.Quantity,
+		Unit:      m.Unit,
+	}
+}
+
+// IsExpired verifies whether the item expired before the given day.
+func (p PantryItem) IsExpired(now time.Time) bool {
+	return !p.ExpiresAt.IsZero() && p.ExpiresAt.Before(now.Truncate(24*time.Hour))
+}
+
+// QuantityString represents the item's quantity in the most readable unit.
+func (p PantryItem) QuantityString() string {
+	return quantityString(p.Quantity, p.Unit)
+}
+
+// Covers reports whether the ingredient of a recipe, e.g. "2 cups all-purpose flour", is the pantry item.
+// Every word of the item's name must be found in the ingredient, singular or plural.
+func (p PantryItem) Covers(ingredient string) bool {
+	words := ingredientWords(ingredient)
+	names := ingredientWords(p.Name)
+	if len(names) == 0 {
+		return false
+	}
+
+	for _, name := range names {
+		if !slices.ContainsFunc(words, func(w string) bool { return isSameIngredient(w, name) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// SearchTerm is the full-text search term matching the recipes that might use the item.
+func (p PantryItem) SearchTerm() string {
+	names := ingredientWords(p.Name)
+	if len(names) == 0 {
+		return ""
+	}
+
+	stem := names[len(names)-1]
+	if len(stem) > 3 {
+		stem = strings.TrimSuffix(strings.TrimSuffix(stem, "s"), "e")
+	}
+	return `"` + stem + `"*`
+}
+
+// ingredientWords splits the ingredient, up to the first comma, into lowercase words.
+func ingredientWords(ingredient string) []string {
+	before, _, _ := strings.Cut(strings.ToLower(ingredient), ",")
+	return strings.FieldsFunc(before, func(r rune) bool { return !unicode.IsLetter(r) })
+}
+
+// Pantry holds the ingredients the user has at home.
+type Pantry []PantryItem
+
+// Match computes how many ingredients of the recipe the pantry covers.
+func (p Pantry) Match(recipe Recipe) PantryMatch {
+	match := PantryMatch{Recipe: recipe, NumIngredients: len(recipe.Ingredients)}
+	for _, ingredient := range recipe.Ingredients {
+		if slices.ContainsFunc(p, func(item PantryItem) bool { return item.Covers(ingredient) }) {
+			match.NumCovered++
+			continue
+		}
+
+		name := ingredientName(ingredient)
+		if name == "" {
+			name = ingredient
+		}
+		match.Missing = append(match.Missing, name)
+	}
+	return match
+}
+
+// Rank sorts the recipes using at least one item of the pantry from the most covered to the least.
+func (p Pantry) Rank(recipes Recipes) []PantryMatch {
+	matches := make([]PantryMatch, 0, len(recipes))
+	for _, r := range recipes {
+		m := p.Match(r)
+		if m.NumCovered > 0 {
+			matches = append(matches, m)
+		}
+	}
+
+	slices.SortStableFunc(matches, func(a, b PantryMatch) int {
+		if c := cmp.Compare(b.Coverage(), a.Coverage()); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(len(a.Missing), len(b.Missing)); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Recipe.Name, b.Recipe.Name)
+	})
+	return matches
+}
+
+// SearchTerms is the full-text search query on the ingredients column matching the recipes that
+// might use an item of the pantry.
+func (p Pantry) SearchTerms() string {
+	terms := make([]string, 0, len(p))
+	for _, item := range p {
+		term := item.SearchTerm()
+		if term != "" && !slices.Contains(terms, term) {
+			terms = append(terms, term)
+		}
+	}
+
+	if len(terms) == 0 {
+		return ""
+	}
+	return "ingredients:(" + strings.Join(terms, " OR ") + ")"
+}
+
+// PantryMatch holds how well the pantry covers the ingredients of a recipe.
+type PantryMatch struct {
+	Missing        []string
+	NumCovered     int
+	NumIngredients int
+	Recipe         Recipe
+}
+
+// Coverage is the fraction of the recipe's ingredients found in the pantry.
+func (p PantryMatch) Coverage() float64 {
+	if p.NumIngredients == 0 {
+		return 0
+	}
+	return float64(p.NumCovered) / float64(p.NumIngredients)
+}
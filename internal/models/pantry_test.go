@@ -0,0 +1,85 @@
+package models_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestPantryItem_Covers(t *testing.T) {
+	testcases := []struct {
+		name       string
+		item       string
+		ingredient string
+		want       bool
+	}{
+		{name: "same name", item: "flour", ingredient: "2 cups flour", want: true},
+		{name: "plural", item: "egg", ingredient: "3 large eggs", want: true},
+		{name: "singular", item: "onions", ingredient: "1 onion, diced", want: true},
+		{name: "all words must match", item: "brown sugar", ingredient: "1 cup sugar", want: false},
+		{name: "words after comma are ignored", item: "butter", ingredient: "1 cup flour, sifted with butter", want: false},
+		{name: "different ingredient", item: "milk", ingredient: "1 cup water", want: false},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			item := models.PantryItem{Name: tc.item}
+			if got := item.Covers(tc.ingredient); got != tc.want {
+				t.Fatalf("got %t but want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPantryItem_IsExpired(t *testing.T) {
+	now := time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC)
+
+	testcases := []struct {
+		name      string
+		expiresAt time.Time
+		want      bool
+	}{
+		{name: "no expiry date", want: false},
+		{name: "expired yesterday", expiresAt: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), want: true},
+		{name: "expires today", expiresAt: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), want: false},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			item := models.PantryItem{Name: "milk", ExpiresAt: tc.expiresAt}
+			if got := item.IsExpired(now); got != tc.want {
+				t.Fatalf("got %t but want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPantry_Rank(t *testing.T) {
+	pantry := models.Pantry{{Name: "eggs"}, {Name: "flour"}, {Name: "milk"}}
+	recipes := models.Recipes{
+		{ID: 1, Name: "Pancakes", Ingredients: []string{"2 eggs", "1 cup flour", "1 cup milk", "1 tbsp sugar"}},
+		{ID: 2, Name: "Omelette", Ingredients: []string{"3 eggs", "1 tbsp milk"}},
+		{ID: 3, Name: "Salad", Ingredients: []string{"1 head lettuce"}},
+	}
+
+	got := pantry.Rank(recipes)
+
+	if len(got) != 2 {
+		t.Fatalf("got %d matches but want 2: %+v", len(got), got)
+	}
+	if got[0].Recipe.Name != "Omelette" || got[0].NumCovered != 2 || len(got[0].Missing) != 0 {
+		t.Fatalf("unexpected first match %+v", got[0])
+	}
+	if got[1].Recipe.Name != "Pancakes" || got[1].NumCovered != 3 || got[1].NumIngredients != 4 || !slices.Equal(got[1].Missing, []string{"sugar"}) {
+		t.Fatalf("unexpected second match %+v", got[1])
+	}
+}
+
+func TestPantry_SearchTerms(t *testing.T) {
+	pantry := models.Pantry{{Name: "eggs"}, {Name: "egg"}, {Name: "all-purpose flour"}, {Name: "salt"}}
+
+	want := `ingredients:("egg"* OR "flour"* OR "salt"*)`
+	if got := pantry.SearchTerms(); got != want {
+		t.Fatalf("got %q but want %q", got, want)
+	}
+}
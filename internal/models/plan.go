@@ -0,0 +1,131 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MealSlot is a moment of the day a recipe can be planned for.
+type MealSlot string
+
+// These constants define the meal slots of a day.
+const (
+	MealSlotBreakfast MealSlot = "breakfast"
+	MealSlotLunch     MealSlot = "lunch"
+	MealSlotDinner    MealSlot = "dinner"
+	MealSlotSnack     MealSlot = "snack"
+)
+
+// MealSlots lists the meal slots of a day in chronological order.
+var MealSlots = []MealSlot{MealSlotBreakfast, MealSlotLunch, MealSlotDinner, MealSlotSnack}
+
+// NewMealSlot parses a meal slot from its name.
+func NewMealSlot(s string) (MealSlot, error) {
+	slot := MealSlot(strings.ToLower(strings.TrimSpace(s)))
+	if !slices.Contains(MealSlots, slot) {
+		return "", fmt.Errorf("invalid meal slot %q", s)
+	}
+	return slot, nil
+}
+
+// Title returns the slot's name as it should be displayed.
+func (m MealSlot) Title() string {
+	if m == "" {
+		return ""
+	}
+	return strings.ToUpper(string(m[:1])) + string(m[1:])
+}
+
+// MealPlanEntry is a recipe planned for a meal slot on a given day.
+type MealPlanEntry struct {
+	ID          int64
+	Date        time.Time
+	RecipeID    int64
+	RecipeName  string
+	RecipeYield int16
+	Servings    int16
+	Slot        MealSlot
+}
+
+// ScaleURL returns the URL of the entry's recipe scaled to the planned servings.
+func (m MealPlanEntry) ScaleURL() string {
+	return fmt.Sprintf("/recipes/%d/scale?yield=%d", m.RecipeID, m.Servings)
+}
+
+// MealPlanDay holds the entries planned for a day, grouped by meal slot.
+type MealPlanDay struct {
+	Date    time.Time
+	Entries map[MealSlot][]MealPlanEntry
+}
+
+// MealPlanWeek is a week of the meal planner, starting on Monday.
+type MealPlanWeek struct {
+	Days  []MealPlanDay
+	Start time.Time
+}
+
+// NewMealPlanWeek creates the week starting on the Monday of the given date and dispatches
+// the entries to their respective days. Entries outside the week are ignored.
+func NewMealPlanWeek(date time.Time, entries []MealPlanEntry) MealPlanWeek {
+	start := StartOfWeek(date)
+
+	days := make([]MealPlanDay, 7)
+	for i := range days {
+		days[i] = MealPlanDay{
+			Date:    start.AddDate(0, 0, i),
+			Entries: make(map[MealSlot][]MealPlanEntry, len(MealSlots)),
+		}
+	}
+
+	for _, e := range entries {
+		i := int(e.Date.Sub(start).Hours() / 24)
+		if i < 0 || i >= len(days) {
+			continue
+		}
+		days[i].Entries[e.Slot] = append(days[i].Entries[e.Slot], e)
+	}
+
+	return MealPlanWeek{Days: days, Start: start}
+}
+
+// End returns the last day of the week.
+func (m MealPlanWeek) End() time.Time {
+	return m.Start.AddDate(0, 0, 6)
+}
+
+// Next returns the first day of the following week.
+func (m MealPlanWeek) Next() time.Time {
+	return m.Start.AddDate(0, 0, 7)
+}
+
+// Previous returns the first day of the preceding week.
+func (m MealPlanWeek) Previous() time.Time {
+	return m.Start.AddDate(0, 0, -7)
+}
+
+// TimelineURL returns the URL of the batch-cooking timeline of the recipes planned during the week.
+func (m MealPlanWeek) TimelineURL() string {
+	var ids []string
+	for _, day := range m.Days {
+		for _, entries := range day.Entries {
+			for _, e := range entries {
+				id := "recipeId=" + strconv.FormatInt(e.RecipeID, 10)
+				if !slices.Contains(ids, id) {
+					ids = append(ids, id)
+				}
+			}
+		}
+	}
+	slices.Sort(ids)
+	return "/timeline?" + strings.Join(ids, "&")
+}
+
+// StartOfWeek returns midnight UTC of the Monday of the week the date falls in.
+func StartOfWeek(date time.Time) time.Time {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
@@ -0,0 +1,82 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestNewMealSlot(t *testing.T) {
+	testcases := []struct {
+		in      string
+		want    models.MealSlot
+		wantErr bool
+	}{
+		{in: "breakfast", want: models.MealSlotBreakfast},
+		{in: " Lunch ", want: models.MealSlotLunch},
+		{in: "DINNER", want: models.MealSlotDinner},
+		{in: "snack", want: models.MealSlotSnack},
+		{in: "brunch", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := models.NewMealSlot(tc.in)
+			if tc.wantErr != (err != nil) {
+				t.Fatalf("got error %v but wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Fatalf("got %q but want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewMealPlanWeek(t *testing.T) {
+	monday := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	entries := []models.MealPlanEntry{
+		{ID: 1, Date: monday.AddDate(0, 0, -1), Slot: models.MealSlotDinner},
+		{ID: 2, Date: monday, Slot: models.MealSlotBreakfast},
+		{ID: 3, Date: monday.AddDate(0, 0, 6), Slot: models.MealSlotSnack},
+		{ID: 4, Date: monday.AddDate(0, 0, 6), Slot: models.MealSlotSnack},
+		{ID: 5, Date: monday.AddDate(0, 0, 7), Slot: models.MealSlotLunch},
+	}
+
+	got := models.NewMealPlanWeek(time.Date(2026, 10, 25, 18, 30, 0, 0, time.UTC), entries)
+
+	if !got.Start.Equal(monday) {
+		t.Fatalf("got start %v but want %v", got.Start, monday)
+	}
+	if len(got.Days) != 7 {
+		t.Fatalf("got %d days but want 7", len(got.Days))
+	}
+	if !got.Previous().Equal(monday.AddDate(0, 0, -7)) || !got.Next().Equal(monday.AddDate(0, 0, 7)) || !got.End().Equal(monday.AddDate(0, 0, 6)) {
+		t.Fatal("week boundaries are incorrect")
+	}
+	if diff := cmp.Diff(entries[1:2], got.Days[0].Entries[models.MealSlotBreakfast]); diff != "" {
+		t.Fatalf("monday mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(entries[2:4], got.Days[6].Entries[models.MealSlotSnack]); diff != "" {
+		t.Fatalf("sunday mismatch (-want +got):\n%s", diff)
+	}
+
+	var n int
+	for _, day := range got.Days {
+		for _, xe := range day.Entries {
+			n += len(xe)
+		}
+	}
+	if n != 3 {
+		t.Fatalf("got %d entries in the week but want 3", n)
+	}
+}
+
+func TestMealPlanEntry_ScaleURL(t *testing.T) {
+	e := models.MealPlanEntry{RecipeID: 42, Servings: 6}
+	if got := e.ScaleURL(); got != "/recipes/42/scale?yield=6" {
+		t.Fatalf("got %q", got)
+	}
+}
@@ -61,29 +61,40 @@ func NewBaseRecipe() Recipe {
 
 // Recipe is the struct that holds a recipe's information.
 type Recipe struct {
-	Category     string        `toml:"-"`
-	CreatedAt    time.Time     `toml:"-"`
-	Cuisine      string        `toml:"-"`
-	Description  string        `toml:"-"`
-	ID           int64         `toml:"-"`
-	Images       []uuid.UUID   `toml:"-"`
-	Ingredients  []string      `toml:"-"`
-	Instructions []string      `toml:"-"`
-	Keywords     []string      `toml:"-"`
-	Name         string        `toml:"name"`
-	Nutrition    Nutrition     `toml:"-"`
-	Times        Times         `toml:"-"`
-	Tools        []HowToItem   `toml:"-"`
-	UpdatedAt    time.Time     `toml:"-"`
-	URL          string        `toml:"-"`
-	Videos       []VideoObject `toml:"-"`
-	Yield        int16         `toml:"-"`
+	Category              string        `toml:"-"`
+	CreatedAt             time.Time     `toml:"-"`
+	Cuisine               string        `toml:"-"`
+	Description           string        `toml:"-"`
+	ID                    int64         `toml:"-"`
+	Images                []uuid.UUID   `toml:"-"`
+	IngredientSections    Sections      `toml:"-"`
+	Ingredients           []string      `toml:"-"`
+	InstructionSections   Sections      `toml:"-"`
+	Instructions          []string      `toml:"-"`
+	Keywords              []string      `toml:"-"`
+	Name                  string        `toml:"name"`
+	Nutrition             Nutrition     `toml:"-"`
+	StructuredIngredients []Ingredient  `toml:"-"`
+	Times                 Times         `toml:"-"`
+	Tools                 []HowToItem   `toml:"-"`
+	UpdatedAt             time.Time     `toml:"-"`
+	URL                   string        `toml:"-"`
+	Videos                []VideoObject `toml:"-"`
+	Yield                 float64       `toml:"-"`
+	YieldUnit             string        `toml:"-"` // e.g. cookies or L of stock; servings when empty
 }
 
-// ConvertMeasurementSystem converts a recipe to another units.System.
+// ConvertMeasurementSystem converts a recipe to another units.System. The quantities of the
+// structured ingredients are converted from their parts rather than parsed anew from the converted text.
 func (r *Recipe) ConvertMeasurementSystem(to units.System) (*Recipe, error) {
+	structured := NewIngredients(r.Ingredients, r.StructuredIngredients)
+
 	currentSystem := units.InvalidSystem
-	for _, s := range r.Ingredients {
+	for i, s := range r.Ingredients {
+		if structured[i].Unit != units.Invalid {
+			s = structured[i].Measurement().String()
+		}
+
 		system := units.DetectMeasurementSystem(s)
 		if system != units.InvalidSystem {
 			currentSystem = system
@@ -101,10 +112,18 @@ func (r *Recipe) ConvertMeasurementSystem(to units.System) (*Recipe, error) {
 	for i, s := range r.Ingredients {
 		v, err := units.ConvertSentence(s, currentSystem, to)
 		if err != nil {
-			ingredients[i] = s
-			continue
+			v = s
 		}
 		ingredients[i] = v
+
+		if structured[i].Unit == units.Invalid {
+			structured[i] = structured[i].withText(v)
+			continue
+		}
+
+		// The text may hold other measurements than the ingredient's quantity, e.g. 1 tbsp plus 1 tsp.
+		structured[i] = structured[i].ConvertSystem(to)
+		structured[i].Text = v
 	}
 
 	instructions := make([]string, len(r.Instructions))
@@ -116,6 +135,7 @@ func (r *Recipe) ConvertMeasurementSystem(to units.System) (*Recipe, error) {
 	recipe.Description = units.ConvertParagraph(r.Description, currentSystem, to)
 	recipe.Ingredients = ingredients
 	recipe.Instructions = instructions
+	recipe.StructuredIngredients = structured
 	return &recipe, nil
 }
 
@@ -130,6 +150,12 @@ func (r *Recipe) Copy() Recipe {
 	keywords := make([]string, len(r.Keywords))
 	copy(keywords, r.Keywords)
 
+	var structured []Ingredient
+	if r.StructuredIngredients != nil {
+		structured = make([]Ingredient, len(r.StructuredIngredients))
+		copy(structured, r.StructuredIngredients)
+	}
+
 	tools := make([]HowToItem, len(r.Tools))
 	copy(tools, r.Tools)
 
@@ -137,16 +163,18 @@ func (r *Recipe) Copy() Recipe {
 	copy(videos, r.Videos)
 
 	return Recipe{
-		Category:     r.Category,
-		CreatedAt:    r.CreatedAt,
-		Cuisine:      r.Cuisine,
-		Description:  r.Description,
-		ID:           r.ID,
-		Images:       r.Images,
-		Ingredients:  ingredients,
-		Instructions: instructions,
-		Keywords:     keywords,
-		Name:         r.Name,
+		Category:            r.Category,
+		CreatedAt:           r.CreatedAt,
+		Cuisine:             r.Cuisine,
+		Description:         r.Description,
+		ID:                  r.ID,
+		Images:              r.Images,
+		IngredientSections:  slices.Clone(r.IngredientSections),
+		Ingredients:         ingredients,
+		InstructionSections: slices.Clone(r.InstructionSections),
+		Instructions:        instructions,
+		Keywords:            keywords,
+		Name:                r.Name,
 		Nutrition: Nutrition{
 			Calories:           r.Nutrition.Calories,
 			Cholesterol:        r.Nutrition.Cholesterol,
@@ -160,6 +188,7 @@ func (r *Recipe) Copy() Recipe {
 			Sugars:             r.Nutrition.Sugars,
 			TotalCarbohydrates: r.Nutrition.TotalCarbohydrates,
 		},
+		StructuredIngredients: structured,
 		Times: Times{
 			Prep:  r.Times.Prep,
 			Cook:  r.Times.Cook,
@@ -170,6 +199,7 @@ func (r *Recipe) Copy() Recipe {
 		URL:       r.URL,
 		Videos:    videos,
 		Yield:     r.Yield,
+		YieldUnit: r.YieldUnit,
 	}
 }
 
@@ -179,7 +209,7 @@ func (r *Recipe) IsEmpty() bool {
 		r.ID == 0 && len(r.Images) == 0 && len(r.Ingredients) == 0 && len(r.Instructions) == 0 &&
 		len(r.Keywords) == 0 && r.Name == "" && r.Nutrition.Equal(Nutrition{}) &&
 		r.Times.Equal(Times{}) && len(r.Tools) == 0 && r.UpdatedAt.Equal(time.Time{}) &&
-		r.URL == "" && r.Yield == 0
+		r.URL == "" && r.Yield == 0 && r.YieldUnit == ""
 }
 
 // Normalize normalizes texts for readability.
@@ -211,9 +241,25 @@ func normalizeQuantity(s string) string {
 	return string(xr)
 }
 
-// Scale scales the recipe to the given yield.
-func (r *Recipe) Scale(yield int16) {
-	multiplier := float64(yield) / float64(r.Yield)
+// Scale scales the recipe to the given yield. The quantities of the recipes its ingredients
+// refer to are scaled alike, so that their expansion follows.
+func (r *Recipe) Scale(yield float64) {
+	r.scaleIngredients(yield / r.Yield)
+	r.Yield = yield
+	r.Normalize()
+
+	for i := range r.StructuredIngredients {
+		r.StructuredIngredients[i] = r.StructuredIngredients[i].withText(r.Ingredients[i])
+	}
+}
+
+// scaleIngredients scales the quantities of the ingredients by the multiplier. The ingredients
+// are scaled from their structured form when it has a quantity, and from their text otherwise.
+func (r *Recipe) scaleIngredients(multiplier float64) {
+	if r.StructuredIngredients != nil {
+		r.StructuredIngredients = NewIngredients(r.Ingredients, r.StructuredIngredients)
+	}
+
 	scaledIngredients := make([]string, len(r.Ingredients))
 
 	var wg sync.WaitGroup
@@ -263,9 +309,16 @@ func (r *Recipe) Scale(yield int16) {
 		})
 	}
 
+	for i, in := range r.StructuredIngredients {
+		if in.Quantity > 0 {
+			r.StructuredIngredients[i] = in.Scale(multiplier)
+			scaledIngredients[i] = r.StructuredIngredients[i].Text
+		} else {
+			r.StructuredIngredients[i] = in.withText(scaledIngredients[i])
+		}
+	}
+
 	r.Ingredients = scaledIngredients
-	r.Yield = yield
-	r.Normalize()
 }
 
 // Schema creates the schema representation of the Recipe.
@@ -319,19 +372,25 @@ func (r *Recipe) Schema() RecipeSchema {
 		Description:     &Description{Value: r.Description},
 		Keywords:        &Keywords{Values: strings.Join(r.Keywords, ",")},
 		Image:           &Image{Value: strings.Join(images, ";")},
-		Ingredients:     &Ingredients{Values: r.Ingredients},
-		Instructions:    &Instructions{Values: instructions},
+		Ingredients:     &Ingredients{Sections: r.IngredientSections, Values: r.Ingredients},
+		Instructions:    &Instructions{Sections: r.InstructionSections, Values: instructions},
 		Name:            r.Name,
-		NutritionSchema: r.Nutrition.Schema(strconv.Itoa(int(r.Yield))),
+		NutritionSchema: r.Nutrition.Schema(extensions.FloatToString(r.Yield, "%.2f")),
 		PrepTime:        formatDuration(r.Times.Prep),
 		ThumbnailURL:    &ThumbnailURL{Value: thumbnail},
 		Tools:           &Tools{Values: r.Tools},
 		TotalTime:       formatDuration(r.Times.Total),
-		Yield:           &Yield{Value: r.Yield},
+		Yield:           &Yield{Value: r.Yield, Unit: r.YieldUnit},
 		URL:             r.URL,
 		Video:           video,
 	}
 
+	if r.StructuredIngredients != nil {
+		for _, in := range NewIngredients(r.Ingredients, r.StructuredIngredients) {
+			schema.StructuredIngredients = append(schema.StructuredIngredients, in.Schema())
+		}
+	}
+
 	if schema.CookingMethod.Value == "" {
 		schema.CookingMethod = nil
 	}
@@ -659,7 +718,7 @@ func (n NutrientsFDC) NutritionFact(weight float64) Nutrition {
 		v := nutrient.Value()
 
 		switch nutrient.Name {
-		case "Carbohydrates":
+		case "Carbohydrate, by difference", "Carbohydrates":
 			carbs += v
 		case "Cholesterol":
 			cholesterol += v
@@ -851,21 +910,26 @@ func toArg(s, col string) string {
 func (s *SearchOptionsRecipes) IsBasic() bool {
 	return s.Advanced.Category == "" && s.Advanced.Cuisine == "" && s.Advanced.Description == "" &&
 		s.Advanced.Ingredients == "" && s.Advanced.Instructions == "" && s.Advanced.Keywords == "" && s.Advanced.Name == "" &&
-		s.Advanced.Source == "" && s.Advanced.Tools == ""
+		s.Advanced.Source == "" && s.Advanced.Tools == "" && !s.Advanced.IsPantry && !s.Advanced.IsNeverCooked &&
+		s.Advanced.NotCookedDays == 0 && !s.Advanced.IsCollapseVariants
 }
 
 // AdvancedSearch stores the components of an advanced search query.
 type AdvancedSearch struct {
-	Category     string
-	Cuisine      string
-	Description  string
-	Ingredients  string
-	Instructions string
-	Keywords     string
-	Name         string
-	Source       string
-	Text         string
-	Tools        string
+	Category           string
+	Cuisine            string
+	Description        string
+	Ingredients        string
+	Instructions       string
+	IsCollapseVariants bool // IsCollapseVariants lists the parent of the matching variants in their stead.
+	IsNeverCooked      bool
+	IsPantry           bool
+	Keywords           string
+	Name               string
+	NotCookedDays      int // NotCookedDays restricts the search to recipes not cooked within that many days.
+	Source             string
+	Text               string
+	Tools              string
 }
 
 // Sort defines sorting options.
@@ -878,6 +942,10 @@ type Sort struct {
 
 	IsDefault bool
 	IsRandom  bool
+
+	IsHighestRated   bool
+	IsRecentlyCooked bool
+	IsMostCooked     bool
 }
 
 // IsSort verifies whether there is a sort option enabled.
@@ -898,6 +966,12 @@ func (s *Sort) String() string {
 		return "old-new"
 	case s.IsRandom:
 		return "random"
+	case s.IsHighestRated:
+		return "rating"
+	case s.IsRecentlyCooked:
+		return "last-cooked"
+	case s.IsMostCooked:
+		return "most-cooked"
 	default:
 		return "default"
 	}
@@ -952,6 +1026,18 @@ func NewAdvancedSearch(query string) AdvancedSearch {
 			reset()
 			isInstructions = true
 			a.Instructions = strings.TrimPrefix(s, "ins:")
+		} else if strings.HasPrefix(s, "pantry:") {
+			reset()
+			a.IsPantry, _ = strconv.ParseBool(strings.TrimPrefix(s, "pantry:"))
+		} else if strings.HasPrefix(s, "cooked:") {
+			reset()
+			a.IsNeverCooked = strings.TrimPrefix(s, "cooked:") == "never"
+		} else if strings.HasPrefix(s, "notcooked:") {
+			reset()
+			a.NotCookedDays = parseCookPeriod(strings.TrimPrefix(s, "notcooked:"))
+		} else if strings.HasPrefix(s, "variants:") {
+			reset()
+			a.IsCollapseVariants = strings.TrimPrefix(s, "variants:") == "collapse"
 		} else if strings.HasPrefix(s, "name:") {
 			reset()
 			isName = true
@@ -994,6 +1080,32 @@ func NewAdvancedSearch(query string) AdvancedSearch {
 	return a
 }
 
+// parseCookPeriod converts a period such as "10d", "2w", "6m" or "1y" to a number of days.
+// It returns 0 when the period is invalid.
+func parseCookPeriod(s string) int {
+	if len(s) < 2 {
+		return 0
+	}
+
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n <= 0 {
+		return 0
+	}
+
+	switch s[len(s)-1] {
+	case 'd':
+		return n
+	case 'w':
+		return n * 7
+	case 'm':
+		return n * 30
+	case 'y':
+		return n * 365
+	default:
+		return 0
+	}
+}
+
 func normalizeFTSTerm(s string) string {
 	if s == "" {
 		return ""
@@ -1025,6 +1137,12 @@ func NewSearchOptionsRecipe(query url.Values) SearchOptionsRecipes {
 		opts.Sort.IsOldestToNewest = true
 	case "random":
 		opts.Sort.IsRandom = true
+	case "rating":
+		opts.Sort.IsHighestRated = true
+	case "last-cooked":
+		opts.Sort.IsRecentlyCooked = true
+	case "most-cooked":
+		opts.Sort.IsMostCooked = true
 	default:
 		opts.Sort.IsDefault = true
 	}
@@ -1422,7 +1540,7 @@ func processMetaData(line []byte, recipe *Recipe) {
 		bytes.Contains(line, []byte("styk")) || bytes.Contains(line, []byte("til")) {
 		yield, err := strconv.ParseInt(regex.Digit.FindString(string(line)), 10, 16)
 		if err == nil {
-			recipe.Yield = int16(yield)
+			recipe.Yield = float64(yield)
 		}
 	} else if recipe.Times.Prep == 0 && recipe.Times.Cook == 0 && (bytes.HasPrefix(line, []byte("total")) || bytes.HasPrefix(line, []byte("tid"))) {
 		before, after, found := bytes.Cut(line, []byte(","))
@@ -1507,7 +1625,7 @@ func NewRecipesFromMasterCook(r io.Reader) Recipes {
 			_, after, _ := bytes.Cut(before, []byte(":"))
 			yield, err := strconv.ParseInt(string(bytes.TrimSpace(after)), 10, 16)
 			if err == nil {
-				recipe.Yield = int16(yield)
+				recipe.Yield = float64(yield)
 			}
 
 			_, prep, ok := bytes.Cut(prep, []byte(":"))
@@ -1608,11 +1726,7 @@ func NewRecipesFromRecipeKeeper(root *goquery.Document) Recipes {
 			course = "uncategorized"
 		}
 
-		var yield int16
-		parsed, err := strconv.ParseInt(regex.Digit.FindString(sel.Find("span[itemprop='recipeYield']").Text()), 10, 16)
-		if err == nil {
-			yield = int16(parsed)
-		}
+		yield := NewYield(sel.Find("span[itemprop='recipeYield']").Text())
 
 		var prep time.Duration
 		rs.PrepTime = sel.Find("meta[itemprop='prepTime']").AttrOr("content", "")
@@ -1690,7 +1804,8 @@ func NewRecipesFromRecipeKeeper(root *goquery.Document) Recipes {
 			Tools:     make([]HowToItem, 0),
 			UpdatedAt: time.Time{},
 			URL:       "Recipe Keeper",
-			Yield:     yield,
+			Yield:     yield.Value,
+			YieldUnit: yield.Unit,
 		})
 	})
 	return recipes
@@ -1751,7 +1866,7 @@ func parseSaffron(block []byte) (Recipe, error) {
 			isYield = true
 			parsed, err := strconv.ParseInt(v, 10, 16)
 			if err == nil {
-				recipe.Yield = int16(parsed)
+				recipe.Yield = float64(parsed)
 			}
 		case "Cookbook":
 			isYield = false
@@ -1803,7 +1918,7 @@ func NewRecipesFromAccuChef(r io.Reader) Recipes {
 			case 'D':
 				parsed, err := strconv.ParseInt(content, 10, 16)
 				if err == nil {
-					recipe.Yield = int16(parsed)
+					recipe.Yield = float64(parsed)
 				}
 			case 'P':
 				split := strings.Split(content, ":")
@@ -1950,7 +2065,7 @@ func NewRecipesFromEasyRecipeDeluxe(r io.Reader) Recipes {
 				for _, b := range bytes.Split(after, []byte(" ")) {
 					parsed, err := strconv.ParseInt(string(b), 10, 16)
 					if err == nil {
-						recipe.Yield = int16(parsed)
+						recipe.Yield = float64(parsed)
 						break
 					}
 				}
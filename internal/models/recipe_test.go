@@ -2,6 +2,7 @@ package models_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"io"
 	"math"
@@ -230,6 +231,35 @@ func TestRecipe_ConvertMeasurementSystem(t *testing.T) {
 			},
 		},
 	}
+	t.Run("converts the structured ingredients", func(t *testing.T) {
+		r := models.Recipe{
+			Ingredients:           []string{"1 tablespoon plus 1 teaspoon adobo sauce", "2 cups milk"},
+			StructuredIngredients: models.NewIngredients([]string{"1 tablespoon plus 1 teaspoon adobo sauce", "2 cups milk"}, nil),
+		}
+
+		got, err := r.ConvertMeasurementSystem(units.MetricSystem)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []struct {
+			text string
+			unit units.Unit
+		}{
+			{text: "14.79 ml plus 5 ml adobo sauce", unit: units.Millilitre},
+			{text: "4.73 dl milk", unit: units.Decilitre},
+		}
+		for i, w := range want {
+			in := got.StructuredIngredients[i]
+			if got.Ingredients[i] != w.text || in.Text != w.text || in.Unit != w.unit {
+				t.Errorf("got %q and %+v but want %q in %s", got.Ingredients[i], in, w.text, w.unit)
+			}
+		}
+		if r.StructuredIngredients[1].Unit != units.Cup {
+			t.Error("the recipe must not be modified")
+		}
+	})
+
 	for _, tc := range testcases2 {
 		t.Run("valid "+tc.name, func(t *testing.T) {
 			got, _ := tc.in.ConvertMeasurementSystem(tc.to)
@@ -279,6 +309,31 @@ func TestNewAdvancedSearch(t *testing.T) {
 			query: "q=orange cat likes hot dogs",
 			want:  models.AdvancedSearch{Text: `"orange cat likes hot dogs"`},
 		},
+		{
+			name:  "with pantry",
+			query: "q=quick pantry:true cat:dinner",
+			want:  models.AdvancedSearch{Category: "dinner", IsPantry: true, Text: `"quick"`},
+		},
+		{
+			name:  "never cooked",
+			query: "q=cooked:never cat:dinner",
+			want:  models.AdvancedSearch{Category: "dinner", IsNeverCooked: true},
+		},
+		{
+			name:  "not cooked recently",
+			query: "q=soup notcooked:6m",
+			want:  models.AdvancedSearch{NotCookedDays: 180, Text: `"soup"`},
+		},
+		{
+			name:  "not cooked with invalid period",
+			query: "q=notcooked:soon",
+			want:  models.AdvancedSearch{},
+		},
+		{
+			name:  "variants collapsed",
+			query: "q=pancakes variants:collapse",
+			want:  models.AdvancedSearch{IsCollapseVariants: true, Text: `"pancakes"`},
+		},
 		{
 			name:  "with subcategories",
 			query: "q=cat:Beverages:Coctails:Vodka",
@@ -427,6 +482,14 @@ func TestNewSearchOptionsRecipe(t *testing.T) {
 				Sort: models.Sort{IsDefault: true},
 			},
 		},
+		{
+			name:  "sort by times cooked",
+			query: url.Values{"sort": []string{"most-cooked"}},
+			want: models.SearchOptionsRecipes{
+				Page: 1,
+				Sort: models.Sort{IsMostCooked: true},
+			},
+		},
 		{
 			name:  "basic search query",
 			query: url.Values{"q": []string{"homemade bubble tea"}},
@@ -589,6 +652,9 @@ func TestSearchOptionsRecipes_IsBasicSearch(t *testing.T) {
 		{name: "has instructions", in: models.AdvancedSearch{Instructions: "boil water"}},
 		{name: "has keywords", in: models.AdvancedSearch{Keywords: "easy"}},
 		{name: "has name", in: models.AdvancedSearch{Name: "pasta"}},
+		{name: "is pantry", in: models.AdvancedSearch{IsPantry: true}},
+		{name: "is never cooked", in: models.AdvancedSearch{IsNeverCooked: true}},
+		{name: "is not cooked recently", in: models.AdvancedSearch{NotCookedDays: 14}},
 		{name: "has source", in: models.AdvancedSearch{Source: "grandma"}},
 		{name: "has tools", in: models.AdvancedSearch{Tools: "pot"}},
 	}
@@ -966,6 +1032,30 @@ func TestRecipe_Scale(t *testing.T) {
 		want.Yield = 1
 		assertStructsEqual(t, got, want)
 	})
+
+	t.Run("scales from the structured ingredients", func(t *testing.T) {
+		ingredients := []string{"2-3 cups flour, sifted", "3 eggs", "Lots of big apples"}
+		edited := models.Ingredient{Name: "cake flour", Note: "sifted", Quantity: 2, QuantityMax: 3, Text: ingredients[0], Unit: units.Cup}
+		r := models.Recipe{Ingredients: ingredients, StructuredIngredients: []models.Ingredient{edited}, Yield: 2}
+
+		r.Scale(4)
+
+		want := []string{"4-6 cups cake flour, sifted", "6 eggs", "Lots of big apples"}
+		if !slices.Equal(r.Ingredients, want) {
+			t.Fatalf("got ingredients %q but want %q", r.Ingredients, want)
+		}
+		if len(r.StructuredIngredients) != len(want) {
+			t.Fatalf("got %d structured ingredients but want %d", len(r.StructuredIngredients), len(want))
+		}
+		for i, in := range r.StructuredIngredients {
+			if in.Text != want[i] {
+				t.Errorf("got structured ingredient %q but want %q", in.Text, want[i])
+			}
+		}
+		if got := r.StructuredIngredients[0]; got.Name != "cake flour" || got.Quantity != 4 || got.QuantityMax != 6 {
+			t.Errorf("got %+v but want the edited ingredient scaled", got)
+		}
+	})
 }
 
 func TestRecipe_Schema(t *testing.T) {
@@ -1082,13 +1172,48 @@ func TestRecipe_Schema(t *testing.T) {
 		t.Errorf("wanted tools\n%+v\nbut got\n%+v", wantTools, schema.Tools.Values)
 	}
 	if schema.Yield != nil && schema.Yield.Value != 4 {
-		t.Errorf("wanted yield 4 but got %d", schema.Yield.Value)
+		t.Errorf("wanted yield 4 but got %v", schema.Yield.Value)
 	}
 	if schema.URL != "https://www.google.com" {
 		t.Errorf("wanted url https://www.google.com but got %q", schema.URL)
 	}
 }
 
+func TestRecipe_Schema_StructuredIngredients(t *testing.T) {
+	ingredients := []string{"2-3 cups flour, sifted", "3 eggs"}
+	r := models.Recipe{
+		Category:    "dessert",
+		Ingredients: ingredients,
+		Name:        "Cake",
+		StructuredIngredients: []models.Ingredient{
+			{Name: "cake flour", Note: "sifted", Quantity: 2, QuantityMax: 3, Text: ingredients[0], Unit: units.Cup},
+			{Name: "eggs", Quantity: 3, RecipeID: 2, Text: ingredients[1]},
+		},
+	}
+
+	xb, err := json.Marshal(r.Schema())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var schema models.RecipeSchema
+	err = json.Unmarshal(xb, &schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := schema.Recipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := slices.Clone(r.StructuredIngredients)
+	want[1].RecipeID = 0
+	if !slices.Equal(got.StructuredIngredients, want) {
+		t.Fatalf("got %+v but want %+v", got.StructuredIngredients, want)
+	}
+}
+
 func TestNewTimes(t *testing.T) {
 	actual, err := models.NewTimes("PT1H0M0S", "PT2H0M0S")
 	assertNoError(t, err)
@@ -1509,6 +1634,21 @@ func TestSort_String(t *testing.T) {
 			in:   models.Sort{IsRandom: true},
 			want: "random",
 		},
+		{
+			name: "Highest rated",
+			in:   models.Sort{IsHighestRated: true},
+			want: "rating",
+		},
+		{
+			name: "Recently cooked",
+			in:   models.Sort{IsRecentlyCooked: true},
+			want: "last-cooked",
+		},
+		{
+			name: "Most cooked",
+			in:   models.Sort{IsMostCooked: true},
+			want: "most-cooked",
+		},
 		{
 			name: "None true",
 			in:   models.Sort{},
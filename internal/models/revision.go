@@ -0,0 +1,154 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// RecipeRevision is a snapshot of a recipe taken before it was updated.
+type RecipeRevision struct {
+	CreatedAt  time.Time // CreatedAt is when the recipe was updated.
+	ID         int64
+	Recipe     Recipe // Recipe is the recipe as it was before the update.
+	RecipeID   int64
+	ReplacedBy string // ReplacedBy is the email of the user whose update replaced the snapshot.
+}
+
+// IsRevisedBy verifies whether updating the recipe with the updated one changes it, in which case
+// a revision is kept. The media and the structured ingredients are only compared when the update
+// provides them because the recipe keeps its own otherwise.
+func (r *Recipe) IsRevisedBy(updated *Recipe) bool {
+	if updated.Images != nil && !slices.Equal(r.Images, updated.Images) {
+		return true
+	}
+
+	isSameVideo := func(a, b VideoObject) bool { return a.ID == b.ID }
+	if updated.Videos != nil && !slices.EqualFunc(r.Videos, updated.Videos, isSameVideo) {
+		return true
+	}
+
+	if updated.StructuredIngredients != nil && !slices.Equal(r.StructuredIngredients, updated.StructuredIngredients) {
+		return true
+	}
+
+	return r.Category != updated.Category ||
+		r.Description != updated.Description ||
+		r.Name != updated.Name ||
+		r.URL != updated.URL ||
+		r.Yield != updated.Yield ||
+		r.YieldUnit != updated.YieldUnit ||
+		r.Times.Prep != updated.Times.Prep ||
+		r.Times.Cook != updated.Times.Cook ||
+		!r.Nutrition.Equal(updated.Nutrition) ||
+		!slices.Equal(r.Ingredients, updated.Ingredients) ||
+		!slices.Equal(r.IngredientSections, updated.IngredientSections) ||
+		!slices.Equal(r.Instructions, updated.Instructions) ||
+		!slices.Equal(r.InstructionSections, updated.InstructionSections) ||
+		!slices.Equal(r.Keywords, updated.Keywords) ||
+		!slices.Equal(r.Tools, updated.Tools)
+}
+
+// RecipeHistory holds the revisions of a recipe, from the most recent to the oldest.
+type RecipeHistory struct {
+	Current   Recipe
+	Revisions []RecipeRevision
+}
+
+// Changes lists what the update that created the revision at the index changed.
+// The revision is compared with the recipe that followed it.
+func (h RecipeHistory) Changes(index int) RevisionChanges {
+	next := h.Current
+	if index > 0 {
+		next = h.Revisions[index-1].Recipe
+	}
+	before := h.Revisions[index].Recipe
+
+	changes := RevisionChanges{
+		Fields: slices.DeleteFunc(RecipeChanges(before, next), func(f FieldChange) bool {
+			return f.Field == "Ingredients" || f.Field == "Instructions"
+		}),
+	}
+
+	if !slices.Equal(before.Ingredients, next.Ingredients) {
+		changes.Ingredients = DiffLines(before.Ingredients, next.Ingredients)
+	}
+
+	if !slices.Equal(before.Instructions, next.Instructions) {
+		changes.Instructions = DiffLines(before.Instructions, next.Instructions)
+	}
+	return changes
+}
+
+// RevisionChanges holds the changes brought to a recipe by an update.
+type RevisionChanges struct {
+	Fields       []FieldChange
+	Ingredients  []LineChange
+	Instructions []LineChange
+}
+
+// IsEmpty reports whether the update changed nothing that is displayed in the history.
+func (r RevisionChanges) IsEmpty() bool {
+	return len(r.Fields) == 0 && len(r.Ingredients) == 0 && len(r.Instructions) == 0
+}
+
+// LineChangeKind is the kind of change of a line in a diff.
+type LineChangeKind int
+
+// These constants enumerate the kinds of changes of a line.
+const (
+	LineUnchanged LineChangeKind = iota
+	LineAdded
+	LineRemoved
+)
+
+// LineChange is a line of a diff between two lists.
+type LineChange struct {
+	Kind LineChangeKind
+	Text string
+}
+
+// DiffLines computes the changes to go from the before lines to the after lines
+// using their longest common subsequence.
+func DiffLines(before, after []string) []LineChange {
+	n, m := len(before), len(after)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if before[i] == after[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	changes := make([]LineChange, 0, max(n, m))
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case before[i] == after[j]:
+			changes = append(changes, LineChange{Kind: LineUnchanged, Text: before[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			changes = append(changes, LineChange{Kind: LineRemoved, Text: before[i]})
+			i++
+		default:
+			changes = append(changes, LineChange{Kind: LineAdded, Text: after[j]})
+			j++
+		}
+	}
+
+	for ; i < n; i++ {
+		changes = append(changes, LineChange{Kind: LineRemoved, Text: before[i]})
+	}
+
+	for ; j < m; j++ {
+		changes = append(changes, LineChange{Kind: LineAdded, Text: after[j]})
+	}
+	return changes
+}
@@ -0,0 +1,162 @@
+package models_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestDiffLines(t *testing.T) {
+	testcases := []struct {
+		name   string
+		before []string
+		after  []string
+		want   []models.LineChange
+	}{
+		{
+			name:   "identical",
+			before: []string{"a", "b"},
+			after:  []string{"a", "b"},
+			want: []models.LineChange{
+				{Kind: models.LineUnchanged, Text: "a"},
+				{Kind: models.LineUnchanged, Text: "b"},
+			},
+		},
+		{
+			name:   "line replaced",
+			before: []string{"1 cup flour", "1 egg", "salt"},
+			after:  []string{"1 cup flour", "2 eggs", "salt"},
+			want: []models.LineChange{
+				{Kind: models.LineUnchanged, Text: "1 cup flour"},
+				{Kind: models.LineRemoved, Text: "1 egg"},
+				{Kind: models.LineAdded, Text: "2 eggs"},
+				{Kind: models.LineUnchanged, Text: "salt"},
+			},
+		},
+		{
+			name:   "lines added and removed at the ends",
+			before: []string{"a", "b"},
+			after:  []string{"b", "c"},
+			want: []models.LineChange{
+				{Kind: models.LineRemoved, Text: "a"},
+				{Kind: models.LineUnchanged, Text: "b"},
+				{Kind: models.LineAdded, Text: "c"},
+			},
+		},
+		{
+			name:  "from nothing",
+			after: []string{"a"},
+			want:  []models.LineChange{{Kind: models.LineAdded, Text: "a"}},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := models.DiffLines(tc.before, tc.after)
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("got %+v but want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRecipeHistory_Changes(t *testing.T) {
+	history := models.RecipeHistory{
+		Current: models.Recipe{Name: "Pancakes", Ingredients: []string{"2 cups flour"}, Instructions: []string{"Mix"}},
+		Revisions: []models.RecipeRevision{
+			{ID: 2, Recipe: models.Recipe{Name: "Pancakes", Ingredients: []string{"1 cup flour"}, Instructions: []string{"Mix"}}},
+			{ID: 1, Recipe: models.Recipe{Name: "Crepes", Ingredients: []string{"1 cup flour"}, Instructions: []string{"Mix"}}},
+		},
+	}
+
+	t.Run("latest revision is compared with the current recipe", func(t *testing.T) {
+		got := history.Changes(0)
+
+		if len(got.Fields) != 0 || len(got.Instructions) != 0 {
+			t.Fatalf("only the ingredients changed but got %+v", got)
+		}
+		want := []models.LineChange{
+			{Kind: models.LineRemoved, Text: "1 cup flour"},
+			{Kind: models.LineAdded, Text: "2 cups flour"},
+		}
+		if !slices.Equal(got.Ingredients, want) {
+			t.Fatalf("got %+v but want %+v", got.Ingredients, want)
+		}
+	})
+
+	t.Run("older revision is compared with the next one", func(t *testing.T) {
+		got := history.Changes(1)
+
+		want := []models.FieldChange{{Field: "Title", Saved: "Crepes", Submitted: "Pancakes"}}
+		if !slices.Equal(got.Fields, want) {
+			t.Fatalf("got %+v but want %+v", got.Fields, want)
+		}
+		if got.Ingredients != nil || got.Instructions != nil {
+			t.Fatalf("lists did not change but got %+v", got)
+		}
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		h := models.RecipeHistory{Current: models.Recipe{Name: "A"}, Revisions: []models.RecipeRevision{{Recipe: models.Recipe{Name: "A"}}}}
+		if !h.Changes(0).IsEmpty() {
+			t.Fatal("changes must be empty")
+		}
+	})
+}
+
+func TestRecipe_IsRevisedBy(t *testing.T) {
+	recipe := models.Recipe{
+		ID:                    1,
+		Category:              "breakfast",
+		Images:                []uuid.UUID{uuid.New()},
+		Ingredients:           []string{"1 cup flour"},
+		Instructions:          []string{"Mix"},
+		Name:                  "Pancakes",
+		StructuredIngredients: []models.Ingredient{{Name: "flour", Quantity: 1, Text: "1 cup flour"}},
+		Yield:                 4,
+	}
+
+	testcases := []struct {
+		name   string
+		update func(r *models.Recipe)
+		want   bool
+	}{
+		{
+			name:   "same content",
+			update: func(_ *models.Recipe) {},
+		},
+		{
+			name: "media and structured ingredients not provided",
+			update: func(r *models.Recipe) {
+				r.Images = nil
+				r.StructuredIngredients = nil
+			},
+		},
+		{
+			name:   "name changed",
+			update: func(r *models.Recipe) { r.Name = "Crepes" },
+			want:   true,
+		},
+		{
+			name:   "images cleared",
+			update: func(r *models.Recipe) { r.Images = []uuid.UUID{} },
+			want:   true,
+		},
+		{
+			name:   "instructions changed",
+			update: func(r *models.Recipe) { r.Instructions = []string{"Mix well"} },
+			want:   true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			updated := recipe.Copy()
+			tc.update(&updated)
+
+			if got := recipe.IsRevisedBy(&updated); got != tc.want {
+				t.Fatalf("got %t but want %t", got, tc.want)
+			}
+		})
+	}
+}
@@ -4,13 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
-	"math"
 	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/reaper47/recipya/internal/app"
+	"github.com/reaper47/recipya/internal/units"
 	"github.com/reaper47/recipya/internal/utils/extensions"
 	"github.com/reaper47/recipya/internal/utils/regex"
 
@@ -19,30 +19,31 @@ import (
 
 // RecipeSchema is a representation of the Recipe schema (https://schema.org/Recipe).
 type RecipeSchema struct {
-	AtContext       string           `json:"@context"`
-	AtGraph         []*RecipeSchema  `json:"@graph,omitempty"`
-	AtType          *SchemaType      `json:"@type"`
-	Category        *Category        `json:"recipeCategory,omitempty"`
-	CookTime        string           `json:"cookTime,omitempty"`
-	CookingMethod   *CookingMethod   `json:"cookingMethod,omitempty"`
-	Cuisine         *Cuisine         `json:"recipeCuisine,omitempty"`
-	DateCreated     string           `json:"dateCreated,omitempty"`
-	DateModified    string           `json:"dateModified,omitempty"`
-	DatePublished   string           `json:"datePublished,omitempty"`
-	Description     *Description     `json:"description"`
-	Keywords        *Keywords        `json:"keywords,omitempty"`
-	Image           *Image           `json:"image,omitempty"`
-	Ingredients     *Ingredients     `json:"recipeIngredient,omitempty"`
-	Instructions    *Instructions    `json:"recipeInstructions,omitempty"`
-	Name            string           `json:"name,omitempty"`
-	NutritionSchema *NutritionSchema `json:"nutrition,omitempty"`
-	PrepTime        string           `json:"prepTime,omitempty"`
-	ThumbnailURL    *ThumbnailURL    `json:"thumbnailUrl,omitempty"`
-	Tools           *Tools           `json:"tool,omitempty"`
-	TotalTime       string           `json:"totalTime,omitempty"`
-	Yield           *Yield           `json:"recipeYield,omitempty"`
-	URL             string           `json:"url,omitempty"`
-	Video           *Videos          `json:"video,omitempty"`
+	AtContext             string             `json:"@context"`
+	AtGraph               []*RecipeSchema    `json:"@graph,omitempty"`
+	AtType                *SchemaType        `json:"@type"`
+	Category              *Category          `json:"recipeCategory,omitempty"`
+	CookTime              string             `json:"cookTime,omitempty"`
+	CookingMethod         *CookingMethod     `json:"cookingMethod,omitempty"`
+	Cuisine               *Cuisine           `json:"recipeCuisine,omitempty"`
+	DateCreated           string             `json:"dateCreated,omitempty"`
+	DateModified          string             `json:"dateModified,omitempty"`
+	DatePublished         string             `json:"datePublished,omitempty"`
+	Description           *Description       `json:"description"`
+	Keywords              *Keywords          `json:"keywords,omitempty"`
+	Image                 *Image             `json:"image,omitempty"`
+	Ingredients           *Ingredients       `json:"recipeIngredient,omitempty"`
+	Instructions          *Instructions      `json:"recipeInstructions,omitempty"`
+	Name                  string             `json:"name,omitempty"`
+	NutritionSchema       *NutritionSchema   `json:"nutrition,omitempty"`
+	PrepTime              string             `json:"prepTime,omitempty"`
+	StructuredIngredients []IngredientSchema `json:"structuredIngredients,omitempty"`
+	ThumbnailURL          *ThumbnailURL      `json:"thumbnailUrl,omitempty"`
+	Tools                 *Tools             `json:"tool,omitempty"`
+	TotalTime             string             `json:"totalTime,omitempty"`
+	Yield                 *Yield             `json:"recipeYield,omitempty"`
+	URL                   string             `json:"url,omitempty"`
+	Video                 *Videos            `json:"video,omitempty"`
 }
 
 // Equal verifies whether a RecipeSchema is equal to the other.
@@ -65,7 +66,7 @@ func (r *RecipeSchema) Equal(other RecipeSchema) bool {
 		r.PrepTime == other.PrepTime &&
 		r.Tools != nil && slices.Equal(r.Tools.Values, other.Tools.Values) &&
 		r.TotalTime == other.TotalTime &&
-		r.Yield != nil && r.Yield.Value == other.Yield.Value &&
+		r.Yield != nil && *r.Yield == *other.Yield &&
 		r.URL == other.URL
 }
 
@@ -207,12 +208,16 @@ func (r *RecipeSchema) Recipe() (*Recipe, error) {
 		}
 	}
 
-	var instructions []string
-	if r.Ingredients != nil {
+	var (
+		instructions        []string
+		instructionSections Sections
+	)
+	if r.Instructions != nil {
 		instructions = make([]string, 0, len(r.Instructions.Values))
 		for _, v := range r.Instructions.Values {
 			instructions = append(instructions, v.Text)
 		}
+		instructionSections = r.Instructions.Sections
 	}
 
 	var keywords []string
@@ -230,9 +235,21 @@ func (r *RecipeSchema) Recipe() (*Recipe, error) {
 		description = r.Description.Value
 	}
 
-	var ingredients []string
+	var (
+		ingredients        []string
+		ingredientSections Sections
+	)
 	if r.Ingredients != nil {
 		ingredients = r.Ingredients.Values
+		ingredientSections = r.Ingredients.Sections
+	}
+
+	var structured []Ingredient
+	if len(r.StructuredIngredients) > 0 && len(r.StructuredIngredients) == len(ingredients) {
+		structured = make([]Ingredient, 0, len(r.StructuredIngredients))
+		for _, in := range r.StructuredIngredients {
+			structured = append(structured, in.Ingredient())
+		}
 	}
 
 	var tools []HowToItem
@@ -240,9 +257,9 @@ func (r *RecipeSchema) Recipe() (*Recipe, error) {
 		tools = r.Tools.Values
 	}
 
-	var yield int16
+	var yield Yield
 	if r.Yield != nil {
-		yield = r.Yield.Value
+		yield = *r.Yield
 	}
 
 	var videos []VideoObject
@@ -251,29 +268,63 @@ func (r *RecipeSchema) Recipe() (*Recipe, error) {
 	}
 
 	recipe := Recipe{
-		Category:     category,
-		CreatedAt:    createdAt,
-		Cuisine:      cuisine,
-		Description:  description,
-		ID:           0,
-		Images:       images,
-		Ingredients:  ingredients,
-		Instructions: instructions,
-		Keywords:     keywords,
-		Name:         r.Name,
-		Nutrition:    nutrition,
-		Times:        times,
-		Tools:        tools,
-		UpdatedAt:    updatedAt,
-		URL:          r.URL,
-		Videos:       videos,
-		Yield:        yield,
+		Category:              category,
+		CreatedAt:             createdAt,
+		Cuisine:               cuisine,
+		Description:           description,
+		ID:                    0,
+		Images:                images,
+		IngredientSections:    ingredientSections,
+		Ingredients:           ingredients,
+		InstructionSections:   instructionSections,
+		Instructions:          instructions,
+		Keywords:              keywords,
+		Name:                  r.Name,
+		Nutrition:             nutrition,
+		StructuredIngredients: structured,
+		Times:                 times,
+		Tools:                 tools,
+		UpdatedAt:             updatedAt,
+		URL:                   r.URL,
+		Videos:                videos,
+		Yield:                 yield.Value,
+		YieldUnit:             yield.Unit,
 	}
 
 	recipe.Normalize()
 	return &recipe, nil
 }
 
+// IngredientSchema holds the parts of an ingredient of the RecipeSchema. It is an extension
+// to the schema so that the ingredients edited by hand survive an export followed by an import.
+type IngredientSchema struct {
+	IsOptional  bool    `json:"isOptional,omitempty"`
+	Name        string  `json:"name"`
+	Note        string  `json:"note,omitempty"`
+	Quantity    float64 `json:"quantity,omitempty"`
+	QuantityMax float64 `json:"quantityMax,omitempty"`
+	Text        string  `json:"text"`
+	Unit        string  `json:"unit,omitempty"`
+}
+
+// Ingredient converts the IngredientSchema to an Ingredient.
+func (i IngredientSchema) Ingredient() Ingredient {
+	var unit units.Unit
+	if i.Unit != "" {
+		unit = units.NewUnit(i.Unit)
+	}
+
+	return Ingredient{
+		IsOptional:  i.IsOptional,
+		Name:        i.Name,
+		Note:        i.Note,
+		Quantity:    i.Quantity,
+		QuantityMax: i.QuantityMax,
+		Text:        i.Text,
+		Unit:        unit,
+	}
+}
+
 // SchemaType holds the type of the schema. It should be "Recipe".
 type SchemaType struct {
 	Value string
@@ -573,12 +624,13 @@ func (i *Image) UnmarshalJSON(data []byte) error {
 
 // Ingredients holds a recipe's list of ingredients.
 type Ingredients struct {
-	Values []string
+	Sections Sections
+	Values   []string
 }
 
-// MarshalJSON encodes the ingredients.
+// MarshalJSON encodes the ingredients. The ingredients of a section are grouped in a HowToSection.
 func (i *Ingredients) MarshalJSON() ([]byte, error) {
-	return json.Marshal(i.Values)
+	return marshalSections(i.Values, i.Sections)
 }
 
 // UnmarshalJSON decodes the ingredients according to the schema (https://schema.org/recipeInstructions).
@@ -614,29 +666,54 @@ func (i *Ingredients) UnmarshalJSON(data []byte) error {
 		{"&amp;", "&"},
 	}
 
-	for _, v := range xv {
-		str := v.(string)
+	add := func(str string) {
 		if str == " " {
-			continue
+			return
 		}
 
-		str = strings.TrimSpace(v.(string))
+		str = strings.TrimSpace(str)
 		for _, c := range cases {
 			str = strings.ReplaceAll(str, c.Old, c.New)
 		}
 		i.Values = append(i.Values, str)
 	}
+
+	for _, v := range xv {
+		switch x := v.(type) {
+		case string:
+			add(x)
+		case map[string]any:
+			name, _ := x["name"].(string)
+			if name != "" {
+				i.Sections = append(i.Sections, Section{Index: len(i.Values), Name: strings.TrimSpace(name)})
+			}
+
+			items, _ := x["itemListElement"].([]any)
+			for _, item := range items {
+				switch y := item.(type) {
+				case string:
+					add(y)
+				case map[string]any:
+					text, ok := y["text"].(string)
+					if ok {
+						add(text)
+					}
+				}
+			}
+		}
+	}
 	return nil
 }
 
 // Instructions holds a recipe's list of instructions.
 type Instructions struct {
-	Values []HowToItem
+	Sections Sections
+	Values   []HowToItem
 }
 
-// MarshalJSON encodes the list of instructions.
+// MarshalJSON encodes the list of instructions. The steps of a section are grouped in a HowToSection.
 func (i *Instructions) MarshalJSON() ([]byte, error) {
-	return json.Marshal(i.Values)
+	return marshalSections(i.Values, i.Sections)
 }
 
 // UnmarshalJSON decodes the instructions according to the schema (https://schema.org/recipeInstructions).
@@ -736,6 +813,13 @@ func parseSections(part any, instructions *Instructions) {
 		return
 	}
 
+	if sect.AtType == "HowToSection" && sect.Name != "" {
+		instructions.Sections = append(instructions.Sections, Section{
+			Index: len(instructions.Values),
+			Name:  strings.TrimSpace(sect.Name),
+		})
+	}
+
 	if sect.Text != "" {
 		instructions.Values = append(instructions.Values, NewHowToStep(sect.Text))
 	}
@@ -765,14 +849,19 @@ func parseSections(part any, instructions *Instructions) {
 	}
 }
 
-// Yield holds a recipe's yield.
+// Yield holds a recipe's yield. The unit describes what the recipe yields, e.g. cookies
+// or L of stock. The yield is in servings when the unit is empty.
 type Yield struct {
-	Value int16
+	Value float64
+	Unit  string
 }
 
-// MarshalJSON encodes the value of the yield.
+// MarshalJSON encodes the yield. It is a number when the yield is in servings and a text otherwise.
 func (y *Yield) MarshalJSON() ([]byte, error) {
-	return json.Marshal(y.Value)
+	if y.Unit == "" {
+		return json.Marshal(y.Value)
+	}
+	return json.Marshal(FormatYield(y.Value, y.Unit))
 }
 
 // UnmarshalJSON decodes the yield according to the schema (https://schema.org/recipeYield).
@@ -783,44 +872,37 @@ func (y *Yield) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	*y = Yield{}
 	switch x := v.(type) {
 	case string:
-		parts := strings.Split(v.(string), " ")
-		for _, part := range parts {
-			i, err := strconv.ParseInt(part, 10, 8)
-			if err == nil {
-				y.Value = int16(i)
-				break
-			}
-		}
+		*y = NewYield(x)
 	case float64:
-		y.Value = int16(x)
+		y.Value = x
 	case []any:
-		if len(x) == 0 {
-			break
-		}
+		for _, e := range x {
+			switch t := e.(type) {
+			case float64:
+				if y.Value == 0 {
+					y.Value = t
+				}
+			case string:
+				parsed := NewYield(t)
+				if y.Value == 0 {
+					y.Value = parsed.Value
+				}
 
-		switch t := x[0].(type) {
-		case float64:
-			if t >= math.MinInt16 && t <= math.MaxInt16 {
-				y.Value = int16(t)
-			} else {
-				y.Value = int16(4)
-			}
-		case string:
-			parts := strings.Split(t, " ")
-			for _, part := range parts {
-				i, err := strconv.ParseInt(part, 10, 8)
-				if err == nil {
-					y.Value = int16(i)
-					break
+				if y.Unit == "" && parsed.Value == y.Value {
+					y.Unit = parsed.Unit
 				}
 			}
 		}
 	case map[string]any:
-		v, ok := x["Value"]
-		if ok {
-			y.Value = int16(v.(float64))
+		if value, ok := x["Value"].(float64); ok {
+			y.Value = value
+		}
+
+		if unit, ok := x["Unit"].(string); ok {
+			y.Unit = unit
 		}
 	}
 	return nil
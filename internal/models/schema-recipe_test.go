@@ -201,6 +201,31 @@ func TestIngredient_UnmarshalJSON(t *testing.T) {
 	}
 }
 
+func TestIngredients_Sections(t *testing.T) {
+	want := models.RecipeSchema{
+		Ingredients: &models.Ingredients{
+			Sections: models.Sections{{Index: 1, Name: "For the sauce"}},
+			Values:   []string{"1 chicken", "2 tomatoes", "1 onion"},
+		},
+	}
+
+	t.Run("unmarshal", func(t *testing.T) {
+		assertRecipeSchema(t, `{"recipeIngredient": ["1 chicken", {"@type": "HowToSection", "name": "For the sauce", "itemListElement": ["2 tomatoes", "1 onion"]}]}`, want)
+	})
+
+	t.Run("marshal", func(t *testing.T) {
+		got, err := json.Marshal(want.Ingredients)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		wantJSON := `["1 chicken",{"@type":"HowToSection","name":"For the sauce","itemListElement":["2 tomatoes","1 onion"]}]`
+		if string(got) != wantJSON {
+			t.Fatalf("got %s but want %s", got, wantJSON)
+		}
+	})
+}
+
 func TestInstruction_UnmarshalJSON(t *testing.T) {
 	want := models.RecipeSchema{
 		Instructions: &models.Instructions{
@@ -236,6 +261,33 @@ func TestInstruction_UnmarshalJSON(t *testing.T) {
 	}
 }
 
+func TestInstructions_Sections(t *testing.T) {
+	want := models.RecipeSchema{
+		Instructions: &models.Instructions{
+			Sections: models.Sections{{Index: 0, Name: "Dough"}, {Index: 2, Name: "Baking"}},
+			Values: []models.HowToItem{
+				{Type: "HowToStep", Text: "mix"},
+				{Type: "HowToStep", Text: "knead"},
+				{Type: "HowToStep", Text: "bake"},
+			},
+		},
+	}
+
+	data := `{"recipeInstructions": [
+		{"@type": "HowToSection", "name": "Dough", "itemListElement": [{"@type": "HowToStep", "text": "mix"}, {"@type": "HowToStep", "text": "knead"}]},
+		{"@type": "HowToSection", "name": "Baking", "itemListElement": [{"@type": "HowToStep", "text": "bake"}]}
+	]}`
+	assertRecipeSchema(t, data, want)
+
+	t.Run("round trip", func(t *testing.T) {
+		xb, err := json.Marshal(want)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertRecipeSchema(t, string(xb), want)
+	})
+}
+
 func TestThumnailURL_UnmarshalJSON(t *testing.T) {
 	want := models.RecipeSchema{
 		ThumbnailURL: &models.ThumbnailURL{Value: "thumbnail.png"},
@@ -262,38 +314,84 @@ func TestThumnailURL_UnmarshalJSON(t *testing.T) {
 }
 
 func TestYield_UnmarshalJSON(t *testing.T) {
-	want := models.RecipeSchema{
-		Yield: &models.Yield{Value: 4},
-	}
-
 	testcases := []struct {
 		name string
 		data string
+		want models.Yield
 	}{
 		{
 			name: "text",
 			data: `{"recipeYield": "serves 4 people"}`,
+			want: models.Yield{Value: 4},
 		},
 		{
 			name: "number",
 			data: `{"recipeYield": 4}`,
+			want: models.Yield{Value: 4},
+		},
+		{
+			name: "fractional number",
+			data: `{"recipeYield": 1.5}`,
+			want: models.Yield{Value: 1.5},
 		},
 		{
 			name: "list of number values",
 			data: `{"recipeYield": [4, 5, 6]}`,
+			want: models.Yield{Value: 4},
 		},
 		{
 			name: "list of text values",
 			data: `{"recipeYield": ["makes 4 loaves"]}`,
+			want: models.Yield{Value: 4, Unit: "loaves"},
+		},
+		{
+			name: "list of a number and a text",
+			data: `{"recipeYield": ["24", "24 cookies"]}`,
+			want: models.Yield{Value: 24, Unit: "cookies"},
+		},
+		{
+			name: "text with a unit of measurement",
+			data: `{"recipeYield": "1.5 L of stock"}`,
+			want: models.Yield{Value: 1.5, Unit: "L of stock"},
 		},
 		{
 			name: "map of values",
 			data: `{"recipeYield": {"Value": 4}}`,
+			want: models.Yield{Value: 4},
+		},
+		{
+			name: "map of values with a unit",
+			data: `{"recipeYield": {"Value": 24, "Unit": "cookies"}}`,
+			want: models.Yield{Value: 24, Unit: "cookies"},
 		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
-			assertRecipeSchema(t, tc.data, want)
+			assertRecipeSchema(t, tc.data, models.RecipeSchema{Yield: &tc.want})
+		})
+	}
+}
+
+func TestYield_MarshalJSON(t *testing.T) {
+	testcases := []struct {
+		name string
+		in   models.Yield
+		want string
+	}{
+		{name: "servings", in: models.Yield{Value: 4}, want: `4`},
+		{name: "fractional servings", in: models.Yield{Value: 2.5}, want: `2.5`},
+		{name: "unit", in: models.Yield{Value: 24, Unit: "cookies"}, want: `"24 cookies"`},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := json.Marshal(&tc.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(got) != tc.want {
+				t.Fatalf("got %s but want %s", got, tc.want)
+			}
 		})
 	}
 }
@@ -866,7 +964,7 @@ func TestRecipeSchema_Marshal(t *testing.T) {
 				t.Errorf("got totalTime %v; want %v", v, rs.TotalTime)
 			}
 		case "recipeYield":
-			if int16(v.(float64)) != rs.Yield.Value {
+			if v.(float64) != rs.Yield.Value {
 				t.Errorf("got recipeYield %v; want %v", v, rs.Yield.Value)
 			}
 		case "url":
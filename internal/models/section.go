@@ -0,0 +1,82 @@
+package models
+
+import (
+	"encoding/json"
+	"slices"
+)
+
+// Section is a heading grouping the ingredients or the instructions of a recipe, e.g. "For the dough".
+// It applies to the items from its Index until the next section.
+type Section struct {
+	Index int
+	Name  string
+}
+
+// Sections holds the section headings of a list of items, ordered by index.
+type Sections []Section
+
+// At returns the name of the section starting at the item index, if any. A section
+// directly followed by another one has no items, so the latter wins.
+func (s Sections) At(index int) string {
+	for _, sect := range slices.Backward(s) {
+		if sect.Index == index {
+			return sect.Name
+		}
+	}
+	return ""
+}
+
+// Clean removes the empty and duplicate items, moving the sections along with their items.
+// The sections left without items are dropped.
+func (s Sections) Clean(items []string) ([]string, Sections) {
+	seen := make(map[string]struct{}, len(items))
+	cleaned := make([]string, 0, len(items))
+	var sections Sections
+
+	for i, item := range items {
+		if name := s.At(i); name != "" {
+			sections = slices.DeleteFunc(sections, func(sect Section) bool { return sect.Index == len(cleaned) })
+			sections = append(sections, Section{Index: len(cleaned), Name: name})
+		}
+
+		if _, ok := seen[item]; ok || item == "" {
+			continue
+		}
+		seen[item] = struct{}{}
+		cleaned = append(cleaned, item)
+	}
+
+	sections = slices.DeleteFunc(sections, func(sect Section) bool { return sect.Index >= len(cleaned) })
+	return cleaned, sections
+}
+
+// howToSection is the schema of a HowToSection (https://schema.org/HowToSection).
+type howToSection struct {
+	AtType string `json:"@type"`
+	Name   string `json:"name"`
+	Items  []any  `json:"itemListElement"`
+}
+
+// marshalSections encodes the values, grouped in a HowToSection per section. The values before
+// the first section are encoded as is.
+func marshalSections[T any](values []T, sections Sections) ([]byte, error) {
+	if len(sections) == 0 {
+		return json.Marshal(values)
+	}
+
+	xs := make([]any, 0, len(values))
+	var current *howToSection
+	for i, v := range values {
+		if name := sections.At(i); name != "" {
+			current = &howToSection{AtType: "HowToSection", Name: name}
+			xs = append(xs, current)
+		}
+
+		if current != nil {
+			current.Items = append(current.Items, v)
+		} else {
+			xs = append(xs, v)
+		}
+	}
+	return json.Marshal(xs)
+}
@@ -0,0 +1,75 @@
+package models_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestSections_At(t *testing.T) {
+	sections := models.Sections{{Index: 0, Name: "Dough"}, {Index: 3, Name: "Empty"}, {Index: 3, Name: "Filling"}}
+
+	testcases := []struct {
+		index int
+		want  string
+	}{
+		{index: 0, want: "Dough"},
+		{index: 1, want: ""},
+		{index: 3, want: "Filling"},
+	}
+	for _, tc := range testcases {
+		if got := sections.At(tc.index); got != tc.want {
+			t.Errorf("At(%d): got %q but want %q", tc.index, got, tc.want)
+		}
+	}
+}
+
+func TestSections_Clean(t *testing.T) {
+	testcases := []struct {
+		name         string
+		items        []string
+		sections     models.Sections
+		wantItems    []string
+		wantSections models.Sections
+	}{
+		{
+			name:         "no sections",
+			items:        []string{"a", "", "b", "a"},
+			wantItems:    []string{"a", "b"},
+			wantSections: nil,
+		},
+		{
+			name:         "sections follow their items",
+			items:        []string{"a", "", "b", "a", "c"},
+			sections:     models.Sections{{Index: 0, Name: "Dough"}, {Index: 2, Name: "Filling"}, {Index: 4, Name: "Topping"}},
+			wantItems:    []string{"a", "b", "c"},
+			wantSections: models.Sections{{Index: 0, Name: "Dough"}, {Index: 1, Name: "Filling"}, {Index: 2, Name: "Topping"}},
+		},
+		{
+			name:         "section of removed items",
+			items:        []string{"a", "", "b"},
+			sections:     models.Sections{{Index: 1, Name: "Empty"}, {Index: 2, Name: "Filling"}},
+			wantItems:    []string{"a", "b"},
+			wantSections: models.Sections{{Index: 1, Name: "Filling"}},
+		},
+		{
+			name:         "trailing section",
+			items:        []string{"a", "b"},
+			sections:     models.Sections{{Index: 2, Name: "Nothing"}},
+			wantItems:    []string{"a", "b"},
+			wantSections: nil,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			items, sections := tc.sections.Clean(tc.items)
+			if !slices.Equal(items, tc.wantItems) {
+				t.Errorf("got items %v but want %v", items, tc.wantItems)
+			}
+			if !slices.Equal(sections, tc.wantSections) {
+				t.Errorf("got sections %+v but want %+v", sections, tc.wantSections)
+			}
+		})
+	}
+}
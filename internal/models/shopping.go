@@ -0,0 +1,308 @@
+package models
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/reaper47/recipya/internal/units"
+)
+
+// Aisle is the section of the grocery store an ingredient is found in.
+type Aisle string
+
+// These constants define the aisles of a grocery store.
+const (
+	AisleBakery    Aisle = "Bakery"
+	AisleBeverages Aisle = "Beverages"
+	AisleDairy     Aisle = "Dairy & Eggs"
+	AisleFrozen    Aisle = "Frozen"
+	AisleMeat      Aisle = "Meat & Seafood"
+	AisleOther     Aisle = "Other"
+	AislePantry    Aisle = "Pantry"
+	AisleProduce   Aisle = "Produce"
+	AisleSpices    Aisle = "Spices & Seasonings"
+)
+
+// Aisles lists the aisles in the order they are walked through in a typical grocery store.
+var Aisles = []Aisle{
+	AisleProduce,
+	AisleBakery,
+	AisleMeat,
+	AisleDairy,
+	AislePantry,
+	AisleSpices,
+	AisleFrozen,
+	AisleBeverages,
+	AisleOther,
+}
+
+// aisleKeywords maps the aisles to the words found in the names of their ingredients.
+// The aisles are looked up in the order of the slice, so more specific aisles come first.
+// A keyword may span several words, e.g. "black pepper" is a spice whereas a bell pepper
+// is produce.
+var aisleKeywords = []struct {
+	aisle Aisle
+	words []string
+}{
+	{
+		aisle: AisleFrozen,
+		words: []string{"frozen", "ice"},
+	},
+	{
+		aisle: AisleSpices,
+		words: []string{
+			"allspice", "anise", "basil", "bay", "cardamom", "cayenne", "chili", "cinnamon", "clove", "coriander",
+			"cumin", "curry", "dill", "fennel", "nutmeg", "oregano", "paprika", "black pepper", "peppercorn",
+			"rosemary", "saffron", "sage", "salt", "seasoning", "spice", "tarragon", "thyme", "turmeric", "vanilla",
+			"white pepper",
+		},
+	},
+	{
+		aisle: AisleDairy,
+		words: []string{
+			"butter", "buttermilk", "cheddar", "cheese", "cream", "egg", "feta", "ghee", "milk", "mozzarella",
+			"parmesan", "ricotta", "yogurt", "yoghurt",
+		},
+	},
+	{
+		aisle: AisleMeat,
+		words: []string{
+			"anchovy", "bacon", "beef", "chicken", "chorizo", "clam", "cod", "crab", "duck", "fish", "ham", "lamb",
+			"lobster", "mussel", "pork", "prawn", "salmon", "sausage", "scallop", "shrimp", "steak", "tuna",
+			"turkey", "veal",
+		},
+	},
+	{
+		aisle: AisleBakery,
+		words: []string{"bagel", "baguette", "bread", "brioche", "bun", "croissant", "pita", "roll", "tortilla"},
+	},
+	{
+		aisle: AisleBeverages,
+		words: []string{"beer", "coffee", "juice", "soda", "tea", "water", "wine"},
+	},
+	{
+		aisle: AislePantry,
+		words: []string{
+			"baking", "bean", "broth", "chickpea", "chocolate", "cocoa", "cornstarch", "flour", "honey", "jam",
+			"ketchup", "lentil", "mayonnaise", "mustard", "noodle", "nut", "oat", "oil", "pasta", "quinoa", "rice",
+			"sauce", "stock", "sugar", "syrup", "vinegar", "yeast",
+		},
+	},
+	{
+		aisle: AisleProduce,
+		words: []string{
+			"apple", "avocado", "banana", "berry", "broccoli", "cabbage", "carrot", "celery", "cilantro", "cucumber",
+			"garlic", "ginger", "kale", "leek", "lemon", "lettuce", "lime", "mushroom", "onion", "orange", "parsley",
+			"pea", "pepper", "potato", "scallion", "shallot", "spinach", "squash", "tomato", "zucchini",
+		},
+	},
+}
+
+// NewAisle determines the aisle of the grocery store the ingredient is found in.
+func NewAisle(ingredient string) Aisle {
+	words := strings.Fields(strings.ToLower(ingredient))
+	for _, ak := range aisleKeywords {
+		for i := range words {
+			if slices.ContainsFunc(ak.words, func(k string) bool {
+				n := strings.Count(k, " ") + 1
+				if i+n > len(words) {
+					return false
+				}
+				word := strings.Join(words[i:i+n], " ")
+				return word == k || word == k+"s" || word == k+"es"
+			}) {
+				return ak.aisle
+			}
+		}
+	}
+	return AisleOther
+}
+
+// ShoppingList is a consolidated list of the ingredients of recipes to buy.
+type ShoppingList struct {
+	ID        int64
+	CreatedAt time.Time
+	Items     []ShoppingListItem
+	Name      string
+	Recipes   []ShoppingListRecipe
+}
+
+// AddRecipe adds the recipe's ingredients, scaled to the yield, to the list. The recipe's own
+// yield is used when the yield is not positive.
+func (s *ShoppingList) AddRecipe(recipe Recipe, yield int16) {
+	multiplier := 1.
+	if yield <= 0 {
+		yield = recipe.Servings()
+	} else if recipe.Yield > 0 {
+		multiplier = float64(yield) / recipe.Yield
+	}
+
+	for _, ingredient := range recipe.Ingredients {
+		s.AddIngredient(ingredient, multiplier)
+	}
+
+	s.Recipes = append(s.Recipes, ShoppingListRecipe{RecipeID: recipe.ID, Name: recipe.Name, Yield: yield})
+}
+
+// AddIngredient parses the ingredient and adds its quantity, scaled by the multiplier, to the
+// list. The quantity is summed with that of an item of the same name, singular or plural, when
+// their units are compatible.
+func (s *ShoppingList) AddIngredient(ingredient string, multiplier float64) {
+	name, m := parseIngredient(ingredient)
+	if name == "" {
+		return
+	}
+	m.Quantity *= multiplier
+
+	for i, item := range s.Items {
+		if !isSameIngredient(item.Name, name) {
+			continue
+		}
+
+		if merged, ok := item.add(m); ok {
+			s.Items[i] = merged
+			return
+		}
+	}
+
+	s.Items = append(s.Items, ShoppingListItem{
+		Aisle:    NewAisle(name),
+		Name:     name,
+		Quantity: m.Quantity,
+		Unit:     m.Unit,
+	})
+}
+
+// parseIngredient extracts the name and the measurement of the ingredient.
+func parseIngredient(ingredient string) (string, units.Measurement) {
+	t := units.NewTokenizedIngredientFromText(ingredient)
+
+	name := strings.ToLower(strings.Join(t.Ingredients, " "))
+	if name == "" {
+		name = ingredientName(ingredient)
+	}
+	return name, t.Measurement
+}
+
+// isSameIngredient reports whether both names refer to the same ingredient, ignoring plurals.
+func isSameIngredient(a, b string) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	return a == b || b == a+"s" || b == a+"es"
+}
+
+// ingredientName extracts the name of the ingredient when the tokenizer could not, by
+// skipping the quantity and unit words, e.g. "1 kg flour" yields "flour".
+func ingredientName(ingredient string) string {
+	before, _, _ := strings.Cut(strings.ToLower(ingredient), ",")
+
+	var words []string
+	for _, word := range strings.Fields(before) {
+		if len(words) == 0 {
+			_, err := strconv.ParseFloat(word, 64)
+			if err == nil || strings.ContainsAny(word, "/½¼¾⅓⅔") || units.NewUnit(word) != units.Invalid {
+				continue
+			}
+		}
+		words = append(words, word)
+	}
+	return strings.Join(words, " ")
+}
+
+// ByAisle groups the items by aisle, sorted by name, in the order of the Aisles.
+func (s *ShoppingList) ByAisle() []ShoppingListAisle {
+	groups := make([]ShoppingListAisle, 0, len(Aisles))
+	for _, aisle := range Aisles {
+		var items []ShoppingListItem
+		for _, item := range s.Items {
+			if item.Aisle == aisle {
+				items = append(items, item)
+			}
+		}
+
+		if len(items) > 0 {
+			slices.SortStableFunc(items, func(a, b ShoppingListItem) int { return cmp.Compare(a.Name, b.Name) })
+			groups = append(groups, ShoppingListAisle{Aisle: aisle, Items: items})
+		}
+	}
+	return groups
+}
+
+// NumChecked returns the number of items checked off the list.
+func (s *ShoppingList) NumChecked() int {
+	var n int
+	for _, item := range s.Items {
+		if item.IsChecked {
+			n++
+		}
+	}
+	return n
+}
+
+// ShoppingListAisle holds the items of a shopping list found in the same aisle.
+type ShoppingListAisle struct {
+	Aisle Aisle
+	Items []ShoppingListItem
+}
+
+// ShoppingListItem is an ingredient to buy along with its total quantity.
+// The Unit is units.Invalid for ingredients counted by the piece, e.g. 3 eggs.
+type ShoppingListItem struct {
+	ID        int64
+	Aisle     Aisle
+	IsChecked bool
+	Name      string
+	Quantity  float64
+	Unit      units.Unit
+}
+
+// QuantityString represents the item's quantity in the most readable unit.
+func (s ShoppingListItem) QuantityString() string {
+	return quantityString(s.Quantity, s.Unit)
+}
+
+func quantityString(quantity float64, unit units.Unit) string {
+	switch {
+	case quantity == 0:
+		return ""
+	case unit == units.Invalid:
+		return strconv.FormatFloat(quantity, 'f', -1, 64)
+	default:
+		return units.Measurement{Quantity: quantity, Unit: unit}.Scale(1).String()
+	}
+}
+
+// add sums the measurement with the item's quantity. It returns false when the units are incompatible.
+func (s ShoppingListItem) add(m units.Measurement) (ShoppingListItem, bool) {
+	switch {
+	case m.Quantity == 0:
+		return s, true
+	case s.Quantity == 0:
+		s.Quantity = m.Quantity
+		s.Unit = m.Unit
+	case s.Unit == units.Invalid && m.Unit == units.Invalid:
+		s.Quantity += m.Quantity
+	case s.Unit == units.Invalid || m.Unit == units.Invalid:
+		return s, false
+	default:
+		converted, err := m.Convert(s.Unit)
+		if err != nil {
+			return s, false
+		}
+		s.Quantity += converted.Quantity
+	}
+
+	s.IsChecked = false
+	return s, true
+}
+
+// ShoppingListRecipe is a recipe whose ingredients were added to a shopping list.
+type ShoppingListRecipe struct {
+	RecipeID int64
+	Name     string
+	Yield    int16
+}
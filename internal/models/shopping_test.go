@@ -0,0 +1,129 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestNewAisle(t *testing.T) {
+	testcases := []struct {
+		in   string
+		want models.Aisle
+	}{
+		{in: "butter", want: models.AisleDairy},
+		{in: "large eggs", want: models.AisleDairy},
+		{in: "frozen peas", want: models.AisleFrozen},
+		{in: "yellow onions", want: models.AisleProduce},
+		{in: "tomatoes", want: models.AisleProduce},
+		{in: "ground black pepper", want: models.AisleSpices},
+		{in: "whole peppercorns", want: models.AisleSpices},
+		{in: "red bell peppers", want: models.AisleProduce},
+		{in: "jalapeño pepper", want: models.AisleProduce},
+		{in: "all-purpose flour", want: models.AislePantry},
+		{in: "chicken breasts", want: models.AisleMeat},
+		{in: "sourdough bread", want: models.AisleBakery},
+		{in: "dry white wine", want: models.AisleBeverages},
+		{in: "xanthan", want: models.AisleOther},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			if got := models.NewAisle(tc.in); got != tc.want {
+				t.Fatalf("got %q but want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestShoppingList_AddRecipe(t *testing.T) {
+	cake := models.Recipe{
+		ID:          1,
+		Name:        "Cake",
+		Ingredients: []string{"200 g butter", "3 eggs", "1 kg flour"},
+		Yield:       4,
+	}
+	cookies := models.Recipe{
+		ID:          2,
+		Name:        "Cookies",
+		Ingredients: []string{"0.5 kg butter", "2 eggs"},
+		Yield:       12,
+	}
+
+	t.Run("sums compatible quantities", func(t *testing.T) {
+		var list models.ShoppingList
+		list.AddRecipe(cake, 0)
+		list.AddRecipe(cookies, 0)
+
+		assertShoppingListItems(t, list, map[string]string{
+			"butter": "700 g",
+			"eggs":   "5",
+			"flour":  "1 kg",
+		})
+
+		want := []models.ShoppingListRecipe{{RecipeID: 1, Name: "Cake", Yield: 4}, {RecipeID: 2, Name: "Cookies", Yield: 12}}
+		if len(list.Recipes) != 2 || list.Recipes[0] != want[0] || list.Recipes[1] != want[1] {
+			t.Fatalf("got recipes %+v but want %+v", list.Recipes, want)
+		}
+	})
+
+	t.Run("scales to the yield", func(t *testing.T) {
+		var list models.ShoppingList
+		list.AddRecipe(cake, 8)
+		list.AddRecipe(cookies, 6)
+
+		assertShoppingListItems(t, list, map[string]string{
+			"butter": "650 g",
+			"eggs":   "7",
+			"flour":  "2 kg",
+		})
+	})
+
+	t.Run("adding quantities unchecks the item", func(t *testing.T) {
+		var list models.ShoppingList
+		list.AddRecipe(cake, 0)
+		for i := range list.Items {
+			list.Items[i].IsChecked = true
+		}
+
+		list.AddIngredient("1 egg", 1)
+
+		if got := list.NumChecked(); got != 2 {
+			t.Fatalf("got %d checked items but want 2", got)
+		}
+	})
+}
+
+func TestShoppingList_ByAisle(t *testing.T) {
+	list := models.ShoppingList{
+		Items: []models.ShoppingListItem{
+			{Name: "salt", Aisle: models.AisleSpices},
+			{Name: "onion", Aisle: models.AisleProduce},
+			{Name: "milk", Aisle: models.AisleDairy},
+			{Name: "carrot", Aisle: models.AisleProduce},
+		},
+	}
+
+	got := list.ByAisle()
+
+	if len(got) != 3 {
+		t.Fatalf("got %d aisles but want 3", len(got))
+	}
+	if got[0].Aisle != models.AisleProduce || got[1].Aisle != models.AisleDairy || got[2].Aisle != models.AisleSpices {
+		t.Fatalf("aisles are not in store order: %+v", got)
+	}
+	if got[0].Items[0].Name != "carrot" || got[0].Items[1].Name != "onion" {
+		t.Fatalf("items are not sorted by name: %+v", got[0].Items)
+	}
+}
+
+func assertShoppingListItems(tb testing.TB, list models.ShoppingList, want map[string]string) {
+	tb.Helper()
+	if len(list.Items) != len(want) {
+		tb.Fatalf("got %d items but want %d: %+v", len(list.Items), len(want), list.Items)
+	}
+	for _, item := range list.Items {
+		if got := item.QuantityString(); got != want[item.Name] {
+			tb.Errorf("got %q of %s but want %q", got, item.Name, want[item.Name])
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrSubRecipeCycle is returned when a recipe includes itself through the recipes its ingredients refer to.
+var ErrSubRecipeCycle = errors.New("the sub-recipes form a cycle")
+
+// SubRecipeLink is an ingredient of a recipe referring to another recipe. The recipe is
+// identified by its position in the collection because the IDs change when a backup is restored.
+type SubRecipeLink struct {
+	Index  int // Index is the position of the ingredient.
+	Recipe int // Recipe is the position of the recipe the ingredient refers to.
+}
+
+// SubRecipeFetcher fetches the recipe of the given ID an ingredient refers to.
+type SubRecipeFetcher func(id int64) (*Recipe, error)
+
+// ExpandSubRecipes returns a copy of the recipe to which the ingredients and the instructions
+// of the recipes its ingredients refer to are appended, each under a section named after the
+// sub-recipe. A sub-recipe is scaled to the quantity of the ingredient referring to it, expressed
+// in servings of its yield, and its own sub-recipes are expanded recursively.
+// It fails with ErrSubRecipeCycle when a recipe includes itself.
+func (r *Recipe) ExpandSubRecipes(fetch SubRecipeFetcher) (Recipe, error) {
+	return r.expandSubRecipes(fetch, []int64{r.ID})
+}
+
+func (r *Recipe) expandSubRecipes(fetch SubRecipeFetcher, path []int64) (Recipe, error) {
+	expanded := r.Copy()
+	expanded.StructuredIngredients = NewIngredients(r.Ingredients, r.StructuredIngredients)
+
+	for _, in := range r.StructuredIngredients {
+		if in.RecipeID == 0 {
+			continue
+		}
+
+		if slices.Contains(path, in.RecipeID) {
+			return Recipe{}, fmt.Errorf("%w: recipe %d includes itself", ErrSubRecipeCycle, in.RecipeID)
+		}
+
+		sub, err := fetch(in.RecipeID)
+		if err != nil {
+			return Recipe{}, fmt.Errorf("could not fetch sub-recipe %d: %w", in.RecipeID, err)
+		}
+
+		expandedSub, err := sub.expandSubRecipes(fetch, append(slices.Clone(path), in.RecipeID))
+		if err != nil {
+			return Recipe{}, err
+		}
+		expandedSub.scaleIngredients(in.SubRecipeMultiplier(sub.Yield))
+
+		expanded.Ingredients, expanded.IngredientSections = appendSubRecipeItems(expanded.Ingredients, expanded.IngredientSections, sub.Name, expandedSub.Ingredients, expandedSub.IngredientSections)
+		expanded.Instructions, expanded.InstructionSections = appendSubRecipeItems(expanded.Instructions, expanded.InstructionSections, sub.Name, expandedSub.Instructions, expandedSub.InstructionSections)
+		expanded.StructuredIngredients = append(expanded.StructuredIngredients, expandedSub.StructuredIngredients...)
+	}
+
+	return expanded, nil
+}
+
+// SubRecipeMultiplier is the multiplier to apply to the recipe the ingredient refers to given
+// its yield. An ingredient without a quantity stands for the whole recipe.
+func (i Ingredient) SubRecipeMultiplier(yield float64) float64 {
+	if i.Quantity == 0 || yield <= 0 {
+		return 1
+	}
+	return i.Quantity / yield
+}
+
+// appendSubRecipeItems appends the items of a sub-recipe under a section named after it. The
+// sections of the sub-recipe are prefixed with its name.
+func appendSubRecipeItems(items []string, sections Sections, name string, subItems []string, subSections Sections) ([]string, Sections) {
+	if len(subItems) == 0 {
+		return items, sections
+	}
+
+	offset := len(items)
+	if subSections.At(0) == "" {
+		sections = append(sections, Section{Index: offset, Name: name})
+	}
+
+	for _, sect := range subSections {
+		sections = append(sections, Section{Index: offset + sect.Index, Name: name + " - " + sect.Name})
+	}
+	return append(items, subItems...), sections
+}
+
+// FoodIngredients returns the ingredients that do not refer to another recipe.
+func (r *Recipe) FoodIngredients() []Ingredient {
+	return slices.DeleteFunc(NewIngredients(r.Ingredients, r.StructuredIngredients), func(in Ingredient) bool {
+		return in.RecipeID > 0
+	})
+}
+
+// SubRecipeLinks returns the ingredients of the recipes that refer to another recipe of the
+// collection, keyed by the position of their recipe.
+func (r Recipes) SubRecipeLinks() map[int][]SubRecipeLink {
+	positions := r.positions()
+
+	links := make(map[int][]SubRecipeLink)
+	for pos, recipe := range r {
+		for i, in := range recipe.StructuredIngredients {
+			sub, ok := positions[in.RecipeID]
+			if in.RecipeID == 0 || !ok {
+				continue
+			}
+			links[pos] = append(links[pos], SubRecipeLink{Index: i, Recipe: sub})
+		}
+	}
+	return links
+}
+
+// positions maps the ID of every recipe of the collection to its position.
+func (r Recipes) positions() map[int64]int {
+	positions := make(map[int64]int, len(r))
+	for i, recipe := range r {
+		positions[recipe.ID] = i
+	}
+	return positions
+}
+
+// ExpandSubRecipes expands the sub-recipes of the recipes, looking them up in the collection.
+// The recipes whose sub-recipes could not be expanded are kept as is and the errors are
+// returned along with the recipes.
+func (r Recipes) ExpandSubRecipes() (Recipes, error) {
+	byID := make(map[int64]*Recipe, len(r))
+	for i := range r {
+		byID[r[i].ID] = &r[i]
+	}
+
+	return r.ExpandSubRecipesFunc(func(id int64) (*Recipe, error) {
+		sub, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("recipe %d not found", id)
+		}
+		return sub, nil
+	})
+}
+
+// ExpandSubRecipesFunc expands the sub-recipes of the recipes, looking them up with the fetcher.
+// It is used when the sub-recipes may not be part of the collection, e.g. for a cookbook.
+// The recipes whose sub-recipes could not be expanded are kept as is and the errors are
+// returned along with the recipes.
+func (r Recipes) ExpandSubRecipesFunc(fetch SubRecipeFetcher) (Recipes, error) {
+	var errs []error
+	expanded := make(Recipes, 0, len(r))
+	for _, recipe := range r {
+		e, err := recipe.ExpandSubRecipes(fetch)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("recipe %q: %w", recipe.Name, err))
+			e = recipe
+		}
+		expanded = append(expanded, e)
+	}
+	return expanded, errors.Join(errs...)
+}
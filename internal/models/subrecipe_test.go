@@ -0,0 +1,271 @@
+package models_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/units"
+)
+
+func TestRecipe_ExpandSubRecipes(t *testing.T) {
+	crust := models.Recipe{
+		ID:                 2,
+		Name:               "Pie crust",
+		Ingredients:        []string{"200 g flour", "100 g butter"},
+		IngredientSections: models.Sections{{Index: 1, Name: "Fat"}},
+		Instructions:       []string{"Mix the flour and the butter"},
+		StructuredIngredients: []models.Ingredient{
+			{Name: "flour", Quantity: 200, Text: "200 g flour", Unit: units.Gram},
+			{Name: "butter", Quantity: 100, Text: "100 g butter", Unit: units.Gram},
+		},
+		Yield: 1,
+	}
+
+	pie := models.Recipe{
+		ID:           1,
+		Name:         "Apple pie",
+		Ingredients:  []string{"2 pie crust", "6 apples"},
+		Instructions: []string{"Fill the crust with the apples"},
+		StructuredIngredients: []models.Ingredient{
+			{Name: "pie crust", Quantity: 2, RecipeID: 2, Text: "2 pie crust"},
+			{Name: "apples", Quantity: 6, Text: "6 apples"},
+		},
+		Yield: 8,
+	}
+
+	fetch := func(recipes ...models.Recipe) models.SubRecipeFetcher {
+		return func(id int64) (*models.Recipe, error) {
+			for _, r := range recipes {
+				if r.ID == id {
+					return &r, nil
+				}
+			}
+			return nil, errors.New("not found")
+		}
+	}
+
+	t.Run("sub-recipe is appended and scaled to the quantity", func(t *testing.T) {
+		got, err := pie.ExpandSubRecipes(fetch(crust))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		wantIngredients := []string{"2 pie crust", "6 apples", "400 g flour", "200 g butter"}
+		if !slices.Equal(got.Ingredients, wantIngredients) {
+			t.Errorf("got ingredients %q but want %q", got.Ingredients, wantIngredients)
+		}
+
+		wantSections := models.Sections{{Index: 2, Name: "Pie crust"}, {Index: 3, Name: "Pie crust - Fat"}}
+		if !slices.Equal(got.IngredientSections, wantSections) {
+			t.Errorf("got ingredient sections %+v but want %+v", got.IngredientSections, wantSections)
+		}
+
+		wantInstructions := []string{"Fill the crust with the apples", "Mix the flour and the butter"}
+		if !slices.Equal(got.Instructions, wantInstructions) {
+			t.Errorf("got instructions %q but want %q", got.Instructions, wantInstructions)
+		}
+
+		wantFoods := []string{"6 apples", "400 g flour", "200 g butter"}
+		var foods []string
+		for _, in := range got.FoodIngredients() {
+			foods = append(foods, in.Text)
+		}
+		if !slices.Equal(foods, wantFoods) {
+			t.Errorf("got foods %q but want %q", foods, wantFoods)
+		}
+
+		if len(pie.Ingredients) != 2 || len(pie.IngredientSections) != 0 {
+			t.Error("the recipe must not be modified")
+		}
+	})
+
+	t.Run("scaling the recipe propagates to its sub-recipes", func(t *testing.T) {
+		scaled := pie.Copy()
+		scaled.Scale(4)
+
+		got, err := scaled.ExpandSubRecipes(fetch(crust))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []string{"1 pie crust", "3 apples", "200 g flour", "100 g butter"}
+		if !slices.Equal(got.Ingredients, want) {
+			t.Errorf("got %q but want %q", got.Ingredients, want)
+		}
+	})
+
+	t.Run("sub-recipes are expanded recursively", func(t *testing.T) {
+		dough := models.Recipe{
+			ID:                    3,
+			Name:                  "Dough",
+			Ingredients:           []string{"1 pie crust", "1 egg"},
+			Instructions:          []string{"Add the egg"},
+			StructuredIngredients: []models.Ingredient{{Name: "pie crust", Quantity: 1, RecipeID: 2, Text: "1 pie crust"}},
+			Yield:                 1,
+		}
+
+		tart := models.Recipe{
+			ID:                    4,
+			Name:                  "Tart",
+			Ingredients:           []string{"3 dough"},
+			Instructions:          []string{"Bake"},
+			StructuredIngredients: []models.Ingredient{{Name: "dough", Quantity: 3, RecipeID: 3, Text: "3 dough"}},
+			Yield:                 1,
+		}
+
+		got, err := tart.ExpandSubRecipes(fetch(crust, dough))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []string{"3 dough", "3 pie crust", "3 egg", "600 g flour", "300 g butter"}
+		if !slices.Equal(got.Ingredients, want) {
+			t.Errorf("got %q but want %q", got.Ingredients, want)
+		}
+
+		wantSections := models.Sections{{Index: 1, Name: "Dough"}, {Index: 3, Name: "Dough - Pie crust"}, {Index: 4, Name: "Dough - Pie crust - Fat"}}
+		if !slices.Equal(got.IngredientSections, wantSections) {
+			t.Errorf("got sections %+v but want %+v", got.IngredientSections, wantSections)
+		}
+	})
+
+	t.Run("cycles are detected", func(t *testing.T) {
+		a := models.Recipe{
+			ID:                    5,
+			Name:                  "A",
+			Ingredients:           []string{"1 B"},
+			StructuredIngredients: []models.Ingredient{{Name: "B", Quantity: 1, RecipeID: 6, Text: "1 B"}},
+			Yield:                 1,
+		}
+
+		b := models.Recipe{
+			ID:                    6,
+			Name:                  "B",
+			Ingredients:           []string{"1 A"},
+			StructuredIngredients: []models.Ingredient{{Name: "A", Quantity: 1, RecipeID: 5, Text: "1 A"}},
+			Yield:                 1,
+		}
+
+		_, err := a.ExpandSubRecipes(fetch(a, b))
+		if !errors.Is(err, models.ErrSubRecipeCycle) {
+			t.Fatalf("got error %v but want %v", err, models.ErrSubRecipeCycle)
+		}
+	})
+
+	t.Run("missing sub-recipe", func(t *testing.T) {
+		_, err := pie.ExpandSubRecipes(fetch())
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
+
+func TestRecipes_ExpandSubRecipes(t *testing.T) {
+	recipes := models.Recipes{
+		{
+			ID:                    1,
+			Name:                  "Pancakes",
+			Ingredients:           []string{"4 servings batter"},
+			StructuredIngredients: []models.Ingredient{{Name: "batter", Quantity: 4, RecipeID: 2, Text: "4 servings batter"}},
+			Yield:                 4,
+		},
+		{
+			ID:                    2,
+			Name:                  "Batter",
+			Ingredients:           []string{"2 eggs"},
+			StructuredIngredients: []models.Ingredient{{Name: "eggs", Quantity: 2, Text: "2 eggs"}},
+			Yield:                 2,
+		},
+		{
+			ID:                    3,
+			Name:                  "Orphan",
+			Ingredients:           []string{"1 serving of something"},
+			StructuredIngredients: []models.Ingredient{{Name: "something", Quantity: 1, RecipeID: 9, Text: "1 serving of something"}},
+			Yield:                 1,
+		},
+	}
+
+	got, err := recipes.ExpandSubRecipes()
+	if err == nil {
+		t.Error("expected an error for the missing sub-recipe")
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("got %d recipes but want 3", len(got))
+	}
+
+	if want := []string{"4 servings batter", "4 eggs"}; !slices.Equal(got[0].Ingredients, want) {
+		t.Errorf("got %q but want %q", got[0].Ingredients, want)
+	}
+
+	if want := []string{"2 eggs"}; !slices.Equal(got[1].Ingredients, want) {
+		t.Errorf("got %q but want %q", got[1].Ingredients, want)
+	}
+
+	if want := []string{"1 serving of something"}; !slices.Equal(got[2].Ingredients, want) {
+		t.Errorf("got %q but want %q", got[2].Ingredients, want)
+	}
+}
+
+func TestRecipes_ExpandSubRecipesFunc(t *testing.T) {
+	batter := models.Recipe{
+		ID:                    2,
+		Name:                  "Batter",
+		Ingredients:           []string{"2 eggs"},
+		StructuredIngredients: []models.Ingredient{{Name: "eggs", Quantity: 2, Text: "2 eggs"}},
+		Yield:                 2,
+	}
+
+	cookbook := models.Recipes{
+		{
+			ID:                    1,
+			Name:                  "Pancakes",
+			Ingredients:           []string{"4 servings batter"},
+			StructuredIngredients: []models.Ingredient{{Name: "batter", Quantity: 4, RecipeID: 2, Text: "4 servings batter"}},
+			Yield:                 4,
+		},
+	}
+
+	got, err := cookbook.ExpandSubRecipesFunc(func(id int64) (*models.Recipe, error) {
+		if id != batter.ID {
+			return nil, errors.New("not found")
+		}
+		return &batter, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("got %d recipes but want 1", len(got))
+	}
+
+	if want := []string{"4 servings batter", "4 eggs"}; !slices.Equal(got[0].Ingredients, want) {
+		t.Errorf("got %q but want %q", got[0].Ingredients, want)
+	}
+}
+
+func TestRecipes_SubRecipeLinks(t *testing.T) {
+	recipes := models.Recipes{
+		{
+			ID:          1,
+			Name:        "Pancakes",
+			Ingredients: []string{"1 cup milk", "4 servings batter", "1 serving of something"},
+			StructuredIngredients: []models.Ingredient{
+				{Name: "milk", Quantity: 1, Text: "1 cup milk", Unit: units.Cup},
+				{Name: "batter", Quantity: 4, RecipeID: 2, Text: "4 servings batter"},
+				{Name: "something", Quantity: 1, RecipeID: 9, Text: "1 serving of something"},
+			},
+		},
+		{ID: 2, Name: "Batter", Ingredients: []string{"2 eggs"}},
+	}
+
+	got := recipes.SubRecipeLinks()
+
+	want := map[int][]models.SubRecipeLink{0: {{Index: 1, Recipe: 1}}}
+	if len(got) != len(want) || !slices.Equal(got[0], want[0]) {
+		t.Fatalf("got %+v but want %+v", got, want)
+	}
+}
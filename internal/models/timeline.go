@@ -0,0 +1,240 @@
+package models
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/reaper47/recipya/internal/utils/duration"
+)
+
+// defaultStepDuration is the duration of an instruction when neither the
+// instruction nor the recipe's times give any hint.
+const defaultStepDuration = 5 * time.Minute
+
+// Equipment is the cooking appliance an instruction occupies.
+type Equipment string
+
+// These constants enumerate the appliances a batch-cooking timeline checks for conflicts.
+const (
+	EquipmentNone     Equipment = ""
+	EquipmentOven     Equipment = "oven"
+	EquipmentStovetop Equipment = "stovetop"
+)
+
+// equipmentCapacity is how many instructions a home kitchen's appliance can hold at once.
+var equipmentCapacity = map[Equipment]int{
+	EquipmentOven:     1,
+	EquipmentStovetop: 4,
+}
+
+var equipmentWords = map[Equipment][]string{
+	EquipmentOven:     {"bake", "baked", "baking", "broil", "oven", "roast", "roasting"},
+	EquipmentStovetop: {"boil", "fry", "pan", "pot", "saucepan", "saute", "sauté", "sear", "simmer", "skillet", "stove", "stovetop", "wok"},
+}
+
+// NewEquipment detects the appliance an instruction occupies from its words.
+// The oven wins when an instruction mentions both, e.g. "sear in an oven-safe pan, then roast".
+func NewEquipment(instruction string) Equipment {
+	words := strings.FieldsFunc(strings.ToLower(instruction), func(r rune) bool { return !unicode.IsLetter(r) })
+	for _, e := range []Equipment{EquipmentOven, EquipmentStovetop} {
+		if slices.ContainsFunc(words, func(w string) bool { return slices.Contains(equipmentWords[e], w) }) {
+			return e
+		}
+	}
+	return EquipmentNone
+}
+
+// Title returns the appliance's name as it should be displayed.
+func (e Equipment) Title() string {
+	if e == "" {
+		return ""
+	}
+	return strings.ToUpper(string(e[:1])) + string(e[1:])
+}
+
+// TimelineStep is an instruction of a recipe placed on a batch-cooking timeline.
+type TimelineStep struct {
+	End        time.Time
+	Equipment  Equipment
+	IsConflict bool
+	RecipeID   int64
+	RecipeName string
+	Start      time.Time
+	Step       int
+	Text       string
+}
+
+// Duration is how long the step takes.
+func (t TimelineStep) Duration() time.Duration {
+	return t.End.Sub(t.Start)
+}
+
+// overlaps verifies whether the step is ongoing at the given time.
+func (t TimelineStep) overlaps(at time.Time) bool {
+	return !t.Start.After(at) && t.End.After(at)
+}
+
+// TimelineConflict holds steps of different recipes needing the same appliance
+// beyond its capacity at the same time.
+type TimelineConflict struct {
+	Equipment Equipment
+	Start     time.Time
+	Steps     []TimelineStep
+}
+
+// Timeline is the merged schedule of several recipes cooked at once.
+type Timeline struct {
+	Conflicts []TimelineConflict
+	ReadyAt   time.Time
+	Recipes   Recipes
+	Steps     []TimelineStep
+}
+
+// NewTimeline merges the instructions of the recipes into one schedule where
+// every recipe is ready at the given time. Each instruction lasts as long as the
+// timers cook mode detects in its text, up to the end of a range. The instructions
+// without timers share what remains of the recipe's total time, or the preparation
+// and cooking times when the total is unknown.
+func NewTimeline(recipes Recipes, readyAt time.Time) Timeline {
+	timeline := Timeline{
+		ReadyAt: readyAt,
+		Recipes: recipes,
+	}
+
+	for _, r := range recipes {
+		timeline.Steps = append(timeline.Steps, recipeTimelineSteps(r, readyAt)...)
+	}
+
+	slices.SortStableFunc(timeline.Steps, func(a, b TimelineStep) int {
+		if c := a.Start.Compare(b.Start); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.RecipeName, b.RecipeName); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Step, b.Step)
+	})
+
+	timeline.Conflicts = timeline.findConflicts()
+	return timeline
+}
+
+// Start is when the first step of the timeline begins.
+func (t Timeline) Start() time.Time {
+	if len(t.Steps) == 0 {
+		return t.ReadyAt
+	}
+	return t.Steps[0].Start
+}
+
+// Duration is how long the whole batch takes to cook.
+func (t Timeline) Duration() time.Duration {
+	return t.ReadyAt.Sub(t.Start())
+}
+
+// findConflicts flags the steps needing an appliance beyond its capacity. A conflict
+// is reported once even when several of its steps start while it is ongoing.
+func (t Timeline) findConflicts() []TimelineConflict {
+	var conflicts []TimelineConflict
+	for _, step := range t.Steps {
+		capacity, ok := equipmentCapacity[step.Equipment]
+		if !ok {
+			continue
+		}
+
+		var (
+			ongoing []int
+			recipes = make(map[int64]struct{})
+		)
+		for i, other := range t.Steps {
+			if other.Equipment == step.Equipment && other.overlaps(step.Start) {
+				ongoing = append(ongoing, i)
+				recipes[other.RecipeID] = struct{}{}
+			}
+		}
+		if len(ongoing) <= capacity || len(recipes) < 2 {
+			continue
+		}
+
+		if n := len(conflicts); n > 0 && conflicts[n-1].Equipment == step.Equipment && conflicts[n-1].hasAll(t.Steps, ongoing) {
+			continue
+		}
+
+		conflict := TimelineConflict{Equipment: step.Equipment, Start: step.Start}
+		for _, i := range ongoing {
+			t.Steps[i].IsConflict = true
+			conflict.Steps = append(conflict.Steps, t.Steps[i])
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	return conflicts
+}
+
+func (c TimelineConflict) hasAll(steps []TimelineStep, indices []int) bool {
+	for _, i := range indices {
+		s := steps[i]
+		if !slices.ContainsFunc(c.Steps, func(other TimelineStep) bool { return other.RecipeID == s.RecipeID && other.Step == s.Step }) {
+			return false
+		}
+	}
+	return true
+}
+
+func recipeTimelineSteps(r Recipe, readyAt time.Time) []TimelineStep {
+	if len(r.Instructions) == 0 {
+		return nil
+	}
+
+	total := r.Times.Total
+	if total == 0 {
+		total = r.Times.Prep + r.Times.Cook
+	}
+
+	durations := make([]time.Duration, len(r.Instructions))
+	var (
+		detected   time.Duration
+		numUnknown int
+	)
+	for i, ins := range r.Instructions {
+		for _, span := range duration.FindSpans(ins) {
+			durations[i] += span.Max
+		}
+
+		if durations[i] == 0 {
+			numUnknown++
+		}
+		detected += durations[i]
+	}
+
+	share := defaultStepDuration
+	if numUnknown > 0 && total > detected {
+		share = ((total - detected) / time.Duration(numUnknown)).Round(time.Minute)
+		if share < time.Minute {
+			share = time.Minute
+		}
+	}
+
+	steps := make([]TimelineStep, len(r.Instructions))
+	end := readyAt
+	for i := len(r.Instructions) - 1; i >= 0; i-- {
+		d := durations[i]
+		if d == 0 {
+			d = share
+		}
+
+		steps[i] = TimelineStep{
+			End:        end,
+			Equipment:  NewEquipment(r.Instructions[i]),
+			RecipeID:   r.ID,
+			RecipeName: r.Name,
+			Start:      end.Add(-d),
+			Step:       i + 1,
+			Text:       r.Instructions[i],
+		}
+		end = steps[i].Start
+	}
+	return steps
+}
@@ -0,0 +1,122 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestNewEquipment(t *testing.T) {
+	testcases := []struct {
+		in   string
+		want models.Equipment
+	}{
+		{in: "Bake for 45 minutes.", want: models.EquipmentOven},
+		{in: "Simmer the sauce in a saucepan.", want: models.EquipmentStovetop},
+		{in: "Sear in an oven-safe pan, then roast.", want: models.EquipmentOven},
+		{in: "Chop the onions.", want: models.EquipmentNone},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			if got := models.NewEquipment(tc.in); got != tc.want {
+				t.Fatalf("got %q but want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewTimeline(t *testing.T) {
+	readyAt := time.Date(2026, 10, 18, 18, 0, 0, 0, time.UTC)
+	lasagna := models.Recipe{
+		ID:   1,
+		Name: "Lasagna",
+		Instructions: []string{
+			"Brown the beef in a skillet for 10 minutes.",
+			"Assemble the layers.",
+			"Bake for 45 minutes.",
+		},
+		Times: models.Times{Prep: 30 * time.Minute, Cook: time.Hour},
+	}
+	veggies := models.Recipe{
+		ID:           2,
+		Name:         "Roasted veggies",
+		Instructions: []string{"Chop the vegetables.", "Roast in the oven for 30 minutes."},
+	}
+
+	t.Run("counts back from the ready time", func(t *testing.T) {
+		got := models.NewTimeline(models.Recipes{lasagna}, readyAt)
+
+		want := []struct {
+			start string
+			end   string
+		}{
+			{start: "16:30", end: "16:40"},
+			{start: "16:40", end: "17:15"},
+			{start: "17:15", end: "18:00"},
+		}
+		if len(got.Steps) != len(want) {
+			t.Fatalf("got %d steps but want %d", len(got.Steps), len(want))
+		}
+		for i, w := range want {
+			step := got.Steps[i]
+			if step.Start.Format("15:04") != w.start || step.End.Format("15:04") != w.end {
+				t.Errorf("step %d: got %s-%s but want %s-%s", i+1, step.Start.Format("15:04"), step.End.Format("15:04"), w.start, w.end)
+			}
+		}
+		if got.Duration() != 90*time.Minute {
+			t.Fatalf("got duration %s but want 1h30m", got.Duration())
+		}
+		if len(got.Conflicts) > 0 {
+			t.Fatalf("got conflicts %+v but want none", got.Conflicts)
+		}
+	})
+
+	t.Run("interleaves steps and flags oven conflicts", func(t *testing.T) {
+		got := models.NewTimeline(models.Recipes{lasagna, veggies}, readyAt)
+
+		var names []string
+		for _, step := range got.Steps {
+			names = append(names, step.RecipeName)
+		}
+		want := []string{"Lasagna", "Lasagna", "Lasagna", "Roasted veggies", "Roasted veggies"}
+		for i := range want {
+			if names[i] != want[i] {
+				t.Fatalf("got order %v but want %v", names, want)
+			}
+		}
+
+		if len(got.Conflicts) != 1 {
+			t.Fatalf("got %d conflicts but want 1: %+v", len(got.Conflicts), got.Conflicts)
+		}
+		c := got.Conflicts[0]
+		if c.Equipment != models.EquipmentOven || c.Start.Format("15:04") != "17:30" || len(c.Steps) != 2 {
+			t.Fatalf("unexpected conflict %+v", c)
+		}
+		if !got.Steps[2].IsConflict || !got.Steps[4].IsConflict || got.Steps[0].IsConflict {
+			t.Fatal("only the oven steps must be flagged")
+		}
+	})
+	t.Run("steps last as long as their cook mode timers", func(t *testing.T) {
+		soup := models.Recipe{
+			ID:           3,
+			Name:         "Soup",
+			Instructions: []string{"Sweat the onions for 5 min.", "Simmer 25-30 minutes, then rest 1 hr 15 mins."},
+		}
+
+		got := models.NewTimeline(models.Recipes{soup}, readyAt)
+
+		if len(got.Steps) != 2 || got.Steps[0].Duration() != 5*time.Minute || got.Steps[1].Duration() != 105*time.Minute {
+			t.Fatalf("unexpected steps %+v", got.Steps)
+		}
+		for i, ins := range soup.Instructions {
+			var want time.Duration
+			for _, timer := range models.NewTimers(i, ins) {
+				want += timer.DurationMax
+			}
+			if got.Steps[i].Duration() != want {
+				t.Errorf("step %d: got %s but cook mode has %s", i+1, got.Steps[i].Duration(), want)
+			}
+		}
+	})
+}
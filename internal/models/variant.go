@@ -0,0 +1,58 @@
+package models
+
+import "slices"
+
+// RecipeLink is a reference to one of the user's recipes.
+type RecipeLink struct {
+	ID   int64
+	Name string
+}
+
+// RecipeVariants holds the relatives of a recipe: the recipe it is a variant of and
+// the variants derived from it, e.g. a vegan or a high-altitude version.
+type RecipeVariants struct {
+	Parent   *RecipeLink  // Parent is the recipe the recipe is a variant of, if any.
+	Variants []RecipeLink // Variants are the recipes derived from the recipe.
+}
+
+// IsEmpty reports whether the recipe neither is a variant nor has variants.
+func (v RecipeVariants) IsEmpty() bool {
+	return v.Parent == nil && len(v.Variants) == 0
+}
+
+// VariantComparison holds a variant along with the recipe it derives from.
+type VariantComparison struct {
+	Parent  Recipe
+	Variant Recipe
+}
+
+// Changes lists how the ingredients and the instructions of the variant differ from those of its parent.
+func (c VariantComparison) Changes() RevisionChanges {
+	var changes RevisionChanges
+
+	if !slices.Equal(c.Parent.Ingredients, c.Variant.Ingredients) {
+		changes.Ingredients = DiffLines(c.Parent.Ingredients, c.Variant.Ingredients)
+	}
+
+	if !slices.Equal(c.Parent.Instructions, c.Variant.Instructions) {
+		changes.Instructions = DiffLines(c.Parent.Instructions, c.Variant.Instructions)
+	}
+	return changes
+}
+
+// VariantLinks returns the position of the parent of each variant of the collection, keyed by the
+// position of the variant. The recipes are identified by position because the IDs change when a backup is restored.
+func (r Recipes) VariantLinks(parents map[int64]int64) map[int]int {
+	positions := r.positions()
+
+	links := make(map[int]int)
+	for id, parentID := range parents {
+		pos, ok := positions[id]
+		parent, isParent := positions[parentID]
+		if !ok || !isParent {
+			continue
+		}
+		links[pos] = parent
+	}
+	return links
+}
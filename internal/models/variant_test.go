@@ -0,0 +1,62 @@
+package models_test
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestVariantComparison_Changes(t *testing.T) {
+	parent := models.Recipe{
+		Ingredients:  []string{"2 eggs", "1 cup milk", "1 cup flour"},
+		Instructions: []string{"Mix", "Cook"},
+	}
+
+	t.Run("ingredients and instructions differ", func(t *testing.T) {
+		variant := models.Recipe{
+			Ingredients:  []string{"1 cup oat milk", "1 cup flour", "1 tbsp flax"},
+			Instructions: []string{"Mix", "Cook"},
+		}
+
+		got := models.VariantComparison{Parent: parent, Variant: variant}.Changes()
+
+		want := []models.LineChange{
+			{Kind: models.LineRemoved, Text: "2 eggs"},
+			{Kind: models.LineRemoved, Text: "1 cup milk"},
+			{Kind: models.LineAdded, Text: "1 cup oat milk"},
+			{Kind: models.LineUnchanged, Text: "1 cup flour"},
+			{Kind: models.LineAdded, Text: "1 tbsp flax"},
+		}
+		if !slices.Equal(got.Ingredients, want) {
+			t.Errorf("got ingredients %+v but want %+v", got.Ingredients, want)
+		}
+
+		if got.Instructions != nil {
+			t.Errorf("got instructions %+v but want none", got.Instructions)
+		}
+	})
+
+	t.Run("identical", func(t *testing.T) {
+		got := models.VariantComparison{Parent: parent, Variant: parent}.Changes()
+		if !got.IsEmpty() {
+			t.Fatalf("got %+v but want no changes", got)
+		}
+	})
+}
+
+func TestRecipes_VariantLinks(t *testing.T) {
+	recipes := models.Recipes{
+		{ID: 1, Name: "Pancakes"},
+		{ID: 2, Name: "Vegan pancakes"},
+		{ID: 3, Name: "High-altitude pancakes"},
+	}
+
+	got := recipes.VariantLinks(map[int64]int64{2: 1, 3: 1, 4: 1, 1: 9})
+
+	want := map[int]int{1: 0, 2: 0}
+	if !maps.Equal(got, want) {
+		t.Fatalf("got %+v but want %+v", got, want)
+	}
+}
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookEvent is an event a Webhook may subscribe to.
+type WebhookEvent string
+
+// These constants define the events emitted to webhooks.
+const (
+	WebhookEventBackupCompleted WebhookEvent = "backup.completed"
+	WebhookEventBackupRestored  WebhookEvent = "backup.restored"
+	WebhookEventCookbookChanged WebhookEvent = "cookbook.changed"
+	WebhookEventImportFinished  WebhookEvent = "import.finished"
+	WebhookEventRecipeCreated   WebhookEvent = "recipe.created"
+	WebhookEventRecipeDeleted   WebhookEvent = "recipe.deleted"
+	WebhookEventRecipeUpdated   WebhookEvent = "recipe.updated"
+)
+
+// WebhookEvents lists every event a Webhook may subscribe to.
+var WebhookEvents = []WebhookEvent{
+	WebhookEventRecipeCreated,
+	WebhookEventRecipeUpdated,
+	WebhookEventRecipeDeleted,
+	WebhookEventCookbookChanged,
+	WebhookEventImportFinished,
+	WebhookEventBackupCompleted,
+	WebhookEventBackupRestored,
+}
+
+// NewWebhookEvents parses a comma-separated list of events. Unknown events are ignored.
+func NewWebhookEvents(s string) []WebhookEvent {
+	events := make([]WebhookEvent, 0, len(WebhookEvents))
+	for _, part := range strings.Split(s, ",") {
+		event := WebhookEvent(strings.ToLower(strings.TrimSpace(part)))
+		if slices.Contains(WebhookEvents, event) && !slices.Contains(events, event) {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// Webhook holds details on a URL notified when events happen in a user's library.
+// A global webhook, which only the administrator may register, is notified of every user's events.
+type Webhook struct {
+	ID           int64
+	CreatedAt    time.Time
+	Events       []WebhookEvent
+	IsGlobal     bool
+	LastDelivery WebhookDelivery
+	Secret       string
+	URL          string
+	UserID       int64
+}
+
+// EventsString returns the webhook's events as a comma-separated list.
+func (w *Webhook) EventsString() string {
+	xs := make([]string, len(w.Events))
+	for i, event := range w.Events {
+		xs[i] = string(event)
+	}
+	return strings.Join(xs, ",")
+}
+
+// IsSubscribed verifies whether the webhook wants to be notified of the event.
+func (w *Webhook) IsSubscribed(event WebhookEvent) bool {
+	return slices.Contains(w.Events, event)
+}
+
+// NewWebhookPayload creates a WebhookPayload for the event that happened to the user.
+func NewWebhookPayload(event WebhookEvent, userID int64, data any) WebhookPayload {
+	return WebhookPayload{
+		ID:        uuid.New(),
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+		Event:     event,
+		UserID:    userID,
+	}
+}
+
+// WebhookPayload is the body sent to a Webhook.
+type WebhookPayload struct {
+	ID        uuid.UUID    `json:"id"`
+	CreatedAt time.Time    `json:"createdAt"`
+	Data      any          `json:"data"`
+	Event     WebhookEvent `json:"event"`
+	UserID    int64        `json:"userID"`
+}
+
+// WebhookDelivery holds the outcome of sending a WebhookPayload.
+type WebhookDelivery struct {
+	Attempts    int
+	DeliveredAt time.Time
+	Error       string
+	StatusCode  int
+}
+
+// IsSuccess verifies whether the receiver acknowledged the payload.
+func (w WebhookDelivery) IsSuccess() bool {
+	return w.Error == "" && w.StatusCode >= 200 && w.StatusCode < 300
+}
+
+// NewImportFinishedData creates the data of the WebhookEventImportFinished payload sent
+// once the recipes have been imported.
+func NewImportFinishedData(report Report, imported, total int) map[string]any {
+	return map[string]any{
+		"execTime": report.ExecTime.String(),
+		"imported": imported,
+		"skipped":  total - imported,
+		"total":    total,
+	}
+}
@@ -0,0 +1,145 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/reaper47/recipya/internal/units"
+	"github.com/reaper47/recipya/internal/utils/extensions"
+)
+
+// These errors are returned when a recipe cannot be scaled to a yield.
+var (
+	ErrYieldInvalid      = errors.New("the yield must be greater than zero")
+	ErrYieldUnitMismatch = errors.New("the unit of the yield does not match that of the recipe")
+)
+
+var yieldRegex = regexp.MustCompile(`(-?\d+\s+\d+/\d+|-?\d+/\d+|-?\d+(?:[.,]\d+)?)(?:\s*(?:-|–|to|or)\s*(?:\d+\s+\d+/\d+|\d+/\d+|\d+(?:[.,]\d+)?))?\s*(.*)`)
+
+var (
+	yieldNumberWords = map[string]float64{
+		"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+		"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12, "dozen": 12,
+	}
+	yieldServingWords = []string{
+		"people", "person", "persons", "portion", "portions", "serving", "servings", "undefined",
+		"persone", "personen", "personer", "personnes", "personas", "pessoas", "porciones", "porções",
+		"adag", "adaghoz", "porsjoner", "port", "portionen", "portioner", "porties", "porzioni", "μερίδες", "人分",
+	}
+)
+
+// NewYield parses the text of a yield, e.g. "Makes 24 cookies", "one 9-inch pie" or "1.5 L of stock".
+// The unit is left empty when the yield is in servings, e.g. "Serves 4" and "4 people".
+func NewYield(text string) Yield {
+	s := strings.Join(strings.Fields(units.ReplaceVulgarFractions(text)), " ")
+
+	var y Yield
+	prefix := s
+	if loc := yieldRegex.FindStringSubmatchIndex(s); loc != nil {
+		y.Value = parseIngredientQuantity(s[loc[2]:loc[3]])
+		y.Unit = s[loc[4]:loc[5]]
+
+		// The number is preferred to the number words unless it describes the size
+		// of what is made, e.g. "one 9-inch pie".
+		prefix = ""
+		if isYieldSize(y.Unit) {
+			prefix = s[:loc[0]]
+		}
+	}
+
+	words := strings.Fields(prefix)
+	for i, word := range words {
+		value, ok := yieldNumberWords[strings.ToLower(word)]
+		if !ok {
+			continue
+		}
+
+		rest := strings.Fields(s)[i+1:]
+		if len(rest) > 0 && strings.EqualFold(rest[0], "dozen") && value != 12 {
+			value *= 12
+			rest = rest[1:]
+		}
+		y.Value = value
+		y.Unit = strings.Join(rest, " ")
+		break
+	}
+
+	y.Unit = strings.Trim(y.Unit, " .,;:")
+	if words := strings.Fields(strings.ToLower(y.Unit)); len(words) > 0 {
+		word := strings.Trim(strings.TrimSuffix(words[0], "(s)"), ".,;:()")
+		if slices.Contains(yieldServingWords, word) {
+			y.Unit = ""
+		}
+	}
+	return y
+}
+
+// isYieldSize reports whether the unit following the number of a yield is a length,
+// e.g. the "-inch pie" of "9-inch pie" or the `" brownies` of `2" brownies`.
+func isYieldSize(unit string) bool {
+	if strings.HasPrefix(unit, "-") {
+		return true
+	}
+
+	word, _, _ := strings.Cut(unit, " ")
+	if strings.EqualFold(word, "in") {
+		return false
+	}
+
+	switch units.NewUnit(word) {
+	case units.Centimeter, units.Feet, units.Inch, units.Meter, units.Millimeter:
+		return true
+	default:
+		return false
+	}
+}
+
+// FormatYield formats the quantity of a yield along with its unit, e.g. "24 cookies" or "4 servings".
+func FormatYield(quantity float64, unit string) string {
+	if unit == "" {
+		unit = "servings"
+	}
+	return extensions.FloatToString(quantity, "%.2f") + " " + unit
+}
+
+// YieldText formats the yield of the recipe, e.g. "24 cookies" or "4 servings".
+func (r *Recipe) YieldText() string {
+	return FormatYield(r.Yield, r.YieldUnit)
+}
+
+// Servings returns the yield of the recipe rounded to a whole number of servings, at least one.
+// It is used where recipes are counted in servings, such as meal plans and shopping lists.
+func (r *Recipe) Servings() int16 {
+	return int16(max(1, r.Yield+0.5))
+}
+
+// ScaleTo scales the recipe to the target yield, e.g. "36", "36 cookies" or "750 ml". A target without
+// a unit is in the unit of the recipe's yield. A target in another unit of measurement than that of the
+// yield is converted first, e.g. 750 ml for a recipe yielding 1.5 L of stock.
+func (r *Recipe) ScaleTo(target string) error {
+	y := NewYield(target)
+	if y.Value <= 0 {
+		return ErrYieldInvalid
+	}
+
+	if y.Unit == "" || isSameIngredient(strings.ToLower(y.Unit), strings.ToLower(r.YieldUnit)) {
+		r.Scale(y.Value)
+		return nil
+	}
+
+	from, _ := parseIngredientUnit(r.YieldUnit)
+	to, _ := parseIngredientUnit(y.Unit)
+	if from == units.Invalid || to == units.Invalid {
+		return ErrYieldUnitMismatch
+	}
+
+	m, err := units.Measurement{Quantity: y.Value, Unit: to}.Convert(from)
+	if err != nil {
+		return ErrYieldUnitMismatch
+	}
+
+	r.Scale(m.Quantity)
+	return nil
+}
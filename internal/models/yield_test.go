@@ -0,0 +1,126 @@
+package models_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestNewYield(t *testing.T) {
+	testcases := []struct {
+		in   string
+		want models.Yield
+	}{
+		{in: "4", want: models.Yield{Value: 4}},
+		{in: "Serves 4-6", want: models.Yield{Value: 4}},
+		{in: "4 servings", want: models.Yield{Value: 4}},
+		{in: "8 to 10 people", want: models.Yield{Value: 8}},
+		{in: "Makes 24 cookies.", want: models.Yield{Value: 24, Unit: "cookies"}},
+		{in: "one 9-inch pie", want: models.Yield{Value: 1, Unit: "9-inch pie"}},
+		{in: "a dozen muffins", want: models.Yield{Value: 12, Unit: "muffins"}},
+		{in: `two dozen 2" brownies`, want: models.Yield{Value: 24, Unit: `2" brownies`}},
+		{in: "one 20 cm cake", want: models.Yield{Value: 1, Unit: "20 cm cake"}},
+		{in: "for a crowd of 8", want: models.Yield{Value: 8}},
+		{in: "Makes a batch of 24 cookies", want: models.Yield{Value: 24, Unit: "cookies"}},
+		{in: "1.5 L of stock", want: models.Yield{Value: 1.5, Unit: "L of stock"}},
+		{in: "1 ½ cups", want: models.Yield{Value: 1.5, Unit: "cups"}},
+		{in: "-2", want: models.Yield{Value: -2}},
+		{in: "", want: models.Yield{}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			if got := models.NewYield(tc.in); got != tc.want {
+				t.Fatalf("got %+v but want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRecipe_YieldText(t *testing.T) {
+	testcases := []struct {
+		name string
+		in   models.Recipe
+		want string
+	}{
+		{name: "servings", in: models.Recipe{Yield: 4}, want: "4 servings"},
+		{name: "fractional", in: models.Recipe{Yield: 1.5, YieldUnit: "L of stock"}, want: "1.5 L of stock"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.in.YieldText(); got != tc.want {
+				t.Fatalf("got %q but want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRecipe_Servings(t *testing.T) {
+	testcases := []struct {
+		in   float64
+		want int16
+	}{
+		{in: 0, want: 1},
+		{in: 2.4, want: 2},
+		{in: 2.5, want: 3},
+		{in: 24, want: 24},
+	}
+	for _, tc := range testcases {
+		r := models.Recipe{Yield: tc.in}
+		if got := r.Servings(); got != tc.want {
+			t.Errorf("got %d servings for a yield of %v but want %d", got, tc.in, tc.want)
+		}
+	}
+}
+
+func TestRecipe_ScaleTo(t *testing.T) {
+	cookies := models.Recipe{Ingredients: []string{"2 cups flour"}, Yield: 24, YieldUnit: "cookies"}
+	stock := models.Recipe{Ingredients: []string{"2 carrots"}, Yield: 1.5, YieldUnit: "L of stock"}
+
+	testcases := []struct {
+		name            string
+		recipe          models.Recipe
+		target          string
+		wantIngredients []string
+		wantYield       float64
+	}{
+		{name: "number in the unit of the recipe", recipe: cookies, target: "36", wantIngredients: []string{"3 cups flour"}, wantYield: 36},
+		{name: "same unit", recipe: cookies, target: "36 cookies", wantIngredients: []string{"3 cups flour"}, wantYield: 36},
+		{name: "singular unit", recipe: cookies, target: "12 cookie", wantIngredients: []string{"1 cup flour"}, wantYield: 12},
+		{name: "fractional target", recipe: stock, target: "0.75", wantIngredients: []string{"1 carrots"}, wantYield: 0.75},
+		{name: "other unit of measurement", recipe: stock, target: "3000 ml", wantIngredients: []string{"4 carrots"}, wantYield: 3},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.recipe.Copy()
+
+			err := r.ScaleTo(tc.target)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !slices.Equal(r.Ingredients, tc.wantIngredients) || r.Yield != tc.wantYield {
+				t.Fatalf("got %v for a yield of %v but want %v for %v", r.Ingredients, r.Yield, tc.wantIngredients, tc.wantYield)
+			}
+
+			if r.YieldUnit != tc.recipe.YieldUnit {
+				t.Fatalf("got unit %q but want %q", r.YieldUnit, tc.recipe.YieldUnit)
+			}
+		})
+	}
+
+	t.Run("incompatible unit", func(t *testing.T) {
+		r := cookies.Copy()
+		if err := r.ScaleTo("2 pies"); !errors.Is(err, models.ErrYieldUnitMismatch) {
+			t.Fatalf("got error %v but want %v", err, models.ErrYieldUnitMismatch)
+		}
+	})
+
+	t.Run("invalid target", func(t *testing.T) {
+		r := cookies.Copy()
+		if err := r.ScaleTo("0"); !errors.Is(err, models.ErrYieldInvalid) {
+			t.Fatalf("got error %v but want %v", err, models.ErrYieldInvalid)
+		}
+	})
+}
@@ -33,7 +33,7 @@ func scrapeCdKitchen(root *goquery.Document) (models.RecipeSchema, error) {
 
 	yieldStr := content.Find(".change-servs-input").AttrOr("value", "")
 	yield, _ := strconv.ParseInt(yieldStr, 10, 16)
-	rs.Yield.Value = int16(yield)
+	rs.Yield.Value = float64(yield)
 
 	node = content.Find("span[itemprop=nutrition]")
 	rs.NutritionSchema = &models.NutritionSchema{
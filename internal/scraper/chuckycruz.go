@@ -20,7 +20,7 @@ func scrapeChuckycruz(root *goquery.Document) (models.RecipeSchema, error) {
 		rs.DatePublished = parse.Format(time.DateOnly)
 	}
 
-	var yield int16
+	var yield float64
 	root.Find("p").Each(func(_ int, sel *goquery.Selection) {
 		s := strings.ToLower(sel.Text())
 		if yield == 0 && strings.HasPrefix(s, "makes") {
@@ -42,7 +42,7 @@ func scrapeFoodRepublic(root *goquery.Document) (models.RecipeSchema, error) {
 
 	yieldStr := content.Find(".recipe-card-servings .recipe-card-amount").Text()
 	yield, _ := strconv.ParseInt(yieldStr, 10, 16)
-	rs.Yield.Value = int16(yield)
+	rs.Yield.Value = float64(yield)
 
 	name := content.Find(".recipe-card-title").Text()
 	name = strings.TrimLeft(name, "\n")
@@ -34,7 +34,7 @@ func scrapeFrancescakookt(root *goquery.Document) (models.RecipeSchema, error) {
 	}
 	rs.Category.Value = category
 
-	var yield int16
+	var yield float64
 	root.Find("h3:contains('personen')").Each(func(_ int, sel *goquery.Selection) {
 		if yield != 0 {
 			return
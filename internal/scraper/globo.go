@@ -35,9 +35,9 @@ func scrapeGlobo(root *goquery.Document) (rs models.RecipeSchema, err error) {
 		})
 	}()
 
-	chYield := make(chan int16)
+	chYield := make(chan float64)
 	go func() {
-		var yield int16
+		var yield float64
 		defer func() {
 			_ = recover()
 			chYield <- yield
@@ -45,7 +45,7 @@ func scrapeGlobo(root *goquery.Document) (rs models.RecipeSchema, err error) {
 
 		yieldStr := root.Find("span[itemprop=recipeYield]").AttrOr("content", "")
 		i, _ := strconv.ParseInt(yieldStr, 10, 16)
-		yield = int16(i)
+		yield = float64(i)
 	}()
 
 	chInstructions := make(chan []models.HowToItem)
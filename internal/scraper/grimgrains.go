@@ -18,7 +18,7 @@ func scrapeGrimGrains(root *goquery.Document) (models.RecipeSchema, error) {
 		for _, s := range split {
 			parseInt, err := strconv.ParseInt(s, 10, 16)
 			if err == nil {
-				rs.Yield.Value = int16(parseInt)
+				rs.Yield.Value = float64(parseInt)
 			}
 		}
 
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"github.com/PuerkitoBio/goquery"
 	"github.com/reaper47/recipya/internal/models"
-	"github.com/reaper47/recipya/internal/services"
 	"io"
 	"net/http"
 	"strconv"
@@ -71,7 +70,7 @@ type monsieurCuisineAPI struct {
 	} `json:"data"`
 }
 
-func (s *Scraper) scrapeMonsieurCuisine(root *goquery.Document, rawURL string, files services.FilesService) (models.RecipeSchema, error) {
+func (s *Scraper) scrapeMonsieurCuisine(root *goquery.Document, rawURL string, files imageStorer) (models.RecipeSchema, error) {
 	js := strings.TrimSpace(root.Find("script:contains('window.siteConfig = JSON.parse(')").Last().Text())
 	if js == "" {
 		return models.RecipeSchema{}, errors.New("could not find recipe ID")
@@ -163,7 +162,7 @@ func (s *Scraper) scrapeMonsieurCuisine(root *goquery.Document, rawURL string, f
 		rs.Ingredients.Values = append(rs.Ingredients.Values, sb.String())
 	}
 
-	rs.Yield.Value = int16(block.Amount)
+	rs.Yield.Value = float64(block.Amount)
 
 	var ns models.NutritionSchema
 	for _, n := range m.Data.Recipe.Nutrients {
@@ -17,7 +17,7 @@ func scrapePanelinha(root *goquery.Document) (models.RecipeSchema, error) {
 	for _, s := range strings.Split(node, " ") {
 		yield, err := strconv.ParseInt(s, 10, 16)
 		if err == nil {
-			rs.Yield.Value = int16(yield)
+			rs.Yield.Value = float64(yield)
 		}
 	}
 
@@ -57,7 +57,7 @@ func scrapeRecettesDuQuebec(root *goquery.Document) (models.RecipeSchema, error)
 			for _, s := range strings.Split(yieldStr, " ") {
 				yield64, err := strconv.ParseInt(s, 10, 16)
 				if err == nil {
-					rs.Yield.Value = int16(yield64)
+					rs.Yield.Value = float64(yield64)
 					break
 				}
 			}
@@ -62,7 +62,100 @@ func (s *Scraper) Scrape(rawURL string, files services.FilesService) (models.Rec
 	if err != nil {
 		return rs, ErrNotImplemented
 	}
+	setSchemaDefaults(&rs, rawURL)
 
+	var imageUUID uuid.UUID
+	if rs.Image != nil {
+		imageUUID, err = files.ScrapeAndStoreImage(rs.Image.Value)
+		if err != nil {
+			return rs, err
+		}
+		rs.Image.Value = imageUUID.String()
+	}
+
+	return rs, nil
+}
+
+// These constants define the paths Scrape may take to extract a recipe.
+const (
+	PathGeneric = "parseWebsite"
+	PathSpecial = "scrapeSpecial"
+	PathWebsite = "site-specific"
+)
+
+// Trace holds a recipe extracted by Scraper.Trace along with the path taken to extract it.
+type Trace struct {
+	Host   string
+	Path   string
+	Schema models.RecipeSchema
+}
+
+// Trace extracts the recipe from the URL like Scrape does, without storing its image, and reports
+// the path taken. When html is not nil, the page is parsed from it rather than fetched so that no
+// request is made. The websites scraped through scrapeSpecial cannot be traced offline because they
+// rely on an API.
+func (s *Scraper) Trace(rawURL string, html io.Reader) (Trace, error) {
+	trace := Trace{Host: s.HTTP.GetHost(rawURL)}
+	if trace.Host == "" {
+		return trace, fmt.Errorf("invalid URL %q", rawURL)
+	}
+
+	if isSpecialHost(trace.Host) {
+		trace.Path = PathSpecial
+		if html != nil {
+			return trace, fmt.Errorf("%s is scraped through %s, which requires network access", trace.Host, PathSpecial)
+		}
+
+		rs, _, err := s.scrapeSpecial(trace.Host, rawURL, &traceFiles{})
+		trace.Schema = rs
+		return trace, err
+	}
+
+	var (
+		doc *goquery.Document
+		err error
+	)
+
+	if html != nil {
+		doc, err = goquery.NewDocumentFromReader(html)
+	} else {
+		if trace.Host == "reddit" {
+			rawURL = strings.Replace(rawURL, "www", "old", 1)
+		}
+		doc, err = s.fetchDocument(rawURL)
+	}
+	if err != nil {
+		return trace, err
+	}
+
+	trace.Path = PathWebsite
+	rs, err := scrapeHost(doc, trace.Host, func(doc *goquery.Document) (models.RecipeSchema, error) {
+		trace.Path = PathGeneric
+		return parseWebsite(doc)
+	})
+	if err != nil {
+		return trace, err
+	}
+
+	setSchemaDefaults(&rs, rawURL)
+	trace.Schema = rs
+	return trace, nil
+}
+
+// imageStorer stores the image of a recipe scraped through scrapeSpecial.
+type imageStorer interface {
+	ScrapeAndStoreImage(rawURL string) (uuid.UUID, error)
+}
+
+// traceFiles is the imageStorer of Scraper.Trace. Images are not stored.
+type traceFiles struct{}
+
+// ScrapeAndStoreImage does not download the image.
+func (t *traceFiles) ScrapeAndStoreImage(_ string) (uuid.UUID, error) {
+	return uuid.Nil, nil
+}
+
+func setSchemaDefaults(rs *models.RecipeSchema, rawURL string) {
 	if rs.AtContext == "" {
 		rs.AtContext = atContext
 	}
@@ -80,44 +173,42 @@ func (s *Scraper) Scrape(rawURL string, files services.FilesService) (models.Rec
 	if rs.URL == "" {
 		rs.URL = rawURL
 	}
+}
 
-	var imageUUID uuid.UUID
-	if rs.Image != nil {
-		imageUUID, err = files.ScrapeAndStoreImage(rs.Image.Value)
+// specialScrapers are the scrapers of the websites whose recipe is not parsed from the page
+// of the URL, keyed by host.
+var specialScrapers = map[string]func(s *Scraper, rawURL string, files imageStorer) (models.RecipeSchema, error){
+	"bergamot": func(s *Scraper, rawURL string, _ imageStorer) (models.RecipeSchema, error) {
+		return s.scrapeBergamot(rawURL)
+	},
+	"gousto": func(s *Scraper, rawURL string, _ imageStorer) (models.RecipeSchema, error) {
+		return s.scrapeGousto(rawURL)
+	},
+	"madewithlau": func(s *Scraper, rawURL string, _ imageStorer) (models.RecipeSchema, error) {
+		return s.scrapeMadeWithLau(rawURL)
+	},
+	"monsieur-cuisine": func(s *Scraper, rawURL string, files imageStorer) (models.RecipeSchema, error) {
+		doc, err := s.fetchDocument(rawURL)
 		if err != nil {
-			return rs, err
+			return models.RecipeSchema{}, err
 		}
-		rs.Image.Value = imageUUID.String()
-	}
-
-	return rs, nil
+		return s.scrapeMonsieurCuisine(doc, rawURL, files)
+	},
 }
 
-func (s *Scraper) scrapeSpecial(host, rawURL string, files services.FilesService) (models.RecipeSchema, bool, error) {
-	var (
-		rs        models.RecipeSchema
-		err       error
-		isSpecial = true
-	)
+func isSpecialHost(host string) bool {
+	_, ok := specialScrapers[host]
+	return ok
+}
 
-	switch host {
-	case "bergamot":
-		rs, err = s.scrapeBergamot(rawURL)
-	case "gousto":
-		rs, err = s.scrapeGousto(rawURL)
-	case "madewithlau":
-		rs, err = s.scrapeMadeWithLau(rawURL)
-	case "monsieur-cuisine":
-		doc, err := s.fetchDocument(rawURL)
-		if err != nil {
-			return models.RecipeSchema{}, true, err
-		}
-		rs, err = s.scrapeMonsieurCuisine(doc, rawURL, files)
-	default:
-		isSpecial = false
+func (s *Scraper) scrapeSpecial(host, rawURL string, files imageStorer) (models.RecipeSchema, bool, error) {
+	scrape, ok := specialScrapers[host]
+	if !ok {
+		return models.RecipeSchema{}, false, nil
 	}
 
-	return rs, isSpecial, err
+	rs, err := scrape(s, rawURL, files)
+	return rs, true, err
 }
 
 func (s *Scraper) fetchDocument(url string) (*goquery.Document, error) {
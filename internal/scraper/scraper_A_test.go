@@ -328,6 +328,10 @@ func TestScraper_A(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 0, Name: "For the Toasted Breadcrumbs:"},
+						{Index: 1, Name: "For the Charred Lemon Orzo:"},
+					},
 					Values: []models.HowToItem{
 						{
 							Text: "Heat olive oil in a small non-stick pan over medium heat. Once the oil is hot, stir in the breadcrumbs and Italian seasoning until the breadcrumbs are coated in oil. Cook, stirring frequently, for 4-5 minutes until the breadcrumbs turn a dark, golden brown color. Remove from the heat and set aside.",
@@ -504,7 +508,7 @@ func TestScraper_A(t *testing.T) {
 				Tools:        &models.Tools{Values: []models.HowToItem{}},
 				URL:          "https://akispetretzikis.com/recipe/6867/eukolos-mpaklavas",
 				Video:        &models.Videos{},
-				Yield:        &models.Yield{Value: 1},
+				Yield:        &models.Yield{Value: 18},
 			},
 		},
 		{
@@ -553,7 +557,7 @@ func TestScraper_A(t *testing.T) {
 				Name:      "Foolproof Homemade Bagels Recipe",
 				PrepTime:  "PT20M",
 				TotalTime: "PT-481700H5M43S",
-				Yield:     &models.Yield{Value: 8},
+				Yield:     &models.Yield{Value: 8, Unit: "bagels"},
 				URL:       "https://alexandracooks.com/2018/08/16/very-good-bagels-easy-ish-too/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -943,6 +947,11 @@ func TestScraper_A(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 0, Name: "Crust Procedure:"},
+						{Index: 2, Name: "Filling Procedure:"},
+						{Index: 5, Name: "Assembly Procedure:"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: "Combine the flour, salt, and sugar in the bowl of your food processor and pulse 3-4 times. Add the butter and pulse until texture looks mealy, 5-6 pulses. Then, add the shortening and pulse until incorporated, another 3-4 pulses. Remove the lid and drizzle in 5 tablespoons of the Applejack. Replace the lid and pulse 5 times. Add the remaining Applejack and pulse until the mixture begins to hold together and pull away from the sides of the bowl."},
 						{Type: "HowToStep", Text: "Dump the mixture onto a clean surface and squeeze together with your hands to form a smooth ball. Divide the ball in half and press each into a disk about 1-inch thick. Wrap each dough in plastic wrap and refrigerate for at least 1 hour. (You can refrigerate longer, even overnight, but the dough will have to sit at room temperature for 15 minutes to be malleable enough to roll."},
@@ -1034,7 +1043,7 @@ func TestScraper_A(t *testing.T) {
 				},
 				Name:     "Crispy Grilled Buffalo Wings Recipe",
 				PrepTime: "PT120M",
-				Yield:    &models.Yield{Value: 6},
+				Yield:    &models.Yield{Value: 6, Unit: "appetizer servings"},
 				URL:      "https://amazingribs.com/tested-recipes-chicken-recipes-crispy-grilled-buffalo-wings-recipe/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -1135,6 +1144,10 @@ func TestScraper_A(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 0, Name: "Recipe Instructions"},
+						{Index: 6, Name: "Test Kitchen Techniques"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: "Spray the pan well in step 1 to prevent sticking. If you don’t have nonstick baking spray with flour, mix 1 tablespoon melted butter and 1 tablespoon flour into a paste and brush inside the pan. For fruit pectin we recommend Sure-Jell for Less or No Sugar Needed Recipes. Ball Fruit Pectin will not work. If using frozen berries, thaw them before blending in step 3. This cake can be served plain or with Lemon Glaze or Cinnamon Whipped Cream ."},
 						{Type: "HowToStep", Text: "FOR THE CAKE: Adjust oven rack to lower-middle position and heat oven to 325 degrees. Heavily spray 12-cup nonstick Bundt pan with baking spray with flour. Whisk flour, baking powder, baking soda, salt, and cinnamon together in large bowl. Whisk buttermilk, lemon zest and juice, and vanilla together in medium bowl. Gently whisk eggs and yolk to combine in third bowl."},
@@ -1510,6 +1523,9 @@ func TestScraper_A(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 0, Name: "Första instruktionen"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: "Koka pastan enligt anvisning på förpackningen."},
 						{Type: "HowToStep", Text: "Strimla kycklingköttet. Fräs det i smör-&rapsolja i en stekpanna, salta och peppra."},
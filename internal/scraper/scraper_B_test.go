@@ -144,7 +144,7 @@ func TestScraper_B(t *testing.T) {
 					UnsaturatedFat: "3",
 				},
 				PrepTime: "PT30M",
-				Yield:    &models.Yield{Value: 8},
+				Yield:    &models.Yield{Value: 8, Unit: "pieces"},
 				URL:      "https://www.baking-sense.com/2022/02/23/irish-potato-farls/",
 				Video:    nil,
 			},
@@ -689,6 +689,9 @@ func TestScraper_B(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 0, Name: "*Note: Check out the recipe video + step-by-step instructions above!"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: "Cook the rice in vegetable broth (or salted water) according to package directions. Then allow to cool, gently fluffing occasionally (can be cooked ahead)."},
 						{Type: "HowToStep", Text: "Heat the oil in a large skillet and and sauté the onion for 1-2 minutes until translucent. Then add the garlic and sauté for 30 seconds. Then add the paprika, chili and cumin and sauté briefly."},
@@ -1546,7 +1549,7 @@ func TestScraper_B(t *testing.T) {
 				ThumbnailURL:    &models.ThumbnailURL{},
 				Tools:           &models.Tools{Values: []models.HowToItem{}},
 				TotalTime:       "PT2H",
-				Yield:           &models.Yield{Value: 12},
+				Yield:           &models.Yield{Value: 12, Unit: "lollipops"},
 				URL:             "https://www.bongeats.com/recipe/chicken-lollipop",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -1743,7 +1746,7 @@ func TestScraper_B(t *testing.T) {
 					UnsaturatedFat: ""},
 				PrepTime:  "PT15M",
 				TotalTime: "PT25M",
-				Yield:     &models.Yield{Value: 4},
+				Yield:     &models.Yield{Value: 4, Unit: "1.5 cups each"},
 				URL:       "https://www.budgetbytes.com/easy-vegetable-stir-fry/",
 				Video:     nil,
 			},
@@ -1001,6 +1001,10 @@ func TestScraper_C(t *testing.T) {
 				},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 0, Name: "Como preparar a Calda"},
+						{Index: 4, Name: "Como preparar o Pudim"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: "Em uma panela e no fogo médio vá adicionando o açúcar aos poucos, mexendo sem parar até derreter completamente;"},
 						{Type: "HowToStep", Text: "Adicione a água e continue mexendo até dissolver todas as pelotas formadas pelo açúcar;"},
@@ -1173,7 +1177,7 @@ func TestScraper_C(t *testing.T) {
 				},
 				PrepTime:  "PT10M",
 				TotalTime: "PT45M",
-				Yield:     &models.Yield{Value: 16},
+				Yield:     &models.Yield{Value: 16, Unit: "slices"},
 				URL:       "https://cookieandkate.com/honey-butter-cornbread-recipe/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -1233,7 +1237,7 @@ func TestScraper_C(t *testing.T) {
 				Tools:           &models.Tools{Values: []models.HowToItem{}},
 				URL:             "https://cookpad.com/jp/recipes/19600325-30%E5%88%86%E3%81%A7%E7%B0%A1%E5%8D%98%E6%9C%AC%E6%A0%BC%E3%83%90%E3%82%BF%E3%83%BC%E3%83%81%E3%82%AD%E3%83%B3%E3%82%AB%E3%83%AC%E3%83%BC",
 				Video:           &models.Videos{},
-				Yield:           &models.Yield{Value: 1},
+				Yield:           &models.Yield{Value: 4},
 			},
 		},
 		{
@@ -1695,7 +1699,7 @@ func TestScraper_C(t *testing.T) {
 				},
 				PrepTime:  "PT20M",
 				TotalTime: "PT45M",
-				Yield:     &models.Yield{Value: 12},
+				Yield:     &models.Yield{Value: 12, Unit: "Muffins"},
 				URL:       "https://www.cuisineandtravel.com/pumpkin-vegan-muffins/",
 			},
 		},
@@ -121,7 +121,7 @@ func TestScraper_D(t *testing.T) {
 				},
 				PrepTime:  "PT30M",
 				TotalTime: "PT40M",
-				Yield:     &models.Yield{Value: 6},
+				Yield:     &models.Yield{Value: 6, Unit: "pints"},
 				URL:       "https://www.daringgourmet.com/homemade-giardiniera/",
 			},
 		},
@@ -528,6 +528,12 @@ func TestScraper_D(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 0, Name: "Make keto roti dough"},
+						{Index: 1, Name: "Make samosa filling"},
+						{Index: 3, Name: "Filling samosas"},
+						{Index: 7, Name: "Cook samosas"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: "Start by maxing up a batch of keto roti dough. While the dough is resting you will want to make the filling."},
 						{Type: "HowToStep", Text: "To make the filling heat the oil in a skillet. When the oil is hot add the seasoning(cumin, ginger, Garam masala), garlic, and jalapeno to the skillet. Saute in the skillet for about 1 minute then add the rest of the filling ingredients to the skillet. Let the filling cook for 3-4 minutes, stirring occassionally. Once the filling is done remove it from the heat and let it cool completely."},
@@ -552,7 +558,7 @@ func TestScraper_D(t *testing.T) {
 					Sugar:         "0.9",
 				},
 				PrepTime: "PT30M",
-				Yield:    &models.Yield{Value: 12},
+				Yield:    &models.Yield{Value: 12, Unit: "samosas"},
 				URL:      "https://www.ditchthecarbs.com/how-to-make-keto-samosa-air-fryer-oven/",
 			},
 		},
@@ -589,7 +595,7 @@ func TestScraper_D(t *testing.T) {
 				},
 				Name:     "Strawberry Thyme Cooler and 9 Other Summer Cocktail Recipes",
 				PrepTime: "PT5M",
-				Yield:    &models.Yield{Value: 1},
+				Yield:    &models.Yield{Value: 1, Unit: "cocktail"},
 				URL:      "https://domesticate-me.com/10-summer-cocktail-recipes/",
 			},
 		},
@@ -367,6 +367,12 @@ func TestScraper_E(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 0, Name: "Prepare the Eggplant and Potatoes"},
+						{Index: 4, Name: "Prepare the Lentil Moussaka Filling"},
+						{Index: 7, Name: "Prepare the Vegan Bechamel Sauce (see recipe notes)"},
+						{Index: 9, Name: "Assemble and bake the Vegan Moussaka"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: "Check the video in the post for visual instructions.First, preheat the oven to 390 °F/200 °C and line two baking sheets with parchment paper."},
 						{Type: "HowToStep", Text: "Then, slice each eggplant into 4-5 lengthwise slices and the potatoes into ½-inch (1 cm) thick slices."},
@@ -465,7 +471,7 @@ func TestScraper_E(t *testing.T) {
 				},
 				PrepTime:  "PT10M",
 				TotalTime: "PT20M",
-				Yield:     &models.Yield{Value: 8},
+				Yield:     &models.Yield{Value: 8, Unit: "fish*"},
 				URL:       "https://www.elephantasticvegan.com/banana-blossom-vegan-fish",
 			},
 		},
@@ -697,6 +703,9 @@ func TestScraper_E(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 7, Name: "To Bake"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: "Preheat oven to 300°F/150°C"},
 						{Type: "HowToStep", Text: "In a small bowl, mix the spices for the rub."},
@@ -186,7 +186,7 @@ func TestScraper_F(t *testing.T) {
 				},
 				PrepTime:  "PT15M",
 				TotalTime: "PT880M",
-				Yield:     &models.Yield{Value: 10},
+				Yield:     &models.Yield{Value: 10, Unit: "buns"},
 				URL:       "https://www.farmhouseonboone.com/sourdough-pretzel-buns",
 			},
 		},
@@ -699,7 +699,7 @@ func TestScraper_F(t *testing.T) {
 				},
 				PrepTime:  "PT5M",
 				TotalTime: "PT15M",
-				Yield:     &models.Yield{Value: 8},
+				Yield:     &models.Yield{Value: 8, Unit: "patties"},
 				URL:       "https://fitslowcookerqueen.com/easy-homemade-breakfast-sausage/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -776,7 +776,7 @@ func TestScraper_F(t *testing.T) {
 				TotalTime:    "PT36M",
 				URL:          "https://www.food.com/recipe/jim-lahey-s-no-knead-pizza-margherita-382696",
 				Video:        &models.Videos{},
-				Yield:        &models.Yield{Value: 1},
+				Yield:        &models.Yield{Value: 3, Unit: "10-inch pizzas"},
 			},
 		},
 		{
@@ -895,7 +895,7 @@ func TestScraper_F(t *testing.T) {
 				},
 				PrepTime:  "PT10M",
 				TotalTime: "PT3H15M",
-				Yield:     &models.Yield{Value: 12},
+				Yield:     &models.Yield{Value: 12, Unit: "pieces"},
 				URL:       "https://foodal.com/recipes/candy/chili-chocolate-bark/",
 			},
 		},
@@ -1293,7 +1293,7 @@ func TestScraper_F(t *testing.T) {
 				ThumbnailURL: &models.ThumbnailURL{},
 				Tools:        &models.Tools{Values: []models.HowToItem{}},
 				TotalTime:    "PT0D0H35M",
-				Yield:        &models.Yield{Value: 6},
+				Yield:        &models.Yield{Value: 6, Unit: "cups"},
 				URL:          "https://www.forksoverknives.com/recipes/vegan-snacks-appetizers/crispy-buffalo-cauliflower-bites/",
 				Video:        &models.Videos{},
 			},
@@ -347,7 +347,7 @@ func TestScraper_G(t *testing.T) {
 				Name:      "Miso Chocolate Peanut Butter Cornflake Bars",
 				PrepTime:  "PT20M",
 				TotalTime: "PT20M",
-				Yield:     &models.Yield{Value: 16},
+				Yield:     &models.Yield{Value: 16, Unit: "bars"},
 				URL:       "https://www.gimmesomeoven.com/miso-chocolate-peanut-butter-cornflake-bars-gimme-some-oven/",
 			},
 		},
@@ -1109,7 +1109,7 @@ func TestScraper_G(t *testing.T) {
 				PrepTime:        "PT30M",
 				TotalTime:       "PT45M",
 				URL:             "https://greenevi.com/vegan-onigiri-japanese-stuffed-rice-balls/",
-				Yield:           &models.Yield{Value: 30},
+				Yield:           &models.Yield{Value: 30, Unit: "balls"},
 			},
 		},
 		{
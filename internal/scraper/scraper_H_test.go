@@ -92,6 +92,10 @@ func TestScraper_H(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 1, Name: "For the crust:"},
+						{Index: 2, Name: "For the filling:"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: "Preheat the oven to 325°F."},
 						{Type: "HowToStep", Text: "In the bowl of a food processor, process the crackers and sugar until finely ground. Add the butter and pulse until moistened. Use the bottom of a measuring cup, glass, or ramekin to press the crust mixture into the bottom and up the sides of a 9-inch pie plate. Bake until fragrant, about 10 to 12 minutes. Cool completely on a wire rack."},
@@ -325,7 +329,7 @@ func TestScraper_H(t *testing.T) {
 						},
 					},
 				},
-				Yield: &models.Yield{Value: 25},
+				Yield: &models.Yield{Value: 25, Unit: "Tots"},
 			},
 		},
 		{
@@ -522,7 +526,7 @@ func TestScraper_H(t *testing.T) {
 						{Quantity: 1, Text: "Airlock & Bung", Type: "HowToTool"},
 					},
 				},
-				Yield: &models.Yield{Value: 1},
+				Yield: &models.Yield{Value: 1, Unit: "Gallon / 4.5 Litres"},
 				URL:   "https://homebrewanswers.com/banana-wine-recipe/",
 			},
 		},
@@ -151,6 +151,13 @@ func TestScraper_I(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 0, Name: "For the Dough"},
+						{Index: 5, Name: "For the Cinnamon Caramel Sauce"},
+						{Index: 7, Name: "For the Cinnamon Filling"},
+						{Index: 8, Name: "Assembling and Baking the Cinnamon Rolls"},
+						{Index: 14, Name: "For the Cream Cheese Frosting"},
+					},
 					Values: []models.HowToItem{
 						{Text: "To start, bloom your yeast. Mix your active dry yeast, sugar, and warm milk together and let sit for 10 minutes until foamy on top.", Type: "HowToStep"},
 						{Text: "Next, in a large bowl of a stand mixer with a whisk attachment, mix flour, salt and sugar together.", Type: "HowToStep"},
@@ -212,7 +219,7 @@ func TestScraper_I(t *testing.T) {
 				ThumbnailURL:    nil,
 				Tools:           nil,
 				TotalTime:       "PT225M",
-				Yield:           &models.Yield{Value: 12},
+				Yield:           &models.Yield{Value: 12, Unit: "rolls"},
 				URL:             "https://inbloombakery.com/the-best-cinnamon-rolls-ever",
 			},
 		},
@@ -250,6 +257,10 @@ func TestScraper_I(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 0, Name: "Preparation"},
+						{Index: 3, Name: "How to Make Mango Rice"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: "Cook rice to grainy texture: Add rice to a bowl &amp; rinse it a few times. Pour 4 cups water &amp; place the bowl in a pressure cooker. Cover the bowl &amp; pressure cook for 3 whistles."},
 						{Type: "HowToStep", Text: "When the pressure drops, remove the rice &amp; cool completely."},
@@ -322,6 +333,12 @@ func TestScraper_I(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 0, Name: "Make Rice"},
+						{Index: 3, Name: "Pineapple Prep"},
+						{Index: 4, Name: "Salad Assembly"},
+						{Index: 5, Name: "Taste"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: "Combine ingredients in pot & bring to a boil."},
 						{Type: "HowToStep", Text: "Cover & simmer for 15 minutes."},
@@ -263,7 +263,7 @@ func TestScraper_J(t *testing.T) {
 				},
 				PrepTime:  "PT10M",
 				TotalTime: "PT55M",
-				Yield:     &models.Yield{Value: 8},
+				Yield:     &models.Yield{Value: 8, Unit: "Slices"},
 				URL:       "https://www.jaroflemons.com/vegetarian-hot-honey-pizza/",
 			},
 		},
@@ -515,7 +515,7 @@ func TestScraper_J(t *testing.T) {
 				Name:      "Jambalaya Biscuits",
 				PrepTime:  "PT40M",
 				TotalTime: "PT-481702H57M33S",
-				Yield:     &models.Yield{Value: 8},
+				Yield:     &models.Yield{Value: 8, Unit: "biscuits"},
 				URL:       "https://joythebaker.com/2023/01/jambalaya-biscuits/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -657,6 +657,9 @@ func TestScraper_J(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 7, Name: "Kelly’s Note:"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: "Preheat the oven to 350°F. Grease a nonstick mini muffin pan with cooking spray."},
 						{Type: "HowToStep", Text: "In a medium bowl, whisk together the flour, baking powder, baking soda and salt. Set the mixture aside."},
@@ -686,7 +689,7 @@ func TestScraper_J(t *testing.T) {
 				},
 				PrepTime:  "PT10M",
 				TotalTime: "PT26M",
-				Yield:     &models.Yield{Value: 18},
+				Yield:     &models.Yield{Value: 18, Unit: "mini muffins"},
 				URL:       "https://www.justataste.com/mini-sour-cream-doughnut-muffins-recipe/",
 			},
 		},
@@ -773,6 +776,13 @@ func TestScraper_J(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 1, Name: "To Drain the Tofu (30 Minutes Before Cooking)"},
+						{Index: 3, Name: "To Prepare the Ingredients"},
+						{Index: 9, Name: "To Pan-Fry the Tofu"},
+						{Index: 15, Name: "To Serve"},
+						{Index: 16, Name: "To Store"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: "Before You Start: For the steamed rice, please note that 1½ cups (300 g, 2 rice cooker cups) of uncooked Japanese short-grain rice yield 4⅓ cups (660 g) of cooked rice, enough for 2 donburi servings (3⅓ cups, 500 g). See how to cook short-grain rice with a rice cooker, pot over the stove, Instant Pot, or donabe."},
 						{Type: "HowToStep", Text: "Open the package of 14 oz medium-firm tofu (momen dofu) and drain out the water.Next, wrap the tofu block in a paper towel (or tea towel) and place it on a plate or tray. Now, press the tofu: First, put another tray or plate or even a cutting board on top of the tofu block to evenly distribute the weight. Then, place a heavy item* (I used a marble mortar but a can of food works) on top to apply pressure.Let it sit for at least 30 minutes before using. *The weighted item should not be so heavy that it will crumble or crush the tofu block but heavy enough that it will press out the tofu&#39;s liquid."},
@@ -282,7 +282,7 @@ func TestScraper_K(t *testing.T) {
 				PrepTime:  "PT12M",
 				Tools:     &models.Tools{},
 				TotalTime: "PT40M",
-				Yield:     &models.Yield{Value: 24},
+				Yield:     &models.Yield{Value: 24, Unit: `2" brownies`},
 				URL:       "https://www.kingarthurbaking.com/recipes/fudge-brownies-recipe",
 			},
 		},
@@ -424,7 +424,7 @@ func TestScraper_K(t *testing.T) {
 				ThumbnailURL: nil,
 				Tools:        nil,
 				TotalTime:    "PT40M",
-				Yield:        &models.Yield{Value: 40},
+				Yield:        &models.Yield{Value: 40, Unit: "wings"},
 				URL:          "https://www.kitchensanctuary.com/air-fryer-crispy-chicken-wings/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -791,6 +791,12 @@ func TestScraper_K(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 1, Name: "Make Blackberry Pie Filling"},
+						{Index: 2, Name: "Make Pie Crust"},
+						{Index: 6, Name: "Assemble Pie"},
+						{Index: 9, Name: "Brush with Egg Wash and Bake"},
+					},
 					Values: []models.HowToItem{
 						{Text: "Preheat oven to 400° F with a rack in the lower third of the oven.", Type: "HowToStep"},
 						{Text: "Place blackberries in a large bowl. Add the sugar, cornstarch, lemon juice and vanilla and stir until well combined. Let the filling mixture rest while you make the pie crust so that the berries release some of their juices.", Type: "HowToStep"},
@@ -833,7 +839,7 @@ func TestScraper_K(t *testing.T) {
 				},
 				PrepTime:  "PT35M",
 				TotalTime: "PT95M",
-				Yield:     &models.Yield{Value: 1},
+				Yield:     &models.Yield{Value: 1, Unit: "pie"},
 				URL:       "https://kristineskitchenblog.com/blackberry-pie/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
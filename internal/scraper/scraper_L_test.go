@@ -395,6 +395,9 @@ func TestScraper_L(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 0, Name: "Bereiding"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: "Meng de stukjes kip met de tandoori kruiden (dit kunnen droge kruiden zijn of een kruidenpasta) en de yoghurt in een kom. Dek af en laat ondertussen in de koelkast staan."},
 						{Type: "HowToStep", Text: "Ga verder met het afbakken van de pitabroodjes. Snijd de komkommer in plakjes."},
@@ -409,7 +412,7 @@ func TestScraper_L(t *testing.T) {
 				ThumbnailURL:    &models.ThumbnailURL{},
 				Tools:           &models.Tools{Values: []models.HowToItem{}},
 				TotalTime:       "PT0H30M",
-				Yield:           &models.Yield{Value: 5},
+				Yield:           &models.Yield{Value: 5, Unit: "stuks"},
 				URL:             "https://www.leukerecepten.nl/recepten/pita-tandoori",
 				Video:           &models.Videos{},
 			},
@@ -746,7 +749,7 @@ func TestScraper_L(t *testing.T) {
 				ThumbnailURL: &models.ThumbnailURL{},
 				Tools:        &models.Tools{Values: []models.HowToItem{}},
 				TotalTime:    "PT2H40M",
-				Yield:        &models.Yield{Value: 1},
+				Yield:        &models.Yield{Value: 1, Unit: "rack ribs"},
 				URL:          "https://livelytable.com/bbq-ribs-on-the-charcoal-grill/",
 				Video:        &models.Videos{},
 			},
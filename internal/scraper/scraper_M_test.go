@@ -191,7 +191,7 @@ func TestScraper_M(t *testing.T) {
 				PrepTime:     "P0DT2H20M0S",
 				ThumbnailURL: &models.ThumbnailURL{Value: "https://i.ytimg.com/vi/eTucCw1w6Ak/maxresdefault.jpg?meta=og:image"},
 				TotalTime:    "P0DT2H50M0S",
-				Yield:        &models.Yield{Value: 8},
+				Yield:        &models.Yield{Value: 8, Unit: "pounds (3.6 kg) of Kimchi"},
 				URL:          "https://www.maangchi.com/recipe/tongbaechu-kimchi",
 				Video: &models.Videos{Values: []models.VideoObject{
 					{
@@ -257,7 +257,7 @@ func TestScraper_M(t *testing.T) {
 				},
 				PrepTime:  "PT15M",
 				TotalTime: "PT82M",
-				Yield:     &models.Yield{Value: 12},
+				Yield:     &models.Yield{Value: 12, Unit: "stk"},
 				URL:       "https://madensverden.dk/durumboller-nemme-italienske-boller-med-durum-mel/",
 			},
 		},
@@ -284,6 +284,10 @@ func TestScraper_M(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 0, Name: "Kagedej"},
+						{Index: 7, Name: "Fyld"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: "Tænd ovnen på over- og undervarm, 200 grader."},
 						{Type: "HowToStep", Text: "Smelt 100 g smør i en lille gryde. Hæld dernæst 2 dl mælk i gryden sammen med smørret, og sæt til side."},
@@ -859,6 +863,10 @@ func TestScraper_M(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 0, Name: "TO MAKE MIX"},
+						{Index: 2, Name: "TO MAKE HOT CHOCOLATE"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: "To a large jar or container add cacao powder, ashwagandha, reishi mushroom powder, maca, tocos, ground cinnamon, and he shou wu (optional). At this point you can keep it unsweetened and sweeten to taste per batch, or you can add sweetener. We opted for a bit of stevia (we like Trader Joe’s stevia packets, and we added about 5, so 1/2 a packet / serving). However, not all stevia is made equal, and commonly they’re much sweeter, so add little by little! Alternatively, you could sweeten with coconut sugar. We’d recommend 1-2 tsp / serving, so as the recipe is written, roughly 3-7 Tbsp."},
 						{Type: "HowToStep", Text: "Will keep stored at room temperature (preferably in a cool, dark place) up to 3 months."},
@@ -895,7 +903,7 @@ func TestScraper_M(t *testing.T) {
 						},
 					},
 				},
-				Yield: &models.Yield{Value: 10},
+				Yield: &models.Yield{Value: 10, Unit: "(3-Tbsp servings)"},
 			},
 		},
 		{
@@ -922,6 +930,9 @@ func TestScraper_M(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 2, Name: "Stove Top Recipe:"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: "Add cranberries, apple, and orange juice to the Instant Pot. Layer with sugar, do not mix."},
 						{Type: "HowToStep", Text: "Pressure cook for 2 mins. Allow 5 to 10 mins natural pressure release. Open the Instant Pot. Mix well and allow to cool before serving."},
@@ -1167,6 +1178,10 @@ func TestScraper_M(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 0, Name: "Khinkali Dough"},
+						{Index: 3, Name: "Khinkali Filling"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: "In a large bowl combine the flour with the salt. Make a well in the center and add the eggs. Whisk together using a fork."},
 						{Type: "HowToStep", Text: "Add the water to the center and fold the flour into the liquid. Knead the dough by hand until it feels elastic."},
@@ -1195,7 +1210,7 @@ func TestScraper_M(t *testing.T) {
 				},
 				PrepTime:  "PT30M",
 				TotalTime: "PT45M",
-				Yield:     &models.Yield{Value: 30},
+				Yield:     &models.Yield{Value: 30, Unit: "dumplings"},
 				URL:       "https://momsdish.com/khinkali",
 			},
 		},
@@ -1291,6 +1306,9 @@ func TestScraper_M(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 2, Name: "Dressing"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: "In a large bowl toss together bacon, pasta, lettuce, tomatoes and cheese."},
 						{Type: "HowToStep", Text: "Season with a pinch of salt and pepper."},
@@ -1594,7 +1612,7 @@ func TestScraper_M(t *testing.T) {
 				},
 				PrepTime:  "PT35M",
 				TotalTime: "PT90M",
-				Yield:     &models.Yield{Value: 24},
+				Yield:     &models.Yield{Value: 24, Unit: "片"},
 				URL:       "https://mykitchen101.com/%e5%8e%9f%e5%91%b3%e7%89%9b%e6%b2%b9%e8%9b%8b%e7%b3%95/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -1747,6 +1765,12 @@ func TestScraper_M(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 0, Name: "Preparing the Base Gravy:"},
+						{Index: 4, Name: "Sautéing Vegetables:"},
+						{Index: 6, Name: "Preparing the Main Gravy:"},
+						{Index: 11, Name: "ENJOY THIS RECIPE"},
+					},
 					Values: []models.HowToItem{
 						{Text: "Heat 2-3 tablespoons of oil in a pan. Add cumin seeds, bay leaf, cinnamon stick, black cardamom, cloves, black peppercorns, and green cardamom. Sauté until they release their aroma. Add cashew nuts and sauté for a few seconds. Next, add sliced onions and sauté for 1 minute. Then add ginger, garlic, and green chilies, and sauté until the onions turn translucent and golden.", Type: "HowToStep"},
 						{Text: "Now, add the red chili powder, coriander powder, turmeric powder, cumin powder, and salt. Mix well. Add 1/4 cup of water, mix well, and cook for a couple of minutes. Add roughly sliced tomatoes and cook for a minute. Add 1/2 cup of water, mix well. Cover the pan with a lid and cook over medium-low flame for 4-5 minutes or until the tomatoes become soft.", Type: "HowToStep"},
@@ -1854,7 +1878,7 @@ func TestScraper_M(t *testing.T) {
 				},
 				PrepTime:  "PT35M",
 				TotalTime: "PT90M",
-				Yield:     &models.Yield{Value: 24},
+				Yield:     &models.Yield{Value: 24, Unit: "slices"},
 				Video: &models.Videos{
 					Values: []models.VideoObject{
 						{
@@ -264,7 +264,7 @@ func TestScraper_N(t *testing.T) {
 				},
 				Name:     "Fudgy Tahini Brownies",
 				PrepTime: "PT10M",
-				Yield:    &models.Yield{Value: 12},
+				Yield:    &models.Yield{Value: 12, Unit: "brownies"},
 				URL:      "https://nourishedbynutrition.com/fudgy-gluten-free-tahini-brownies/",
 			},
 		},
@@ -83,7 +83,7 @@ func TestScraper_O(t *testing.T) {
 				ThumbnailURL: &models.ThumbnailURL{},
 				Tools:        &models.Tools{Values: []models.HowToItem{}},
 				TotalTime:    "PT53M",
-				Yield:        &models.Yield{Value: 14},
+				Yield:        &models.Yield{Value: 14, Unit: "balls"},
 				URL:          "https://ohsheglows.com/2017/11/23/bread-free-stuffing-balls/",
 				Video:        &models.Videos{},
 			},
@@ -168,6 +168,15 @@ func TestScraper_O(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 0, Name: "Make the dough"},
+						{Index: 2, Name: "Make the filling"},
+						{Index: 4, Name: "Form the scallion pancakes"},
+						{Index: 8, Name: "Roll out the pancake"},
+						{Index: 10, Name: "Cook the pancakes"},
+						{Index: 12, Name: "Cook frozen pancakes"},
+						{Index: 13, Name: "Store and reheat cooked pancakes"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: "OPTION 1 - USING YOUR HANDS: Combine the flour and salt in a big bowl. Stir to mix well. Slowly drizzle in the hot water while mixing it with a pair of chopsticks (or a fork), until the water is fully absorbed. Slowly drizzle in the cool water, continuing to stir until many dough flakes form. Press the dough together, and try to combine the wet dough with the dry flour. Drizzle in a little extra water if there is any dry flour remaining. Knead until a firm ball is formed, about 5 minutes. Cover and let rest for 20 minutes."},
 						{Type: "HowToStep", Text: "OPTION 2 - USING A MIXER: Combine the flour and salt in the mixer bowl with the dough hook attachment. Turn it to the mix setting and slowly drizzle in the hot water followed by the cool water. After a minute of mixing, drizzling in a little extra water if there is any dry flour remaining. Turn to setting 4 and knead until a ball of dough is formed, about 3 minutes. Cover and let rest for 20 minutes."},
@@ -307,6 +316,11 @@ func TestScraper_O(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 0, Name: "To Make the Korean Barbecue Jackfruit:"},
+						{Index: 3, Name: "To Make the Tahini Slaw:"},
+						{Index: 4, Name: "To Serve:"},
+					},
 					Values: []models.HowToItem{
 						{Text: "Drain and rinse jackfruit well. Blot pieces with a paper towel to remove excess water.", Type: "HowToStep"},
 						{Text: "In small bowl, stir together water, soy sauce, brown sugar, tomato paste, rice vinegar, sriracha and ginger.", Type: "HowToStep"},
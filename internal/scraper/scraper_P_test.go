@@ -66,7 +66,7 @@ func TestScraper_P(t *testing.T) {
 					TransFat:      "1",
 				},
 				PrepTime: "PT15M",
-				Yield:    &models.Yield{Value: 20},
+				Yield:    &models.Yield{Value: 20, Unit: "bars"},
 				URL:      "https://www.paleorunningmomma.com/grain-free-peanut-butter-granola-bars-vegan-paleo-option/",
 			},
 		},
@@ -262,6 +262,11 @@ func TestScraper_P(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 0, Name: "To stale the bread"},
+						{Index: 1, Name: "To prep the casserole"},
+						{Index: 4, Name: "To assemble the casserole"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: `Cube the bread into approximately 1" pieces and spread onto a lipped baking sheet. Bake in a 170°F preheated oven for 30-60 minutes, or until dry. Alternatively, you can cube the bread and let it sit out overnight to stale.`},
 						{Type: "HowToStep", Text: "In a large mixing bowl, whisk together the eggs, milk, vanilla, and 1 teaspoon of the cinnamon."},
@@ -425,7 +430,7 @@ func TestScraper_P(t *testing.T) {
 				},
 				PrepTime:  "PT10M",
 				TotalTime: "PT20M",
-				Yield:     &models.Yield{Value: 12},
+				Yield:     &models.Yield{Value: 12, Unit: "cookies"},
 				URL:       "https://pinchofyum.com/the-best-soft-chocolate-chip-cookies",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -604,7 +609,7 @@ func TestScraper_P(t *testing.T) {
 				},
 				PrepTime:  "PT15M",
 				TotalTime: "PT30M",
-				Yield:     &models.Yield{Value: 12},
+				Yield:     &models.Yield{Value: 12, Unit: "squares"},
 				URL:       "https://www.platingpixels.com/mushroom-tart-recipe/",
 			},
 		},
@@ -767,7 +772,7 @@ func TestScraper_P(t *testing.T) {
 				},
 				PrepTime:  "PT10M",
 				TotalTime: "PT45M",
-				Yield:     &models.Yield{Value: 15},
+				Yield:     &models.Yield{Value: 15, Unit: "pieces"},
 				URL:       "https://plowingthroughlife.com/the-best-rich-and-moist-chocolate-cake/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -283,6 +283,10 @@ func TestScraper_R(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 0, Name: "Creme"},
+						{Index: 1, Name: "Montagem"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: "Em uma panela, misture o Leite MOÇA, o Leite NINHO, as gemas e o amido de milho e leve ao fogo, mexendo sempre até engrossar. Deixe esfriar e reserve."},
 						{Type: "HowToStep", Text: "Passe rapidamente parte dos biscoitos na calda do pêssego, acomodando-os no fundo de um refratário retangular (20 x 30 cm)."},
@@ -384,6 +388,11 @@ func TestScraper_R(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 0, Name: "PREPARE THE CHOPS:"},
+						{Index: 1, Name: "PREPARE THE SALSA:"},
+						{Index: 2, Name: "GRILL THE CHOPS:"},
+					},
 					Values: []models.HowToItem{
 						{Text: "In a shallow bowl, combine the chili powder, brown sugar, garlic powder, onion powder and salt. Sprinkle both sides of the chops with the spice mixture. Let the chops sit at room temperature while you prepare the salsa.", Type: "HowToStep"},
 						{Text: "Prepare a stovetop or outdoor grill to medium-high heat and lightly oil the grate. Grill the pineapple and jalapeño until lightly charred (2 to 3 minutes per side). Remove from the grill and dice the pineapple and jalapeño. In a medium bowl, combine the pineapple, jalapeño and lime juice. Season to taste with salt.", Type: "HowToStep"},
@@ -498,6 +507,9 @@ func TestScraper_R(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 5, Name: "To Serve"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: "Marinade chicken - Combine the marinade ingredients in a large ziplock bag. Add the chicken, seal, the massage from the outside with your hands to make sure each piece is coated. Marinate 24 hours (minimum 3 hours)."},
 						{Type: "HowToStep", Text: "Yogurt Sauce - Combine the Yogurt Sauce ingredients in a bowl and mix. Cover and put in the fridge until required (it will last for 3 days in the fridge)."},
@@ -607,6 +619,12 @@ func TestScraper_R(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 0, Name: "Mix the sauce"},
+						{Index: 1, Name: "Fry the egg"},
+						{Index: 3, Name: "Fry the pork"},
+						{Index: 5, Name: "Fry the vegetables &amp; rice"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: "In a small bowl, mix oyster sauce, light soy sauce, dark soy sauce and white pepper. Set aside."},
 						{Type: "HowToStep", Text: "Heat an empty, well-seasoned wok over high heat until smoking hot. Add 1 tablespoon of oil (see note 3 if using other cookware). Swirl to coat a bigger perimeter."},
@@ -840,7 +858,7 @@ func TestScraper_R(t *testing.T) {
 				ThumbnailURL:    &models.ThumbnailURL{},
 				Tools:           &models.Tools{Values: []models.HowToItem{}},
 				TotalTime:       "PT30M",
-				Yield:           &models.Yield{Value: 1},
+				Yield:           &models.Yield{Value: 550, Unit: "g di Dolcetti mandorle e limone"},
 				URL:             "https://www.ricetteperbimby.it/ricette/dolcetti-mandorle-e-limone-bimby",
 				Video:           &models.Videos{},
 			},
@@ -873,6 +891,9 @@ func TestScraper_R(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 2, Name: "Making the pizzas"},
+					},
 					Values: []models.HowToItem{
 						{Text: "The day before, place the warm water, yeast and sugar in alarge storage tub and stir to mix. Let stand at room temperature until bubbly,about 10 minutes. Using a wooden spoon, stir in the flours and salt, and mixjust until combined. Use your hands to knead just enough to make it smooth,adding a little flour if it is sticking to your hands. Let the dough stand atroom temperature for about an hour to double in size. Cover the tub andrefrigerate overnight.", Type: "HowToStep"},
 						{Text: "Two hours before dinner, take the dough out of therefrigerator. Divide the dough into two pieces, shape each into an oval, placeon a floured counter and let it come to room temperature.", Type: "HowToStep"},
@@ -109,7 +109,7 @@ func TestScraper_S(t *testing.T) {
 				Name:      "Breakfast Pastries with Shortcut Homemade Dough",
 				PrepTime:  "PT6H",
 				TotalTime: "PT6H25M",
-				Yield:     &models.Yield{Value: 16},
+				Yield:     &models.Yield{Value: 16, Unit: "pastries"},
 				URL:       "https://sallysbakingaddiction.com/breakfast-pastries/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -344,6 +344,17 @@ func TestScraper_S(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 0, Name: "to cook the sushi rice"},
+						{Index: 3, Name: "preparations"},
+						{Index: 10, Name: "to make the avocado and mango sushi (2 rolls)"},
+						{Index: 12, Name: "to make the carrot lox and cream cheese sushi (2 rolls)"},
+						{Index: 13, Name: "for the peanut tofu (2 rolls)"},
+						{Index: 16, Name: "for the eggplant bacon sushi (2 rolls)"},
+						{Index: 20, Name: "for the spicy red pepper sushi (2 rolls)"},
+						{Index: 23, Name: "for the beetroot and basil sushi roll"},
+						{Index: 25, Name: "to serve"},
+					},
 					Values: []models.HowToItem{
 						{Text: "Wash the sushi rice with running water for roughly a minutes until the water comes out clean.", Type: "HowToStep"},
 						{Text: "Add sushi rice to a saucepan with roughly 5 cups of water. Bring to a boil, stirring once in a while. Then, lower the heat and put on the lid for 8 minutes.", Type: "HowToStep"},
@@ -429,7 +440,7 @@ func TestScraper_S(t *testing.T) {
 				},
 				Name:      "vegan sushi",
 				TotalTime: "PT90M",
-				Yield:     &models.Yield{Value: 12},
+				Yield:     &models.Yield{Value: 12, Unit: "sushi rolls"},
 				URL:       "https://sarahsveganguide.com/vegan-sushi-guide",
 			},
 		},
@@ -539,7 +550,7 @@ func TestScraper_S(t *testing.T) {
 				},
 				PrepTime:  "PT15M",
 				TotalTime: "PT75M",
-				Yield:     &models.Yield{Value: 12},
+				Yield:     &models.Yield{Value: 12, Unit: "slices"},
 				URL:       "https://www.savorynothings.com/whole-wheat-cinnamon-crunch-banana-bread/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -1927,7 +1938,7 @@ func TestScraper_S(t *testing.T) {
 				},
 				PrepTime:  "PT5M",
 				TotalTime: "PT50M",
-				Yield:     &models.Yield{Value: 2},
+				Yield:     &models.Yield{Value: 2, Unit: "cups"},
 				URL:       "https://sweetcsdesigns.com/roasted-tomato-marinara-sauce/",
 			},
 		},
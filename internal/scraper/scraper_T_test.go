@@ -719,7 +719,7 @@ func TestScraper_T(t *testing.T) {
 				Name:      "Homemade Sourdough Breadcrumbs",
 				PrepTime:  "PT10M",
 				TotalTime: "PT35M",
-				Yield:     &models.Yield{Value: 4},
+				Yield:     &models.Yield{Value: 4, Unit: "cups"},
 				URL:       "https://www.theclevercarrot.com/2021/10/homemade-sourdough-breadcrumbs/",
 			},
 		},
@@ -1537,7 +1537,7 @@ func TestScraper_T(t *testing.T) {
 				Name:      "cinnamon toast crunch cookies",
 				PrepTime:  "PT30M",
 				TotalTime: "PT45M",
-				Yield:     &models.Yield{Value: 8},
+				Yield:     &models.Yield{Value: 8, Unit: "large cookies"},
 				URL:       "https://www.thepalatablelife.com/cinnamon-toast-crunch-cookies-2",
 			},
 		},
@@ -1651,7 +1651,7 @@ func TestScraper_T(t *testing.T) {
 				},
 				PrepTime:  "PT15M",
 				TotalTime: "PT20M",
-				Yield:     &models.Yield{Value: 8},
+				Yield:     &models.Yield{Value: 8, Unit: "eggrolls"},
 				URL:       "https://therecipecritic.com/avocado-egg-rolls/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -1716,7 +1716,7 @@ func TestScraper_T(t *testing.T) {
 				},
 				PrepTime:  "PT15M",
 				TotalTime: "PT65M",
-				Yield:     &models.Yield{Value: 1},
+				Yield:     &models.Yield{Value: 1, Unit: "Loaf"},
 				URL:       "https://thesaltymarshmallow.com/best-banana-bread-recipe/",
 				Video: &models.Videos{
 					Values: []models.VideoObject{
@@ -1845,7 +1845,7 @@ func TestScraper_T(t *testing.T) {
 				ThumbnailURL:    &models.ThumbnailURL{},
 				Tools:           &models.Tools{Values: []models.HowToItem{}},
 				TotalTime:       "PT10M",
-				Yield:           &models.Yield{Value: 2},
+				Yield:           &models.Yield{Value: 2, Unit: "sandwiches"},
 				URL:             "https://www.thevintagemixer.com/roasted-asparagus-grilled-cheese/",
 				Video:           &models.Videos{},
 			},
@@ -2117,6 +2117,11 @@ func TestScraper_T(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 0, Name: "Oppskrift"},
+						{Index: 1, Name: "Tikka masala:"},
+						{Index: 4, Name: "Raita:"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: "Kok ris etter anvisningen på pakken."},
 						{Type: "HowToStep", Text: "Del kylling i biter. Brun kyllingen i smør i en stekepanne på middels varme."},
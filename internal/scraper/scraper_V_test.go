@@ -112,6 +112,12 @@ func TestScraper_V(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 0, Name: "For the Cake/Bread"},
+						{Index: 7, Name: "For the Icing"},
+						{Index: 10, Name: "Ice the Bread/Cake"},
+						{Index: 11, Name: "For Storing"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: "Arrange an oven rack in the center of the oven. Preheat the oven to 350F (180C). Place the nuts on a small sheet pan and put them in the oven while the oven is preheating. Toast the nuts for about 15 minutes, or until the nuts are fragrant and starting to turn slightly darker. Chop fine when cool. Set aside."},
 						{Type: "HowToStep", Text: "Grease a 9 inch by 5 inch (23 centimeters by 13 centimeters) loaf pan thoroughly on the all sides and bottom. Be sure to get the corners good! Line the pan with parchment paper, just one strip across the bottom and sides will act as a handle to remove the bread once baked. Clip the sides of the parchment to the pan if desired."},
@@ -145,7 +151,7 @@ func TestScraper_V(t *testing.T) {
 				},
 				PrepTime:  "PT30M",
 				TotalTime: "PT90M",
-				Yield:     &models.Yield{Value: 10},
+				Yield:     &models.Yield{Value: 10, Unit: "Slices (1 loaf)"},
 				URL:       "https://vanillaandbean.com/carrot-cake-bread/",
 			},
 		},
@@ -261,7 +267,7 @@ func TestScraper_V(t *testing.T) {
 				ThumbnailURL:    &models.ThumbnailURL{},
 				Tools:           &models.Tools{Values: []models.HowToItem{}},
 				TotalTime:       "PT45M",
-				Yield:           &models.Yield{Value: 16},
+				Yield:           &models.Yield{Value: 16, Unit: "stk"},
 				URL:             "https://www.vegetarbloggen.no/2023/07/15/peanottkake/",
 				Video:           &models.Videos{},
 			},
@@ -298,6 +304,9 @@ func TestScraper_V(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 1, Name: "Cuociamo i pancake"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: "Mescolate la farina di semi di lino con 6 cucchiai di acqua e lasciate riposare 10 minuti fino a che si sarà formato un composto gelatinoso. In una ciotolina versate il latte di soia e l'aceto di mele e lasciate cagliare per 5 minuti. Riunite in una ciotola la farina di quinoa, la farina di cocco, l'amido di mais, lo zucchero di canna, il lievito e il bicarbonato e mescolate. Aggiungete agli ingredienti secchi il latte di soia, il composto di semi di lino e l'olio e amalgamate bene il tutto fino ad ottenere un composto omogeneo e abbastanza denso."},
 						{Type: "HowToStep", Text: "Scaldate una padella antiaderente e ungetela leggermente con un pezzo di carta assorbente imbevuto di olio di semi. Versate 1-2 cucchiaiate di impasto per ciascun pancake e lasciate cuocere a fiamma medio-bassa per 3-4 minuti per lato. Man mano che i vostri pancake saranno pronti disponeteli su un piatto, e completate poi ciascuna porzione con sciroppo d'acero a piacere, fragole fresche e cocco in scaglie."},
@@ -356,6 +365,12 @@ func TestScraper_V(t *testing.T) {
 					},
 				},
 				Instructions: &models.Instructions{
+					Sections: models.Sections{
+						{Index: 0, Name: "Preparation"},
+						{Index: 5, Name: "Making tomato gravy"},
+						{Index: 11, Name: "Making paneer butter masala"},
+						{Index: 20, Name: "Serving Suggestions"},
+					},
 					Values: []models.HowToItem{
 						{Type: "HowToStep", Text: "Soak cashews in a hot water for 20 to 30 minutes. When the cashews are soaking, you can prep the other ingredients like chopping tomatoes, preparing ginger-garlic paste, slicing paneer etc."},
 						{Type: "HowToStep", Text: "Then drain and add the soaked cashews in a blender or mixer-grinder."},
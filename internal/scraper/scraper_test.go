@@ -95,6 +95,74 @@ func testFile(t *testing.T, name, url string) models.RecipeSchema {
 	return got
 }
 
+func TestScraper_Trace(t *testing.T) {
+	s := scraper.NewScraper(&mockHTTPClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+		t.Fatalf("unexpected request to %s", req.URL)
+		return nil, nil
+	}})
+
+	openHTML := func(t *testing.T, name string) io.Reader {
+		t.Helper()
+		_, fileName, _, _ := runtime.Caller(0)
+		xb, err := os.ReadFile(filepath.Join(path.Dir(fileName), "testdata", name+".html"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return bytes.NewReader(xb)
+	}
+
+	testcases := []struct {
+		name     string
+		file     string
+		url      string
+		wantPath string
+		wantName string
+	}{
+		{
+			name:     "generic fallback",
+			file:     "101cookbooks",
+			url:      "https://www.101cookbooks.com/simple-bruschetta/",
+			wantPath: scraper.PathGeneric,
+			wantName: "Simple Bruschetta",
+		},
+		{
+			name:     "website-specific function",
+			file:     "15gram",
+			url:      "https://15gram.be/recepten/mac-n-cheese-met-gehakt-en-pompoen",
+			wantPath: scraper.PathWebsite,
+			wantName: "Mac 'n cheese met gehakt en pompoen",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := s.Trace(tc.url, openHTML(t, tc.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got.Path != tc.wantPath {
+				t.Fatalf("got path %q but want %q", got.Path, tc.wantPath)
+			}
+			if got.Schema.Name != tc.wantName {
+				t.Fatalf("got name %q but want %q", got.Schema.Name, tc.wantName)
+			}
+			if got.Schema.URL != tc.url {
+				t.Fatalf("got URL %q but want %q", got.Schema.URL, tc.url)
+			}
+		})
+	}
+
+	t.Run("special websites cannot be traced offline", func(t *testing.T) {
+		got, err := s.Trace("https://www.gousto.co.uk/cookbook/recipes/a-recipe", strings.NewReader("<html></html>"))
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if got.Path != scraper.PathSpecial {
+			t.Fatalf("got path %q but want %q", got.Path, scraper.PathSpecial)
+		}
+	})
+}
+
 func updateHTMLFile(t *testing.T, name, url string) {
 	t.Helper()
 
@@ -164,6 +232,10 @@ func (m *mockFiles) MergeImagesToPDF(_ []io.Reader) io.ReadWriter {
 	panic("implement me")
 }
 
+func (m *mockFiles) RestoreGlobalBackup(_ string) error {
+	return nil
+}
+
 func (m *mockFiles) ScrapeAndStoreImage(_ string) (uuid.UUID, error) {
 	return anUploadedImage, nil
 }
@@ -172,12 +244,12 @@ func (m *mockFiles) ExtractUserBackup(_ string, _ int64) (*models.UserBackup, er
 	return nil, nil
 }
 
-func (m *mockFiles) ExportCookbook(cookbook models.Cookbook, fileType models.FileType) (string, error) {
+func (m *mockFiles) ExportCookbook(cookbook models.Cookbook, _ map[int64]models.RecipeNotes, fileType models.FileType) (string, error) {
 	m.exportHitCount++
 	return cookbook.Title + fileType.Ext(), nil
 }
 
-func (m *mockFiles) ExportRecipes(recipes models.Recipes, cookbooks []models.Cookbook, _ models.FileType, _ chan int) (*bytes.Buffer, error) {
+func (m *mockFiles) ExportRecipes(recipes models.Recipes, cookbooks []models.Cookbook, _ map[int64]models.RecipeNotes, _ models.FileType, _ chan int) (*bytes.Buffer, error) {
 	var sb strings.Builder
 	for _, recipe := range recipes {
 		sb.WriteString(recipe.Name + "-")
@@ -186,6 +258,11 @@ func (m *mockFiles) ExportRecipes(recipes models.Recipes, cookbooks []models.Coo
 	return bytes.NewBufferString(sb.String()), nil
 }
 
+func (m *mockFiles) ExportTimeline(_ models.Timeline) (string, error) {
+	m.exportHitCount++
+	return "Batch_cooking.pdf", nil
+}
+
 func (m *mockFiles) ExtractRecipes(fileHeaders []*multipart.FileHeader) models.Recipes {
 	if m.extractRecipesFunc != nil {
 		return m.extractRecipesFunc(fileHeaders)
@@ -215,3 +292,7 @@ func (m *mockFiles) UploadImage(rc io.ReadCloser) (uuid.UUID, error) {
 func (m *mockFiles) UploadVideo(_ io.ReadCloser, _ services.RepositoryService) (uuid.UUID, error) {
 	return uuid.New(), nil
 }
+
+func (m *mockFiles) VerifyBackup(_ string, _ int64) (models.BackupVerification, error) {
+	return models.BackupVerification{}, nil
+}
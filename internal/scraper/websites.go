@@ -12,6 +12,11 @@ import (
 var ErrNotImplemented = errors.New("domain is not implemented")
 
 func scrapeWebsite(doc *goquery.Document, host string) (models.RecipeSchema, error) {
+	return scrapeHost(doc, host, parseWebsite)
+}
+
+// scrapeHost extracts the recipe with the host's scraper. The generic function is used for hosts without one.
+func scrapeHost(doc *goquery.Document, host string, generic func(*goquery.Document) (models.RecipeSchema, error)) (models.RecipeSchema, error) {
 	switch []rune(host)[0] {
 	case 'a':
 		switch host {
@@ -36,7 +41,7 @@ func scrapeWebsite(doc *goquery.Document, host string) (models.RecipeSchema, err
 		case "archanaskitchen":
 			return scrapeArchanasKitchen(doc)
 		default:
-			return parseWebsite(doc)
+			return generic(doc)
 		}
 	case 'b':
 		switch host {
@@ -67,7 +72,7 @@ func scrapeWebsite(doc *goquery.Document, host string) (models.RecipeSchema, err
 		case "bongeats":
 			return scrapeBongeats(doc)
 		default:
-			return parseWebsite(doc)
+			return generic(doc)
 		}
 	case 'c':
 		switch host {
@@ -94,7 +99,7 @@ func scrapeWebsite(doc *goquery.Document, host string) (models.RecipeSchema, err
 		case "culy":
 			return scrapeCuly(doc)
 		default:
-			return parseWebsite(doc)
+			return generic(doc)
 		}
 	case 'd':
 		switch host {
@@ -109,7 +114,7 @@ func scrapeWebsite(doc *goquery.Document, host string) (models.RecipeSchema, err
 		case "drinkoteket":
 			return scrapeDrinkoteket(doc)
 		default:
-			return parseWebsite(doc)
+			return generic(doc)
 		}
 	case 'e':
 		switch host {
@@ -122,7 +127,7 @@ func scrapeWebsite(doc *goquery.Document, host string) (models.RecipeSchema, err
 		case "expressen":
 			return scrapeEspressen(doc)
 		default:
-			return parseWebsite(doc)
+			return generic(doc)
 		}
 	case 'f':
 		switch host {
@@ -143,9 +148,9 @@ func scrapeWebsite(doc *goquery.Document, host string) (models.RecipeSchema, err
 		case "francescakookt":
 			return scrapeFrancescakookt(doc)
 		case "franzoesischkochen":
-			return parseWebsite(doc)
+			return generic(doc)
 		default:
-			return parseWebsite(doc)
+			return generic(doc)
 		}
 	case 'g':
 		switch host {
@@ -172,7 +177,7 @@ func scrapeWebsite(doc *goquery.Document, host string) (models.RecipeSchema, err
 		case "gutekueche":
 			return scrapeGutekueche(doc)
 		default:
-			return parseWebsite(doc)
+			return generic(doc)
 		}
 	case 'h':
 		switch host {
@@ -185,14 +190,14 @@ func scrapeWebsite(doc *goquery.Document, host string) (models.RecipeSchema, err
 		case "homechef":
 			return scrapeHomechef(doc)
 		default:
-			return parseWebsite(doc)
+			return generic(doc)
 		}
 	case 'i':
 		switch host {
 		case "instantpot":
 			return scrapeInstantPot(doc)
 		default:
-			return parseWebsite(doc)
+			return generic(doc)
 		}
 	case 'j':
 		switch host {
@@ -205,7 +210,7 @@ func scrapeWebsite(doc *goquery.Document, host string) (models.RecipeSchema, err
 		case "justbento":
 			return scrapeJustbento(doc)
 		default:
-			return parseWebsite(doc)
+			return generic(doc)
 		}
 	case 'k':
 		switch host {
@@ -230,7 +235,7 @@ func scrapeWebsite(doc *goquery.Document, host string) (models.RecipeSchema, err
 		case "kwestiasmaku":
 			return scrapeKwestiasmaku(doc)
 		default:
-			return parseWebsite(doc)
+			return generic(doc)
 		}
 	case 'l':
 		switch host {
@@ -251,7 +256,7 @@ func scrapeWebsite(doc *goquery.Document, host string) (models.RecipeSchema, err
 		case "loveandlemons":
 			return scrapeLoveAndLemons(doc)
 		default:
-			return parseWebsite(doc)
+			return generic(doc)
 		}
 	case 'm':
 		switch host {
@@ -270,7 +275,7 @@ func scrapeWebsite(doc *goquery.Document, host string) (models.RecipeSchema, err
 		case "myplate":
 			return scrapeMyPlate(doc)
 		default:
-			return parseWebsite(doc)
+			return generic(doc)
 		}
 	case 'n':
 		switch host {
@@ -279,7 +284,7 @@ func scrapeWebsite(doc *goquery.Document, host string) (models.RecipeSchema, err
 		case "ninjatestkitchen":
 			return scrapeNinjatestkitchen(doc)
 		default:
-			return parseWebsite(doc)
+			return generic(doc)
 		}
 	case 'o':
 		switch host {
@@ -288,7 +293,7 @@ func scrapeWebsite(doc *goquery.Document, host string) (models.RecipeSchema, err
 		case "owen-han":
 			return scrapeOwenhan(doc)
 		default:
-			return parseWebsite(doc)
+			return generic(doc)
 		}
 	case 'p':
 		switch host {
@@ -317,14 +322,14 @@ func scrapeWebsite(doc *goquery.Document, host string) (models.RecipeSchema, err
 		case "puurgezond":
 			return scrapePuurgezond(doc)
 		default:
-			return parseWebsite(doc)
+			return generic(doc)
 		}
 	case 'q':
 		switch host {
 		case "quitoque":
 			return scrapeQuitoque(doc)
 		default:
-			return parseWebsite(doc)
+			return generic(doc)
 		}
 	case 'r':
 		switch host {
@@ -345,7 +350,7 @@ func scrapeWebsite(doc *goquery.Document, host string) (models.RecipeSchema, err
 		case "rosannapansino":
 			return scrapeRosannapansino(doc)
 		default:
-			return parseWebsite(doc)
+			return generic(doc)
 		}
 	case 's':
 		switch host {
@@ -366,7 +371,7 @@ func scrapeWebsite(doc *goquery.Document, host string) (models.RecipeSchema, err
 		case "sunset":
 			return scrapeSunset(doc)
 		default:
-			return parseWebsite(doc)
+			return generic(doc)
 		}
 	case 't':
 		switch host {
@@ -387,7 +392,7 @@ func scrapeWebsite(doc *goquery.Document, host string) (models.RecipeSchema, err
 		case "theheartysoul":
 			return scrapeTheHeartySoul(doc)
 		default:
-			return parseWebsite(doc)
+			return generic(doc)
 		}
 	case 'u':
 		switch host {
@@ -396,7 +401,7 @@ func scrapeWebsite(doc *goquery.Document, host string) (models.RecipeSchema, err
 		case "usapears":
 			return scrapeUsapears(doc)
 		default:
-			return parseWebsite(doc)
+			return generic(doc)
 		}
 	case 'v':
 		switch host {
@@ -405,7 +410,7 @@ func scrapeWebsite(doc *goquery.Document, host string) (models.RecipeSchema, err
 		case "vegan-pratique":
 			return scrapeVeganPratique(doc)
 		default:
-			return parseWebsite(doc)
+			return generic(doc)
 		}
 	case 'w':
 		switch host {
@@ -418,7 +423,7 @@ func scrapeWebsite(doc *goquery.Document, host string) (models.RecipeSchema, err
 		case "woop":
 			return scrapeWoop(doc)
 		default:
-			return parseWebsite(doc)
+			return generic(doc)
 		}
 	case 'y':
 		switch host {
@@ -432,14 +437,14 @@ func scrapeWebsite(doc *goquery.Document, host string) (models.RecipeSchema, err
 		case "zeit":
 			return scrapeZeit(doc)
 		default:
-			return parseWebsite(doc)
+			return generic(doc)
 		}
 	default:
 		switch host {
 		case "15gram":
 			return scrape15gram(doc)
 		}
-		return parseWebsite(doc)
+		return generic(doc)
 	}
 	return models.RecipeSchema{}, ErrNotImplemented
 }
@@ -0,0 +1,715 @@
+package server
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/services"
+)
+
+const apiPrefix = "/api/v1"
+
+var (
+	errAPIInvalidJSON   = errors.New("invalid JSON body")
+	errAPIInvalidRecipe = errors.New("invalid recipe")
+	errAPIRecipeName    = errors.New("missing the name of the recipe")
+)
+
+type apiError struct {
+	Error apiErrorBody `json:"error"`
+}
+
+type apiErrorBody struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+type apiRecipe struct {
+	ID int64 `json:"id"`
+	models.RecipeSchema
+}
+
+func newAPIRecipe(r *models.Recipe) apiRecipe {
+	return apiRecipe{ID: r.ID, RecipeSchema: r.Schema()}
+}
+
+// recipeETag is the entity tag of the recipe's version, which clients send
+// back in the If-Match header to avoid overwriting changes made elsewhere.
+func recipeETag(r *models.Recipe) string {
+	return `"` + r.UpdatedAt.UTC().Format(time.RFC3339Nano) + `"`
+}
+
+type apiRecipes struct {
+	Page    uint64      `json:"page"`
+	Recipes []apiRecipe `json:"recipes"`
+	Total   uint64      `json:"total"`
+}
+
+func newAPIRecipes(recipes models.Recipes, page, total uint64) apiRecipes {
+	xr := make([]apiRecipe, 0, len(recipes))
+	for _, r := range recipes {
+		xr = append(xr, newAPIRecipe(&r))
+	}
+	return apiRecipes{Page: page, Recipes: xr, Total: total}
+}
+
+type apiCookbook struct {
+	ID      int64       `json:"id"`
+	Count   int64       `json:"count"`
+	Image   string      `json:"image,omitempty"`
+	Recipes []apiRecipe `json:"recipes,omitempty"`
+	Title   string      `json:"title"`
+}
+
+func newAPICookbook(c models.Cookbook) apiCookbook {
+	cookbook := apiCookbook{
+		ID:    c.ID,
+		Count: c.Count,
+		Title: c.Title,
+	}
+
+	if c.Image != uuid.Nil {
+		cookbook.Image = c.Image.String()
+	}
+
+	if len(c.Recipes) > 0 {
+		cookbook.Recipes = newAPIRecipes(c.Recipes, 1, uint64(len(c.Recipes))).Recipes
+	}
+
+	return cookbook
+}
+
+type apiLink struct {
+	Link string `json:"link"`
+}
+
+type apiID struct {
+	ID int64 `json:"id"`
+}
+
+// apiTimer is a duration detected in an instruction. The step is the 1-based number of the instruction.
+type apiTimer struct {
+	Step       int    `json:"step"`
+	Seconds    int64  `json:"seconds"`
+	SecondsMax int64  `json:"secondsMax"`
+	Text       string `json:"text"`
+}
+
+func newAPITimers(timers []models.Timer) []apiTimer {
+	xt := make([]apiTimer, 0, len(timers))
+	for _, t := range timers {
+		xt = append(xt, apiTimer{
+			Step:       t.Step + 1,
+			Seconds:    int64(t.Duration.Seconds()),
+			SecondsMax: int64(t.DurationMax.Seconds()),
+			Text:       t.Text,
+		})
+	}
+	return xt
+}
+
+func isAPIRequest(r *http.Request) bool {
+	return strings.HasPrefix(r.URL.Path, apiPrefix+"/")
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if v != nil {
+		_ = json.NewEncoder(w).Encode(v)
+	}
+}
+
+func writeJSONError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, apiError{Error: apiErrorBody{Status: status, Message: msg}})
+}
+
+// writeJSONDecodeError writes the error returned by decodeJSON or decodeRecipeSchema.
+func writeJSONDecodeError(w http.ResponseWriter, err error) {
+	msg := "Invalid JSON body."
+	switch {
+	case errors.Is(err, errAPIRecipeName):
+		msg = "The recipe's name is required."
+	case errors.Is(err, errAPIInvalidRecipe):
+		msg = "Invalid recipe."
+	}
+	writeJSONError(w, http.StatusBadRequest, msg)
+}
+
+// writeJSONRecipeError writes the error returned when fetching a recipe.
+func writeJSONRecipeError(w http.ResponseWriter, err error, recipeID, userID int64) {
+	if errors.Is(err, sql.ErrNoRows) {
+		writeJSONError(w, http.StatusNotFound, "Recipe not found.")
+		return
+	}
+
+	slog.Error("Failed to fetch recipe", slog.Int64("userID", userID), "recipeID", recipeID, "error", err)
+	writeJSONError(w, http.StatusInternalServerError, "Failed to fetch recipe.")
+}
+
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) error {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, 8<<20))
+	err := dec.Decode(v)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errAPIInvalidJSON, err)
+	}
+	return nil
+}
+
+func decodeRecipeSchema(w http.ResponseWriter, r *http.Request) (*models.Recipe, error) {
+	var rs models.RecipeSchema
+	err := decodeJSON(w, r, &rs)
+	if err != nil {
+		return nil, err
+	}
+
+	if rs.AtType == nil {
+		rs.AtType = &models.SchemaType{Value: "Recipe"}
+	}
+
+	if strings.TrimSpace(rs.Name) == "" {
+		return nil, errAPIRecipeName
+	}
+
+	recipe, err := rs.Recipe()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errAPIInvalidRecipe, err)
+	}
+
+	if recipe.Category == "" {
+		recipe.Category = "uncategorized"
+	}
+
+	if recipe.Yield == 0 {
+		recipe.Yield = 1
+	}
+
+	return recipe, nil
+}
+
+func (s *Server) apiCategoriesHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		categories, err := s.Repository.Categories(userID)
+		if err != nil {
+			slog.Error("Failed to fetch categories", slog.Int64("userID", userID), "error", err)
+			writeJSONError(w, http.StatusInternalServerError, "Failed to fetch categories.")
+			return
+		}
+
+		if categories == nil {
+			categories = make([]string, 0)
+		}
+
+		writeJSON(w, http.StatusOK, categories)
+	}
+}
+
+func (s *Server) apiCategoriesPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		var req struct {
+			Name string `json:"name"`
+		}
+		err := decodeJSON(w, r, &req)
+		if err != nil {
+			writeJSONDecodeError(w, err)
+			return
+		}
+
+		req.Name = strings.TrimSpace(req.Name)
+		if req.Name == "" {
+			writeJSONError(w, http.StatusBadRequest, "The category's name is required.")
+			return
+		}
+
+		err = s.Repository.AddRecipeCategory(req.Name, userID)
+		if errors.Is(err, services.ErrCategoryExists) {
+			writeJSONError(w, http.StatusConflict, "The category already exists.")
+			return
+		} else if err != nil {
+			slog.Error("Failed to add category", slog.Int64("userID", userID), "category", req.Name, "error", err)
+			writeJSONError(w, http.StatusInternalServerError, "Failed to add category.")
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, req)
+	}
+}
+
+func (s *Server) apiCategoriesDeleteHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		name := r.PathValue("name")
+
+		err := s.Repository.DeleteRecipeCategory(name, userID)
+		if errors.Is(err, services.ErrCategoryInvalid) {
+			writeJSONError(w, http.StatusBadRequest, "The category cannot be deleted.")
+			return
+		} else if errors.Is(err, sql.ErrNoRows) {
+			writeJSONError(w, http.StatusNotFound, "Category not found.")
+			return
+		} else if err != nil {
+			slog.Error("Failed to delete category", slog.Int64("userID", userID), "category", name, "error", err)
+			writeJSONError(w, http.StatusInternalServerError, "Failed to delete category.")
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) apiCookbooksHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		page, err := strconv.ParseUint(r.URL.Query().Get("page"), 10, 64)
+		if err != nil || page == 0 {
+			page = 1
+		}
+
+		cookbooks, err := s.Repository.Cookbooks(userID, page)
+		if err != nil {
+			slog.Error("Failed to fetch cookbooks", slog.Int64("userID", userID), "error", err)
+			writeJSONError(w, http.StatusInternalServerError, "Failed to fetch cookbooks.")
+			return
+		}
+
+		xc := make([]apiCookbook, 0, len(cookbooks))
+		for _, c := range cookbooks {
+			xc = append(xc, newAPICookbook(c))
+		}
+
+		writeJSON(w, http.StatusOK, xc)
+	}
+}
+
+func (s *Server) apiCookbooksPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		var req struct {
+			Title string `json:"title"`
+		}
+		err := decodeJSON(w, r, &req)
+		if err != nil {
+			writeJSONDecodeError(w, err)
+			return
+		}
+
+		req.Title = strings.TrimSpace(req.Title)
+		if req.Title == "" {
+			writeJSONError(w, http.StatusBadRequest, "The cookbook's title is required.")
+			return
+		}
+
+		id, err := s.Repository.AddCookbook(req.Title, userID)
+		if errors.Is(err, services.ErrCookbookExists) {
+			writeJSONError(w, http.StatusConflict, "The cookbook already exists.")
+			return
+		} else if err != nil {
+			slog.Error("Failed to create cookbook", slog.Int64("userID", userID), "title", req.Title, "error", err)
+			writeJSONError(w, http.StatusInternalServerError, "Failed to create cookbook.")
+			return
+		}
+
+		s.emitCookbookChanged(userID, id, "created")
+		w.Header().Set("Location", apiPrefix+"/cookbooks/"+strconv.FormatInt(id, 10))
+		writeJSON(w, http.StatusCreated, apiCookbook{ID: id, Title: req.Title})
+	}
+}
+
+func (s *Server) apiCookbookHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "Invalid cookbook ID.")
+			return
+		}
+
+		cookbook, err := s.Repository.Cookbook(id, userID)
+		if errors.Is(err, sql.ErrNoRows) {
+			writeJSONError(w, http.StatusNotFound, "Cookbook not found.")
+			return
+		} else if err != nil {
+			slog.Error("Failed to fetch cookbook", slog.Int64("userID", userID), "cookbookID", id, "error", err)
+			writeJSONError(w, http.StatusInternalServerError, "Failed to fetch cookbook.")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, newAPICookbook(cookbook))
+	}
+}
+
+func (s *Server) apiCookbookDeleteHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "Invalid cookbook ID.")
+			return
+		}
+
+		err = s.Repository.DeleteCookbook(id, userID)
+		if err != nil {
+			slog.Error("Failed to delete cookbook", slog.Int64("userID", userID), "cookbookID", id, "error", err)
+			writeJSONError(w, http.StatusInternalServerError, "Failed to delete cookbook.")
+			return
+		}
+		s.emitCookbookChanged(userID, id, "deleted")
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) apiCookbookRecipesPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "Invalid cookbook ID.")
+			return
+		}
+
+		var req struct {
+			RecipeID int64 `json:"recipeID"`
+		}
+		err = decodeJSON(w, r, &req)
+		if err != nil {
+			writeJSONDecodeError(w, err)
+			return
+		}
+
+		if req.RecipeID <= 0 {
+			writeJSONError(w, http.StatusBadRequest, "Invalid recipe ID.")
+			return
+		}
+
+		err = s.Repository.AddCookbookRecipe(id, req.RecipeID, userID)
+		if errors.Is(err, sql.ErrNoRows) {
+			writeJSONError(w, http.StatusNotFound, "Recipe or cookbook not found.")
+			return
+		} else if errors.Is(err, services.ErrCookbookRecipeExists) {
+			writeJSONError(w, http.StatusConflict, "The recipe is already in the cookbook.")
+			return
+		} else if err != nil {
+			slog.Error("Failed to add recipe to cookbook", slog.Int64("userID", userID), "cookbookID", id, "recipeID", req.RecipeID, "error", err)
+			writeJSONError(w, http.StatusInternalServerError, "Failed to add recipe to cookbook.")
+			return
+		}
+		s.emitCookbookChanged(userID, id, "recipe_added")
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) apiCookbookRecipeDeleteHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "Invalid cookbook ID.")
+			return
+		}
+
+		recipeID, err := parsePathPositiveID(r.PathValue("recipeID"))
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "Invalid recipe ID.")
+			return
+		}
+
+		numRecipes, err := s.Repository.DeleteRecipeFromCookbook(recipeID, id, userID)
+		if err != nil {
+			slog.Error("Failed to remove recipe from cookbook", slog.Int64("userID", userID), "cookbookID", id, "recipeID", recipeID, "error", err)
+			writeJSONError(w, http.StatusInternalServerError, "Failed to remove recipe from cookbook.")
+			return
+		} else if numRecipes == -1 {
+			writeJSONError(w, http.StatusNotFound, "Cookbook not found.")
+			return
+		}
+		s.emitCookbookChanged(userID, id, "recipe_removed")
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) apiCookbookSharePostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "Invalid cookbook ID.")
+			return
+		}
+
+		link, err := s.Repository.AddShareLink(models.Share{CookbookID: id, RecipeID: -1, UserID: userID})
+		if err != nil {
+			slog.Error("Failed to create share link", slog.Int64("userID", userID), "cookbookID", id, "error", err)
+			writeJSONError(w, http.StatusNotFound, "Cookbook not found.")
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, apiLink{Link: absoluteURL(r, link)})
+	}
+}
+
+func (s *Server) apiRecipesHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		opts := models.NewSearchOptionsRecipe(r.URL.Query())
+
+		counts, err := s.Repository.Counts(userID)
+		if err != nil {
+			slog.Error("Failed to fetch counts", slog.Int64("userID", userID), "error", err)
+			writeJSONError(w, http.StatusInternalServerError, "Failed to fetch recipes.")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, newAPIRecipes(s.Repository.Recipes(userID, opts), opts.Page, counts.Recipes))
+	}
+}
+
+func (s *Server) apiRecipesPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		recipe, err := decodeRecipeSchema(w, r)
+		if err != nil {
+			writeJSONDecodeError(w, err)
+			return
+		}
+
+		ids, _, err := s.Repository.AddRecipes(models.Recipes{*recipe}, userID, nil)
+		if err != nil {
+			slog.Error("Failed to add recipe", slog.Int64("userID", userID), "recipe", recipe, "error", err)
+			writeJSONError(w, http.StatusInternalServerError, "Failed to add recipe.")
+			return
+		} else if len(ids) == 0 {
+			writeJSONError(w, http.StatusConflict, "The recipe could not be added. It may already exist.")
+			return
+		}
+
+		s.emitWebhookEvent(models.WebhookEventRecipeCreated, userID, map[string]any{"recipeIDs": ids})
+		w.Header().Set("Location", apiPrefix+"/recipes/"+strconv.FormatInt(ids[0], 10))
+		writeJSON(w, http.StatusCreated, apiID{ID: ids[0]})
+	}
+}
+
+func (s *Server) apiRecipeHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "Invalid recipe ID.")
+			return
+		}
+
+		recipe, err := s.Repository.Recipe(id, userID)
+		if err != nil {
+			writeJSONRecipeError(w, err, id, userID)
+			return
+		}
+
+		w.Header().Set("ETag", recipeETag(recipe))
+		writeJSON(w, http.StatusOK, newAPIRecipe(recipe))
+	}
+}
+
+func (s *Server) apiRecipePutHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "Invalid recipe ID.")
+			return
+		}
+
+		_, err = s.Repository.Recipe(id, userID)
+		if err != nil {
+			writeJSONRecipeError(w, err, id, userID)
+			return
+		}
+
+		recipe, err := decodeRecipeSchema(w, r)
+		if err != nil {
+			writeJSONDecodeError(w, err)
+			return
+		}
+
+		recipe.UpdatedAt = time.Time{}
+		if v := r.Header.Get("If-Match"); v != "" {
+			recipe.UpdatedAt, err = time.Parse(time.RFC3339Nano, strings.Trim(strings.TrimPrefix(v, "W/"), `"`))
+			if err != nil {
+				writeJSONError(w, http.StatusBadRequest, "Invalid If-Match header.")
+				return
+			}
+		}
+
+		err = s.Repository.UpdateRecipe(recipe, userID, id)
+		if errors.Is(err, models.ErrConflict) {
+			writeJSONError(w, http.StatusPreconditionFailed, "Recipe was modified since it was loaded.")
+			return
+		} else if err != nil {
+			slog.Error("Failed to update recipe", slog.Int64("userID", userID), "recipeID", id, "error", err)
+			writeJSONError(w, http.StatusInternalServerError, "Failed to update recipe.")
+			return
+		}
+		s.emitWebhookEvent(models.WebhookEventRecipeUpdated, userID, map[string]any{"recipeID": id})
+		s.Brokers.SendRecipeUpdated(id, userID)
+
+		updated, err := s.Repository.Recipe(id, userID)
+		if err != nil {
+			slog.Error("Failed to fetch updated recipe", slog.Int64("userID", userID), "recipeID", id, "error", err)
+			writeJSONError(w, http.StatusInternalServerError, "The recipe was updated but could not be fetched.")
+			return
+		}
+
+		w.Header().Set("ETag", recipeETag(updated))
+		writeJSON(w, http.StatusOK, newAPIRecipe(updated))
+	}
+}
+
+func (s *Server) apiRecipeDeleteHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "Invalid recipe ID.")
+			return
+		}
+
+		err = s.Repository.DeleteRecipe(id, userID)
+		if errors.Is(err, sql.ErrNoRows) {
+			writeJSONError(w, http.StatusNotFound, "Recipe not found.")
+			return
+		} else if err != nil {
+			slog.Error("Failed to delete recipe", slog.Int64("userID", userID), "recipeID", id, "error", err)
+			writeJSONError(w, http.StatusInternalServerError, "Failed to delete recipe.")
+			return
+		}
+		s.emitWebhookEvent(models.WebhookEventRecipeDeleted, userID, map[string]any{"recipeID": id})
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) apiRecipeScaleHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "Invalid recipe ID.")
+			return
+		}
+
+		target := r.URL.Query().Get("yield")
+		if models.NewYield(target).Value <= 0 {
+			writeJSONError(w, http.StatusBadRequest, "Yield must be greater than zero.")
+			return
+		}
+
+		recipe, err := s.Repository.Recipe(id, userID)
+		if err != nil {
+			writeJSONRecipeError(w, err, id, userID)
+			return
+		}
+
+		scaled := recipe.Copy()
+		err = scaled.ScaleTo(target)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "A recipe yielding "+recipe.YieldText()+" cannot be scaled to "+target+".")
+			return
+		}
+		writeJSON(w, http.StatusOK, newAPIRecipe(&scaled))
+	}
+}
+
+func (s *Server) apiRecipeTimersHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "Invalid recipe ID.")
+			return
+		}
+
+		recipe, err := s.Repository.Recipe(id, userID)
+		if err != nil {
+			writeJSONRecipeError(w, err, id, userID)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, newAPITimers(recipe.Timers()))
+	}
+}
+
+func (s *Server) apiRecipeSharePostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "Invalid recipe ID.")
+			return
+		}
+
+		link, err := s.Repository.AddShareLink(models.Share{CookbookID: -1, RecipeID: id, UserID: userID})
+		if err != nil {
+			slog.Error("Failed to create share link", slog.Int64("userID", userID), "recipeID", id, "error", err)
+			writeJSONError(w, http.StatusNotFound, "Recipe not found.")
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, apiLink{Link: absoluteURL(r, link)})
+	}
+}
+
+func (s *Server) apiRecipesSearchHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		opts := models.NewSearchOptionsRecipe(r.URL.Query())
+
+		recipes, total, err := s.Repository.SearchRecipes(opts, userID)
+		if err != nil {
+			slog.Error("Failed to search recipes", slog.Int64("userID", userID), "opts", opts, "error", err)
+			writeJSONError(w, http.StatusInternalServerError, "Failed to search recipes.")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, newAPIRecipes(recipes, opts.Page, total))
+	}
+}
+
+func absoluteURL(r *http.Request, path string) string {
+	link := r.Host + path
+	if !strings.HasPrefix(link, "http") {
+		if r.TLS != nil {
+			link = "https://" + link
+		} else {
+			link = "http://" + link
+		}
+	}
+	return link
+}
@@ -0,0 +1,883 @@
+package server_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/reaper47/recipya/internal/auth"
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func assertAPIError(tb testing.TB, body string, wantStatus int, wantMessage string) {
+	tb.Helper()
+
+	var got struct {
+		Error struct {
+			Status  int    `json:"status"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	err := json.Unmarshal([]byte(body), &got)
+	if err != nil {
+		tb.Fatalf("could not decode error body %q: %v", body, err)
+	}
+
+	if got.Error.Status != wantStatus || got.Error.Message != wantMessage {
+		tb.Fatalf("got error %+v but want status %d and message %q", got.Error, wantStatus, wantMessage)
+	}
+}
+
+func TestHandlers_API_Tokens(t *testing.T) {
+	srv := newServerTest()
+
+	newToken := func(scopes []models.APIScope, expiresAt time.Time) string {
+		token, selector, hashValidator := auth.GenerateAPIToken()
+		repo := srv.Repository.(*mockRepository)
+		_, _ = repo.AddAPIToken(models.APIToken{Name: token[:8], Scopes: scopes, ExpiresAt: expiresAt, UserID: 1}, selector, hashValidator)
+		return token
+	}
+
+	sendWithToken := func(method, target, token string, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, target, strings.NewReader(body))
+		r.Header.Set("Authorization", "Bearer "+token)
+		if body != "" {
+			r.Header.Set("Content-Type", string(jsonHeader))
+		}
+		rr := httptest.NewRecorder()
+		srv.Router.ServeHTTP(rr, r)
+		return rr
+	}
+
+	readToken := newToken([]models.APIScope{models.APIScopeRead}, time.Time{})
+	writeToken := newToken([]models.APIScope{models.APIScopeRead, models.APIScopeWrite}, time.Now().Add(time.Hour))
+	expiredToken := newToken([]models.APIScope{models.APIScopeRead}, time.Now().Add(-time.Hour))
+
+	t.Run("malformed token", func(t *testing.T) {
+		rr := sendWithToken(http.MethodGet, "/api/v1/recipes", "not-a-token", "")
+
+		assertAPIError(t, getBodyHTML(rr), http.StatusUnauthorized, "Invalid API token.")
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		token, _, _ := auth.GenerateAPIToken()
+
+		rr := sendWithToken(http.MethodGet, "/api/v1/recipes", token, "")
+
+		assertAPIError(t, getBodyHTML(rr), http.StatusUnauthorized, "Invalid API token.")
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		rr := sendWithToken(http.MethodGet, "/api/v1/recipes", expiredToken, "")
+
+		assertAPIError(t, getBodyHTML(rr), http.StatusUnauthorized, "Invalid API token.")
+	})
+
+	t.Run("read scope may read", func(t *testing.T) {
+		rr := sendWithToken(http.MethodGet, "/api/v1/recipes", readToken, "")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+	})
+
+	t.Run("read scope may not write", func(t *testing.T) {
+		rr := sendWithToken(http.MethodPost, "/api/v1/categories", readToken, `{"name":"breakfast"}`)
+
+		assertAPIError(t, getBodyHTML(rr), http.StatusForbidden, "The API token lacks the 'write' scope.")
+	})
+
+	t.Run("write scope may write", func(t *testing.T) {
+		rr := sendWithToken(http.MethodPost, "/api/v1/categories", writeToken, `{"name":"breakfast"}`)
+
+		assertStatus(t, rr.Code, http.StatusCreated)
+	})
+
+	t.Run("tokens are not accepted outside the API", func(t *testing.T) {
+		rr := sendWithToken(http.MethodGet, "/recipes", writeToken, "")
+
+		assertStatus(t, rr.Code, http.StatusSeeOther)
+	})
+}
+
+func TestHandlers_API_Recipes(t *testing.T) {
+	srv := newServerTest()
+
+	const uri = "/api/v1/recipes"
+
+	t.Run("must be logged in", func(t *testing.T) {
+		rr := sendRequestNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusUnauthorized)
+		assertHeader(t, rr, "Content-Type", "application/json")
+		assertAPIError(t, rr.Body.String(), http.StatusUnauthorized, "Authentication required.")
+	})
+
+	t.Run("list recipes", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "One", Category: "lunch", Ingredients: []string{"2 eggs"}, Instructions: []string{"Mix"}, Yield: 2},
+					{ID: 2, Name: "Two", Category: "soup", Instructions: []string{"Chop", "Simmer 25-30 minutes"}, Yield: 4},
+				},
+			},
+			ShareLinks: make(map[string]models.Share),
+		}
+
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		var got struct {
+			Page    uint64 `json:"page"`
+			Total   uint64 `json:"total"`
+			Recipes []struct {
+				ID   int64  `json:"id"`
+				Name string `json:"name"`
+			} `json:"recipes"`
+		}
+		_ = json.Unmarshal(rr.Body.Bytes(), &got)
+		if got.Page != 1 || got.Total != 2 || len(got.Recipes) != 2 || got.Recipes[1].ID != 2 || got.Recipes[1].Name != "Two" {
+			t.Fatalf("unexpected response %+v", got)
+		}
+	})
+
+	t.Run("get recipe", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "One", Category: "lunch", Ingredients: []string{"2 eggs"}, Instructions: []string{"Mix"}, Yield: 2},
+					{ID: 2, Name: "Two", Category: "soup", Instructions: []string{"Chop", "Simmer 25-30 minutes"}, Yield: 4},
+				},
+			},
+			ShareLinks: make(map[string]models.Share),
+		}
+
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/1")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		body := rr.Body.String()
+		for _, want := range []string{`"id":1`, `"@type":"Recipe"`, `"name":"One"`, `"recipeIngredient":["2 eggs"]`, `"recipeYield":2`} {
+			if !strings.Contains(body, want) {
+				t.Fatalf("%q not found in %s", want, body)
+			}
+		}
+	})
+
+	t.Run("recipe not found", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "One", Category: "lunch", Ingredients: []string{"2 eggs"}, Instructions: []string{"Mix"}, Yield: 2},
+					{ID: 2, Name: "Two", Category: "soup", Instructions: []string{"Chop", "Simmer 25-30 minutes"}, Yield: 4},
+				},
+			},
+			ShareLinks: make(map[string]models.Share),
+		}
+
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/10")
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		assertAPIError(t, rr.Body.String(), http.StatusNotFound, "Recipe not found.")
+	})
+
+	t.Run("recipe fails to load", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			RecipeFunc: func(_, _ int64) (*models.Recipe, error) {
+				return nil, errors.New("database is locked")
+			},
+		}
+
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/1")
+
+		assertStatus(t, rr.Code, http.StatusInternalServerError)
+		assertAPIError(t, rr.Body.String(), http.StatusInternalServerError, "Failed to fetch recipe.")
+	})
+
+	t.Run("invalid recipe id", func(t *testing.T) {
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/-1")
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertAPIError(t, rr.Body.String(), http.StatusBadRequest, "Invalid recipe ID.")
+	})
+
+	t.Run("create recipe", func(t *testing.T) {
+		repo := &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "One", Category: "lunch", Ingredients: []string{"2 eggs"}, Instructions: []string{"Mix"}, Yield: 2},
+					{ID: 2, Name: "Two", Category: "soup", Instructions: []string{"Chop", "Simmer 25-30 minutes"}, Yield: 4},
+				},
+			},
+			ShareLinks: make(map[string]models.Share),
+		}
+		srv.Repository = repo
+
+		body := `{"@context":"https://schema.org","@type":"Recipe","name":"Three","recipeIngredient":["1 cup flour"],"recipeInstructions":[{"@type":"HowToStep","text":"Bake"}],"recipeYield":6}`
+		rr := sendRequestAsLoggedIn(srv, http.MethodPost, uri, jsonHeader, strings.NewReader(body))
+
+		assertStatus(t, rr.Code, http.StatusCreated)
+		if len(repo.RecipesRegistered[1]) != 3 {
+			t.Fatalf("expected 3 recipes but got %d", len(repo.RecipesRegistered[1]))
+		}
+		got := repo.RecipesRegistered[1][2]
+		if got.Name != "Three" || got.Yield != 6 || got.Instructions[0] != "Bake" || got.Category != "uncategorized" {
+			t.Fatalf("unexpected recipe %+v", got)
+		}
+		assertHeader(t, rr, "Location", "/api/v1/recipes/1")
+	})
+
+	t.Run("create recipe with invalid body", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "One", Category: "lunch", Ingredients: []string{"2 eggs"}, Instructions: []string{"Mix"}, Yield: 2},
+					{ID: 2, Name: "Two", Category: "soup", Instructions: []string{"Chop", "Simmer 25-30 minutes"}, Yield: 4},
+				},
+			},
+			ShareLinks: make(map[string]models.Share),
+		}
+
+		rr := sendRequestAsLoggedIn(srv, http.MethodPost, uri, jsonHeader, strings.NewReader(`{"name":}`))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertAPIError(t, rr.Body.String(), http.StatusBadRequest, "Invalid JSON body.")
+	})
+
+	t.Run("create recipe without name", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "One", Category: "lunch", Ingredients: []string{"2 eggs"}, Instructions: []string{"Mix"}, Yield: 2},
+					{ID: 2, Name: "Two", Category: "soup", Instructions: []string{"Chop", "Simmer 25-30 minutes"}, Yield: 4},
+				},
+			},
+			ShareLinks: make(map[string]models.Share),
+		}
+
+		rr := sendRequestAsLoggedIn(srv, http.MethodPost, uri, jsonHeader, strings.NewReader(`{"@type":"Recipe"}`))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertAPIError(t, rr.Body.String(), http.StatusBadRequest, "The recipe's name is required.")
+	})
+
+	t.Run("update recipe", func(t *testing.T) {
+		repo := &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "One", Category: "lunch", Ingredients: []string{"2 eggs"}, Instructions: []string{"Mix"}, Yield: 2},
+					{ID: 2, Name: "Two", Category: "soup", Instructions: []string{"Chop", "Simmer 25-30 minutes"}, Yield: 4},
+				},
+			},
+			ShareLinks: make(map[string]models.Share),
+		}
+		srv.Repository = repo
+
+		body := `{"@type":"Recipe","name":"One updated","recipeCategory":"dinner","recipeIngredient":["3 eggs"],"recipeYield":3}`
+		rr := sendRequestAsLoggedIn(srv, http.MethodPut, uri+"/1", jsonHeader, strings.NewReader(body))
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		got := repo.RecipesRegistered[1][0]
+		if got.Name != "One updated" || got.Category != "dinner" || got.Ingredients[0] != "3 eggs" || got.Yield != 3 {
+			t.Fatalf("unexpected recipe %+v", got)
+		}
+	})
+
+	t.Run("update recipe modified since it was loaded", func(t *testing.T) {
+		repo := &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "One", Category: "lunch", Ingredients: []string{"2 eggs"}, Instructions: []string{"Mix"}, Yield: 2},
+					{ID: 2, Name: "Two", Category: "soup", Instructions: []string{"Chop", "Simmer 25-30 minutes"}, Yield: 4},
+				},
+			},
+			ShareLinks: make(map[string]models.Share),
+		}
+		repo.RecipesRegistered[1][0].UpdatedAt = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+		srv.Repository = repo
+
+		body := `{"@type":"Recipe","name":"One updated","recipeIngredient":["3 eggs"]}`
+		req := prepareRequest(http.MethodPut, uri+"/1", jsonHeader, strings.NewReader(body))
+		req.Header.Set("If-Match", `"2026-10-18T11:00:00Z"`)
+		rr := httptest.NewRecorder()
+		srv.Router.ServeHTTP(rr, req)
+
+		assertStatus(t, rr.Code, http.StatusPreconditionFailed)
+		assertAPIError(t, rr.Body.String(), http.StatusPreconditionFailed, "Recipe was modified since it was loaded.")
+		if repo.RecipesRegistered[1][0].Name != "One" {
+			t.Fatal("the recipe must not have been updated")
+		}
+	})
+
+	t.Run("update recipe with matching entity tag", func(t *testing.T) {
+		repo := &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "One", Category: "lunch", Ingredients: []string{"2 eggs"}, Instructions: []string{"Mix"}, Yield: 2},
+					{ID: 2, Name: "Two", Category: "soup", Instructions: []string{"Chop", "Simmer 25-30 minutes"}, Yield: 4},
+				},
+			},
+			ShareLinks: make(map[string]models.Share),
+		}
+		repo.RecipesRegistered[1][0].UpdatedAt = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+		srv.Repository = repo
+
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/1")
+		assertHeader(t, rr, "ETag", `"2026-10-18T12:00:00Z"`)
+
+		body := `{"@type":"Recipe","name":"One updated","recipeIngredient":["3 eggs"]}`
+		req := prepareRequest(http.MethodPut, uri+"/1", jsonHeader, strings.NewReader(body))
+		req.Header.Set("If-Match", rr.Header().Get("ETag"))
+		rr = httptest.NewRecorder()
+		srv.Router.ServeHTTP(rr, req)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if repo.RecipesRegistered[1][0].Name != "One updated" {
+			t.Fatal("the recipe must have been updated")
+		}
+	})
+
+	t.Run("delete recipe", func(t *testing.T) {
+		repo := &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "One", Category: "lunch", Ingredients: []string{"2 eggs"}, Instructions: []string{"Mix"}, Yield: 2},
+					{ID: 2, Name: "Two", Category: "soup", Instructions: []string{"Chop", "Simmer 25-30 minutes"}, Yield: 4},
+				},
+			},
+			ShareLinks: make(map[string]models.Share),
+		}
+		srv.Repository = repo
+
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/2")
+
+		assertStatus(t, rr.Code, http.StatusNoContent)
+		if len(repo.RecipesRegistered[1]) != 1 {
+			t.Fatalf("expected 1 recipe but got %d", len(repo.RecipesRegistered[1]))
+		}
+	})
+
+	t.Run("delete recipe not found", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "One", Category: "lunch", Ingredients: []string{"2 eggs"}, Instructions: []string{"Mix"}, Yield: 2},
+					{ID: 2, Name: "Two", Category: "soup", Instructions: []string{"Chop", "Simmer 25-30 minutes"}, Yield: 4},
+				},
+			},
+			ShareLinks: make(map[string]models.Share),
+		}
+
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/5")
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		assertAPIError(t, rr.Body.String(), http.StatusNotFound, "Recipe not found.")
+	})
+
+	t.Run("scale recipe", func(t *testing.T) {
+		repo := &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "One", Category: "lunch", Ingredients: []string{"2 eggs"}, Instructions: []string{"Mix"}, Yield: 2},
+					{ID: 2, Name: "Two", Category: "soup", Instructions: []string{"Chop", "Simmer 25-30 minutes"}, Yield: 4},
+				},
+			},
+			ShareLinks: make(map[string]models.Share),
+		}
+		srv.Repository = repo
+
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/1/scale?yield=4")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		body := rr.Body.String()
+		if !strings.Contains(body, `"recipeIngredient":["4 eggs"]`) || !strings.Contains(body, `"recipeYield":4`) {
+			t.Fatalf("recipe not scaled: %s", body)
+		}
+		if repo.RecipesRegistered[1][0].Yield != 2 {
+			t.Fatal("scaling must not modify the stored recipe")
+		}
+	})
+
+	t.Run("scale recipe to a fractional yield", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "One", Category: "lunch", Ingredients: []string{"2 eggs"}, Instructions: []string{"Mix"}, Yield: 2},
+					{ID: 2, Name: "Two", Category: "soup", Instructions: []string{"Chop", "Simmer 25-30 minutes"}, Yield: 4},
+				},
+			},
+			ShareLinks: make(map[string]models.Share),
+		}
+
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/1/scale?yield=1.5")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		body := rr.Body.String()
+		if !strings.Contains(body, `"recipeIngredient":["1 1/2 eggs"]`) || !strings.Contains(body, `"recipeYield":1.5`) {
+			t.Fatalf("recipe not scaled: %s", body)
+		}
+	})
+
+	t.Run("scale recipe to another yield unit", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "One", Category: "lunch", Ingredients: []string{"2 eggs"}, Instructions: []string{"Mix"}, Yield: 2},
+					{ID: 2, Name: "Two", Category: "soup", Instructions: []string{"Chop", "Simmer 25-30 minutes"}, Yield: 4},
+				},
+			},
+			ShareLinks: make(map[string]models.Share),
+		}
+
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/1/scale?yield=3%20pies")
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertAPIError(t, rr.Body.String(), http.StatusBadRequest, "A recipe yielding 2 servings cannot be scaled to 3 pies.")
+	})
+
+	t.Run("scale recipe invalid yield", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "One", Category: "lunch", Ingredients: []string{"2 eggs"}, Instructions: []string{"Mix"}, Yield: 2},
+					{ID: 2, Name: "Two", Category: "soup", Instructions: []string{"Chop", "Simmer 25-30 minutes"}, Yield: 4},
+				},
+			},
+			ShareLinks: make(map[string]models.Share),
+		}
+
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/1/scale?yield=0")
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertAPIError(t, rr.Body.String(), http.StatusBadRequest, "Yield must be greater than zero.")
+	})
+
+	t.Run("recipe timers", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "One", Category: "lunch", Ingredients: []string{"2 eggs"}, Instructions: []string{"Mix"}, Yield: 2},
+					{ID: 2, Name: "Two", Category: "soup", Instructions: []string{"Chop", "Simmer 25-30 minutes"}, Yield: 4},
+				},
+			},
+			ShareLinks: make(map[string]models.Share),
+		}
+
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/2/timers")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		want := `[{"step":2,"seconds":1500,"secondsMax":1800,"text":"25-30 minutes"}]` + "\n"
+		if rr.Body.String() != want {
+			t.Fatalf("got %s but want %s", rr.Body.String(), want)
+		}
+	})
+
+	t.Run("timers of a recipe without any", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "One", Category: "lunch", Ingredients: []string{"2 eggs"}, Instructions: []string{"Mix"}, Yield: 2},
+					{ID: 2, Name: "Two", Category: "soup", Instructions: []string{"Chop", "Simmer 25-30 minutes"}, Yield: 4},
+				},
+			},
+			ShareLinks: make(map[string]models.Share),
+		}
+
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/1/timers")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if rr.Body.String() != "[]\n" {
+			t.Fatalf("got %s but want an empty list", rr.Body.String())
+		}
+	})
+
+	t.Run("share recipe", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "One", Category: "lunch", Ingredients: []string{"2 eggs"}, Instructions: []string{"Mix"}, Yield: 2},
+					{ID: 2, Name: "Two", Category: "soup", Instructions: []string{"Chop", "Simmer 25-30 minutes"}, Yield: 4},
+				},
+			},
+			ShareLinks: make(map[string]models.Share),
+		}
+
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodPost, uri+"/1/share")
+
+		assertStatus(t, rr.Code, http.StatusCreated)
+		want := `{"link":"http://example.com/r/33320755-82f9-47e5-bb0a-d1b55cbd3f7b"}` + "\n"
+		if rr.Body.String() != want {
+			t.Fatalf("got %s but want %s", rr.Body.String(), want)
+		}
+	})
+
+	t.Run("search recipes", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "One", Category: "lunch", Ingredients: []string{"2 eggs"}, Instructions: []string{"Mix"}, Yield: 2},
+					{ID: 2, Name: "Two", Category: "soup", Instructions: []string{"Chop", "Simmer 25-30 minutes"}, Yield: 4},
+				},
+			},
+			ShareLinks: make(map[string]models.Share),
+		}
+
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/search?q=soup")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		body := rr.Body.String()
+		if !strings.Contains(body, `"name":"Two"`) || strings.Contains(body, `"name":"One"`) || !strings.Contains(body, `"total":1`) {
+			t.Fatalf("unexpected search results: %s", body)
+		}
+	})
+
+	t.Run("unknown endpoint", func(t *testing.T) {
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodGet, "/api/v1/unknown")
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		assertAPIError(t, rr.Body.String(), http.StatusNotFound, "The requested resource does not exist.")
+	})
+}
+
+func TestHandlers_API_Categories(t *testing.T) {
+	srv := newServerTest()
+
+	const uri = "/api/v1/categories"
+
+	t.Run("list categories", func(t *testing.T) {
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if rr.Body.String() != `["chicken"]`+"\n" {
+			t.Fatalf("got unexpected body %s", rr.Body.String())
+		}
+	})
+
+	t.Run("add category", func(t *testing.T) {
+		rr := sendRequestAsLoggedIn(srv, http.MethodPost, uri, jsonHeader, strings.NewReader(`{"name":"dessert"}`))
+
+		assertStatus(t, rr.Code, http.StatusCreated)
+		categories, _ := srv.Repository.Categories(1)
+		if len(categories) != 2 || categories[1] != "dessert" {
+			t.Fatalf("got categories %v", categories)
+		}
+	})
+
+	t.Run("add existing category", func(t *testing.T) {
+		rr := sendRequestAsLoggedIn(srv, http.MethodPost, uri, jsonHeader, strings.NewReader(`{"name":"chicken"}`))
+
+		assertStatus(t, rr.Code, http.StatusConflict)
+		assertAPIError(t, rr.Body.String(), http.StatusConflict, "The category already exists.")
+	})
+
+	t.Run("add category fails", func(t *testing.T) {
+		repo := srv.Repository.(*mockRepository)
+		repo.AddRecipeCategoryFunc = func(_ string, _ int64) error {
+			return errors.New("database is locked")
+		}
+		defer func() {
+			repo.AddRecipeCategoryFunc = nil
+		}()
+
+		rr := sendRequestAsLoggedIn(srv, http.MethodPost, uri, jsonHeader, strings.NewReader(`{"name":"soups"}`))
+
+		assertStatus(t, rr.Code, http.StatusInternalServerError)
+		assertAPIError(t, rr.Body.String(), http.StatusInternalServerError, "Failed to add category.")
+	})
+
+	t.Run("delete uncategorized", func(t *testing.T) {
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/uncategorized")
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertAPIError(t, rr.Body.String(), http.StatusBadRequest, "The category cannot be deleted.")
+	})
+
+	t.Run("delete category that does not exist", func(t *testing.T) {
+		repo := srv.Repository.(*mockRepository)
+		repo.DeleteCategoryFunc = func(_ string, _ int64) error {
+			return sql.ErrNoRows
+		}
+		defer func() {
+			repo.DeleteCategoryFunc = nil
+		}()
+
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/soups")
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		assertAPIError(t, rr.Body.String(), http.StatusNotFound, "Category not found.")
+	})
+
+	t.Run("delete category fails", func(t *testing.T) {
+		repo := srv.Repository.(*mockRepository)
+		repo.DeleteCategoryFunc = func(_ string, _ int64) error {
+			return errors.New("database is locked")
+		}
+		defer func() {
+			repo.DeleteCategoryFunc = nil
+		}()
+
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/chicken")
+
+		assertStatus(t, rr.Code, http.StatusInternalServerError)
+		assertAPIError(t, rr.Body.String(), http.StatusInternalServerError, "Failed to delete category.")
+	})
+}
+
+func TestHandlers_API_Cookbooks(t *testing.T) {
+	srv := newServerTest()
+
+	const uri = "/api/v1/cookbooks"
+
+	t.Run("must be logged in", func(t *testing.T) {
+		rr := sendRequestNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("list cookbooks", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			CookbooksRegistered: map[int64][]models.Cookbook{
+				1: {
+					{ID: 1, Title: "Breakfast", Count: 1, Recipes: models.Recipes{{ID: 1, Name: "Eggs"}}},
+					{ID: 2, Title: "Lunch"},
+				},
+			},
+			RecipesRegistered: map[int64]models.Recipes{1: {{ID: 1, Name: "Eggs"}}},
+			ShareLinks:        make(map[string]models.Share),
+		}
+
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		body := rr.Body.String()
+		if !strings.Contains(body, `"title":"Breakfast"`) || !strings.Contains(body, `"title":"Lunch"`) {
+			t.Fatalf("unexpected body %s", body)
+		}
+	})
+
+	t.Run("get cookbook", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			CookbooksRegistered: map[int64][]models.Cookbook{
+				1: {
+					{ID: 1, Title: "Breakfast", Count: 1, Recipes: models.Recipes{{ID: 1, Name: "Eggs"}}},
+					{ID: 2, Title: "Lunch"},
+				},
+			},
+			RecipesRegistered: map[int64]models.Recipes{1: {{ID: 1, Name: "Eggs"}}},
+			ShareLinks:        make(map[string]models.Share),
+		}
+
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/1")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		body := rr.Body.String()
+		if !strings.Contains(body, `"recipes":[{"id":1,`) || !strings.Contains(body, `"name":"Eggs"`) {
+			t.Fatalf("unexpected body %s", body)
+		}
+	})
+
+	t.Run("cookbook not found", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			CookbooksRegistered: map[int64][]models.Cookbook{
+				1: {
+					{ID: 1, Title: "Breakfast", Count: 1, Recipes: models.Recipes{{ID: 1, Name: "Eggs"}}},
+					{ID: 2, Title: "Lunch"},
+				},
+			},
+			RecipesRegistered: map[int64]models.Recipes{1: {{ID: 1, Name: "Eggs"}}},
+			ShareLinks:        make(map[string]models.Share),
+		}
+
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/8")
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		assertAPIError(t, rr.Body.String(), http.StatusNotFound, "Cookbook not found.")
+	})
+
+	t.Run("create cookbook", func(t *testing.T) {
+		repo := &mockRepository{
+			CookbooksRegistered: map[int64][]models.Cookbook{
+				1: {
+					{ID: 1, Title: "Breakfast", Count: 1, Recipes: models.Recipes{{ID: 1, Name: "Eggs"}}},
+					{ID: 2, Title: "Lunch"},
+				},
+			},
+			RecipesRegistered: map[int64]models.Recipes{1: {{ID: 1, Name: "Eggs"}}},
+			ShareLinks:        make(map[string]models.Share),
+		}
+		srv.Repository = repo
+
+		rr := sendRequestAsLoggedIn(srv, http.MethodPost, uri, jsonHeader, strings.NewReader(`{"title":"Dinner"}`))
+
+		assertStatus(t, rr.Code, http.StatusCreated)
+		if len(repo.CookbooksRegistered[1]) != 3 {
+			t.Fatalf("expected 3 cookbooks but got %d", len(repo.CookbooksRegistered[1]))
+		}
+	})
+
+	t.Run("create cookbook without title", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			CookbooksRegistered: map[int64][]models.Cookbook{
+				1: {
+					{ID: 1, Title: "Breakfast", Count: 1, Recipes: models.Recipes{{ID: 1, Name: "Eggs"}}},
+					{ID: 2, Title: "Lunch"},
+				},
+			},
+			RecipesRegistered: map[int64]models.Recipes{1: {{ID: 1, Name: "Eggs"}}},
+			ShareLinks:        make(map[string]models.Share),
+		}
+
+		rr := sendRequestAsLoggedIn(srv, http.MethodPost, uri, jsonHeader, strings.NewReader(`{"title":" "}`))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertAPIError(t, rr.Body.String(), http.StatusBadRequest, "The cookbook's title is required.")
+	})
+
+	t.Run("create existing cookbook", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			CookbooksRegistered: map[int64][]models.Cookbook{
+				1: {
+					{ID: 1, Title: "Breakfast", Count: 1, Recipes: models.Recipes{{ID: 1, Name: "Eggs"}}},
+					{ID: 2, Title: "Lunch"},
+				},
+			},
+		}
+
+		rr := sendRequestAsLoggedIn(srv, http.MethodPost, uri, jsonHeader, strings.NewReader(`{"title":"Lunch"}`))
+
+		assertStatus(t, rr.Code, http.StatusConflict)
+		assertAPIError(t, rr.Body.String(), http.StatusConflict, "The cookbook already exists.")
+	})
+
+	t.Run("delete cookbook", func(t *testing.T) {
+		repo := &mockRepository{
+			CookbooksRegistered: map[int64][]models.Cookbook{
+				1: {
+					{ID: 1, Title: "Breakfast", Count: 1, Recipes: models.Recipes{{ID: 1, Name: "Eggs"}}},
+					{ID: 2, Title: "Lunch"},
+				},
+			},
+			RecipesRegistered: map[int64]models.Recipes{1: {{ID: 1, Name: "Eggs"}}},
+			ShareLinks:        make(map[string]models.Share),
+		}
+		srv.Repository = repo
+
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/2")
+
+		assertStatus(t, rr.Code, http.StatusNoContent)
+		if len(repo.CookbooksRegistered[1]) != 1 {
+			t.Fatalf("expected 1 cookbook but got %d", len(repo.CookbooksRegistered[1]))
+		}
+	})
+
+	t.Run("add recipe to cookbook that does not exist", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			CookbooksRegistered: map[int64][]models.Cookbook{1: {{ID: 1, Title: "Breakfast"}}},
+			RecipesRegistered:   map[int64]models.Recipes{1: {{ID: 1, Name: "Eggs"}}},
+		}
+
+		rr := sendRequestAsLoggedIn(srv, http.MethodPost, uri+"/8/recipes", jsonHeader, strings.NewReader(`{"recipeID":1}`))
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		assertAPIError(t, rr.Body.String(), http.StatusNotFound, "Recipe or cookbook not found.")
+	})
+
+	t.Run("remove recipe from cookbook", func(t *testing.T) {
+		repo := &mockRepository{
+			CookbooksRegistered: map[int64][]models.Cookbook{
+				1: {
+					{ID: 1, Title: "Breakfast", Count: 1, Recipes: models.Recipes{{ID: 1, Name: "Eggs"}}},
+					{ID: 2, Title: "Lunch"},
+				},
+			},
+			RecipesRegistered: map[int64]models.Recipes{1: {{ID: 1, Name: "Eggs"}}},
+			ShareLinks:        make(map[string]models.Share),
+		}
+		srv.Repository = repo
+
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/1/recipes/1")
+
+		assertStatus(t, rr.Code, http.StatusNoContent)
+		if len(repo.CookbooksRegistered[1][0].Recipes) != 0 {
+			t.Fatal("recipe should have been removed from the cookbook")
+		}
+	})
+
+	t.Run("share cookbook", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			CookbooksRegistered: map[int64][]models.Cookbook{
+				1: {
+					{ID: 1, Title: "Breakfast", Count: 1, Recipes: models.Recipes{{ID: 1, Name: "Eggs"}}},
+					{ID: 2, Title: "Lunch"},
+				},
+			},
+			RecipesRegistered: map[int64]models.Recipes{1: {{ID: 1, Name: "Eggs"}}},
+			ShareLinks:        make(map[string]models.Share),
+		}
+
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodPost, uri+"/1/share")
+
+		assertStatus(t, rr.Code, http.StatusCreated)
+		if !strings.Contains(rr.Body.String(), `"link":"http://example.com/c/`) {
+			t.Fatalf("unexpected body %s", rr.Body.String())
+		}
+	})
+}
+
+func TestHandlers_API_WebhookEvents(t *testing.T) {
+	srv := newServerTest()
+	webhooks := &mockWebhooks{payloads: make(chan models.WebhookPayload, 10)}
+	srv.Webhooks = webhooks
+
+	repo := srv.Repository.(*mockRepository)
+	repo.RecipesRegistered[1] = models.Recipes{{ID: 1, Name: "Lovely Ukraine"}}
+	repo.WebhooksRegistered = []models.Webhook{
+		{ID: 1, UserID: 1, Events: []models.WebhookEvent{models.WebhookEventRecipeDeleted}},
+		{ID: 2, UserID: 1, Events: []models.WebhookEvent{models.WebhookEventCookbookChanged}},
+		{ID: 3, UserID: 2, Events: []models.WebhookEvent{models.WebhookEventRecipeDeleted}},
+	}
+
+	receive := func(t *testing.T) models.WebhookPayload {
+		t.Helper()
+		select {
+		case p := <-webhooks.payloads:
+			return p
+		case <-time.After(time.Second):
+			t.Fatal("no webhook payload delivered")
+			return models.WebhookPayload{}
+		}
+	}
+
+	t.Run("recipe deleted", func(t *testing.T) {
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodDelete, "/api/v1/recipes/1")
+
+		assertStatus(t, rr.Code, http.StatusNoContent)
+		got := receive(t)
+		if got.Event != models.WebhookEventRecipeDeleted || got.UserID != 1 {
+			t.Fatalf("unexpected payload %+v", got)
+		}
+		if data, ok := got.Data.(map[string]any); !ok || data["recipeID"] != int64(1) {
+			t.Fatalf("unexpected payload data %+v", got.Data)
+		}
+		select {
+		case p := <-webhooks.payloads:
+			t.Fatalf("only one webhook must have been notified but got %+v", p)
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("cookbook changed", func(t *testing.T) {
+		rr := sendRequestAsLoggedIn(srv, http.MethodPost, "/api/v1/cookbooks", jsonHeader, strings.NewReader(`{"title":"Weekdays"}`))
+
+		assertStatus(t, rr.Code, http.StatusCreated)
+		got := receive(t)
+		if got.Event != models.WebhookEventCookbookChanged {
+			t.Fatalf("unexpected payload %+v", got)
+		}
+		if data := got.Data.(map[string]any); data["change"] != "created" {
+			t.Fatalf("unexpected payload data %+v", got.Data)
+		}
+	})
+}
@@ -1,6 +1,8 @@
 package server
 
 import (
+	"errors"
+	"fmt"
 	"github.com/reaper47/recipya/internal/app"
 	"github.com/reaper47/recipya/internal/models"
 	"github.com/reaper47/recipya/internal/templates"
@@ -11,6 +13,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 )
 
 func (s *Server) cookbooksHandler() http.HandlerFunc {
@@ -100,6 +103,7 @@ func (s *Server) cookbooksPostHandler() http.HandlerFunc {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
+		s.emitCookbookChanged(userID, cookbookID, "created")
 
 		settings, err := s.Repository.UserSettings(userID)
 		if err != nil {
@@ -187,6 +191,7 @@ func (s *Server) cookbooksDeleteCookbookHandler() http.HandlerFunc {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
+		s.emitCookbookChanged(userID, cookbookID, "deleted")
 
 		p, err := newCookbooksPagination(s, w, userID, page, true)
 		if err != nil {
@@ -263,6 +268,7 @@ func (s *Server) cookbooksDeleteCookbookRecipeHandler() http.HandlerFunc {
 		}
 
 		slog.Info("Removed recipe from cookbook", userIDAttr, cookbookIDAttr, recipeIDAttr)
+		s.emitCookbookChanged(userID, cookbookID, "recipe_removed")
 
 		if numRecipes == 0 {
 			_ = components.CookbookIndexNoRecipes(true).Render(r.Context(), w)
@@ -294,7 +300,19 @@ func (s *Server) cookbooksDownloadCookbookHandler() http.HandlerFunc {
 			return
 		}
 
-		fileName, err := s.Files.ExportCookbook(cookbook, models.PDF)
+		cookbook.Recipes, err = cookbook.Recipes.ExpandSubRecipesFunc(func(id int64) (*models.Recipe, error) {
+			return s.Repository.Recipe(id, userID)
+		})
+		if err != nil {
+			slog.Warn("Failed to expand the sub-recipes of some recipes", "userID", userID, "cookbookID", cookbookID, "error", err)
+		}
+
+		notes, err := s.Repository.RecipesNotes(userID)
+		if err != nil {
+			slog.Error("Failed to get recipe notes", "userID", userID, "error", err)
+		}
+
+		fileName, err := s.Files.ExportCookbook(cookbook, notes.GroupByRecipe(), models.PDF)
 		if err != nil {
 			s.Brokers.SendToast(models.NewErrorFilesToast("Failed to export cookbook."), userID)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -421,6 +439,7 @@ func (s *Server) cookbooksImagePostCookbookHandler() http.HandlerFunc {
 		}
 
 		slog.Info("Updated cookbook image", userIDAttr, cookbookIDAttr, imageUUIDAttr)
+		s.emitCookbookChanged(userID, cookbookID, "image_updated")
 		w.WriteHeader(http.StatusCreated)
 	}
 }
@@ -458,6 +477,7 @@ func (s *Server) cookbookPostCookbookHandler() http.HandlerFunc {
 		}
 
 		slog.Info("Added recipe to cookbook", userIDAttr, cookbookIDAttr, recipeIDAttr)
+		s.emitCookbookChanged(userID, cookbookID, "recipe_added")
 		w.WriteHeader(http.StatusCreated)
 	}
 }
@@ -517,6 +537,7 @@ func (s *Server) cookbooksRecipesSearchHandler() http.HandlerFunc {
 					PageNumber: opts.Page,
 					Recipes:    recipes,
 					Title:      cookbook.Title,
+					UpdatedAt:  cookbook.UpdatedAt,
 				},
 				ShareData: templates.ShareData{
 					IsFromHost: true,
@@ -581,8 +602,23 @@ func (s *Server) cookbooksPostCookbookReorderHandler() http.HandlerFunc {
 			recipeIDs[i] = id
 		}
 
-		err = s.Repository.ReorderCookbookRecipes(cookbookID, recipeIDs, userID)
-		if err != nil {
+		var updatedAt time.Time
+		if v := r.FormValue("updated-at"); v != "" {
+			updatedAt, err = time.Parse(time.RFC3339Nano, v)
+			if err != nil {
+				s.Brokers.SendToast(models.NewErrorFormToast("Invalid 'updated-at' in body."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		updatedAt, err = s.Repository.ReorderCookbookRecipes(cookbookID, recipeIDs, updatedAt, userID)
+		if errors.Is(err, models.ErrConflict) {
+			slog.Warn("Cookbook reorder conflict", userIDAttr, cookbookIDAttr, "updatedAt", updatedAt)
+			s.Brokers.SendToast(models.NewWarningToast("Cookbook changed", "The cookbook was modified elsewhere. Reload it before reordering its recipes.", fmt.Sprintf("Reload /cookbooks/%d", cookbookID)), userID)
+			w.WriteHeader(http.StatusConflict)
+			return
+		} else if err != nil {
 			msg := "Failed to update indices."
 			slog.Error(msg, userIDAttr, cookbookIDAttr, "recipeIDs", recipeIDs, "error", err)
 			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
@@ -591,6 +627,8 @@ func (s *Server) cookbooksPostCookbookReorderHandler() http.HandlerFunc {
 		}
 
 		slog.Info("Reordered recipes in cookbook", userIDAttr, cookbookIDAttr, "recipeIDs", recipeIDs)
+		s.emitCookbookChanged(userID, cookbookID, "recipes_reordered")
+		w.Header().Set("HX-Trigger", `{"cookbookUpdated": "`+updatedAt.UTC().Format(time.RFC3339Nano)+`"}`)
 		w.WriteHeader(http.StatusNoContent)
 	}
 }
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/web/components"
+)
+
+func (s *Server) recipeCookLogHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Recipe ID in path must be > 0."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		s.renderRecipeCookLog(w, r, id, userID)
+	}
+}
+
+func (s *Server) recipeCookLogPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Recipe ID in path must be > 0."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		entry := models.CookLogEntry{
+			CookedAt: time.Now(),
+			Notes:    strings.TrimSpace(r.FormValue("notes")),
+			RecipeID: id,
+		}
+
+		if value := r.FormValue("cooked-at"); value != "" {
+			entry.CookedAt, err = time.Parse(time.DateOnly, value)
+			if err != nil {
+				s.Brokers.SendToast(models.NewErrorFormToast("The date must be formatted as YYYY-MM-DD."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		if value := r.FormValue("rating"); value != "" {
+			entry.Rating, err = strconv.Atoi(value)
+			if err != nil || entry.Rating < 0 || entry.Rating > models.MaxCookRating {
+				s.Brokers.SendToast(models.NewErrorFormToast("The rating must be between 0 and 5."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		entry.ID, err = s.Repository.AddCookLogEntry(entry, userID)
+		if errors.Is(err, sql.ErrNoRows) {
+			s.Brokers.SendToast(models.NewErrorGeneralToast("Recipe not found."), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if err != nil {
+			msg := "Could not log the cook."
+			slog.Error(msg, userIDAttr, "entry", entry, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Logged cook", userIDAttr, "id", entry.ID, "recipeID", id)
+		s.renderRecipeCookLog(w, r, id, userID)
+	}
+}
+
+func (s *Server) recipeCookLogDeleteHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Recipe ID in path must be > 0."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		entryID, err := parsePathPositiveID(r.PathValue("entryID"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Cook ID in path must be > 0."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = s.Repository.DeleteCookLogEntry(entryID, id, userID)
+		if errors.Is(err, sql.ErrNoRows) {
+			s.Brokers.SendToast(models.NewErrorGeneralToast("Cook not found."), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if err != nil {
+			msg := "Could not delete the cook."
+			slog.Error(msg, userIDAttr, "id", entryID, "recipeID", id, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Deleted cook", userIDAttr, "id", entryID, "recipeID", id)
+		s.renderRecipeCookLog(w, r, id, userID)
+	}
+}
+
+// renderRecipeCookLog renders the cook log of the user's recipe.
+func (s *Server) renderRecipeCookLog(w http.ResponseWriter, r *http.Request, recipeID, userID int64) {
+	cooks, err := s.Repository.CookLog(recipeID, userID)
+	if err != nil {
+		msg := "Error getting the cook log."
+		slog.Error(msg, "userID", userID, "id", recipeID, "error", err)
+		s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	_ = components.RecipeCookLog(recipeID, cooks, time.Now()).Render(r.Context(), w)
+}
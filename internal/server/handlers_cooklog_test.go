@@ -0,0 +1,143 @@
+package server_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestHandlers_RecipeCookLog(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	uri := ts.URL + "/recipes/1/cooks"
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri)
+		assertMustBeLoggedIn(t, srv, http.MethodPost, uri)
+		assertMustBeLoggedIn(t, srv, http.MethodDelete, uri+"/1")
+	})
+
+	t.Run("displays the log", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			CookLogRegistered: map[int64]models.CookLog{1: {
+				{CookedAt: time.Date(2026, time.October, 10, 0, 0, 0, 0, time.UTC), ID: 2, Notes: "Less salt next time", Rating: 4, RecipeID: 1},
+				{CookedAt: time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC), ID: 1, Rating: 0, RecipeID: 1},
+			}},
+			RecipesRegistered: map[int64]models.Recipes{1: {{ID: 1, Name: "Pancakes"}}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<p>Cooked 2 times, last on 10 Oct 2026. Average rating of 4.0/5.</p>`,
+			`<li class="flex items-start gap-2 py-1"><span class="font-semibold whitespace-nowrap">10 Oct 2026</span><span class="whitespace-nowrap text-orange-400" title="4/5">★★★★☆</span><span class="grow whitespace-pre-line break-words">Less salt next time</span> <button class="btn btn-ghost btn-xs" title="Delete cook" hx-delete="/recipes/1/cooks/2" hx-target="#cook_log" hx-swap="outerHTML" hx-confirm="Are you sure you wish to delete this cook?">`,
+			`<li class="flex items-start gap-2 py-1"><span class="font-semibold whitespace-nowrap">01 Sep 2026</span><span class="grow whitespace-pre-line break-words"></span>`,
+		})
+	})
+
+	t.Run("never cooked", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{1: {{ID: 1, Name: "Pancakes"}}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{`<p>You have not cooked this recipe yet.</p>`})
+	})
+
+	t.Run("log a cook", func(t *testing.T) {
+		repo := &mockRepository{
+			CookLogRegistered: map[int64]models.CookLog{1: {
+				{CookedAt: time.Date(2026, time.October, 10, 0, 0, 0, 0, time.UTC), ID: 2, Notes: "Less salt next time", Rating: 4, RecipeID: 1},
+				{CookedAt: time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC), ID: 1, Rating: 0, RecipeID: 1},
+			}},
+			RecipesRegistered: map[int64]models.Recipes{1: {{ID: 1, Name: "Pancakes"}}},
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("cooked-at=2026-10-18&rating=5&notes=Perfect"))
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		got := repo.CookLogRegistered[1][0]
+		want := models.CookLogEntry{CookedAt: time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC), ID: 3, Notes: "Perfect", Rating: 5, RecipeID: 1}
+		if got != want {
+			t.Fatalf("got %+v but want %+v", got, want)
+		}
+		assertStringsInHTML(t, getBodyHTML(rr), []string{`<p>Cooked 3 times, last on 18 Oct 2026. Average rating of 4.5/5.</p>`})
+	})
+
+	t.Run("invalid form", func(t *testing.T) {
+		testcases := []struct {
+			name string
+			body string
+			want string
+		}{
+			{name: "invalid date", body: "cooked-at=18/10/2026", want: "The date must be formatted as YYYY-MM-DD."},
+			{name: "rating too high", body: "rating=6", want: "The rating must be between 0 and 5."},
+			{name: "rating not a number", body: "rating=great", want: "The rating must be between 0 and 5."},
+		}
+		for _, tc := range testcases {
+			t.Run(tc.name, func(t *testing.T) {
+				srv.Repository = &mockRepository{
+					RecipesRegistered: map[int64]models.Recipes{1: {{ID: 1, Name: "Pancakes"}}},
+				}
+
+				rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader(tc.body))
+
+				assertStatus(t, rr.Code, http.StatusBadRequest)
+				assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"`+tc.want+`","title":"Form Error"}}`)
+			})
+		}
+	})
+
+	t.Run("log a cook of another user's recipe", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{1: {{ID: 1, Name: "Pancakes"}}},
+		}
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, ts.URL+"/recipes/2/cooks", formHeader, strings.NewReader("rating=3"))
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Recipe not found.","title":"General Error"}}`)
+	})
+
+	t.Run("delete a cook", func(t *testing.T) {
+		repo := &mockRepository{
+			CookLogRegistered: map[int64]models.CookLog{1: {
+				{CookedAt: time.Date(2026, time.October, 10, 0, 0, 0, 0, time.UTC), ID: 2, Notes: "Less salt next time", Rating: 4, RecipeID: 1},
+				{CookedAt: time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC), ID: 1, Rating: 0, RecipeID: 1},
+			}},
+			RecipesRegistered: map[int64]models.Recipes{1: {{ID: 1, Name: "Pancakes"}}},
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/2")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if got := repo.CookLogRegistered[1]; len(got) != 1 || got[0].ID != 1 {
+			t.Fatalf("got %+v but want only the cook 1", got)
+		}
+		assertStringsInHTML(t, getBodyHTML(rr), []string{`<p>Cooked once, on 01 Sep 2026.</p>`})
+	})
+
+	t.Run("delete a cook that does not exist", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			CookLogRegistered: map[int64]models.CookLog{1: {
+				{CookedAt: time.Date(2026, time.October, 10, 0, 0, 0, 0, time.UTC), ID: 2, Notes: "Less salt next time", Rating: 4, RecipeID: 1},
+				{CookedAt: time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC), ID: 1, Rating: 0, RecipeID: 1},
+			}},
+			RecipesRegistered: map[int64]models.Recipes{1: {{ID: 1, Name: "Pancakes"}}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/9")
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Cook not found.","title":"General Error"}}`)
+	})
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/templates"
+	"github.com/reaper47/recipya/web/components"
+)
+
+func (s *Server) recipeCookModeHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Recipe ID in path must be > 0."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		recipe, err := s.Repository.Recipe(id, userID)
+		if err != nil {
+			slog.Error("Failed to fetch recipe", "userID", userID, "id", id, "error", err)
+			notFoundHandler(w, r)
+			return
+		}
+
+		_ = components.CookMode(templates.Data{
+			About:           templates.NewAboutData(),
+			IsAdmin:         userID == 1,
+			IsAuthenticated: true,
+			IsHxRequest:     r.Header.Get("Hx-Request") == "true",
+			Title:           "Cook " + recipe.Name,
+			View:            templates.NewViewRecipeData(id, recipe, nil, nil, true, false),
+		}).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) recipeCookModeTimerPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Recipe ID in path must be > 0."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		step, err := strconv.Atoi(r.FormValue("step"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorFormToast("The step of the timer is invalid."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		index, err := strconv.Atoi(r.FormValue("index"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorFormToast("The timer is invalid."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		recipe, err := s.Repository.Recipe(id, userID)
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorGeneralToast("Recipe not found."), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if step < 0 || step >= len(recipe.Instructions) {
+			s.Brokers.SendToast(models.NewErrorFormToast("The step of the timer is invalid."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		timers := models.NewTimers(step, recipe.Instructions[step])
+		if index < 0 || index >= len(timers) {
+			s.Brokers.SendToast(models.NewErrorFormToast("The timer is invalid."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		label := fmt.Sprintf("%s, step %d: %s", recipe.Name, step+1, timers[index].Label())
+		s.Brokers.SendTimerFinished(id, fmt.Sprintf("%d-%d-%d", id, step, index), label, userID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,97 @@
+package server_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestHandlers_RecipeCookMode(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	srv.Repository = &mockRepository{
+		RecipesRegistered: map[int64]models.Recipes{1: {
+			{
+				ID:           1,
+				Name:         "Pancakes",
+				Ingredients:  []string{"2 eggs", "1 cup milk"},
+				Instructions: []string{"Whisk the eggs with the milk.", "Cook 2-3 minutes per side."},
+			},
+			{ID: 2, Name: "Toast"},
+		}},
+	}
+
+	uri := ts.URL + "/recipes/1/cook"
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri)
+	})
+
+	t.Run("shows one step at a time", func(t *testing.T) {
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<title hx-swap-oob="true">Cook Pancakes | Recipya</title>`,
+			`<article class="cook-step card card-bordered bg-base-100 border-gray-700"><div class="card-body gap-4"><p class="text-sm text-gray-500">Step 1 of 2 </p><p class="text-xl md:text-2xl">Whisk the eggs with the milk.</p><ul class="list-disc pl-6"><li>2 eggs</li><li>1 cup milk</li></ul></div></article>`,
+			`<article class="cook-step card card-bordered bg-base-100 border-gray-700 hidden"><div class="card-body gap-4"><p class="text-sm text-gray-500">Step 2 of 2 </p><p class="text-xl md:text-2xl">Cook 2-3 minutes per side.</p><div class="flex flex-wrap gap-2"><button class="cook-timer btn btn-outline" title="Start a timer for 2-3 minutes" data-recipe="1" data-step="1" data-index="0" data-seconds="120" data-label="2 min – 3 min" onclick="toggleCookTimer(this)">2 min – 3 min</button></div></div></article>`,
+			`<button id="cook_previous" class="join-item btn" disabled onclick="showCookStep(-1)">Previous</button> <button id="cook_next" class="join-item btn btn-primary" onclick="showCookStep(1)">Next</button>`,
+		})
+	})
+
+	t.Run("recipe without instructions", func(t *testing.T) {
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, ts.URL+"/recipes/2/cook")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<p class="text-center text-gray-500">This recipe has no instructions to follow.</p>`,
+		})
+	})
+
+	t.Run("recipe of another user", func(t *testing.T) {
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, ts.URL+"/recipes/3/cook")
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+	})
+
+	t.Run("recipe view links to cook mode", func(t *testing.T) {
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, ts.URL+"/recipes/1")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{`hx-get="/recipes/1/cook"`})
+	})
+}
+
+func TestHandlers_RecipeCookModeTimer(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	srv.Repository = &mockRepository{
+		RecipesRegistered: map[int64]models.Recipes{1: {
+			{ID: 1, Name: "Pancakes", Instructions: []string{"Mix.", "Cook 2-3 minutes per side."}},
+		}},
+	}
+
+	uri := ts.URL + "/recipes/1/cook/timers"
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodPost, uri)
+	})
+
+	t.Run("step without timers", func(t *testing.T) {
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("step=0&index=0"))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"The timer is invalid.","title":"Form Error"}}`)
+	})
+
+	t.Run("finished timer rings every device", func(t *testing.T) {
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("step=1&index=0"))
+
+		assertStatus(t, rr.Code, http.StatusNoContent)
+		assertWebsocket(t, c, 1, `{"type":"timerFinished","fileName":"","data":"1-1-0","toast":{"action":"View /recipes/1/cook","background":"alert-info","message":"Pancakes, step 2: 2 min – 3 min","title":"Timer finished"}}`)
+	})
+}
@@ -328,6 +328,7 @@ func (s *Server) updateHandler() http.HandlerFunc {
 				slog.Error("Backing up global data", "error", err)
 				return
 			}
+			s.emitWebhookEvent(models.WebhookEventBackupCompleted, 0, map[string]any{"scope": "global"})
 
 			f, err := os.Create("sessions.csv")
 			if err != nil {
@@ -85,6 +85,8 @@ func (s *Server) integrationsImport() http.HandlerFunc {
 				recipeIDs, _, err = s.Repository.AddRecipes(recipes, id, progress2)
 				if err != nil {
 					slog.Error("Failed to add recipes", userIDAttr, "error", err)
+				} else if len(recipeIDs) > 0 {
+					s.emitWebhookEvent(models.WebhookEventRecipeCreated, id, map[string]any{"recipeIDs": recipeIDs})
 				}
 			}()
 
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/templates"
+	"github.com/reaper47/recipya/web/components"
+)
+
+func (s *Server) recipeNotesPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Recipe ID in path must be > 0."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		recipe, err := s.Repository.Recipe(id, userID)
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorGeneralToast("Recipe not found."), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if strings.TrimSpace(r.FormValue("text")) == "" {
+			s.Brokers.SendToast(models.NewErrorFormToast("The note cannot be empty."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		note, err := models.NewRecipeNote(recipe, r.FormValue("target"), r.FormValue("text"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorFormToast("The note must be attached to the recipe, one of its ingredients or one of its instructions."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		note.ID, err = s.Repository.AddRecipeNote(note, userID)
+		if errors.Is(err, sql.ErrNoRows) {
+			s.Brokers.SendToast(models.NewErrorGeneralToast("Recipe not found."), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if err != nil {
+			msg := "Could not add the note."
+			slog.Error(msg, userIDAttr, "note", note, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Added recipe note", userIDAttr, "id", note.ID, "recipeID", id)
+		s.renderRecipeNotes(w, r, recipe, userID)
+	}
+}
+
+func (s *Server) recipeNoteDeleteHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Recipe ID in path must be > 0."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		noteID, err := parsePathPositiveID(r.PathValue("noteID"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Note ID in path must be > 0."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		recipe, err := s.Repository.Recipe(id, userID)
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorGeneralToast("Recipe not found."), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		err = s.Repository.DeleteRecipeNote(noteID, id, userID)
+		if errors.Is(err, sql.ErrNoRows) {
+			s.Brokers.SendToast(models.NewErrorGeneralToast("Note not found."), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if err != nil {
+			msg := "Could not delete the note."
+			slog.Error(msg, userIDAttr, "id", noteID, "recipeID", id, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Deleted recipe note", userIDAttr, "id", noteID, "recipeID", id)
+		s.renderRecipeNotes(w, r, recipe, userID)
+	}
+}
+
+// renderRecipeNotes renders the private notes of the user's recipe along with the
+// ingredients and instructions they are attached to.
+func (s *Server) renderRecipeNotes(w http.ResponseWriter, r *http.Request, recipe *models.Recipe, userID int64) {
+	notes, err := s.Repository.RecipeNotes(recipe.ID, userID)
+	if err != nil {
+		msg := "Error getting the notes of the recipe."
+		slog.Error(msg, "userID", userID, "id", recipe.ID, "error", err)
+		s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	_ = components.RecipeNotes(&templates.ViewRecipeData{
+		ID:     recipe.ID,
+		Notes:  notes,
+		Recipe: recipe,
+	}).Render(r.Context(), w)
+}
@@ -0,0 +1,194 @@
+package server_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestHandlers_RecipeNotes(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	uri := ts.URL + "/recipes/1/notes"
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodPost, uri)
+		assertMustBeLoggedIn(t, srv, http.MethodDelete, uri+"/1")
+	})
+
+	t.Run("notes are displayed in the recipe", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			NotesRegistered: map[int64]models.RecipeNotes{1: {
+				{Anchor: models.NoteAnchorRecipe, ID: 1, RecipeID: 1, Text: "Kids love it"},
+				{Anchor: models.NoteAnchorIngredient, ID: 2, Index: 1, RecipeID: 1, Text: "Use brown sugar"},
+				{Anchor: models.NoteAnchorInstruction, ID: 3, Index: 0, RecipeID: 1, Text: "Whisk by hand"},
+			}},
+			RecipesRegistered: map[int64]models.Recipes{1: {{
+				ID:           1,
+				Ingredients:  []string{"1 cup flour", "2 tbsp sugar"},
+				Instructions: []string{"Mix everything", "Bake"},
+				Name:         "Pancakes",
+			}}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, ts.URL+"/recipes/1")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		body := getBodyHTML(rr)
+		assertStringsInHTML(t, body, []string{
+			`<h2 class="card-title text-base">My notes</h2>`,
+			`<li class="flex items-start gap-2 py-1"><span class="grow whitespace-pre-line break-words">Kids love it</span><button class="btn btn-ghost btn-xs" title="Delete note" hx-delete="/recipes/1/notes/1" hx-target="#recipe_notes" hx-swap="outerHTML" hx-confirm="Are you sure you wish to delete this note?">`,
+			`<span class="label-text pl-2">2 tbsp sugar</span></label><ul class="pl-8 pb-1"><li class="flex items-start gap-1 text-xs italic text-gray-500 dark:text-gray-400"><span class="grow whitespace-pre-line break-words">Use brown sugar</span>`,
+			`<span class="whitespace-pre-line">Mix everything</span><ul class="pl-8 pb-1"><li class="flex items-start gap-1 text-xs italic text-gray-500 dark:text-gray-400"><span class="grow whitespace-pre-line break-words">Whisk by hand</span>`,
+			`<option value="recipe" selected>The whole recipe</option> <optgroup label="Ingredients"><option value="ingredient:0">1. 1 cup flour</option><option value="ingredient:1">2. 2 tbsp sugar</option></optgroup> <optgroup label="Instructions"><option value="instruction:0">1. Mix everything</option><option value="instruction:1">2. Bake</option></optgroup>`,
+			`<span class="pl-2">2 tbsp sugar</span><p class="pl-6 text-xs italic">Note: Use brown sugar</p>`,
+		})
+	})
+
+	t.Run("notes are not part of share links", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			NotesRegistered: map[int64]models.RecipeNotes{1: {
+				{Anchor: models.NoteAnchorRecipe, ID: 1, RecipeID: 1, Text: "Kids love it"},
+				{Anchor: models.NoteAnchorIngredient, ID: 2, Index: 1, RecipeID: 1, Text: "Use brown sugar"},
+				{Anchor: models.NoteAnchorInstruction, ID: 3, Index: 0, RecipeID: 1, Text: "Whisk by hand"},
+			}},
+			RecipesRegistered: map[int64]models.Recipes{1: {{
+				ID:           1,
+				Ingredients:  []string{"1 cup flour", "2 tbsp sugar"},
+				Instructions: []string{"Mix everything", "Bake"},
+				Name:         "Pancakes",
+			}}},
+			ShareLinks: map[string]models.Share{"/r/33320755-82f9-47e5-bb0a-d1b55cbd3f7b": {CookbookID: -1, RecipeID: 1, UserID: 1}},
+		}
+
+		rr := sendRequestAsLoggedInNoBody(srv, http.MethodGet, "/r/33320755-82f9-47e5-bb0a-d1b55cbd3f7b")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsNotInHTML(t, getBodyHTML(rr), []string{"My notes", "Kids love it", "Use brown sugar", "Whisk by hand"})
+	})
+
+	t.Run("add a note to an ingredient", func(t *testing.T) {
+		repo := &mockRepository{
+			NotesRegistered: map[int64]models.RecipeNotes{1: {
+				{Anchor: models.NoteAnchorRecipe, ID: 1, RecipeID: 1, Text: "Kids love it"},
+				{Anchor: models.NoteAnchorIngredient, ID: 2, Index: 1, RecipeID: 1, Text: "Use brown sugar"},
+				{Anchor: models.NoteAnchorInstruction, ID: 3, Index: 0, RecipeID: 1, Text: "Whisk by hand"},
+			}},
+			RecipesRegistered: map[int64]models.Recipes{1: {{
+				ID:           1,
+				Ingredients:  []string{"1 cup flour", "2 tbsp sugar"},
+				Instructions: []string{"Mix everything", "Bake"},
+				Name:         "Pancakes",
+			}}},
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("target=ingredient:0&text=Sift+it"))
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		notes := repo.NotesRegistered[1]
+		want := models.RecipeNote{Anchor: models.NoteAnchorIngredient, ID: 4, Index: 0, RecipeID: 1, Text: "Sift it"}
+		if got := notes[len(notes)-1]; got != want {
+			t.Fatalf("got %+v but want %+v", got, want)
+		}
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<div id="recipe_notes"`,
+			`<div id="ingredients-instructions-container" class="grid text-sm md:grid-flow-col md:col-span-6" hx-swap-oob="true">`,
+			`<span class="label-text pl-2">1 cup flour</span></label><ul class="pl-8 pb-1"><li class="flex items-start gap-1 text-xs italic text-gray-500 dark:text-gray-400"><span class="grow whitespace-pre-line break-words">Sift it</span>`,
+		})
+	})
+
+	t.Run("invalid note", func(t *testing.T) {
+		testcases := []struct {
+			name string
+			body string
+			want string
+		}{
+			{name: "empty text", body: "target=recipe&text=+", want: "The note cannot be empty."},
+			{name: "ingredient does not exist", body: "target=ingredient:2&text=Hello", want: "The note must be attached to the recipe, one of its ingredients or one of its instructions."},
+			{name: "unknown target", body: "target=tool:0&text=Hello", want: "The note must be attached to the recipe, one of its ingredients or one of its instructions."},
+		}
+		for _, tc := range testcases {
+			t.Run(tc.name, func(t *testing.T) {
+				srv.Repository = &mockRepository{
+					RecipesRegistered: map[int64]models.Recipes{1: {{
+						ID:           1,
+						Ingredients:  []string{"1 cup flour", "2 tbsp sugar"},
+						Instructions: []string{"Mix everything", "Bake"},
+						Name:         "Pancakes",
+					}}},
+				}
+
+				rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader(tc.body))
+
+				assertStatus(t, rr.Code, http.StatusBadRequest)
+				assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"`+tc.want+`","title":"Form Error"}}`)
+			})
+		}
+	})
+
+	t.Run("add a note to another user's recipe", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{1: {{
+				ID:           1,
+				Ingredients:  []string{"1 cup flour", "2 tbsp sugar"},
+				Instructions: []string{"Mix everything", "Bake"},
+				Name:         "Pancakes",
+			}}},
+		}
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, ts.URL+"/recipes/2/notes", formHeader, strings.NewReader("text=Mine"))
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Recipe not found.","title":"General Error"}}`)
+	})
+
+	t.Run("delete a note", func(t *testing.T) {
+		repo := &mockRepository{
+			NotesRegistered: map[int64]models.RecipeNotes{1: {
+				{Anchor: models.NoteAnchorRecipe, ID: 1, RecipeID: 1, Text: "Kids love it"},
+				{Anchor: models.NoteAnchorIngredient, ID: 2, Index: 1, RecipeID: 1, Text: "Use brown sugar"},
+				{Anchor: models.NoteAnchorInstruction, ID: 3, Index: 0, RecipeID: 1, Text: "Whisk by hand"},
+			}},
+			RecipesRegistered: map[int64]models.Recipes{1: {{
+				ID:           1,
+				Ingredients:  []string{"1 cup flour", "2 tbsp sugar"},
+				Instructions: []string{"Mix everything", "Bake"},
+				Name:         "Pancakes",
+			}}},
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/2")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if got := repo.NotesRegistered[1]; len(got) != 2 || got[0].ID != 1 || got[1].ID != 3 {
+			t.Fatalf("got %+v but want the notes 1 and 3", got)
+		}
+		assertStringsNotInHTML(t, getBodyHTML(rr), []string{"Use brown sugar"})
+	})
+
+	t.Run("delete a note that does not exist", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			NotesRegistered: map[int64]models.RecipeNotes{1: {
+				{Anchor: models.NoteAnchorRecipe, ID: 1, RecipeID: 1, Text: "Kids love it"},
+				{Anchor: models.NoteAnchorIngredient, ID: 2, Index: 1, RecipeID: 1, Text: "Use brown sugar"},
+				{Anchor: models.NoteAnchorInstruction, ID: 3, Index: 0, RecipeID: 1, Text: "Whisk by hand"},
+			}},
+			RecipesRegistered: map[int64]models.Recipes{1: {{
+				ID:           1,
+				Ingredients:  []string{"1 cup flour", "2 tbsp sugar"},
+				Instructions: []string{"Mix everything", "Bake"},
+				Name:         "Pancakes",
+			}}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/9")
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Note not found.","title":"General Error"}}`)
+	})
+}
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/web/components"
+)
+
+func (s *Server) recipeNutritionHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Recipe ID in path must be > 0."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		s.renderRecipeNutrition(w, r, id, userID)
+	}
+}
+
+func (s *Server) recipeNutritionFoodsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Recipe ID in path must be > 0."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		query := strings.TrimSpace(r.URL.Query().Get("q"))
+		if query == "" {
+			return
+		}
+
+		foods, err := s.Repository.SearchFoodsFDC(query)
+		if err != nil {
+			msg := "Could not search the foods."
+			slog.Error(msg, "userID", userID, "query", query, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_ = components.NutritionFoods(id, r.URL.Query().Get("ingredient"), foods).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) recipeNutritionPinPutHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Recipe ID in path must be > 0."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		pin := models.NutritionPin{Ingredient: strings.TrimSpace(r.FormValue("ingredient"))}
+		if pin.Ingredient == "" {
+			s.Brokers.SendToast(models.NewErrorFormToast("The ingredient is missing."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if value := r.FormValue("fdcId"); value != "" {
+			pin.FdcID, err = parsePathPositiveID(value)
+			if err != nil {
+				s.Brokers.SendToast(models.NewErrorFormToast("The food must be a food of the FDC database."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		if value := r.FormValue("grams"); value != "" {
+			pin.Grams, err = strconv.ParseFloat(value, 64)
+			if err != nil || pin.Grams < 0 {
+				s.Brokers.SendToast(models.NewErrorFormToast("The weight must be a positive number of grams."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		err = s.Repository.UpdateNutritionPin(id, pin, userID)
+		if errors.Is(err, sql.ErrNoRows) {
+			s.Brokers.SendToast(models.NewErrorGeneralToast("Recipe not found."), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if err != nil {
+			msg := "Could not update the nutrition of the ingredient."
+			slog.Error(msg, userIDAttr, "id", id, "pin", pin, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Updated nutrition pin", userIDAttr, "id", id, "pin", pin)
+		s.renderRecipeNutrition(w, r, id, userID)
+	}
+}
+
+// renderRecipeNutrition renders the per-ingredient nutrition breakdown of the user's recipe.
+func (s *Server) renderRecipeNutrition(w http.ResponseWriter, r *http.Request, recipeID, userID int64) {
+	breakdown, err := s.Repository.IngredientsNutrition(recipeID, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		s.Brokers.SendToast(models.NewErrorGeneralToast("Recipe not found."), userID)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	} else if err != nil {
+		msg := "Error getting the nutrition of the ingredients."
+		slog.Error(msg, "userID", userID, "id", recipeID, "error", err)
+		s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	_ = components.RecipeNutrition(recipeID, breakdown).Render(r.Context(), w)
+}
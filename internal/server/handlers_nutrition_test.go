@@ -0,0 +1,170 @@
+package server_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestHandlers_RecipeNutrition(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	uri := ts.URL + "/recipes/1/nutrition"
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri)
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri+"/foods")
+		assertMustBeLoggedIn(t, srv, http.MethodPut, uri+"/pins")
+	})
+
+	t.Run("displays the breakdown", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			IngredientsNutritionRegistered: map[int64]models.IngredientsNutrition{1: {
+				{Calories: 910, Food: models.FoodFDC{ID: 10, Description: "Wheat flour, white, all-purpose"}, Grams: 250, Ingredient: "2 cups flour", Protein: 25.8},
+				{Ingredient: "1 pinch salt"},
+			}},
+			RecipesRegistered: map[int64]models.Recipes{1: {
+				{ID: 1, Name: "Pancakes", Ingredients: []string{"2 cups flour", "1 pinch salt"}},
+			}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<td class="break-words">2 cups flour</td><td class="break-words">Wheat flour, white, all-purpose</td><td>250 g</td><td>910 kcal</td><td>0.0 g</td><td>0.0 g</td><td>25.8 g</td>`,
+			`<td class="break-words">1 pinch salt</td><td class="break-words"><span class="opacity-70">No match</span></td><td>0 g</td>`,
+			`<th colspan="3">Total</th><th colspan="5">910 kcal</th>`,
+		})
+	})
+
+	t.Run("recipe of another user", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			IngredientsNutritionRegistered: map[int64]models.IngredientsNutrition{1: {
+				{Calories: 910, Food: models.FoodFDC{ID: 10, Description: "Wheat flour, white, all-purpose"}, Grams: 250, Ingredient: "2 cups flour", Protein: 25.8},
+				{Ingredient: "1 pinch salt"},
+			}},
+			RecipesRegistered: map[int64]models.Recipes{1: {
+				{ID: 1, Name: "Pancakes", Ingredients: []string{"2 cups flour", "1 pinch salt"}},
+			}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, ts.URL+"/recipes/2/nutrition")
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Recipe not found.","title":"General Error"}}`)
+	})
+
+	t.Run("search foods", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			FoodsFDC: []models.FoodFDC{
+				{ID: 10, Description: "Wheat flour, white, all-purpose"},
+				{ID: 11, Description: "Rice flour, white"},
+				{ID: 12, Description: "Milk, whole"},
+			},
+			RecipesRegistered: map[int64]models.Recipes{1: {
+				{ID: 1, Name: "Pancakes", Ingredients: []string{"2 cups flour", "1 pinch salt"}},
+			}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/foods?q=flour&ingredient=2+cups+flour")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		body := getBodyHTML(rr)
+		assertStringsInHTML(t, body, []string{
+			`<form hx-put="/recipes/1/nutrition/pins" hx-target="#nutrition_breakdown" hx-swap="outerHTML"><input type="hidden" name="ingredient" value="2 cups flour"> <input type="hidden" name="fdcId" value="11"><button type="submit" class="text-left w-full">Rice flour, white</button></form>`,
+		})
+		if strings.Contains(body, "Milk, whole") {
+			t.Fatal("only the foods matching the query must be listed")
+		}
+	})
+
+	t.Run("pin a food", func(t *testing.T) {
+		repo := &mockRepository{
+			FoodsFDC: []models.FoodFDC{
+				{ID: 10, Description: "Wheat flour, white, all-purpose"},
+				{ID: 11, Description: "Rice flour, white"},
+				{ID: 12, Description: "Milk, whole"},
+			},
+			IngredientsNutritionRegistered: map[int64]models.IngredientsNutrition{1: {
+				{Calories: 910, Food: models.FoodFDC{ID: 10, Description: "Wheat flour, white, all-purpose"}, Grams: 250, Ingredient: "2 cups flour", Protein: 25.8},
+				{Ingredient: "1 pinch salt"},
+			}},
+			RecipesRegistered: map[int64]models.Recipes{1: {
+				{ID: 1, Name: "Pancakes", Ingredients: []string{"2 cups flour", "1 pinch salt"}},
+			}},
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri+"/pins", formHeader, strings.NewReader("ingredient=2 cups flour&fdcId=11"))
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		got := repo.IngredientsNutritionRegistered[1][0]
+		if !got.IsPinned || got.Food.ID != 11 {
+			t.Fatalf("got %+v but want the rice flour pinned", got)
+		}
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<td class="break-words">Rice flour, white <span class="badge badge-ghost badge-sm">pinned</span></td>`,
+		})
+	})
+
+	t.Run("pin a weight", func(t *testing.T) {
+		repo := &mockRepository{
+			IngredientsNutritionRegistered: map[int64]models.IngredientsNutrition{1: {
+				{Calories: 910, Food: models.FoodFDC{ID: 10, Description: "Wheat flour, white, all-purpose"}, Grams: 250, Ingredient: "2 cups flour", Protein: 25.8},
+				{Ingredient: "1 pinch salt"},
+			}},
+			RecipesRegistered: map[int64]models.Recipes{1: {
+				{ID: 1, Name: "Pancakes", Ingredients: []string{"2 cups flour", "1 pinch salt"}},
+			}},
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri+"/pins", formHeader, strings.NewReader("ingredient=1 pinch salt&grams=0.4"))
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		got := repo.IngredientsNutritionRegistered[1][1]
+		if !got.IsPinned || got.Grams != 0.4 {
+			t.Fatalf("got %+v but want 0.4 g pinned", got)
+		}
+	})
+
+	testcases := []struct {
+		name string
+		form string
+		want string
+	}{
+		{
+			name: "missing ingredient",
+			form: "fdcId=11",
+			want: "The ingredient is missing.",
+		},
+		{
+			name: "invalid food",
+			form: "ingredient=2 cups flour&fdcId=-4",
+			want: "The food must be a food of the FDC database.",
+		},
+		{
+			name: "invalid weight",
+			form: "ingredient=2 cups flour&grams=-1",
+			want: "The weight must be a positive number of grams.",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv.Repository = &mockRepository{
+				RecipesRegistered: map[int64]models.Recipes{1: {
+					{ID: 1, Name: "Pancakes", Ingredients: []string{"2 cups flour", "1 pinch salt"}},
+				}},
+			}
+
+			rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri+"/pins", formHeader, strings.NewReader(tc.form))
+
+			assertStatus(t, rr.Code, http.StatusBadRequest)
+			assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"`+tc.want+`","title":"Form Error"}}`)
+		})
+	}
+}
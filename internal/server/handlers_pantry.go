@@ -0,0 +1,106 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/templates"
+	"github.com/reaper47/recipya/web/components"
+)
+
+func (s *Server) pantryHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		items, err := s.Repository.PantryItems(userID)
+		if err != nil {
+			msg := "Error getting the pantry."
+			slog.Error(msg, "userID", userID, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_ = components.Pantry(templates.Data{
+			About:           templates.NewAboutData(),
+			IsAdmin:         userID == 1,
+			IsAuthenticated: true,
+			IsHxRequest:     r.Header.Get("Hx-Request") == "true",
+			Pantry:          items,
+			Title:           "Pantry",
+		}).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) pantryPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		var expiresAt time.Time
+		if value := r.FormValue("expires-at"); value != "" {
+			var err error
+			expiresAt, err = time.Parse(time.DateOnly, value)
+			if err != nil {
+				s.Brokers.SendToast(models.NewErrorFormToast("The expiry date must be formatted as YYYY-MM-DD."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		item := models.NewPantryItem(strings.TrimSpace(r.FormValue("ingredient")), expiresAt)
+		if item.Name == "" {
+			s.Brokers.SendToast(models.NewErrorFormToast("Enter the name of the ingredient."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		id, err := s.Repository.AddPantryItem(item, userID)
+		if err != nil {
+			msg := "Could not add the item to the pantry."
+			slog.Error(msg, userIDAttr, "item", item, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		item.ID = id
+
+		slog.Info("Added pantry item", userIDAttr, "id", id, "name", item.Name)
+		w.WriteHeader(http.StatusCreated)
+		_ = components.PantryItem(item, time.Now()).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) pantryDeleteHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Pantry item ID in path must be > 0."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = s.Repository.DeletePantryItem(id, userID)
+		if errors.Is(err, sql.ErrNoRows) {
+			s.Brokers.SendToast(models.NewErrorGeneralToast("Pantry item not found."), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if err != nil {
+			msg := "Could not delete the pantry item."
+			slog.Error(msg, userIDAttr, "id", id, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Deleted pantry item", userIDAttr, "id", id)
+	}
+}
@@ -0,0 +1,138 @@
+package server_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/units"
+)
+
+func TestHandlers_Pantry(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	uri := ts.URL + "/pantry"
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri)
+		assertMustBeLoggedIn(t, srv, http.MethodPost, uri)
+		assertMustBeLoggedIn(t, srv, http.MethodDelete, uri+"/1")
+	})
+
+	t.Run("displays the pantry", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			PantryRegistered: map[int64][]models.PantryItem{1: {
+				{ID: 1, ExpiresAt: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), Name: "milk"},
+				{ID: 2, Name: "flour", Quantity: 2, Unit: units.Kilogram},
+			}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<title hx-swap-oob="true">Pantry | Recipya</title>`,
+			`<button class="btn btn-sm btn-primary" hx-get="/recipes/search?q=pantry:true" hx-target="#content" hx-push-url="true">What can I cook?</button>`,
+			`<span class="grow break-words">milk</span><span class="badge badge-error">Expired Jan 2, 2020</span>`,
+			`<span class="grow break-words"><span class="font-medium">2 kg</span> flour</span>`,
+			`hx-delete="/pantry/2" hx-target="closest .pantry-item" hx-swap="outerHTML"`,
+		})
+	})
+
+	t.Run("add item", func(t *testing.T) {
+		repo := &mockRepository{
+			PantryRegistered: map[int64][]models.PantryItem{1: {
+				{ID: 1, ExpiresAt: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), Name: "milk"},
+				{ID: 2, Name: "flour", Quantity: 2, Unit: units.Kilogram},
+			}},
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("ingredient=12 eggs&expires-at=2099-10-30"))
+
+		assertStatus(t, rr.Code, http.StatusCreated)
+		items := repo.PantryRegistered[1]
+		if len(items) != 3 || items[2].Name != "eggs" || items[2].Quantity != 12 {
+			t.Fatalf("unexpected items %+v", items)
+		}
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<span class="grow break-words"><span class="font-medium">12</span> eggs</span><span class="badge badge-ghost">Expires Oct 30, 2099</span>`,
+		})
+	})
+
+	t.Run("add item without name", func(t *testing.T) {
+		srv.Repository = &mockRepository{}
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("ingredient=  "))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Enter the name of the ingredient.","title":"Form Error"}}`)
+	})
+
+	t.Run("add item invalid expiry date", func(t *testing.T) {
+		srv.Repository = &mockRepository{}
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("ingredient=eggs&expires-at=tomorrow"))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"The expiry date must be formatted as YYYY-MM-DD.","title":"Form Error"}}`)
+	})
+
+	t.Run("delete item", func(t *testing.T) {
+		repo := &mockRepository{
+			PantryRegistered: map[int64][]models.PantryItem{1: {
+				{ID: 1, ExpiresAt: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), Name: "milk"},
+				{ID: 2, Name: "flour", Quantity: 2, Unit: units.Kilogram},
+			}},
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/1")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if len(repo.PantryRegistered[1]) != 1 {
+			t.Fatal("item must have been deleted")
+		}
+	})
+
+	t.Run("delete item not found", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			PantryRegistered: map[int64][]models.PantryItem{1: {
+				{ID: 1, ExpiresAt: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), Name: "milk"},
+				{ID: 2, Name: "flour", Quantity: 2, Unit: units.Kilogram},
+			}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/9")
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Pantry item not found.","title":"General Error"}}`)
+	})
+
+	t.Run("search recipes with the pantry", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			PantryRegistered: map[int64][]models.PantryItem{1: {
+				{ID: 1, ExpiresAt: time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), Name: "milk"},
+				{ID: 2, Name: "flour", Quantity: 2, Unit: units.Kilogram},
+			}},
+			RecipesRegistered: map[int64]models.Recipes{1: {
+				{ID: 1, Name: "Pancakes", Ingredients: []string{"2 cups flour", "1 cup milk", "2 eggs"}},
+				{ID: 2, Name: "Salad", Ingredients: []string{"1 head lettuce"}},
+			}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, ts.URL+"/recipes/search?q=pantry:true")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		body := getBodyHTML(rr)
+		assertStringsInHTML(t, body, []string{
+			`<div class="text-xs"><p class="font-medium">2/3 ingredients in pantry</p><p class="opacity-70 break-words">Missing: eggs</p></div>`,
+		})
+		if strings.Contains(body, "Salad") {
+			t.Fatal("recipes without pantry ingredients must not be listed")
+		}
+	})
+}
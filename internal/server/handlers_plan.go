@@ -0,0 +1,230 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/templates"
+	"github.com/reaper47/recipya/web/components"
+)
+
+func (s *Server) planHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		date := time.Now()
+		if week := r.URL.Query().Get("week"); week != "" {
+			var err error
+			date, err = time.Parse(time.DateOnly, week)
+			if err != nil {
+				s.Brokers.SendToast(models.NewErrorReqToast("The week must be formatted as YYYY-MM-DD."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		week, err := s.mealPlanWeek(date, userID)
+		if err != nil {
+			msg := "Error getting the meal plan."
+			slog.Error(msg, "userID", userID, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_ = components.Plan(templates.Data{
+			About:           templates.NewAboutData(),
+			Functions:       templates.NewFunctionsData[int64](),
+			IsAdmin:         userID == 1,
+			IsAuthenticated: true,
+			IsHxRequest:     r.Header.Get("Hx-Request") == "true",
+			MealPlan:        week,
+			Recipes:         s.Repository.Recipes(userID, models.NewSearchOptionsRecipe(nil)),
+			Title:           "Meal plan",
+		}).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) planEntriesPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		recipeID, err := parsePathPositiveID(r.FormValue("recipeId"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorFormToast("Missing 'recipeId' in body."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		entry, err := parseMealPlanEntryForm(r)
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorFormToast("Could not plan the recipe: "+err.Error()+"."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		entry.RecipeID = recipeID
+
+		entry.ID, err = s.Repository.AddMealPlanEntry(entry, userID)
+		if errors.Is(err, sql.ErrNoRows) {
+			s.Brokers.SendToast(models.NewErrorGeneralToast("Recipe not found."), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if err != nil {
+			msg := "Could not add the recipe to the meal plan."
+			slog.Error(msg, userIDAttr, "entry", entry, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Planned recipe", userIDAttr, "entry", entry)
+		s.renderMealPlanWeek(w, r, entry.Date, userID, http.StatusCreated)
+	}
+}
+
+func (s *Server) planEntryDeleteHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Entry ID in path must be > 0."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = s.Repository.DeleteMealPlanEntry(id, userID)
+		if errors.Is(err, sql.ErrNoRows) {
+			s.Brokers.SendToast(models.NewErrorGeneralToast("Planned recipe not found."), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if err != nil {
+			msg := "Could not remove the recipe from the meal plan."
+			slog.Error(msg, userIDAttr, "id", id, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Unplanned recipe", userIDAttr, "id", id)
+	}
+}
+
+func (s *Server) planEntryPutHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Entry ID in path must be > 0."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		entry, err := parseMealPlanEntryForm(r)
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorFormToast("Could not update the planned recipe: "+err.Error()+"."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		entry.ID = id
+
+		err = s.Repository.UpdateMealPlanEntry(entry, userID)
+		if errors.Is(err, sql.ErrNoRows) {
+			s.Brokers.SendToast(models.NewErrorGeneralToast("Planned recipe not found."), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if err != nil {
+			msg := "Could not update the planned recipe."
+			slog.Error(msg, userIDAttr, "entry", entry, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Updated planned recipe", userIDAttr, "entry", entry)
+		s.renderMealPlanWeek(w, r, entry.Date, userID, http.StatusOK)
+	}
+}
+
+func (s *Server) planRecipesSearchHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		opts := models.NewSearchOptionsRecipe(r.URL.Query())
+
+		var recipes models.Recipes
+		if r.URL.Query().Get("q") == "" {
+			recipes = s.Repository.Recipes(userID, opts)
+		} else {
+			var err error
+			recipes, _, err = s.Repository.SearchRecipes(opts, userID)
+			if err != nil {
+				s.Brokers.SendToast(models.NewErrorDBToast("Error searching recipes."), userID)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		if len(recipes) == 0 {
+			_ = components.SearchNoResult().Render(r.Context(), w)
+			return
+		}
+
+		_ = components.PlanSearchRecipes(templates.Data{
+			Functions: templates.NewFunctionsData[int64](),
+			Recipes:   recipes,
+		}).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) mealPlanWeek(date time.Time, userID int64) (models.MealPlanWeek, error) {
+	start := models.StartOfWeek(date)
+
+	entries, err := s.Repository.MealPlan(userID, start, start.AddDate(0, 0, 6))
+	if err != nil {
+		return models.MealPlanWeek{}, err
+	}
+	return models.NewMealPlanWeek(start, entries), nil
+}
+
+func (s *Server) renderMealPlanWeek(w http.ResponseWriter, r *http.Request, date time.Time, userID int64, status int) {
+	week, err := s.mealPlanWeek(date, userID)
+	if err != nil {
+		msg := "Error getting the meal plan."
+		slog.Error(msg, "userID", userID, "error", err)
+		s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+	_ = components.PlanWeek(week).Render(r.Context(), w)
+}
+
+func parseMealPlanEntryForm(r *http.Request) (models.MealPlanEntry, error) {
+	date, err := time.Parse(time.DateOnly, r.FormValue("date"))
+	if err != nil {
+		return models.MealPlanEntry{}, errors.New("the date must be formatted as YYYY-MM-DD")
+	}
+
+	slot, err := models.NewMealSlot(r.FormValue("slot"))
+	if err != nil {
+		return models.MealPlanEntry{}, errors.New("the meal slot must be one of breakfast, lunch, dinner or snack")
+	}
+
+	servings, err := strconv.ParseInt(r.FormValue("servings"), 10, 16)
+	if err != nil || servings <= 0 {
+		return models.MealPlanEntry{}, errors.New("the servings must be greater than zero")
+	}
+
+	return models.MealPlanEntry{Date: date, Servings: int16(servings), Slot: slot}, nil
+}
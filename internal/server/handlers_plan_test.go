@@ -0,0 +1,263 @@
+package server_test
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestHandlers_Plan(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	monday := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	uri := ts.URL + "/plan"
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri)
+	})
+
+	t.Run("invalid week", func(t *testing.T) {
+		srv.Repository = &mockRepository{}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"?week=next")
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"The week must be formatted as YYYY-MM-DD.","title":"Request Error"}}`)
+	})
+
+	t.Run("displays the week of the date", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			MealPlanRegistered: map[int64][]models.MealPlanEntry{
+				1: {
+					{ID: 1, Date: monday.AddDate(0, 0, 2), RecipeID: 1, RecipeName: "Lasagna", RecipeYield: 4, Servings: 6, Slot: models.MealSlotDinner},
+					{ID: 2, Date: monday.AddDate(0, 0, 7), RecipeID: 2, RecipeName: "Pancakes", RecipeYield: 2, Servings: 2, Slot: models.MealSlotBreakfast},
+				},
+			},
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "Lasagna", Yield: 4},
+					{ID: 2, Name: "Pancakes", Yield: 2},
+				},
+			},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"?week=2026-10-22")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		body := getBodyHTML(rr)
+		assertStringsInHTML(t, body, []string{
+			`<title hx-swap-oob="true">Meal plan | Recipya</title>`,
+			`<button class="btn btn-sm btn-ghost print:hidden" hx-get="/plan?week=2026-10-12" hx-target="#content" hx-push-url="true">« Previous</button><div class="flex items-center gap-2"><h1 class="font-semibold md:text-lg">Oct 19 – Oct 25, 2026</h1>`,
+			`<button class="btn btn-sm btn-ghost print:hidden" title="Create a shopping list from the week" hx-post="/shopping-lists" hx-vals="{"week": "2026-10-19"}">`,
+			`<button class="btn btn-sm btn-ghost print:hidden" title="Batch cook the week's recipes" hx-get="/timeline?recipeId=1" hx-target="#content" hx-push-url="true">`,
+			`<button class="btn btn-sm btn-ghost print:hidden" hx-get="/plan?week=2026-10-26" hx-target="#content" hx-push-url="true">Next »</button>`,
+			`<h2 class="font-semibold">Monday <span class="font-normal opacity-70">Oct 19</span></h2>`,
+			`<h2 class="font-semibold">Sunday <span class="font-normal opacity-70">Oct 25</span></h2>`,
+			`data-date="2026-10-21" data-slot="dinner"`,
+			`<li class="plan-entry flex items-center gap-1 rounded bg-base-200 p-1 text-xs cursor-grab" draggable="true" _="on dragstart call planDragStart(event, 'entry', 1, 6)">`,
+			`hx-get="/recipes/1/scale?yield=6" hx-target="#plan_recipe_content"`,
+			`hx-put="/plan/entries/1" hx-vals="{"date": "2026-10-21", "slot": "dinner"}" hx-trigger="change" hx-target="#plan-week" hx-swap="outerHTML"`,
+			`<button class="btn btn-ghost btn-xs px-1" title="Remove from the plan" hx-delete="/plan/entries/1" hx-target="closest .plan-entry" hx-swap="outerHTML">`,
+			`_="on dragstart call planDragStart(event, 'recipe', 2, 2)"`,
+		})
+		if strings.Contains(body, "Pancakes</a>") {
+			t.Fatal("entries of the following week must not be displayed")
+		}
+	})
+
+	t.Run("search recipes to plan", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "Lasagna", Yield: 4},
+					{ID: 2, Name: "Pancakes", Yield: 2},
+				},
+			},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/recipes/search?q=lasagna")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		body := getBodyHTML(rr)
+		assertStringsInHTML(t, body, []string{
+			`<p class="font-medium break-words">Lasagna</p><p class="text-xs opacity-70">4 servings</p>`,
+		})
+		if strings.Contains(body, "Pancakes") {
+			t.Fatal("search results must only contain matching recipes")
+		}
+	})
+
+	t.Run("search recipes no result", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{
+				1: {
+					{ID: 1, Name: "Lasagna", Yield: 4},
+					{ID: 2, Name: "Pancakes", Yield: 2},
+				},
+			},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/recipes/search?q=sushi")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{`<p>No results found.</p>`})
+	})
+}
+
+func TestHandlers_Plan_Entries(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	uri := ts.URL + "/plan/entries"
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodPost, uri)
+		assertMustBeLoggedIn(t, srv, http.MethodPut, uri+"/1")
+		assertMustBeLoggedIn(t, srv, http.MethodDelete, uri+"/1")
+	})
+
+	t.Run("add entry invalid form", func(t *testing.T) {
+		testcases := []struct {
+			name string
+			in   string
+			want string
+		}{
+			{
+				name: "missing recipe",
+				in:   "date=2026-10-22&slot=lunch&servings=2",
+				want: "Missing 'recipeId' in body.",
+			},
+			{
+				name: "invalid date",
+				in:   "recipeId=1&date=22/10/2026&slot=lunch&servings=2",
+				want: "Could not plan the recipe: the date must be formatted as YYYY-MM-DD.",
+			},
+			{
+				name: "invalid slot",
+				in:   "recipeId=1&date=2026-10-22&slot=brunch&servings=2",
+				want: "Could not plan the recipe: the meal slot must be one of breakfast, lunch, dinner or snack.",
+			},
+			{
+				name: "invalid servings",
+				in:   "recipeId=1&date=2026-10-22&slot=lunch&servings=0",
+				want: "Could not plan the recipe: the servings must be greater than zero.",
+			},
+		}
+		for _, tc := range testcases {
+			t.Run(tc.name, func(t *testing.T) {
+				srv.Repository = &mockRepository{
+					RecipesRegistered: map[int64]models.Recipes{1: {{ID: 1, Name: "Lasagna", Yield: 4}}},
+				}
+
+				rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader(tc.in))
+
+				assertStatus(t, rr.Code, http.StatusBadRequest)
+				assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"`+tc.want+`","title":"Form Error"}}`)
+			})
+		}
+	})
+
+	t.Run("add entry recipe not found", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{1: {{ID: 1, Name: "Lasagna", Yield: 4}}},
+		}
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("recipeId=7&date=2026-10-22&slot=lunch&servings=2"))
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Recipe not found.","title":"General Error"}}`)
+	})
+
+	t.Run("add entry", func(t *testing.T) {
+		repo := &mockRepository{
+			MealPlanRegistered: map[int64][]models.MealPlanEntry{
+				1: {{ID: 1, Date: time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC), RecipeID: 1, RecipeName: "Lasagna", RecipeYield: 4, Servings: 4, Slot: models.MealSlotDinner}},
+			},
+			RecipesRegistered: map[int64]models.Recipes{1: {{ID: 1, Name: "Lasagna", Yield: 4}}},
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("recipeId=1&date=2026-10-22&slot=lunch&servings=2"))
+
+		assertStatus(t, rr.Code, http.StatusCreated)
+		want := models.MealPlanEntry{ID: 2, Date: time.Date(2026, 10, 22, 0, 0, 0, 0, time.UTC), RecipeID: 1, RecipeName: "Lasagna", RecipeYield: 4, Servings: 2, Slot: models.MealSlotLunch}
+		if !slices.Contains(repo.MealPlanRegistered[1], want) {
+			t.Fatalf("entry %+v not planned: %+v", want, repo.MealPlanRegistered[1])
+		}
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<div id="plan-week" class="grid gap-2 sm:grid-cols-2 lg:grid-cols-4 2xl:grid-cols-7">`,
+			`hx-get="/recipes/1/scale?yield=4"`,
+			`hx-get="/recipes/1/scale?yield=2"`,
+		})
+	})
+
+	t.Run("move entry", func(t *testing.T) {
+		repo := &mockRepository{
+			MealPlanRegistered: map[int64][]models.MealPlanEntry{
+				1: {{ID: 1, Date: time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC), RecipeID: 1, RecipeName: "Lasagna", RecipeYield: 4, Servings: 4, Slot: models.MealSlotDinner}},
+			},
+			RecipesRegistered: map[int64]models.Recipes{1: {{ID: 1, Name: "Lasagna", Yield: 4}}},
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri+"/1", formHeader, strings.NewReader("date=2026-10-24&slot=snack&servings=8"))
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		want := models.MealPlanEntry{ID: 1, Date: time.Date(2026, 10, 24, 0, 0, 0, 0, time.UTC), RecipeID: 1, RecipeName: "Lasagna", RecipeYield: 4, Servings: 8, Slot: models.MealSlotSnack}
+		if got := repo.MealPlanRegistered[1][0]; got != want {
+			t.Fatalf("got %+v but want %+v", got, want)
+		}
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`hx-vals="{"date": "2026-10-24", "slot": "snack"}"`,
+			`hx-get="/recipes/1/scale?yield=8"`,
+		})
+	})
+
+	t.Run("update entry not found", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			MealPlanRegistered: map[int64][]models.MealPlanEntry{
+				1: {{ID: 1, Date: time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC), RecipeID: 1, RecipeName: "Lasagna", RecipeYield: 4, Servings: 4, Slot: models.MealSlotDinner}},
+			},
+		}
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri+"/5", formHeader, strings.NewReader("date=2026-10-24&slot=snack&servings=8"))
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Planned recipe not found.","title":"General Error"}}`)
+	})
+
+	t.Run("delete entry not found", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			MealPlanRegistered: map[int64][]models.MealPlanEntry{
+				1: {{ID: 1, Date: time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC), RecipeID: 1, RecipeName: "Lasagna", RecipeYield: 4, Servings: 4, Slot: models.MealSlotDinner}},
+			},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/5")
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Planned recipe not found.","title":"General Error"}}`)
+	})
+
+	t.Run("delete entry", func(t *testing.T) {
+		repo := &mockRepository{
+			MealPlanRegistered: map[int64][]models.MealPlanEntry{
+				1: {{ID: 1, Date: time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC), RecipeID: 1, RecipeName: "Lasagna", RecipeYield: 4, Servings: 4, Slot: models.MealSlotDinner}},
+			},
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/1")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if len(repo.MealPlanRegistered[1]) > 0 {
+			t.Fatal("entry must have been deleted")
+		}
+	})
+}
@@ -3,6 +3,8 @@ package server
 import (
 	"bytes"
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -21,6 +23,7 @@ import (
 	"github.com/reaper47/recipya/internal/app"
 	"github.com/reaper47/recipya/internal/models"
 	"github.com/reaper47/recipya/internal/templates"
+	"github.com/reaper47/recipya/internal/units"
 	"github.com/reaper47/recipya/internal/utils/extensions"
 	"github.com/reaper47/recipya/web/components"
 )
@@ -152,6 +155,8 @@ func (s *Server) recipesAddImportHandler() http.HandlerFunc {
 				recipeIDs, report.Logs, err = s.Repository.AddRecipes(recipes, userID, progress)
 				if err != nil {
 					slog.Error("Error adding recipes", userIDAttr, "recipes", recipes, "error", err)
+				} else if len(recipeIDs) > 0 {
+					s.emitWebhookEvent(models.WebhookEventRecipeCreated, userID, map[string]any{"recipeIDs": recipeIDs})
 				}
 			}()
 
@@ -165,6 +170,7 @@ func (s *Server) recipesAddImportHandler() http.HandlerFunc {
 
 			numSuccess := len(recipeIDs)
 			skipped := total - numSuccess
+			s.emitWebhookEvent(models.WebhookEventImportFinished, userID, models.NewImportFinishedData(report, numSuccess, total))
 
 			redirect := "/reports?view=latest"
 			if numSuccess == 1 {
@@ -202,6 +208,7 @@ func (s *Server) recipeAddManualHandler() http.HandlerFunc {
 				Categories: categories,
 				Keywords:   keywords,
 				Recipe:     &models.Recipe{},
+				Recipes:    s.Repository.RecipesAll(userID),
 			},
 		}).Render(r.Context(), w)
 	}
@@ -271,22 +278,11 @@ func (s *Server) recipeAddManualPostHandler() http.HandlerFunc {
 			}
 		}
 
-		var ingredients []string
-		xs, ok := r.Form["ingredients"]
-		if ok {
-			ingredients = make([]string, 0, len(xs))
-			ingredients = append(ingredients, xs...)
-		}
-
-		var instructions []string
-		xs, ok = r.Form["instructions"]
-		if ok {
-			instructions = make([]string, 0, len(xs))
-			instructions = append(instructions, xs...)
-		}
+		ingredients, ingredientSections := newFormSections(r.Form["ingredients"])
+		instructions, instructionSections := newFormSections(r.Form["instructions"])
 
 		var keywords []string
-		xs, ok = r.Form["keywords"]
+		xs, ok := r.Form["keywords"]
 		if ok {
 			keywords = make([]string, 0, len(xs))
 			keywords = append(keywords, xs...)
@@ -323,21 +319,23 @@ func (s *Server) recipeAddManualPostHandler() http.HandlerFunc {
 			return
 		}
 
-		yield, err := strconv.ParseInt(r.FormValue("yield"), 10, 16)
-		if err != nil {
+		yield, err := strconv.ParseFloat(r.FormValue("yield"), 64)
+		if err != nil || yield <= 0 {
 			yield = 1
 		}
 
 		recipe := models.Recipe{
-			Category:     strings.ToLower(r.FormValue("category")),
-			CreatedAt:    time.Time{},
-			Cuisine:      "",
-			Description:  r.FormValue("description"),
-			Images:       imageUUIDs,
-			Ingredients:  ingredients,
-			Instructions: instructions,
-			Keywords:     keywords,
-			Name:         r.FormValue("title"),
+			Category:            strings.ToLower(r.FormValue("category")),
+			CreatedAt:           time.Time{},
+			Cuisine:             "",
+			Description:         r.FormValue("description"),
+			Images:              imageUUIDs,
+			IngredientSections:  ingredientSections,
+			Ingredients:         ingredients,
+			InstructionSections: instructionSections,
+			Instructions:        instructions,
+			Keywords:            keywords,
+			Name:                r.FormValue("title"),
 			Nutrition: models.Nutrition{
 				Calories:           r.FormValue("calories"),
 				Cholesterol:        r.FormValue("cholesterol"),
@@ -351,11 +349,34 @@ func (s *Server) recipeAddManualPostHandler() http.HandlerFunc {
 				Sugars:             r.FormValue("sugars"),
 				TotalCarbohydrates: r.FormValue("total-carbohydrates"),
 			},
-			Times:  times,
-			Tools:  tools,
-			URL:    r.FormValue("source"),
-			Videos: videos,
-			Yield:  int16(yield),
+			StructuredIngredients: newFormIngredients(r.Form),
+			Times:                 times,
+			Tools:                 tools,
+			URL:                   r.FormValue("source"),
+			Videos:                videos,
+			Yield:                 yield,
+			YieldUnit:             strings.TrimSpace(r.FormValue("yield-unit")),
+		}
+
+		err = s.checkSubRecipes(&recipe, 0, userID)
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorFormToast(subRecipeErrorMessage(err)), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var parentID int64
+		if r.FormValue("parent-id") != "" {
+			parentID, err = parsePathPositiveID(r.FormValue("parent-id"))
+			if err == nil {
+				_, err = s.Repository.Recipe(parentID, userID)
+			}
+
+			if err != nil {
+				s.Brokers.SendToast(models.NewErrorFormToast("The recipe this is a variant of does not exist."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
 		}
 
 		recipeIDs, _, err := s.Repository.AddRecipes(models.Recipes{recipe}, userID, nil)
@@ -367,12 +388,136 @@ func (s *Server) recipeAddManualPostHandler() http.HandlerFunc {
 			return
 		}
 
+		if parentID > 0 {
+			err = s.Repository.UpdateRecipeParent(recipeIDs[0], parentID, userID)
+			if err != nil {
+				slog.Error("Could not link the variant to its parent", userIDAttr, "id", recipeIDs[0], "parentID", parentID, "error", err)
+			}
+		}
+
 		slog.Info("Recipe added", userIDAttr, "recipeNumber", recipeIDs[0], "recipe", recipe.Name)
+		s.emitWebhookEvent(models.WebhookEventRecipeCreated, userID, map[string]any{"recipeIDs": recipeIDs})
 		w.Header().Set("HX-Redirect", "/recipes/"+strconv.FormatInt(recipeIDs[0], 10))
 		w.WriteHeader(http.StatusCreated)
 	}
 }
 
+// newFormIngredients reads the parts of the ingredients edited in the recipe form. Only the
+// parts still matching the ingredient's text are kept, so that edited ingredients get parsed
+// anew while keeping the recipe they refer to. It returns nil when the form has no parts,
+// e.g. when sent through the API.
+func newFormIngredients(form url.Values) []models.Ingredient {
+	texts := form["ingredients"]
+	parsed := form["ingredient-parsed"]
+	names := form["ingredient-name"]
+	notes := form["ingredient-note"]
+	optionals := form["ingredient-optional"]
+	quantities := form["ingredient-quantity"]
+	quantitiesMax := form["ingredient-quantity-max"]
+	recipeIDs := form["ingredient-recipe"]
+	unitNames := form["ingredient-unit"]
+
+	n := len(texts)
+	if n == 0 || len(parsed) != n || len(names) != n || len(notes) != n || len(optionals) != n ||
+		len(quantities) != n || len(quantitiesMax) != n || len(unitNames) != n {
+		return nil
+	}
+
+	ingredients := make([]models.Ingredient, 0, n)
+	for i, text := range texts {
+		var recipeID int64
+		if len(recipeIDs) == n {
+			recipeID, _ = strconv.ParseInt(recipeIDs[i], 10, 64)
+			recipeID = max(recipeID, 0)
+		}
+
+		name := strings.TrimSpace(names[i])
+		if parsed[i] != text && (parsed[i] != "" || name == "") {
+			if recipeID > 0 {
+				in := models.NewIngredient(text)
+				in.RecipeID = recipeID
+				ingredients = append(ingredients, in)
+			}
+			continue
+		}
+
+		quantity, _ := strconv.ParseFloat(quantities[i], 64)
+		quantityMax, _ := strconv.ParseFloat(quantitiesMax[i], 64)
+
+		ingredients = append(ingredients, models.Ingredient{
+			IsOptional:  optionals[i] == "true",
+			Name:        name,
+			Note:        strings.TrimSpace(notes[i]),
+			Quantity:    max(quantity, 0),
+			QuantityMax: max(quantityMax, 0),
+			RecipeID:    recipeID,
+			Text:        text,
+			Unit:        units.NewUnit(unitNames[i]),
+		})
+	}
+	return ingredients
+}
+
+// checkSubRecipes verifies that the recipes the ingredients refer to belong to the user and
+// that the recipe of the given ID, zero when new, does not include itself through them. A
+// reference without a quantity is set to the yield of the recipe it refers to.
+func (s *Server) checkSubRecipes(recipe *models.Recipe, id, userID int64) error {
+	fetch := func(subID int64) (*models.Recipe, error) {
+		return s.Repository.Recipe(subID, userID)
+	}
+
+	for i, in := range recipe.StructuredIngredients {
+		if in.RecipeID == 0 {
+			continue
+		}
+
+		sub, err := fetch(in.RecipeID)
+		if err != nil {
+			return fmt.Errorf("sub-recipe %d: %w", in.RecipeID, err)
+		}
+
+		if in.Quantity == 0 {
+			recipe.StructuredIngredients[i].Quantity = max(sub.Yield, 1)
+		}
+	}
+
+	r := *recipe
+	r.ID = id
+	_, err := r.ExpandSubRecipes(fetch)
+	return err
+}
+
+func subRecipeErrorMessage(err error) string {
+	if errors.Is(err, models.ErrSubRecipeCycle) {
+		return "A recipe cannot include itself through its sub-recipes."
+	}
+	return "An ingredient refers to a recipe that does not exist."
+}
+
+// newFormSections separates the section headings of a list of the recipe form from its items.
+// A heading is an item starting with "# ", e.g. "# For the sauce".
+func newFormSections(items []string) ([]string, models.Sections) {
+	if items == nil {
+		return nil, nil
+	}
+
+	values := make([]string, 0, len(items))
+	var sections models.Sections
+	for _, item := range items {
+		name, isSection := strings.CutPrefix(strings.TrimSpace(item), "# ")
+		if !isSection {
+			values = append(values, item)
+			continue
+		}
+
+		name = strings.TrimSpace(name)
+		if name != "" {
+			sections = append(sections, models.Section{Index: len(values), Name: name})
+		}
+	}
+	return values, sections
+}
+
 func (s *Server) recipesAddOCRHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		userID := getUserID(r)
@@ -506,6 +651,10 @@ func (s *Server) recipesAddOCRHandler() http.HandlerFunc {
 				return
 			}
 
+			if len(recipeIDs) > 0 {
+				s.emitWebhookEvent(models.WebhookEventRecipeCreated, id, map[string]any{"recipeIDs": recipeIDs})
+			}
+
 			s.Brokers.HideNotification(id)
 
 			switch len(recipeIDs) {
@@ -624,6 +773,7 @@ func (s *Server) recipesAddWebsiteHandler() http.HandlerFunc {
 
 							report.Logs = append(report.Logs, models.NewReportLog(u, true, err, "/recipes/"+strconv.FormatInt(ids[0], 10)))
 							countSuccess.Add(1)
+							s.emitWebhookEvent(models.WebhookEventRecipeCreated, userID, map[string]any{"recipeIDs": ids})
 						} else {
 							ids = []int64{recipe.ID}
 							report.Logs = append(report.Logs, models.NewReportLog(u, false, nil, "/recipes/"+strconv.FormatInt(recipe.ID, 10)))
@@ -654,6 +804,8 @@ func (s *Server) recipesAddWebsiteHandler() http.HandlerFunc {
 				numWarning = countWarning.Load()
 			)
 
+			s.emitWebhookEvent(models.WebhookEventImportFinished, userID, models.NewImportFinishedData(report, int(numSuccess), total))
+
 			if total == 1 {
 				if numWarning == 1 {
 					recipeID := recipeIDs[0]
@@ -699,7 +851,10 @@ func (s *Server) recipeDeleteHandler() http.HandlerFunc {
 		idAttr := slog.Int64("id", id)
 
 		err = s.Repository.DeleteRecipe(id, userID)
-		if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if err != nil {
 			msg := "Recipe could not be deleted."
 			slog.Error(msg, userIDAttr, idAttr, "error", err)
 			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
@@ -708,6 +863,7 @@ func (s *Server) recipeDeleteHandler() http.HandlerFunc {
 		}
 
 		slog.Info("Recipe deleted", userIDAttr, idAttr)
+		s.emitWebhookEvent(models.WebhookEventRecipeDeleted, userID, map[string]any{"recipeID": id})
 		w.Header().Set("HX-Redirect", "/")
 		w.WriteHeader(http.StatusNoContent)
 	}
@@ -743,12 +899,15 @@ func (s *Server) recipesEditHandler() http.HandlerFunc {
 
 		recipe.Videos = slices.DeleteFunc(recipe.Videos, func(v models.VideoObject) bool { return v.ID == uuid.Nil })
 
+		data := templates.NewViewRecipeData(id, recipe, categories, keywords, true, false)
+		data.Recipes = slices.DeleteFunc(slices.Clone(s.Repository.RecipesAll(userID)), func(r models.Recipe) bool { return r.ID == id })
+
 		_ = components.EditRecipe(templates.Data{
 			About:           templates.NewAboutData(),
 			IsAdmin:         userID == 1,
 			IsAuthenticated: true,
 			IsHxRequest:     r.Header.Get("HX-Request") == "true",
-			View:            templates.NewViewRecipeData(id, recipe, categories, keywords, true, false),
+			View:            data,
 		}).Render(r.Context(), w)
 	}
 }
@@ -1013,28 +1172,55 @@ func (s *Server) recipesEditPutHandler() http.HandlerFunc {
 			}
 		}
 
-		xs, ok = r.Form["ingredients"]
+		updatedRecipe.Ingredients, updatedRecipe.IngredientSections = newFormSections(r.Form["ingredients"])
+		updatedRecipe.StructuredIngredients = newFormIngredients(r.Form)
+		updatedRecipe.Instructions, updatedRecipe.InstructionSections = newFormSections(r.Form["instructions"])
+
+		xs, ok = r.Form["keywords"]
 		if ok {
-			updatedRecipe.Ingredients = append(updatedRecipe.Ingredients, xs...)
+			updatedRecipe.Keywords = append(updatedRecipe.Keywords, xs...)
 		}
 
-		xs, ok = r.Form["instructions"]
-		if ok {
-			updatedRecipe.Instructions = append(updatedRecipe.Instructions, xs...)
+		yield, err := strconv.ParseFloat(r.FormValue("yield"), 64)
+		if err == nil && yield > 0 {
+			updatedRecipe.Yield = yield
 		}
+		updatedRecipe.YieldUnit = strings.TrimSpace(r.FormValue("yield-unit"))
 
-		xs, ok = r.Form["keywords"]
-		if ok {
-			updatedRecipe.Keywords = append(updatedRecipe.Keywords, xs...)
+		if v := r.FormValue("updated-at"); v != "" {
+			updatedRecipe.UpdatedAt, err = time.Parse(time.RFC3339Nano, v)
+			if err != nil {
+				s.Brokers.SendToast(models.NewErrorFormToast("Invalid 'updated-at' in form."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
 		}
 
-		yield, err := strconv.ParseInt(r.FormValue("yield"), 10, 16)
-		if err == nil {
-			updatedRecipe.Yield = int16(yield)
+		err = s.checkSubRecipes(&updatedRecipe, recipeNum, userID)
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorFormToast(subRecipeErrorMessage(err)), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
 		}
 
 		err = s.Repository.UpdateRecipe(&updatedRecipe, userID, recipeNum)
-		if err != nil {
+		if errors.Is(err, models.ErrConflict) {
+			saved, err := s.Repository.Recipe(recipeNum, userID)
+			if err != nil {
+				msg := "Error updating recipe"
+				slog.Error(msg, userIDAttr, "recipeNum", recipeNum, "error", err)
+				s.Brokers.SendToast(models.NewErrorDBToast(msg+"."), userID)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			slog.Warn("Recipe edit conflict", userIDAttr, "recipeNum", recipeNum, "updatedAt", updatedRecipe.UpdatedAt, "savedAt", saved.UpdatedAt)
+			w.Header().Set("HX-Retarget", "#recipe-conflict")
+			w.Header().Set("HX-Reswap", "innerHTML")
+			w.WriteHeader(http.StatusConflict)
+			_ = components.RecipeConflict(recipeNum, saved.UpdatedAt, models.RecipeChanges(*saved, updatedRecipe)).Render(r.Context(), w)
+			return
+		} else if err != nil {
 			msg := "Error updating recipe"
 			slog.Error(msg, userIDAttr, "updatedRecipe", updatedRecipe, "error", err)
 			s.Brokers.SendToast(models.NewErrorDBToast(msg+": "+err.Error()+"."), userID)
@@ -1043,6 +1229,8 @@ func (s *Server) recipesEditPutHandler() http.HandlerFunc {
 		}
 
 		slog.Info("Recipe updated", userIDAttr, "recipeNum", recipeNumStr, "updatedRecipe", updatedRecipe)
+		s.emitWebhookEvent(models.WebhookEventRecipeUpdated, userID, map[string]any{"recipeID": recipeNum})
+		s.Brokers.SendRecipeUpdated(recipeNum, userID)
 		w.Header().Set("HX-Redirect", "/recipes/"+recipeNumStr)
 		w.WriteHeader(http.StatusNoContent)
 	}
@@ -1082,11 +1270,8 @@ func (s *Server) recipeScaleHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		userID := getUserID(r)
 
-		yield, err := strconv.ParseInt(r.URL.Query().Get("yield"), 10, 16)
-		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			return
-		} else if yield <= 0 {
+		target := r.URL.Query().Get("yield")
+		if models.NewYield(target).Value <= 0 {
 			s.Brokers.SendToast(models.NewErrorGeneralToast("Yield must be greater than zero."), userID)
 			w.WriteHeader(http.StatusBadRequest)
 			return
@@ -1104,9 +1289,20 @@ func (s *Server) recipeScaleHandler() http.HandlerFunc {
 			w.WriteHeader(http.StatusNotFound)
 			return
 		}
-		recipe.Scale(int16(yield))
 
-		_ = components.IngredientsInstructions(&templates.ViewRecipeData{Recipe: recipe}).Render(r.Context(), w)
+		err = recipe.ScaleTo(target)
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorGeneralToast(fmt.Sprintf("A recipe yielding %s cannot be scaled to %s.", recipe.YieldText(), target)), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		notes, err := s.Repository.RecipeNotes(id, userID)
+		if err != nil {
+			slog.Error("Failed to fetch recipe notes", "userID", userID, "id", id, "error", err)
+		}
+
+		_ = components.IngredientsInstructions(&templates.ViewRecipeData{Notes: notes, Recipe: recipe}).Render(r.Context(), w)
 	}
 }
 
@@ -1177,6 +1373,8 @@ func (s *Server) recipeShareAddHandler() http.HandlerFunc {
 			return
 		}
 
+		s.emitWebhookEvent(models.WebhookEventRecipeCreated, userID, map[string]any{"recipeIDs": []int64{newRecipeID}})
+
 		redirect := "/recipes/" + strconv.FormatInt(newRecipeID, 10)
 		if isHxRequest {
 			w.Header().Set("HX-Redirect", redirect)
@@ -1188,45 +1386,62 @@ func (s *Server) recipeShareAddHandler() http.HandlerFunc {
 
 func (s *Server) recipeDuplicateHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var (
-			userID     = getUserID(r)
-			userIDAttr = slog.Int64("userID", userID)
-		)
+		s.renderRecipeCopy(w, r, false)
+	}
+}
 
-		recipeID, err := parsePathPositiveID(r.PathValue("id"))
-		if err != nil {
-			slog.Error("Failed to parse recipe ID", userIDAttr, "error", err)
-			w.WriteHeader(http.StatusInternalServerError)
-			return
-		}
+// renderRecipeCopy renders the manual recipe form prefilled with a copy of the user's recipe.
+// The copy becomes a variant of the recipe when isVariant is true.
+func (s *Server) renderRecipeCopy(w http.ResponseWriter, r *http.Request, isVariant bool) {
+	var (
+		userID     = getUserID(r)
+		userIDAttr = slog.Int64("userID", userID)
+	)
 
-		recipe, err := s.Repository.Recipe(recipeID, getUserID(r))
-		if err != nil {
-			slog.Error("Failed to fetch recipe", userIDAttr, "error", err)
-			notFoundHandler(w, r)
-			return
-		}
-		recipe.Name = recipe.Name + " (copy)"
+	recipeID, err := parsePathPositiveID(r.PathValue("id"))
+	if err != nil {
+		slog.Error("Failed to parse recipe ID", userIDAttr, "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
 
-		categories, err := s.Repository.Categories(userID)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
+	recipe, err := s.Repository.Recipe(recipeID, getUserID(r))
+	if err != nil {
+		slog.Error("Failed to fetch recipe", userIDAttr, "error", err)
+		notFoundHandler(w, r)
+		return
+	}
 
-		keywords, err := s.Repository.Keywords()
-		if err != nil {
-			slog.Error("Failed to fetch keywords", "error", err)
-		}
+	var variants models.RecipeVariants
+	if isVariant {
+		variants.Parent = &models.RecipeLink{ID: recipeID, Name: recipe.Name}
+		recipe.Name = recipe.Name + " (variant)"
+	} else {
+		recipe.Name = recipe.Name + " (copy)"
+	}
 
-		_ = components.AddRecipeManual(templates.Data{
-			About:           templates.NewAboutData(),
-			IsAdmin:         userID == 1,
-			IsAuthenticated: true,
-			IsHxRequest:     r.Header.Get("Hx-Request") == "true",
-			View:            templates.NewViewRecipeData(recipeID, recipe, categories, keywords, true, false),
-		}).Render(r.Context(), w)
+	categories, err := s.Repository.Categories(userID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
+
+	keywords, err := s.Repository.Keywords()
+	if err != nil {
+		slog.Error("Failed to fetch keywords", "error", err)
+	}
+
+	data := templates.NewViewRecipeData(recipeID, recipe, categories, keywords, true, false)
+	data.Recipes = s.Repository.RecipesAll(userID)
+	data.Variants = variants
+
+	_ = components.AddRecipeManual(templates.Data{
+		About:           templates.NewAboutData(),
+		IsAdmin:         userID == 1,
+		IsAuthenticated: true,
+		IsHxRequest:     r.Header.Get("Hx-Request") == "true",
+		View:            data,
+	}).Render(r.Context(), w)
 }
 
 func (s *Server) recipesCategoriesDeleteHandler() http.HandlerFunc {
@@ -1289,7 +1504,24 @@ func (s *Server) recipesSearchHandler() http.HandlerFunc {
 
 		userID := getUserID(r)
 
-		recipes, totalCount, err := s.Repository.SearchRecipes(opts, userID)
+		var (
+			recipes       models.Recipes
+			pantryMatches map[int64]models.PantryMatch
+			totalCount    uint64
+			err           error
+		)
+
+		if opts.Advanced.IsPantry {
+			var matches []models.PantryMatch
+			matches, totalCount, err = s.Repository.SearchRecipesPantry(opts, userID)
+			pantryMatches = make(map[int64]models.PantryMatch, len(matches))
+			for _, m := range matches {
+				recipes = append(recipes, m.Recipe)
+				pantryMatches[m.Recipe.ID] = m
+			}
+		} else {
+			recipes, totalCount, err = s.Repository.SearchRecipes(opts, userID)
+		}
 		if err != nil {
 			msg := "Error searching recipes."
 			slog.Error(msg, "user", userID, "opts", opts, "error", err)
@@ -1321,6 +1553,7 @@ func (s *Server) recipesSearchHandler() http.HandlerFunc {
 			IsHxRequest:     htmx.IsSwap,
 			Functions:       templates.NewFunctionsData[int64](),
 			Pagination:      p,
+			PantryMatches:   pantryMatches,
 			Recipes:         recipes,
 			Searchbar:       templates.SearchbarData{Sort: opts.Sort.String(), Term: r.URL.Query().Get("q")},
 		}).Render(r.Context(), w)
@@ -1387,12 +1620,23 @@ func (s *Server) recipesViewHandler() http.HandlerFunc {
 			return
 		}
 
+		view := templates.NewViewRecipeData(id, recipe, nil, nil, true, false)
+		view.Notes, err = s.Repository.RecipeNotes(id, userID)
+		if err != nil {
+			slog.Error("Failed to fetch recipe notes", "userID", userID, "id", id, "error", err)
+		}
+
+		view.Variants, err = s.Repository.RecipeVariants(id, userID)
+		if err != nil {
+			slog.Error("Failed to fetch recipe variants", "userID", userID, "id", id, "error", err)
+		}
+
 		_ = components.ViewRecipe(templates.Data{
 			About:           templates.NewAboutData(),
 			IsAdmin:         userID == 1,
 			IsAuthenticated: true,
 			IsHxRequest:     r.Header.Get("Hx-Request") == "true",
-			View:            templates.NewViewRecipeData(id, recipe, nil, nil, true, false),
+			View:            view,
 		}).Render(r.Context(), w)
 	}
 }
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/templates"
+	"github.com/reaper47/recipya/web/components"
+)
+
+func (s *Server) recipeHistoryHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Recipe ID in path must be > 0."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		recipe, err := s.Repository.Recipe(id, userID)
+		if err != nil {
+			slog.Error("Failed to fetch recipe", "userID", userID, "id", id, "error", err)
+			notFoundHandler(w, r)
+			return
+		}
+
+		revisions, err := s.Repository.RecipeRevisions(id, userID)
+		if err != nil {
+			msg := "Error getting the history of the recipe."
+			slog.Error(msg, "userID", userID, "id", id, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_ = components.RecipeHistory(templates.Data{
+			About:           templates.NewAboutData(),
+			History:         models.RecipeHistory{Current: *recipe, Revisions: revisions},
+			IsAdmin:         userID == 1,
+			IsAuthenticated: true,
+			IsHxRequest:     r.Header.Get("Hx-Request") == "true",
+			Title:           "History of " + recipe.Name,
+		}).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) recipeRevisionRevertHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Recipe ID in path must be > 0."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		revisionID, err := parsePathPositiveID(r.PathValue("revisionID"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Revision ID in path must be > 0."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		revisions, err := s.Repository.RecipeRevisions(id, userID)
+		if err != nil {
+			msg := "Error getting the history of the recipe."
+			slog.Error(msg, userIDAttr, "id", id, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		var revision *models.RecipeRevision
+		for i := range revisions {
+			if revisions[i].ID == revisionID {
+				revision = &revisions[i]
+				break
+			}
+		}
+
+		if revision == nil {
+			s.Brokers.SendToast(models.NewErrorGeneralToast("Revision not found."), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// The revision replaces whatever is saved, hence the conflict check is skipped.
+		reverted := revision.Recipe.Copy()
+		reverted.ID = id
+		reverted.UpdatedAt = time.Time{}
+
+		err = s.Repository.UpdateRecipe(&reverted, userID, id)
+		if err != nil {
+			msg := "Error reverting the recipe."
+			slog.Error(msg, userIDAttr, "id", id, "revisionID", revisionID, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Recipe reverted", userIDAttr, "id", id, "revisionID", revisionID)
+		s.emitWebhookEvent(models.WebhookEventRecipeUpdated, userID, map[string]any{"recipeID": id})
+		s.Brokers.SendRecipeUpdated(id, userID)
+		w.Header().Set("HX-Redirect", "/recipes/"+strconv.FormatInt(id, 10))
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,143 @@
+package server_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestHandlers_RecipeHistory(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	uri := ts.URL + "/recipes/1/history"
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri)
+		assertMustBeLoggedIn(t, srv, http.MethodPost, ts.URL+"/recipes/1/revisions/1/revert")
+	})
+
+	t.Run("displays the revisions", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{1: {
+				{ID: 1, Name: "Pancakes", Category: "breakfast", Ingredients: []string{"2 cups flour", "2 eggs"}, Instructions: []string{"Mix"}},
+			}},
+			RevisionsRegistered: map[int64][]models.RecipeRevision{1: {
+				{
+					CreatedAt:  time.Date(2026, time.October, 18, 12, 30, 0, 0, time.UTC),
+					ID:         1,
+					Recipe:     models.Recipe{ID: 1, Name: "Crepes", Category: "breakfast", Ingredients: []string{"2 cups flour", "1 egg"}, Instructions: []string{"Mix"}},
+					RecipeID:   1,
+					ReplacedBy: "test@example.com",
+				},
+			}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<title hx-swap-oob="true">History of Pancakes | Recipya</title>`,
+			`<span class="font-semibold">18 Oct 2026 12:30</span> <span class="text-gray-500">replaced by test@example.com</span>`,
+			`hx-post="/recipes/1/revisions/1/revert"`,
+			`<tr><th>Title</th><td class="whitespace-pre-line">Crepes</td><td class="whitespace-pre-line">Pancakes</td></tr>`,
+			`<p class="font-semibold">Ingredients</p><ul class="font-mono text-xs"><li class="px-1">&nbsp; 2 cups flour</li><li class="bg-error/20 px-1 line-through">- 1 egg</li><li class="bg-success/20 px-1">+ 2 eggs</li></ul>`,
+		})
+	})
+
+	t.Run("no revisions", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{1: {
+				{ID: 1, Name: "Pancakes", Category: "breakfast", Ingredients: []string{"2 cups flour", "2 eggs"}, Instructions: []string{"Mix"}},
+			}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<p>This recipe has not been modified since it was added.</p>`,
+		})
+	})
+
+	t.Run("recipe of another user", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{1: {
+				{ID: 1, Name: "Pancakes", Category: "breakfast", Ingredients: []string{"2 cups flour", "2 eggs"}, Instructions: []string{"Mix"}},
+			}},
+			RevisionsRegistered: map[int64][]models.RecipeRevision{1: {
+				{
+					CreatedAt:  time.Date(2026, time.October, 18, 12, 30, 0, 0, time.UTC),
+					ID:         1,
+					Recipe:     models.Recipe{ID: 1, Name: "Crepes", Category: "breakfast", Ingredients: []string{"2 cups flour", "1 egg"}, Instructions: []string{"Mix"}},
+					RecipeID:   1,
+					ReplacedBy: "test@example.com",
+				},
+			}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, ts.URL+"/recipes/2/history")
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+	})
+}
+
+func TestHandlers_RecipeRevisionRevert(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	t.Run("revert to a revision", func(t *testing.T) {
+		repo := &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{1: {
+				{ID: 1, Name: "Pancakes", Ingredients: []string{"2 cups flour", "2 eggs"}, Instructions: []string{"Mix"}, UpdatedAt: time.Now()},
+			}},
+			RevisionsRegistered: map[int64][]models.RecipeRevision{1: {
+				{ID: 1, Recipe: models.Recipe{ID: 1, Name: "Crepes", Ingredients: []string{"2 cups flour", "1 egg"}, Instructions: []string{"Mix"}}, RecipeID: 1},
+			}},
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodPost, ts.URL+"/recipes/1/revisions/1/revert")
+
+		assertStatus(t, rr.Code, http.StatusNoContent)
+		assertWebsocket(t, c, 1, `{"type":"recipeUpdated","fileName":"","data":"1","toast":{"action":"","background":"","message":"","title":""}}`)
+		if got := rr.Header().Get("HX-Redirect"); got != "/recipes/1" {
+			t.Fatalf("got redirect %q but want /recipes/1", got)
+		}
+		got := repo.RecipesRegistered[1][0]
+		if got.Name != "Crepes" || got.Ingredients[1] != "1 egg" {
+			t.Fatalf("recipe not reverted: %+v", got)
+		}
+		revisions := repo.RevisionsRegistered[1]
+		if len(revisions) != 2 || revisions[0].Recipe.Name != "Pancakes" {
+			t.Fatalf("the revert must be a revision itself but got %+v", revisions)
+		}
+	})
+
+	t.Run("revision not found", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{1: {
+				{ID: 1, Name: "Pancakes", Ingredients: []string{"2 cups flour", "2 eggs"}, Instructions: []string{"Mix"}, UpdatedAt: time.Now()},
+			}},
+			RevisionsRegistered: map[int64][]models.RecipeRevision{1: {
+				{ID: 1, Recipe: models.Recipe{ID: 1, Name: "Crepes", Ingredients: []string{"2 cups flour", "1 egg"}, Instructions: []string{"Mix"}}, RecipeID: 1},
+			}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodPost, ts.URL+"/recipes/1/revisions/9/revert")
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Revision not found.","title":"General Error"}}`)
+	})
+
+	t.Run("invalid revision ID", func(t *testing.T) {
+		srv.Repository = &mockRepository{}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodPost, ts.URL+"/recipes/1/revisions/0/revert")
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Revision ID in path must be \u003e 0.","title":"Request Error"}}`)
+	})
+}
@@ -2,15 +2,21 @@ package server
 
 import (
 	"bytes"
+	"errors"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/reaper47/recipya/internal/app"
+	"github.com/reaper47/recipya/internal/auth"
 	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/services"
 	"github.com/reaper47/recipya/internal/templates"
 	"github.com/reaper47/recipya/internal/units"
 	"github.com/reaper47/recipya/web/components"
@@ -70,6 +76,16 @@ func (s *Server) settingsHandler() http.HandlerFunc {
 		}
 		categories = slices.DeleteFunc(categories, func(s string) bool { return s == "uncategorized" })
 
+		data.APITokens, err = s.Repository.APITokens(userID)
+		if err != nil {
+			slog.Error("Failed to fetch API tokens", userIDAttr, "error", err)
+		}
+
+		data.Webhooks, err = s.Repository.Webhooks(userID)
+		if err != nil {
+			slog.Error("Failed to fetch webhooks", userIDAttr, "error", err)
+		}
+
 		_ = components.SettingsDialogContent(templates.Data{
 			About:    templates.NewAboutData(),
 			IsAdmin:  userID == 1,
@@ -79,6 +95,91 @@ func (s *Server) settingsHandler() http.HandlerFunc {
 	}
 }
 
+func (s *Server) settingsAPITokensPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		name := strings.TrimSpace(r.FormValue("name"))
+		if name == "" {
+			s.Brokers.SendToast(models.NewErrorFormToast("The token's name is required."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		scopes := models.NewAPIScopes(r.FormValue("scopes"))
+		if len(scopes) == 0 {
+			s.Brokers.SendToast(models.NewErrorFormToast("At least one scope is required."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if slices.Contains(scopes, models.APIScopeWrite) && !slices.Contains(scopes, models.APIScopeRead) {
+			scopes = append([]models.APIScope{models.APIScopeRead}, scopes...)
+		}
+
+		token := models.APIToken{Name: name, Scopes: scopes, UserID: userID}
+
+		expires := r.FormValue("expires")
+		if expires != "" && expires != "never" {
+			days, err := strconv.Atoi(expires)
+			if err != nil || days <= 0 {
+				s.Brokers.SendToast(models.NewErrorFormToast("Invalid expiration."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			token.ExpiresAt = time.Now().AddDate(0, 0, days)
+		}
+
+		secret, selector, hashValidator := auth.GenerateAPIToken()
+
+		token, err := s.Repository.AddAPIToken(token, selector, hashValidator)
+		if err != nil {
+			msg := "Failed to create the API token."
+			slog.Error(msg, userIDAttr, "name", name, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		tokens, err := s.Repository.APITokens(userID)
+		if err != nil {
+			slog.Error("Failed to fetch API tokens", userIDAttr, "error", err)
+			tokens = []models.APIToken{token}
+		}
+
+		slog.Info("Created API token", userIDAttr, "tokenID", token.ID, "name", name, "scopes", token.ScopesString())
+		w.WriteHeader(http.StatusCreated)
+		_ = components.SettingsAPITokens(tokens, secret).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) settingsAPITokensDeleteHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Invalid token ID."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = s.Repository.DeleteAPIToken(id, userID)
+		if err != nil {
+			msg := "Failed to revoke the API token."
+			slog.Error(msg, userIDAttr, "tokenID", id, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		slog.Info("Revoked API token", userIDAttr, "tokenID", id)
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
 func (s *Server) settingsBackupsRestoreHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		userID := getUserID(r)
@@ -102,6 +203,7 @@ func (s *Server) settingsBackupsRestoreHandler() http.HandlerFunc {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
+		s.emitWebhookEvent(models.WebhookEventBackupCompleted, userID, map[string]any{"scope": "user"})
 
 		backup, err := s.Files.ExtractUserBackup(dateStr, userID)
 		if err != nil {
@@ -124,6 +226,7 @@ func (s *Server) settingsBackupsRestoreHandler() http.HandlerFunc {
 
 		msg := "Backup restored successfully."
 		slog.Info(msg, userIDAttr, "date", dateStr)
+		s.emitWebhookEvent(models.WebhookEventBackupRestored, userID, map[string]any{"scope": "user", "date": dateStr})
 		s.Brokers.SendToast(models.NewInfoToast(msg, "", ""), userID)
 		w.WriteHeader(http.StatusOK)
 	}
@@ -238,11 +341,25 @@ func (s *Server) settingsExportRecipesHandler() http.HandlerFunc {
 				return
 			}
 
+			recipes, err := recipes.ExpandSubRecipes()
+			if err != nil {
+				slog.Warn("Failed to expand the sub-recipes of some recipes", "userID", userID, "error", err)
+			}
+
 			cookbooks, err := s.Repository.CookbooksUser(userID)
 			if err != nil {
 				slog.Error("Failed to get cookbooks", "userID", userID, "error", err)
 			}
 
+			var notes map[int64]models.RecipeNotes
+			if fileType == models.PDF {
+				all, err := s.Repository.RecipesNotes(userID)
+				if err != nil {
+					slog.Error("Failed to get recipe notes", "userID", userID, "error", err)
+				}
+				notes = all.GroupByRecipe()
+			}
+
 			var (
 				iter       = make(chan int)
 				errors     = make(chan error, 1)
@@ -252,7 +369,7 @@ func (s *Server) settingsExportRecipesHandler() http.HandlerFunc {
 			var data *bytes.Buffer
 			go func() {
 				defer close(iter)
-				data, err = s.Files.ExportRecipes(recipes, cookbooks, fileType, iter)
+				data, err = s.Files.ExportRecipes(recipes, cookbooks, notes, fileType, iter)
 				if err != nil {
 					errors <- err
 					return
@@ -327,3 +444,97 @@ func (s *Server) settingsMeasurementSystemsPostHandler() http.HandlerFunc {
 		w.WriteHeader(http.StatusNoContent)
 	}
 }
+
+func (s *Server) settingsWebhooksPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		err := r.ParseForm()
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorFormToast("Could not parse the form."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		rawURL := strings.TrimSpace(r.FormValue("url"))
+		u, err := url.ParseRequestURI(rawURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			s.Brokers.SendToast(models.NewErrorFormToast("The webhook's URL must be an absolute HTTP or HTTPS URL."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		events := models.NewWebhookEvents(strings.Join(r.Form["events"], ","))
+		if len(events) == 0 {
+			s.Brokers.SendToast(models.NewErrorFormToast("At least one event is required."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		webhook := models.Webhook{
+			Events:   events,
+			IsGlobal: userID == 1 && r.FormValue("global") == "on",
+			Secret:   services.GenerateWebhookSecret(),
+			URL:      u.String(),
+			UserID:   userID,
+		}
+
+		err = s.Webhooks.Validate(webhook)
+		if errors.Is(err, services.ErrWebhookAddressNotAllowed) {
+			s.Brokers.SendToast(models.NewErrorFormToast("The webhook's URL must point to a public address."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		} else if err != nil {
+			slog.Warn("Failed to resolve webhook", userIDAttr, "url", rawURL, "error", err)
+			s.Brokers.SendToast(models.NewErrorFormToast("The webhook's host could not be resolved."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		webhook, err = s.Repository.AddWebhook(webhook)
+		if err != nil {
+			msg := "Failed to register the webhook."
+			slog.Error(msg, userIDAttr, "url", rawURL, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		webhooks, err := s.Repository.Webhooks(userID)
+		if err != nil {
+			slog.Error("Failed to fetch webhooks", userIDAttr, "error", err)
+			webhooks = []models.Webhook{webhook}
+		}
+
+		slog.Info("Registered webhook", userIDAttr, "webhookID", webhook.ID, "url", webhook.URL, "events", webhook.EventsString(), "isGlobal", webhook.IsGlobal)
+		w.WriteHeader(http.StatusCreated)
+		_ = components.SettingsWebhooks(webhooks, webhook.Secret).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) settingsWebhooksDeleteHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Invalid webhook ID."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = s.Repository.DeleteWebhook(id, userID)
+		if err != nil {
+			msg := "Failed to delete the webhook."
+			slog.Error(msg, userIDAttr, "webhookID", id, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		slog.Info("Deleted webhook", userIDAttr, "webhookID", id)
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"slices"
 	"strings"
@@ -158,13 +159,91 @@ func TestHandlers_Settings(t *testing.T) {
 			`<div id="settings_connections" class="p-3 overflow-y-auto max-h-96 hidden md:p-0 md:pr-4"><div class="flex justify-between items-center text-sm"><details class="w-full"><summary class="font-semibold cursor-default">SMTP Server<br><span class="text-xs font-normal">This connection is used to send emails.</span></summary><form class="grid w-full" hx-put="/settings/config" hx-swap="none"><label class="form-control w-full"><span class="label"><span class="label-text text-sm">From</span></span> <input name="email.from" type="text" placeholder="SMTP email" value="" autocomplete="off" class="input input-bordered input-sm w-full"></label> <label class="form-control w-full"><span class="label"><span class="label-text text-sm">SMTP Host</span></span> <input name="email.host" type="text" placeholder="smtp.gmail.com" value="" autocomplete="off" class="input input-bordered input-sm w-full"></label> <label class="form-control w-full"><span class="label"><span class="label-text text-sm">SMTP Username</span></span> <input name="email.username" type="text" placeholder="email@example.com" value="" autocomplete="off" class="input input-bordered input-sm w-full"></label> <label class="form-control w-full"><span class="label"><span class="label-text text-sm">SMTP Password</span></span> <input name="email.password" type="password" placeholder="SMTP password or app password" value="" autocomplete="off" class="input input-bordered input-sm w-full"></label> <button class="btn btn-sm mt-2">Update</button></form></details> <button type="button" title="Test connection" class="btn btn-xs float-right self-baseline" hx-get="/integrations/test-connection?api=smtp" hx-swap="none"><svg xmlns="http://www.w3.org/2000/svg" fill="none" viewBox="0 0 24 24" stroke-width="1.5" stroke="currentColor" class="w-6 h-6"><path stroke-linecap="round" stroke-linejoin="round" d="M16.023 9.348h4.992v-.001M2.985 19.644v-4.992m0 0h4.992m-4.993 0 3.181 3.183a8.25 8.25 0 0 0 13.803-3.7M4.031 9.865a8.25 8.25 0 0 1 13.803-3.7l3.181 3.182m0-4.991v4.99"></path></svg></button></div><div class="divider m-0"></div><div class="flex justify-between items-center text-sm"><details class="w-full"><summary class="font-semibold cursor-default">Azure AI Document Intelligence<br><span class="text-xs font-normal">This connection is used to digitize recipe images.</span></summary><form class="grid w-full" hx-put="/settings/config" hx-swap="none"><label class="form-control w-full"><span class="label"><span class="label-text text-sm">Resource key</span></span> <input name="integrations.ocr.key" type="text" placeholder="Resource key 1" value="" autocomplete="off" class="input input-bordered input-sm w-full"></label> <label class="form-control w-full"><span class="label"><span class="label-text text-sm">Endpoint</span></span> <input name="integrations.ocr.url" type="url" placeholder="Vision endpoint URL" value="" autocomplete="off" class="input input-bordered input-sm w-full"></label> <button class="btn btn-sm mt-2">Update</button></form></details> <button type="button" title="Test connection" class="btn btn-xs float-right self-baseline" hx-get="/integrations/test-connection?api=azure-di" hx-swap="none"><svg xmlns="http://www.w3.org/2000/svg" fill="none" viewBox="0 0 24 24" stroke-width="1.5" stroke="currentColor" class="w-6 h-6"><path stroke-linecap="round" stroke-linejoin="round" d="M16.023 9.348h4.992v-.001M2.985 19.644v-4.992m0 0h4.992m-4.993 0 3.181 3.183a8.25 8.25 0 0 0 13.803-3.7M4.031 9.865a8.25 8.25 0 0 1 13.803-3.7l3.181 3.182m0-4.991v4.99"></path></svg></button></div>`,
 			`<div id="settings_server" class="hidden p-3 md:p-0 md:pr-4 md:max-h-96"><div class="flex justify-between items-center text-sm"><form class="grid w-full" hx-put="/settings/config" hx-swap="none"><p class="font-semibold">Configuration</p><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Autologin</span> <input name="server.autologin" type="checkbox" class="checkbox"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">No signups</span> <input name="server.noSignups" type="checkbox" class="checkbox"></label></div><div class="form-control"><label class="label cursor-pointer"><span class="label-text">Is production</span> <input name="server.production" type="checkbox" class="checkbox"></label></div><button class="btn btn-sm mt-2">Update</button></form></div></div>`,
 			`<div id="settings_data" class="hidden p-3 md:p-0 md:pr-4"><div class="flex justify-between items-center text-sm"><details class="w-full"><summary class="font-semibold cursor-default">Import data<br><span class="text-xs font-normal">Import from Mealie, Tandoor, Nextcloud, etc.</span></summary><form class="flex flex-col text-sm" hx-post="/integrations/import" hx-swap="none"><label class="form-control w-full"><span class="label"><span class="label-text text-sm">Solution</span></span> <select name="integration" class="w-fit select select-bordered select-sm"><option value="mealie" selected>Mealie</option> <option value="nextcloud">Nextcloud</option> <option value="tandoor">Tandoor</option></select></label> <label class="form-control w-full"><span class="label"><span class="label-text text-sm">Base URL</span></span> <input type="url" name="url" placeholder="https://instance.mydomain.com" class="input input-bordered input-sm w-full" required></label> <label class="form-control w-full"><span class="label"><span class="label-text text-sm">Username</span></span> <input type="text" name="username" placeholder="Enter your username" class="input input-bordered input-sm w-full" required></label> <label class="form-control w-full"><span class="label"><span class="label-text text-sm">Password</span></span> <input type="password" name="password" placeholder="Enter your password" class="input input-bordered input-sm w-full" required></label> <button class="btn btn-sm mt-2"><svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" fill="currentColor" class="bi bi-cloud-arrow-down" viewBox="0 0 16 16"><path fill-rule="evenodd" d="M7.646 10.854a.5.5 0 0 0 .708 0l2-2a.5.5 0 0 0-.708-.708L8.5 9.293V5.5a.5.5 0 0 0-1 0v3.793L6.354 8.146a.5.5 0 1 0-.708.708l2 2z"></path> <path d="M4.406 3.342A5.53 5.53 0 0 1 8 2c2.69 0 4.923 2 5.166 4.579C14.758 6.804 16 8.137 16 9.773 16 11.569 14.502 13 12.687 13H3.781C1.708 13 0 11.366 0 9.318c0-1.763 1.266-3.223 2.942-3.593.143-.863.698-1.723 1.464-2.383zm.653.757c-.757.653-1.153 1.44-1.153 2.056v.448l-.445.049C2.064 6.805 1 7.952 1 9.318 1 10.785 2.23 12 3.781 12h8.906C13.98 12 15 10.988 15 9.773c0-1.216-1.02-2.228-2.313-2.228h-.5v-.5C12.188 4.825 10.328 3 8 3a4.53 4.53 0 0 0-2.941 1.1z"></path></svg>Import</button></form></details></div><div class="divider m-0"></div><div class="flex justify-between items-center text-sm"><div><p class="font-semibold">Export data</p><p class="text-xs">Download your data in the selected file format.</p></div><form class="grid gap-1 grid-flow-col w-fit" hx-get="/settings/export/recipes" hx-include="select[name='type']" hx-swap="none"><label class="form-control w-full max-w-xs"><select required id="file-type" name="type" class="w-fit select select-bordered select-sm"><optgroup label="Recipes"><option value="json" selected>JSON</option> <option value="pdf">PDF</option></optgroup></select></label> <button class="btn btn-outline btn-sm"><svg xmlns="http://www.w3.org/2000/svg" class="w-5 h-5 ml-1" fill="black" viewBox="0 0 24 24" stroke="currentColor"><path d="M16 11v5H2v-5H0v5a2 2 0 0 0 2 2h14a2 2 0 0 0 2-2v-5z"></path> <path d="m9 14 5-6h-4V0H8v8H4z"></path></svg></button></form></div></div>`,
-			`<div id="settings_account" class="hidden p-3 md:p-0 md:pr-4 md:max-h-96"><div><div class="flex justify-between items-center text-sm"><div><p class="font-semibold">Theme</p><p class="font-normal text-sm">Select your preferred theme.</p></div><div id="themes_palette" class="dropdown dropdown-end hidden z-30 [@supports(color:oklch(0%_0_0))]:block" _="on load call themeChange(document.querySelector('#theme_palette'))"><div tabindex="0" role="button" class="btn btn-ghost"><svg width="20" height="20" xmlns="http://www.w3.org/2000/svg" fill="none" viewBox="0 0 24 24" class="h-5 w-5 stroke-current md:hidden"><path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M7 21a4 4 0 01-4-4V5a2 2 0 012-2h4a2 2 0 012 2v12a4 4 0 01-4 4zm0 0h12a2 2 0 002-2v-4a2 2 0 00-2-2h-2.343M11 7.343l1.657-1.657a2 2 0 012.828 0l2.829 2.829a2 2 0 010 2.828l-8.486 8.485M7 17h.01"></path></svg> <span id="theme_name" class="hidden font-normal md:inline" _="on load set theme to localStorage.getItem('theme') then if not theme put 'system' into me else put theme into me">Theme</span> <svg width="12px" height="12px" class="hidden h-2 w-2 fill-current opacity-60 sm:inline-block" xmlns="http://www.w3.org/2000/svg" viewBox="0 0 2048 2048"><path d="M1799 349l242 241-1017 1017L7 590l242-241 775 775 775-775z"></path></svg></div><div tabindex="0" class="dropdown-content bg-base-200 text-base-content rounded-box top-px h-[28.6rem] max-h-[calc(100vh-10rem)] w-56 overflow-y-auto border border-white/5 shadow-2xl outline outline-1 outline-black/5 mt-16"><div class="grid grid-cols-1 gap-3 p-3"><button class="outline-base-content text-stbbcgoodfood.com/recipesart outline-offset-4 [&_svg]:visible" data-act-class="[&_svg]:visible" data-set-theme="" _="on click put 'system' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme=""><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">system</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="light" _="on click put 'light' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="light"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg id="light_checkmark" xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">light</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="dark" _="on click put 'dark' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="dark"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">dark</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="cupcake" _="on click put 'cupcake' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="cupcake"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">cupcake</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="bumblebee" _="on click put 'bumblebee' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="bumblebee"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">bumblebee</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="emerald" _="on click put 'emerald' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="emerald"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">emerald</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="corporate" _="on click put 'corporate' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="corporate"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">corporate</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="synthwave" _="on click put 'synthwave' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="synthwave"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">synthwave</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="retro" _="on click put 'retro' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="retro"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">retro</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="cyberpunk" _="on click put 'cyberpunk' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="cyberpunk"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">cyberpunk</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="valentine" _="on click put 'valentine' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="valentine"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">valentine</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="halloween" _="on click put 'halloween' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="halloween"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">halloween</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="garden" _="on click put 'garden' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="garden"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">garden</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="forest" _="on click put 'forest' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="forest"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">forest</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="aqua" _="on click put 'aqua' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="aqua"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">aqua</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="lofi" _="on click put 'lofi' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="lofi"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">lofi</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="pastel" _="on click put 'pastel' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="pastel"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">pastel</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="fantasy" _="on click put 'fantasy' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="fantasy"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">fantasy</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="wireframe" _="on click put 'wireframe' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="wireframe"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">wireframe</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="black" _="on click put 'black' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="black"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">black</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="luxury" _="on click put 'luxury' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="luxury"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">luxury</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="dracula" _="on click put 'dracula' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="dracula"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">dracula</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="cmyk" _="on click put 'cmyk' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="cmyk"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">cmyk</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="autumn" _="on click put 'autumn' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="autumn"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">autumn</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="business" _="on click put 'business' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="business"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">business</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="acid" _="on click put 'acid' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="acid"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">acid</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="lemonade" _="on click put 'lemonade' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="lemonade"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">lemonade</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="night" _="on click put 'night' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="night"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">night</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="coffee" _="on click put 'coffee' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="coffee"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">coffee</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="winter" _="on click put 'winter' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="winter"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">winter</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="dim" _="on click put 'dim' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="dim"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">dim</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="nord" _="on click put 'nord' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="nord"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">nord</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="sunset" _="on click put 'sunset' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="sunset"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">sunset</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <a class="outline-base-content overflow-hidden rounded-lg text-center" href="/theme-generator/"><p class="px-2 text-xs">Credits to DaisyUI for this list</p></a></div></div></div></div></div><div class="divider m-0"></div><div class="flex justify-between items-center text-sm"><details class="w-full"><summary class="font-semibold cursor-default">Change password</summary><form class="flex flex-col text-sm" hx-post="/auth/change-password" hx-indicator="#fullscreen-loader" hx-swap="none"><label class="form-control w-full"><span class="label"><span class="label-text text-sm">Current password</span></span> <input type="password" placeholder="Enter current password" class="input input-bordered input-sm w-full" name="password-current" required></label> <label class="form-control w-full"><span class="label"><span class="label-text text-sm">New password</span></span> <input type="password" placeholder="Enter new password" class="input input-bordered input-sm w-full" name="password-new" required></label> <label class="form-control w-full"><span class="label"><span class="label-text text-sm">Confirm password</span></span> <input type="password" placeholder="Retype new password" class="input input-bordered input-sm w-full" name="password-confirm" required></label> <button class="btn btn-sm mt-2">Update password</button></form></details></div><div class="divider m-0"></div><div><div class="flex justify-between items-center text-sm"><div><p class="font-semibold">Delete Account</p><p class="font-normal text-sm">This will delete all your data.</p></div><button type="submit" class="btn btn-sm" hx-delete="/auth/user" hx-confirm="Are you sure you want to delete your account? This action is irreversible.">Delete</button></div></div></div>`,
+			`<div id="settings_account" class="hidden p-3 md:p-0 md:pr-4 md:max-h-96"><div><div class="flex justify-between items-center text-sm"><div><p class="font-semibold">Theme</p><p class="font-normal text-sm">Select your preferred theme.</p></div><div id="themes_palette" class="dropdown dropdown-end hidden z-30 [@supports(color:oklch(0%_0_0))]:block" _="on load call themeChange(document.querySelector('#theme_palette'))"><div tabindex="0" role="button" class="btn btn-ghost"><svg width="20" height="20" xmlns="http://www.w3.org/2000/svg" fill="none" viewBox="0 0 24 24" class="h-5 w-5 stroke-current md:hidden"><path stroke-linecap="round" stroke-linejoin="round" stroke-width="2" d="M7 21a4 4 0 01-4-4V5a2 2 0 012-2h4a2 2 0 012 2v12a4 4 0 01-4 4zm0 0h12a2 2 0 002-2v-4a2 2 0 00-2-2h-2.343M11 7.343l1.657-1.657a2 2 0 012.828 0l2.829 2.829a2 2 0 010 2.828l-8.486 8.485M7 17h.01"></path></svg> <span id="theme_name" class="hidden font-normal md:inline" _="on load set theme to localStorage.getItem('theme') then if not theme put 'system' into me else put theme into me">Theme</span> <svg width="12px" height="12px" class="hidden h-2 w-2 fill-current opacity-60 sm:inline-block" xmlns="http://www.w3.org/2000/svg" viewBox="0 0 2048 2048"><path d="M1799 349l242 241-1017 1017L7 590l242-241 775 775 775-775z"></path></svg></div><div tabindex="0" class="dropdown-content bg-base-200 text-base-content rounded-box top-px h-[28.6rem] max-h-[calc(100vh-10rem)] w-56 overflow-y-auto border border-white/5 shadow-2xl outline outline-1 outline-black/5 mt-16"><div class="grid grid-cols-1 gap-3 p-3"><button class="outline-base-content text-stbbcgoodfood.com/recipesart outline-offset-4 [&_svg]:visible" data-act-class="[&_svg]:visible" data-set-theme="" _="on click put 'system' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme=""><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">system</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="light" _="on click put 'light' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="light"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg id="light_checkmark" xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">light</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="dark" _="on click put 'dark' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="dark"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">dark</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="cupcake" _="on click put 'cupcake' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="cupcake"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">cupcake</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="bumblebee" _="on click put 'bumblebee' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="bumblebee"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">bumblebee</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="emerald" _="on click put 'emerald' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="emerald"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">emerald</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="corporate" _="on click put 'corporate' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="corporate"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">corporate</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="synthwave" _="on click put 'synthwave' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="synthwave"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">synthwave</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="retro" _="on click put 'retro' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="retro"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">retro</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="cyberpunk" _="on click put 'cyberpunk' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="cyberpunk"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">cyberpunk</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="valentine" _="on click put 'valentine' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="valentine"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">valentine</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="halloween" _="on click put 'halloween' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="halloween"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">halloween</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="garden" _="on click put 'garden' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="garden"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">garden</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="forest" _="on click put 'forest' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="forest"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">forest</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="aqua" _="on click put 'aqua' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="aqua"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">aqua</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="lofi" _="on click put 'lofi' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="lofi"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">lofi</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="pastel" _="on click put 'pastel' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="pastel"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">pastel</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="fantasy" _="on click put 'fantasy' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="fantasy"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">fantasy</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="wireframe" _="on click put 'wireframe' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="wireframe"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">wireframe</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="black" _="on click put 'black' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="black"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">black</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="luxury" _="on click put 'luxury' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="luxury"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">luxury</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="dracula" _="on click put 'dracula' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="dracula"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">dracula</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="cmyk" _="on click put 'cmyk' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="cmyk"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">cmyk</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="autumn" _="on click put 'autumn' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="autumn"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">autumn</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="business" _="on click put 'business' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="business"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">business</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="acid" _="on click put 'acid' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="acid"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">acid</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="lemonade" _="on click put 'lemonade' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="lemonade"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">lemonade</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="night" _="on click put 'night' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="night"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">night</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="coffee" _="on click put 'coffee' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="coffee"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">coffee</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="winter" _="on click put 'winter' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="winter"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">winter</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="dim" _="on click put 'dim' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="dim"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">dim</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="nord" _="on click put 'nord' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="nord"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">nord</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <button class="outline-base-content text-start outline-offset-4" data-act-class="[&_svg]:visible" data-set-theme="sunset" _="on click put 'sunset' into #theme_name"><span class="bg-base-100 rounded-btn text-base-content block w-full cursor-pointer font-sans" data-theme="sunset"><span class="grid grid-cols-5 grid-rows-3"><span class="col-span-5 row-span-3 row-start-1 flex items-center gap-2 px-4 py-3"><svg xmlns="http://www.w3.org/2000/svg" width="16" height="16" viewBox="0 0 24 24" fill="currentColor" class="invisible h-3 w-3 shrink-0"><path d="M20.285 2l-11.285 11.567-5.286-5.011-3.714 3.716 9 8.728 15-15.285z"></path></svg> <span class="flex-grow text-sm">sunset</span> <span class="flex h-full shrink-0 flex-wrap gap-1"><span class="bg-primary rounded-badge w-2"></span> <span class="bg-secondary rounded-badge w-2"></span> <span class="bg-accent rounded-badge w-2"></span> <span class="bg-neutral rounded-badge w-2"></span></span></span></span></span></button> <a class="outline-base-content overflow-hidden rounded-lg text-center" href="/theme-generator/"><p class="px-2 text-xs">Credits to DaisyUI for this list</p></a></div></div></div></div></div><div class="divider m-0"></div><div class="flex justify-between items-center text-sm"><details class="w-full"><summary class="font-semibold cursor-default">Change password</summary><form class="flex flex-col text-sm" hx-post="/auth/change-password" hx-indicator="#fullscreen-loader" hx-swap="none"><label class="form-control w-full"><span class="label"><span class="label-text text-sm">Current password</span></span> <input type="password" placeholder="Enter current password" class="input input-bordered input-sm w-full" name="password-current" required></label> <label class="form-control w-full"><span class="label"><span class="label-text text-sm">New password</span></span> <input type="password" placeholder="Enter new password" class="input input-bordered input-sm w-full" name="password-new" required></label> <label class="form-control w-full"><span class="label"><span class="label-text text-sm">Confirm password</span></span> <input type="password" placeholder="Retype new password" class="input input-bordered input-sm w-full" name="password-confirm" required></label> <button class="btn btn-sm mt-2">Update password</button></form></details></div><div class="divider m-0"></div><div class="flex justify-between items-center text-sm"><details class="w-full"><summary class="font-semibold cursor-default">API tokens<br><span class="text-xs font-normal">Access the API from scripts and automations with a Bearer token.</span></summary><form class="flex flex-col text-sm" hx-post="/settings/api-tokens" hx-target="#settings_api_tokens" hx-swap="outerHTML" _="on htmx:afterRequest if event.detail.successful then me.reset()"><label class="form-control w-full"><span class="label"><span class="label-text text-sm">Name</span></span> <input type="text" name="name" placeholder="Home automation" class="input input-bordered input-sm w-full" autocomplete="off" required></label><div class="grid grid-flow-col gap-2"><label class="form-control w-full"><span class="label"><span class="label-text text-sm">Scopes</span></span> <select name="scopes" class="select select-bordered select-sm"><option value="read" selected>Read</option> <option value="read,write">Read and write</option></select></label> <label class="form-control w-full"><span class="label"><span class="label-text text-sm">Expires</span></span> <select name="expires" class="select select-bordered select-sm"><option value="30">In 30 days</option> <option value="90" selected>In 90 days</option> <option value="365">In 1 year</option> <option value="never">Never</option></select></label></div><button class="btn btn-sm mt-2">Generate token</button></form><div id="settings_api_tokens" class="mt-2"><p class="text-xs p-2">You have not created any API token yet.</p></div></details></div><div class="divider m-0"></div><div class="flex justify-between items-center text-sm"><details class="w-full"><summary class="font-semibold cursor-default">Webhooks<br><span class="text-xs font-normal">Notify other systems when your library changes.</span></summary><form class="flex flex-col text-sm" hx-post="/settings/webhooks" hx-target="#settings_webhooks" hx-swap="outerHTML" _="on htmx:afterRequest if event.detail.successful then me.reset()"><label class="form-control w-full"><span class="label"><span class="label-text text-sm">Payload URL</span></span> <input type="url" name="url" placeholder="https://example.com/hooks/recipya" class="input input-bordered input-sm w-full" required></label><div class="label"><span class="label-text text-sm">Events</span></div><div class="grid grid-cols-2 gap-1"><label class="label cursor-pointer justify-start gap-2 py-0"><input type="checkbox" name="events" value="recipe.created" class="checkbox checkbox-xs" checked> <span class="label-text text-xs">recipe.created</span></label><label class="label cursor-pointer justify-start gap-2 py-0"><input type="checkbox" name="events" value="recipe.updated" class="checkbox checkbox-xs" checked> <span class="label-text text-xs">recipe.updated</span></label><label class="label cursor-pointer justify-start gap-2 py-0"><input type="checkbox" name="events" value="recipe.deleted" class="checkbox checkbox-xs" checked> <span class="label-text text-xs">recipe.deleted</span></label><label class="label cursor-pointer justify-start gap-2 py-0"><input type="checkbox" name="events" value="cookbook.changed" class="checkbox checkbox-xs" checked> <span class="label-text text-xs">cookbook.changed</span></label><label class="label cursor-pointer justify-start gap-2 py-0"><input type="checkbox" name="events" value="import.finished" class="checkbox checkbox-xs" checked> <span class="label-text text-xs">import.finished</span></label><label class="label cursor-pointer justify-start gap-2 py-0"><input type="checkbox" name="events" value="backup.completed" class="checkbox checkbox-xs" checked> <span class="label-text text-xs">backup.completed</span></label><label class="label cursor-pointer justify-start gap-2 py-0"><input type="checkbox" name="events" value="backup.restored" class="checkbox checkbox-xs" checked> <span class="label-text text-xs">backup.restored</span></label></div><label class="label cursor-pointer justify-start gap-2"><input type="checkbox" name="global" class="checkbox checkbox-xs"> <span class="label-text text-xs">Receive the events of all users</span></label> <button class="btn btn-sm mt-2">Add webhook</button></form><div id="settings_webhooks" class="mt-2"><p class="text-xs p-2">You have not registered any webhook yet.</p></div></details></div><div class="divider m-0"></div><div><div class="flex justify-between items-center text-sm"><div><p class="font-semibold">Delete Account</p><p class="font-normal text-sm">This will delete all your data.</p></div><button type="submit" class="btn btn-sm" hx-delete="/auth/user" hx-confirm="Are you sure you want to delete your account? This action is irreversible.">Delete</button></div></div></div>`,
 			`<div id="settings_about" class="p-3 md:p-0 md:pr-4 hidden"><div><div class="flex justify-between items-center text-sm"><div><p class="font-semibold">Recipya Version</p><p class="text-sm mt-2">v1.3.0 (latest)</p><p class="text-xs">Last checked: 0001-01-01<br>Last updated: 0001-01-01<br><br>Read the <a class="link" href="https://recipya.musicavis.ca/about/changelog/v1.3.0" target="_blank">release notes</a></p></div><div class="flex flex-row self-start"><img id="settings_about_update_check" class="htmx-indicator mr-1" src="/static/img/bars.svg" alt="Checking..."> <button class="btn btn-sm" hx-get="/update/check" hx-target="#settings_about" hx-swap="outerHTML" hx-indicator="#settings_about_update_check">Check for updates</button></div></div></div><div class="divider m-0"></div><div class="flex space-x-1"><a href="https://app.element.io/#/room/#recipya:matrix.org"><img alt="Support" src="https://img.shields.io/badge/Element-Recipya-blue?logo=element&logoColor=white"></a> <a href="https://github.com/reaper47/recipya" target="_blank"><img alt="Github Repo" src="https://img.shields.io/github/stars/reaper47/recipya?style=social&label=Star on Github"></a></div></div></div></div>`,
 		}
 		assertStringsInHTML(t, getBodyHTML(rr), want)
 	})
 }
 
+func TestHandlers_Settings_APITokens(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	uri := ts.URL + "/settings/api-tokens"
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodPost, uri)
+		assertMustBeLoggedIn(t, srv, http.MethodDelete, uri+"/1")
+	})
+
+	t.Run("name is required", func(t *testing.T) {
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("name=&scopes=read&expires=30"))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		want := `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"The token's name is required.","title":"Form Error"}}`
+		assertWebsocket(t, c, 1, want)
+	})
+
+	t.Run("invalid expiration", func(t *testing.T) {
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("name=Script&scopes=read&expires=-3"))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		want := `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Invalid expiration.","title":"Form Error"}}`
+		assertWebsocket(t, c, 1, want)
+	})
+
+	t.Run("create token shows the secret once", func(t *testing.T) {
+		repo := &mockRepository{}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("name=Home+Assistant&scopes=write&expires=never"))
+
+		assertStatus(t, rr.Code, http.StatusCreated)
+		if len(repo.APITokensRegistered) != 1 {
+			t.Fatalf("got %d tokens but want 1", len(repo.APITokensRegistered))
+		}
+		got := repo.APITokensRegistered[0].token
+		if got.Name != "Home Assistant" || !got.HasScope(models.APIScopeRead) || !got.HasScope(models.APIScopeWrite) || !got.ExpiresAt.IsZero() {
+			t.Fatalf("unexpected token %+v", got)
+		}
+		body := getBodyHTML(rr)
+		assertStringsInHTML(t, body, []string{
+			`<div id="settings_api_tokens" class="mt-2">`,
+			`Copy your new token now. You will not be able to see it again.`,
+			`value="rcp_`,
+			`<td>Home Assistant</td><td>read,write</td><td>Never</td><td>Never</td>`,
+			`hx-delete="/settings/api-tokens/1"`,
+		})
+	})
+
+	t.Run("revoke token of other user", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			APITokensRegistered: []mockAPIToken{{token: models.APIToken{ID: 1, UserID: 2}}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/1")
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		want := `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Failed to revoke the API token.","title":"Database Error"}}`
+		assertWebsocket(t, c, 1, want)
+	})
+
+	t.Run("revoke token", func(t *testing.T) {
+		repo := &mockRepository{
+			APITokensRegistered: []mockAPIToken{{token: models.APIToken{ID: 1, UserID: 1}}},
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/1")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if len(repo.APITokensRegistered) != 0 {
+			t.Fatal("token must have been deleted")
+		}
+	})
+}
+
 func TestHandlers_Settings_BackupsRestore(t *testing.T) {
 	srv, ts, c := createWSServer()
 	defer c.CloseNow()
@@ -707,3 +786,120 @@ func TestHandlers_Settings_MeasurementSystems(t *testing.T) {
 		})
 	}
 }
+
+func TestHandlers_Settings_Webhooks(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	uri := ts.URL + "/settings/webhooks"
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodPost, uri)
+		assertMustBeLoggedIn(t, srv, http.MethodDelete, uri+"/1")
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		for _, u := range []string{"", "example.com/hook", "ftp://example.com/hook", "/hook"} {
+			t.Run(u, func(t *testing.T) {
+				rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("url="+u+"&events=recipe.created"))
+
+				assertStatus(t, rr.Code, http.StatusBadRequest)
+				want := `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"The webhook's URL must be an absolute HTTP or HTTPS URL.","title":"Form Error"}}`
+				assertWebsocket(t, c, 1, want)
+			})
+		}
+	})
+
+	t.Run("events are required", func(t *testing.T) {
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("url=https://example.com/hook&events=recipe.eaten"))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		want := `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"At least one event is required.","title":"Form Error"}}`
+		assertWebsocket(t, c, 1, want)
+	})
+
+	t.Run("only the admin may target private addresses", func(t *testing.T) {
+		repo := &mockRepository{}
+		srv.Repository = repo
+
+		for _, u := range []string{"http://127.0.0.1:8080/hook", "http://169.254.169.254/latest", "http://10.0.0.4/hook", "http://[::1]/hook", "http://localhost/hook"} {
+			t.Run(u, func(t *testing.T) {
+				rr := sendRequestAsLoggedInOther(srv, http.MethodPost, uri, formHeader, strings.NewReader("url="+url.QueryEscape(u)+"&events=recipe.created"))
+
+				assertStatus(t, rr.Code, http.StatusBadRequest)
+				if len(repo.WebhooksRegistered) != 0 {
+					t.Fatalf("got %d webhooks but want 0", len(repo.WebhooksRegistered))
+				}
+			})
+		}
+
+		rr := sendRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("url="+url.QueryEscape("http://192.168.1.20:8123/hook")+"&events=recipe.created"))
+
+		assertStatus(t, rr.Code, http.StatusCreated)
+		if len(repo.WebhooksRegistered) != 1 {
+			t.Fatalf("got %d webhooks but want 1", len(repo.WebhooksRegistered))
+		}
+	})
+
+	t.Run("register webhook shows the secret once", func(t *testing.T) {
+		repo := &mockRepository{}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("url=https://example.com/hook&events=recipe.created&events=backup.completed&global=on"))
+
+		assertStatus(t, rr.Code, http.StatusCreated)
+		if len(repo.WebhooksRegistered) != 1 {
+			t.Fatalf("got %d webhooks but want 1", len(repo.WebhooksRegistered))
+		}
+		got := repo.WebhooksRegistered[0]
+		if got.URL != "https://example.com/hook" || got.EventsString() != "recipe.created,backup.completed" || !got.IsGlobal || !strings.HasPrefix(got.Secret, "whsec_") {
+			t.Fatalf("unexpected webhook %+v", got)
+		}
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<div id="settings_webhooks" class="mt-2">`,
+			`value="` + got.Secret + `"`,
+			`<td class="break-all">https://example.com/hook <span class="badge badge-xs badge-accent">All users</span></td><td class="break-all">recipe.created,backup.completed</td><td>Never</td>`,
+			`hx-delete="/settings/webhooks/1"`,
+		})
+	})
+
+	t.Run("only the admin may register global webhooks", func(t *testing.T) {
+		repo := &mockRepository{}
+		srv.Repository = repo
+
+		rr := sendRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("url=https://example.com/hook&events=recipe.created&global=on"))
+		assertStatus(t, rr.Code, http.StatusCreated)
+		if !repo.WebhooksRegistered[0].IsGlobal {
+			t.Fatal("admin webhook must be global")
+		}
+
+		rr = sendRequestAsLoggedInOther(srv, http.MethodPost, uri, formHeader, strings.NewReader("url=https://example.com/hook&events=recipe.created&global=on"))
+
+		assertStatus(t, rr.Code, http.StatusCreated)
+		if repo.WebhooksRegistered[1].IsGlobal {
+			t.Fatal("non-admin webhook must not be global")
+		}
+	})
+
+	t.Run("delete webhook of other user", func(t *testing.T) {
+		srv.Repository = &mockRepository{WebhooksRegistered: []models.Webhook{{ID: 1, UserID: 2}}}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/1")
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		want := `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Failed to delete the webhook.","title":"Database Error"}}`
+		assertWebsocket(t, c, 1, want)
+	})
+
+	t.Run("delete webhook", func(t *testing.T) {
+		repo := &mockRepository{WebhooksRegistered: []models.Webhook{{ID: 1, UserID: 1}}}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/1")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if len(repo.WebhooksRegistered) != 0 {
+			t.Fatal("webhook must have been deleted")
+		}
+	})
+}
@@ -0,0 +1,345 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/templates"
+	"github.com/reaper47/recipya/web/components"
+)
+
+func (s *Server) shoppingListsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		lists, err := s.Repository.ShoppingLists(userID)
+		if err != nil {
+			msg := "Error getting the shopping lists."
+			slog.Error(msg, "userID", userID, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_ = components.ShoppingLists(templates.Data{
+			About:           templates.NewAboutData(),
+			IsAdmin:         userID == 1,
+			IsAuthenticated: true,
+			IsHxRequest:     r.Header.Get("Hx-Request") == "true",
+			Recipes:         s.Repository.RecipesAll(userID),
+			ShoppingLists:   lists,
+			Title:           "Shopping lists",
+		}).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) shoppingListsPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		var (
+			list models.ShoppingList
+			ok   bool
+		)
+
+		switch {
+		case r.FormValue("cookbookId") != "":
+			list, ok = s.shoppingListFromCookbook(w, r.FormValue("cookbookId"), userID)
+		case r.FormValue("week") != "":
+			list, ok = s.shoppingListFromMealPlan(w, r.FormValue("week"), userID)
+		default:
+			list, ok = s.shoppingListFromRecipes(w, r, userID)
+		}
+		if !ok {
+			return
+		}
+
+		if name := strings.TrimSpace(r.FormValue("name")); name != "" {
+			list.Name = name
+		}
+
+		id, err := s.Repository.AddShoppingList(list, userID)
+		if err != nil {
+			msg := "Could not create the shopping list."
+			slog.Error(msg, userIDAttr, "name", list.Name, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Created shopping list", userIDAttr, "id", id, "name", list.Name, "numItems", len(list.Items))
+		w.Header().Set("HX-Redirect", "/shopping-lists/"+strconv.FormatInt(id, 10))
+		w.WriteHeader(http.StatusCreated)
+	}
+}
+
+func (s *Server) shoppingListHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		list, ok := s.userShoppingList(w, r, userID)
+		if !ok {
+			return
+		}
+
+		_ = components.ShoppingList(templates.Data{
+			About:           templates.NewAboutData(),
+			IsAdmin:         userID == 1,
+			IsAuthenticated: true,
+			IsHxRequest:     r.Header.Get("Hx-Request") == "true",
+			Recipes:         s.Repository.RecipesAll(userID),
+			ShoppingList:    list,
+			Title:           list.Name,
+		}).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) shoppingListDeleteHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Shopping list ID in path must be > 0."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		err = s.Repository.DeleteShoppingList(id, userID)
+		if errors.Is(err, sql.ErrNoRows) {
+			s.Brokers.SendToast(models.NewErrorGeneralToast("Shopping list not found."), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if err != nil {
+			msg := "Could not delete the shopping list."
+			slog.Error(msg, userIDAttr, "id", id, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Deleted shopping list", userIDAttr, "id", id)
+	}
+}
+
+func (s *Server) shoppingListItemPutHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		listID, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Shopping list ID in path must be > 0."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		itemID, err := parsePathPositiveID(r.PathValue("itemID"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Item ID in path must be > 0."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		isChecked := r.FormValue("isChecked") == "on"
+
+		err = s.Repository.UpdateShoppingListItem(itemID, listID, isChecked, userID)
+		if errors.Is(err, sql.ErrNoRows) {
+			s.Brokers.SendToast(models.NewErrorGeneralToast("Item not found."), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if err != nil {
+			msg := "Could not update the item."
+			slog.Error(msg, userIDAttr, "listID", listID, "itemID", itemID, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) shoppingListRecipesPostHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		userIDAttr := slog.Int64("userID", userID)
+
+		list, ok := s.userShoppingList(w, r, userID)
+		if !ok {
+			return
+		}
+
+		recipeID, err := parsePathPositiveID(r.FormValue("recipeId"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorFormToast("Missing 'recipeId' in body."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var yield int16
+		if v := r.FormValue("yield"); v != "" {
+			parsed, err := strconv.ParseInt(v, 10, 16)
+			if err != nil || parsed <= 0 {
+				s.Brokers.SendToast(models.NewErrorFormToast("The yield must be greater than zero."), userID)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			yield = int16(parsed)
+		}
+
+		recipe, err := s.Repository.Recipe(recipeID, userID)
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorGeneralToast("Recipe not found."), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		list.AddRecipe(*recipe, yield)
+
+		err = s.Repository.UpdateShoppingList(list, userID)
+		if err != nil {
+			msg := "Could not add the recipe to the shopping list."
+			slog.Error(msg, userIDAttr, "listID", list.ID, "recipeID", recipeID, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		list, err = s.Repository.ShoppingList(list.ID, userID)
+		if err != nil {
+			msg := "Error getting the shopping list."
+			slog.Error(msg, userIDAttr, "id", list.ID, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		slog.Info("Added recipe to shopping list", userIDAttr, "listID", list.ID, "recipeID", recipeID, "yield", yield)
+		_ = components.ShoppingListContent(list).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) shoppingListFromCookbook(w http.ResponseWriter, value string, userID int64) (models.ShoppingList, bool) {
+	id, err := parsePathPositiveID(value)
+	if err != nil {
+		s.Brokers.SendToast(models.NewErrorFormToast("Cookbook ID must be > 0."), userID)
+		w.WriteHeader(http.StatusBadRequest)
+		return models.ShoppingList{}, false
+	}
+
+	cookbook, err := s.Repository.Cookbook(id, userID)
+	if err != nil {
+		s.Brokers.SendToast(models.NewErrorGeneralToast("Cookbook not found."), userID)
+		w.WriteHeader(http.StatusNotFound)
+		return models.ShoppingList{}, false
+	}
+
+	if len(cookbook.Recipes) == 0 {
+		s.Brokers.SendToast(models.NewErrorReqToast("Cookbook is empty."), userID)
+		w.WriteHeader(http.StatusBadRequest)
+		return models.ShoppingList{}, false
+	}
+
+	list := models.ShoppingList{Name: cookbook.Title}
+	for _, recipe := range cookbook.Recipes {
+		list.AddRecipe(recipe, 0)
+	}
+	return list, true
+}
+
+func (s *Server) shoppingListFromMealPlan(w http.ResponseWriter, value string, userID int64) (models.ShoppingList, bool) {
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		s.Brokers.SendToast(models.NewErrorFormToast("The week must be formatted as YYYY-MM-DD."), userID)
+		w.WriteHeader(http.StatusBadRequest)
+		return models.ShoppingList{}, false
+	}
+
+	start := models.StartOfWeek(date)
+
+	entries, err := s.Repository.MealPlan(userID, start, start.AddDate(0, 0, 6))
+	if err != nil {
+		msg := "Error getting the meal plan."
+		slog.Error(msg, "userID", userID, "error", err)
+		s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+		w.WriteHeader(http.StatusInternalServerError)
+		return models.ShoppingList{}, false
+	}
+
+	if len(entries) == 0 {
+		s.Brokers.SendToast(models.NewErrorReqToast("No recipes are planned this week."), userID)
+		w.WriteHeader(http.StatusBadRequest)
+		return models.ShoppingList{}, false
+	}
+
+	list := models.ShoppingList{Name: "Week of " + start.Format("Jan 2, 2006")}
+	for _, entry := range entries {
+		recipe, err := s.Repository.Recipe(entry.RecipeID, userID)
+		if err != nil {
+			slog.Warn("Skipped planned recipe", "userID", userID, "recipeID", entry.RecipeID, "error", err)
+			continue
+		}
+		list.AddRecipe(*recipe, entry.Servings)
+	}
+	return list, true
+}
+
+func (s *Server) shoppingListFromRecipes(w http.ResponseWriter, r *http.Request, userID int64) (models.ShoppingList, bool) {
+	err := r.ParseForm()
+	if err != nil || len(r.Form["recipeId"]) == 0 {
+		s.Brokers.SendToast(models.NewErrorFormToast("Select at least one recipe."), userID)
+		w.WriteHeader(http.StatusBadRequest)
+		return models.ShoppingList{}, false
+	}
+
+	list := models.ShoppingList{Name: "Shopping list"}
+	for _, value := range r.Form["recipeId"] {
+		id, err := parsePathPositiveID(value)
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorFormToast("Recipe ID must be > 0."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return models.ShoppingList{}, false
+		}
+
+		recipe, err := s.Repository.Recipe(id, userID)
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorGeneralToast("Recipe not found."), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return models.ShoppingList{}, false
+		}
+		list.AddRecipe(*recipe, 0)
+	}
+	return list, true
+}
+
+func (s *Server) userShoppingList(w http.ResponseWriter, r *http.Request, userID int64) (models.ShoppingList, bool) {
+	id, err := parsePathPositiveID(r.PathValue("id"))
+	if err != nil {
+		s.Brokers.SendToast(models.NewErrorReqToast("Shopping list ID in path must be > 0."), userID)
+		w.WriteHeader(http.StatusBadRequest)
+		return models.ShoppingList{}, false
+	}
+
+	list, err := s.Repository.ShoppingList(id, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		s.Brokers.SendToast(models.NewErrorGeneralToast("Shopping list not found."), userID)
+		w.WriteHeader(http.StatusNotFound)
+		return models.ShoppingList{}, false
+	} else if err != nil {
+		msg := "Error getting the shopping list."
+		slog.Error(msg, "userID", userID, "id", id, "error", err)
+		s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+		w.WriteHeader(http.StatusInternalServerError)
+		return models.ShoppingList{}, false
+	}
+	return list, true
+}
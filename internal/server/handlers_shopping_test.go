@@ -0,0 +1,405 @@
+package server_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/units"
+)
+
+func TestHandlers_ShoppingLists(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	recipes := models.Recipes{
+		{ID: 1, Name: "Cake", Ingredients: []string{"200 g butter", "3 eggs"}, Yield: 4},
+		{ID: 2, Name: "Cookies", Ingredients: []string{"0.5 kg butter", "1 egg"}, Yield: 12},
+	}
+
+	uri := ts.URL + "/shopping-lists"
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri)
+		assertMustBeLoggedIn(t, srv, http.MethodPost, uri)
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri+"/1")
+		assertMustBeLoggedIn(t, srv, http.MethodDelete, uri+"/1")
+		assertMustBeLoggedIn(t, srv, http.MethodPut, uri+"/1/items/1")
+		assertMustBeLoggedIn(t, srv, http.MethodPost, uri+"/1/recipes")
+	})
+
+	t.Run("displays the lists", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			CookbooksRegistered: map[int64][]models.Cookbook{1: {
+				{ID: 1, Title: "Desserts", Recipes: recipes},
+				{ID: 2, Title: "Empty"},
+			}},
+			RecipesRegistered: map[int64]models.Recipes{1: recipes},
+			ShoppingListsRegistered: map[int64][]models.ShoppingList{1: {
+				{
+					ID:        1,
+					CreatedAt: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+					Items: []models.ShoppingListItem{
+						{ID: 1, Aisle: models.AisleDairy, IsChecked: true, Name: "butter", Quantity: 200, Unit: units.Gram},
+						{ID: 2, Aisle: models.AisleProduce, Name: "onions", Quantity: 2},
+					},
+					Name:    "Groceries",
+					Recipes: []models.ShoppingListRecipe{{RecipeID: 1, Name: "Cake", Yield: 4}},
+				},
+			}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<title hx-swap-oob="true">Shopping lists | Recipya</title>`,
+			`<a class="link link-hover font-semibold break-words" hx-get="/shopping-lists/1" hx-target="#content" hx-push-url="true">Groceries</a><p class="text-xs opacity-70">1/2 items checked · Oct 18, 2026</p>`,
+			`hx-delete="/shopping-lists/1" hx-target="closest .shopping-list" hx-swap="outerHTML"`,
+			`<input type="checkbox" name="recipeId" value="2" class="checkbox checkbox-sm"> <span class="label-text break-words">Cookies</span>`,
+		})
+	})
+
+	t.Run("create from no recipes", func(t *testing.T) {
+		srv.Repository = &mockRepository{}
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("name=Party"))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Select at least one recipe.","title":"Form Error"}}`)
+	})
+
+	t.Run("create from recipes", func(t *testing.T) {
+		repo := &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{1: recipes},
+			ShoppingListsRegistered: map[int64][]models.ShoppingList{1: {
+				{
+					ID:        1,
+					CreatedAt: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+					Items: []models.ShoppingListItem{
+						{ID: 1, Aisle: models.AisleDairy, IsChecked: true, Name: "butter", Quantity: 200, Unit: units.Gram},
+						{ID: 2, Aisle: models.AisleProduce, Name: "onions", Quantity: 2},
+					},
+					Name:    "Groceries",
+					Recipes: []models.ShoppingListRecipe{{RecipeID: 1, Name: "Cake", Yield: 4}},
+				},
+			}},
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("name=Party&recipeId=1&recipeId=2"))
+
+		assertStatus(t, rr.Code, http.StatusCreated)
+		assertHeader(t, rr, "HX-Redirect", "/shopping-lists/2")
+		list := repo.ShoppingListsRegistered[1][1]
+		if list.Name != "Party" || len(list.Recipes) != 2 {
+			t.Fatalf("unexpected list %+v", list)
+		}
+		assertShoppingItems(t, list, map[string]string{"butter": "700 g", "eggs": "4"})
+	})
+
+	t.Run("create from empty cookbook", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			CookbooksRegistered: map[int64][]models.Cookbook{1: {
+				{ID: 1, Title: "Desserts", Recipes: recipes},
+				{ID: 2, Title: "Empty"},
+			}},
+		}
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("cookbookId=2"))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Cookbook is empty.","title":"Request Error"}}`)
+	})
+
+	t.Run("create from cookbook", func(t *testing.T) {
+		repo := &mockRepository{
+			CookbooksRegistered: map[int64][]models.Cookbook{1: {
+				{ID: 1, Title: "Desserts", Recipes: recipes},
+				{ID: 2, Title: "Empty"},
+			}},
+			ShoppingListsRegistered: map[int64][]models.ShoppingList{1: {
+				{
+					ID:        1,
+					CreatedAt: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+					Items: []models.ShoppingListItem{
+						{ID: 1, Aisle: models.AisleDairy, IsChecked: true, Name: "butter", Quantity: 200, Unit: units.Gram},
+						{ID: 2, Aisle: models.AisleProduce, Name: "onions", Quantity: 2},
+					},
+					Name:    "Groceries",
+					Recipes: []models.ShoppingListRecipe{{RecipeID: 1, Name: "Cake", Yield: 4}},
+				},
+			}},
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("cookbookId=1"))
+
+		assertStatus(t, rr.Code, http.StatusCreated)
+		list := repo.ShoppingListsRegistered[1][1]
+		if list.Name != "Desserts" {
+			t.Fatalf("got name %q but want the cookbook's title", list.Name)
+		}
+		assertShoppingItems(t, list, map[string]string{"butter": "700 g", "eggs": "4"})
+	})
+
+	t.Run("create from meal plan uses the planned servings", func(t *testing.T) {
+		repo := &mockRepository{
+			MealPlanRegistered: map[int64][]models.MealPlanEntry{1: {
+				{ID: 1, Date: time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC), RecipeID: 1, RecipeName: "Cake", RecipeYield: 4, Servings: 8, Slot: models.MealSlotDinner},
+			}},
+			RecipesRegistered: map[int64]models.Recipes{1: recipes},
+			ShoppingListsRegistered: map[int64][]models.ShoppingList{1: {
+				{
+					ID:        1,
+					CreatedAt: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+					Items: []models.ShoppingListItem{
+						{ID: 1, Aisle: models.AisleDairy, IsChecked: true, Name: "butter", Quantity: 200, Unit: units.Gram},
+						{ID: 2, Aisle: models.AisleProduce, Name: "onions", Quantity: 2},
+					},
+					Name:    "Groceries",
+					Recipes: []models.ShoppingListRecipe{{RecipeID: 1, Name: "Cake", Yield: 4}},
+				},
+			}},
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("week=2026-10-22"))
+
+		assertStatus(t, rr.Code, http.StatusCreated)
+		list := repo.ShoppingListsRegistered[1][1]
+		if list.Name != "Week of Oct 19, 2026" {
+			t.Fatalf("got name %q", list.Name)
+		}
+		assertShoppingItems(t, list, map[string]string{"butter": "400 g", "eggs": "6"})
+	})
+
+	t.Run("create from week without plan", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			MealPlanRegistered: map[int64][]models.MealPlanEntry{1: {
+				{ID: 1, Date: time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC), RecipeID: 1, RecipeName: "Cake", RecipeYield: 4, Servings: 8, Slot: models.MealSlotDinner},
+			}},
+		}
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri, formHeader, strings.NewReader("week=2026-11-02"))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"No recipes are planned this week.","title":"Request Error"}}`)
+	})
+
+	t.Run("list not found", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			ShoppingListsRegistered: map[int64][]models.ShoppingList{1: {
+				{
+					ID:        1,
+					CreatedAt: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+					Items: []models.ShoppingListItem{
+						{ID: 1, Aisle: models.AisleDairy, IsChecked: true, Name: "butter", Quantity: 200, Unit: units.Gram},
+						{ID: 2, Aisle: models.AisleProduce, Name: "onions", Quantity: 2},
+					},
+					Name:    "Groceries",
+					Recipes: []models.ShoppingListRecipe{{RecipeID: 1, Name: "Cake", Yield: 4}},
+				},
+			}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/5")
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Shopping list not found.","title":"General Error"}}`)
+	})
+
+	t.Run("displays the list by aisle", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			ShoppingListsRegistered: map[int64][]models.ShoppingList{1: {
+				{
+					ID:        1,
+					CreatedAt: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+					Items: []models.ShoppingListItem{
+						{ID: 1, Aisle: models.AisleDairy, IsChecked: true, Name: "butter", Quantity: 200, Unit: units.Gram},
+						{ID: 2, Aisle: models.AisleProduce, Name: "onions", Quantity: 2},
+					},
+					Name:    "Groceries",
+					Recipes: []models.ShoppingListRecipe{{RecipeID: 1, Name: "Cake", Yield: 4}},
+				},
+			}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/1")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		body := getBodyHTML(rr)
+		assertStringsInHTML(t, body, []string{
+			`<title hx-swap-oob="true">Groceries | Recipya</title>`,
+			`<li class="badge badge-outline">Cake (4 servings)</li>`,
+			`<h2 class="font-semibold border-b mb-1 dark:border-slate-600">Produce</h2>`,
+			`<input type="checkbox" name="isChecked" class="checkbox checkbox-sm peer" checked hx-put="/shopping-lists/1/items/1" hx-trigger="change" hx-swap="none"> <span class="label-text peer-checked:line-through peer-checked:opacity-50"><span class="font-medium">200 g</span> butter</span>`,
+			`<span class="font-medium">2</span> onions`,
+		})
+		if produce, dairy := strings.Index(body, ">Produce</h2>"), strings.Index(body, ">Dairy &amp; Eggs</h2>"); dairy == -1 || produce > dairy {
+			t.Fatal("aisles must be displayed in store order")
+		}
+	})
+
+	t.Run("add recipe at a different yield", func(t *testing.T) {
+		repo := &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{1: recipes},
+			ShoppingListsRegistered: map[int64][]models.ShoppingList{1: {
+				{
+					ID:        1,
+					CreatedAt: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+					Items: []models.ShoppingListItem{
+						{ID: 1, Aisle: models.AisleDairy, IsChecked: true, Name: "butter", Quantity: 200, Unit: units.Gram},
+						{ID: 2, Aisle: models.AisleProduce, Name: "onions", Quantity: 2},
+					},
+					Name:    "Groceries",
+					Recipes: []models.ShoppingListRecipe{{RecipeID: 1, Name: "Cake", Yield: 4}},
+				},
+			}},
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri+"/1/recipes", formHeader, strings.NewReader("recipeId=2&yield=6"))
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		list := repo.ShoppingListsRegistered[1][0]
+		assertShoppingItems(t, list, map[string]string{"butter": "450 g", "onions": "2", "egg": "0.5"})
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<li class="badge badge-outline">Cookies (6 servings)</li>`,
+		})
+	})
+
+	t.Run("add recipe invalid yield", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{1: recipes},
+			ShoppingListsRegistered: map[int64][]models.ShoppingList{1: {
+				{
+					ID:        1,
+					CreatedAt: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+					Items: []models.ShoppingListItem{
+						{ID: 1, Aisle: models.AisleDairy, IsChecked: true, Name: "butter", Quantity: 200, Unit: units.Gram},
+						{ID: 2, Aisle: models.AisleProduce, Name: "onions", Quantity: 2},
+					},
+					Name:    "Groceries",
+					Recipes: []models.ShoppingListRecipe{{RecipeID: 1, Name: "Cake", Yield: 4}},
+				},
+			}},
+		}
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, uri+"/1/recipes", formHeader, strings.NewReader("recipeId=2&yield=-1"))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"The yield must be greater than zero.","title":"Form Error"}}`)
+	})
+
+	t.Run("check item", func(t *testing.T) {
+		repo := &mockRepository{
+			ShoppingListsRegistered: map[int64][]models.ShoppingList{1: {
+				{
+					ID:        1,
+					CreatedAt: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+					Items: []models.ShoppingListItem{
+						{ID: 1, Aisle: models.AisleDairy, IsChecked: true, Name: "butter", Quantity: 200, Unit: units.Gram},
+						{ID: 2, Aisle: models.AisleProduce, Name: "onions", Quantity: 2},
+					},
+					Name:    "Groceries",
+					Recipes: []models.ShoppingListRecipe{{RecipeID: 1, Name: "Cake", Yield: 4}},
+				},
+			}},
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri+"/1/items/2", formHeader, strings.NewReader("isChecked=on"))
+
+		assertStatus(t, rr.Code, http.StatusNoContent)
+		if !repo.ShoppingListsRegistered[1][0].Items[1].IsChecked {
+			t.Fatal("item must be checked")
+		}
+	})
+
+	t.Run("uncheck item", func(t *testing.T) {
+		repo := &mockRepository{
+			ShoppingListsRegistered: map[int64][]models.ShoppingList{1: {
+				{
+					ID:        1,
+					CreatedAt: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+					Items: []models.ShoppingListItem{
+						{ID: 1, Aisle: models.AisleDairy, IsChecked: true, Name: "butter", Quantity: 200, Unit: units.Gram},
+						{ID: 2, Aisle: models.AisleProduce, Name: "onions", Quantity: 2},
+					},
+					Name:    "Groceries",
+					Recipes: []models.ShoppingListRecipe{{RecipeID: 1, Name: "Cake", Yield: 4}},
+				},
+			}},
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodPut, uri+"/1/items/1")
+
+		assertStatus(t, rr.Code, http.StatusNoContent)
+		if repo.ShoppingListsRegistered[1][0].Items[0].IsChecked {
+			t.Fatal("item must be unchecked")
+		}
+	})
+
+	t.Run("check item not found", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			ShoppingListsRegistered: map[int64][]models.ShoppingList{1: {
+				{
+					ID:        1,
+					CreatedAt: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+					Items: []models.ShoppingListItem{
+						{ID: 1, Aisle: models.AisleDairy, IsChecked: true, Name: "butter", Quantity: 200, Unit: units.Gram},
+						{ID: 2, Aisle: models.AisleProduce, Name: "onions", Quantity: 2},
+					},
+					Name:    "Groceries",
+					Recipes: []models.ShoppingListRecipe{{RecipeID: 1, Name: "Cake", Yield: 4}},
+				},
+			}},
+		}
+
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPut, uri+"/1/items/9", formHeader, strings.NewReader("isChecked=on"))
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Item not found.","title":"General Error"}}`)
+	})
+
+	t.Run("delete list", func(t *testing.T) {
+		repo := &mockRepository{
+			ShoppingListsRegistered: map[int64][]models.ShoppingList{1: {
+				{
+					ID:        1,
+					CreatedAt: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+					Items: []models.ShoppingListItem{
+						{ID: 1, Aisle: models.AisleDairy, IsChecked: true, Name: "butter", Quantity: 200, Unit: units.Gram},
+						{ID: 2, Aisle: models.AisleProduce, Name: "onions", Quantity: 2},
+					},
+					Name:    "Groceries",
+					Recipes: []models.ShoppingListRecipe{{RecipeID: 1, Name: "Cake", Yield: 4}},
+				},
+			}},
+		}
+		srv.Repository = repo
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodDelete, uri+"/1")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		if len(repo.ShoppingListsRegistered[1]) > 0 {
+			t.Fatal("list must have been deleted")
+		}
+	})
+}
+
+func assertShoppingItems(tb testing.TB, list models.ShoppingList, want map[string]string) {
+	tb.Helper()
+	if len(list.Items) != len(want) {
+		tb.Fatalf("got %d items but want %d: %+v", len(list.Items), len(want), list.Items)
+	}
+	for _, item := range list.Items {
+		if got := item.QuantityString(); got != want[item.Name] {
+			tb.Errorf("got %q of %s but want %q", got, item.Name, want[item.Name])
+		}
+	}
+}
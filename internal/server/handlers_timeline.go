@@ -0,0 +1,104 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/templates"
+	"github.com/reaper47/recipya/web/components"
+)
+
+// readyAtLayout is the layout of the value of a datetime-local input.
+const readyAtLayout = "2006-01-02T15:04"
+
+func (s *Server) timelineHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		var timeline models.Timeline
+		if len(r.URL.Query()["recipeId"]) > 0 {
+			var ok bool
+			timeline, ok = s.userTimeline(w, r, userID)
+			if !ok {
+				return
+			}
+		}
+
+		_ = components.Timeline(templates.Data{
+			About:           templates.NewAboutData(),
+			IsAdmin:         userID == 1,
+			IsAuthenticated: true,
+			IsHxRequest:     r.Header.Get("Hx-Request") == "true",
+			Recipes:         s.Repository.RecipesAll(userID),
+			Timeline:        timeline,
+			Title:           "Batch cooking",
+		}).Render(r.Context(), w)
+	}
+}
+
+func (s *Server) timelinePDFHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		timeline, ok := s.userTimeline(w, r, userID)
+		if !ok {
+			return
+		}
+
+		fileName, err := s.Files.ExportTimeline(timeline)
+		if err != nil {
+			slog.Error("Failed to export timeline", "userID", userID, "error", err)
+			s.Brokers.SendToast(models.NewErrorFilesToast("Failed to export the timeline."), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("HX-Redirect", "/download/"+fileName)
+		w.WriteHeader(http.StatusSeeOther)
+	}
+}
+
+// userTimeline merges the recipes of the request's recipeId values into a timeline ready at the
+// request's ready-at time. When no time is given, the recipes are ready as soon as possible.
+func (s *Server) userTimeline(w http.ResponseWriter, r *http.Request, userID int64) (models.Timeline, bool) {
+	query := r.URL.Query()
+	if len(query["recipeId"]) == 0 {
+		s.Brokers.SendToast(models.NewErrorFormToast("Select at least one recipe."), userID)
+		w.WriteHeader(http.StatusBadRequest)
+		return models.Timeline{}, false
+	}
+
+	recipes := make(models.Recipes, 0, len(query["recipeId"]))
+	for _, value := range query["recipeId"] {
+		id, err := parsePathPositiveID(value)
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorFormToast("Recipe ID must be > 0."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return models.Timeline{}, false
+		}
+
+		recipe, err := s.Repository.Recipe(id, userID)
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorGeneralToast("Recipe not found."), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return models.Timeline{}, false
+		}
+		recipes = append(recipes, *recipe)
+	}
+
+	if value := query.Get("ready-at"); value != "" {
+		readyAt, err := time.ParseInLocation(readyAtLayout, value, time.Local)
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorFormToast("The ready time must be formatted as YYYY-MM-DDTHH:MM."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return models.Timeline{}, false
+		}
+		return models.NewTimeline(recipes, readyAt), true
+	}
+
+	now := time.Now().Truncate(time.Minute)
+	readyAt := now.Add(models.NewTimeline(recipes, now).Duration())
+	return models.NewTimeline(recipes, readyAt), true
+}
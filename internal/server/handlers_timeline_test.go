@@ -0,0 +1,89 @@
+package server_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestHandlers_Timeline(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	srv.Repository = &mockRepository{
+		RecipesRegistered: map[int64]models.Recipes{1: {
+			{
+				ID:           1,
+				Name:         "Lasagna",
+				Instructions: []string{"Assemble the layers for 15 minutes.", "Bake for 45 minutes."},
+			},
+			{
+				ID:           2,
+				Name:         "Roasted veggies",
+				Instructions: []string{"Roast in the oven for 30 minutes."},
+			},
+		}},
+	}
+
+	uri := ts.URL + "/timeline"
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri)
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri+"/pdf")
+	})
+
+	t.Run("displays the recipes to select", func(t *testing.T) {
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<title hx-swap-oob="true">Batch cooking | Recipya</title>`,
+			`<input type="checkbox" name="recipeId" value="2" class="checkbox checkbox-sm"> <span class="label-text break-words">Roasted veggies</span>`,
+			`<p>Select the recipes to cook at once to merge their steps into a single schedule.</p>`,
+		})
+	})
+
+	t.Run("merges the recipes", func(t *testing.T) {
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"?recipeId=1&recipeId=2&ready-at=2026-10-18T18:00")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<input type="datetime-local" name="ready-at" class="input input-bordered input-sm" value="2026-10-18T18:00">`,
+			`<input type="checkbox" name="recipeId" value="1" class="checkbox checkbox-sm" checked>`,
+			`<h1 class="font-semibold md:text-lg">Start at 17:00 to have everything ready at 18:00</h1>`,
+			`<div role="alert" class="alert alert-warning text-sm">Oven conflict at 17:30: Lasagna (step 2), Roasted veggies (step 1)</div>`,
+			`<li class="grid grid-cols-[6.5rem_1fr] gap-2 p-2 rounded-lg border dark:border-slate-600"><span class="font-medium">17:00 – 17:15</span><div><p class="font-semibold">Lasagna <span class="text-xs font-normal opacity-70">step 1</span></p><p class="break-words">Assemble the layers for 15 minutes.</p></div></li>`,
+			`<li class="grid grid-cols-[6.5rem_1fr] gap-2 p-2 rounded-lg border dark:border-slate-600 border-error bg-error/10"><span class="font-medium">17:15 – 18:00</span>`,
+		})
+	})
+
+	t.Run("invalid ready time", func(t *testing.T) {
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"?recipeId=1&ready-at=6pm")
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"The ready time must be formatted as YYYY-MM-DDTHH:MM.","title":"Form Error"}}`)
+	})
+
+	t.Run("recipe not found", func(t *testing.T) {
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"?recipeId=9")
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Recipe not found.","title":"General Error"}}`)
+	})
+
+	t.Run("export without recipes", func(t *testing.T) {
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/pdf")
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"Select at least one recipe.","title":"Form Error"}}`)
+	})
+
+	t.Run("export to PDF", func(t *testing.T) {
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri+"/pdf?recipeId=1&ready-at="+time.Now().Format("2006-01-02T15:04"))
+
+		assertStatus(t, rr.Code, http.StatusSeeOther)
+		assertHeader(t, rr, "HX-Redirect", "/download/Batch_cooking.pdf")
+	})
+}
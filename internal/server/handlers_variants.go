@@ -0,0 +1,67 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/templates"
+	"github.com/reaper47/recipya/web/components"
+)
+
+func (s *Server) recipeVariantHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.renderRecipeCopy(w, r, true)
+	}
+}
+
+func (s *Server) recipeCompareHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+
+		id, err := parsePathPositiveID(r.PathValue("id"))
+		if err != nil {
+			s.Brokers.SendToast(models.NewErrorReqToast("Recipe ID in path must be > 0."), userID)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		variant, err := s.Repository.Recipe(id, userID)
+		if err != nil {
+			slog.Error("Failed to fetch recipe", "userID", userID, "id", id, "error", err)
+			notFoundHandler(w, r)
+			return
+		}
+
+		variants, err := s.Repository.RecipeVariants(id, userID)
+		if err != nil {
+			msg := "Error getting the variants of the recipe."
+			slog.Error(msg, "userID", userID, "id", id, "error", err)
+			s.Brokers.SendToast(models.NewErrorDBToast(msg), userID)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if variants.Parent == nil {
+			s.Brokers.SendToast(models.NewErrorGeneralToast("This recipe is not a variant of another recipe."), userID)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		parent, err := s.Repository.Recipe(variants.Parent.ID, userID)
+		if err != nil {
+			slog.Error("Failed to fetch parent recipe", "userID", userID, "id", id, "parentID", variants.Parent.ID, "error", err)
+			notFoundHandler(w, r)
+			return
+		}
+
+		_ = components.RecipeComparison(templates.Data{
+			About:           templates.NewAboutData(),
+			Comparison:      models.VariantComparison{Parent: *parent, Variant: *variant},
+			IsAdmin:         userID == 1,
+			IsAuthenticated: true,
+			IsHxRequest:     r.Header.Get("Hx-Request") == "true",
+			Title:           variant.Name + " compared with " + parent.Name,
+		}).Render(r.Context(), w)
+	}
+}
@@ -0,0 +1,228 @@
+package server_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+func TestHandlers_RecipeVariant(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	uri := ts.URL + "/recipes/1/variant"
+
+	t.Run("must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodGet, uri)
+	})
+
+	t.Run("form is prefilled with the recipe", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			categories: map[int64][]string{1: {"breakfast"}},
+			RecipesRegistered: map[int64]models.Recipes{1: {
+				{ID: 1, Name: "Pancakes", Category: "breakfast", Ingredients: []string{"2 eggs", "1 cup milk"}, Instructions: []string{"Mix"}, Yield: 4},
+			}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, uri)
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`value="Pancakes (variant)"`,
+			`<input type="hidden" name="parent-id" value="1"><p class="text-sm text-center py-1">Variant of <span class="font-semibold">Pancakes</span></p>`,
+		})
+	})
+
+	t.Run("recipe of another user", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			RecipesRegistered: map[int64]models.Recipes{1: {
+				{ID: 1, Name: "Pancakes", Category: "breakfast", Ingredients: []string{"2 eggs", "1 cup milk"}, Instructions: []string{"Mix"}, Yield: 4},
+			}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, ts.URL+"/recipes/2/variant")
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+	})
+
+	t.Run("parent of the submitted variant does not exist", func(t *testing.T) {
+		repo := &mockRepository{
+			categories: map[int64][]string{1: {"breakfast"}},
+			RecipesRegistered: map[int64]models.Recipes{1: {
+				{ID: 1, Name: "Pancakes", Category: "breakfast", Ingredients: []string{"2 eggs", "1 cup milk"}, Instructions: []string{"Mix"}, Yield: 4},
+			}},
+		}
+		srv.Repository = repo
+
+		contentType, body := createMultipartForm(map[string][]string{
+			"title":        {"Vegan pancakes"},
+			"ingredients":  {"1 cup oat milk"},
+			"instructions": {"Mix"},
+			"parent-id":    {"7"},
+		})
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, ts.URL+"/recipes/add/manual", header(contentType), strings.NewReader(body))
+
+		assertStatus(t, rr.Code, http.StatusBadRequest)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"The recipe this is a variant of does not exist.","title":"Form Error"}}`)
+		if len(repo.RecipesRegistered[1]) != 1 {
+			t.Fatal("the variant must not have been added")
+		}
+	})
+
+	t.Run("submitted variant is linked to its parent", func(t *testing.T) {
+		repo := &mockRepository{
+			categories: map[int64][]string{1: {"breakfast"}},
+			RecipesRegistered: map[int64]models.Recipes{1: {
+				{ID: 1, Name: "Pancakes", Category: "breakfast", Ingredients: []string{"2 eggs", "1 cup milk"}, Instructions: []string{"Mix"}, Yield: 4},
+			}},
+		}
+		repo.AddRecipesFunc = func(recipes models.Recipes, userID int64, _ chan models.Progress) ([]int64, []models.ReportLog, error) {
+			recipe := recipes[0]
+			recipe.ID = int64(len(repo.RecipesRegistered[userID]) + 1)
+			repo.RecipesRegistered[userID] = append(repo.RecipesRegistered[userID], recipe)
+			return []int64{recipe.ID}, nil, nil
+		}
+		srv.Repository = repo
+
+		contentType, body := createMultipartForm(map[string][]string{
+			"title":        {"Vegan pancakes"},
+			"ingredients":  {"1 cup oat milk"},
+			"instructions": {"Mix"},
+			"parent-id":    {"1"},
+		})
+		rr := sendHxRequestAsLoggedIn(srv, http.MethodPost, ts.URL+"/recipes/add/manual", header(contentType), strings.NewReader(body))
+
+		assertStatus(t, rr.Code, http.StatusCreated)
+		assertHeader(t, rr, "HX-Redirect", "/recipes/2")
+		if got := repo.ParentsRegistered[2]; got != 1 {
+			t.Fatalf("got parent %d but want 1", got)
+		}
+	})
+}
+
+func TestHandlers_RecipeVariants(t *testing.T) {
+	srv, ts, c := createWSServer()
+	defer c.CloseNow()
+
+	t.Run("parent lists its variants", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			ParentsRegistered: map[int64]int64{2: 1, 3: 1},
+			RecipesRegistered: map[int64]models.Recipes{1: {
+				{ID: 1, Name: "Pancakes", Ingredients: []string{"2 eggs", "1 cup milk", "1 cup flour"}, Instructions: []string{"Mix", "Cook"}},
+				{ID: 2, Name: "Vegan pancakes", Ingredients: []string{"1 cup oat milk", "1 cup flour"}, Instructions: []string{"Mix", "Cook"}},
+				{ID: 3, Name: "High-altitude pancakes", Ingredients: []string{"2 eggs", "1 cup milk", "1 cup flour"}, Instructions: []string{"Mix", "Cook"}},
+				{ID: 4, Name: "Waffles", Ingredients: []string{"2 eggs"}, Instructions: []string{"Cook"}},
+			}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, ts.URL+"/recipes/1")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		body := getBodyHTML(rr)
+		assertStringsInHTML(t, body, []string{
+			`<h2 class="card-title text-base">Variants</h2><ul class="list-disc pl-6"><li><a class="link link-primary" href="/recipes/2" hx-get="/recipes/2" hx-push-url="true" hx-target="#content" hx-swap="innerHTML transition:true">Vegan pancakes</a></li><li><a class="link link-primary" href="/recipes/3" hx-get="/recipes/3" hx-push-url="true" hx-target="#content" hx-swap="innerHTML transition:true">High-altitude pancakes</a></li></ul>`,
+			`hx-get="/recipes/1/variant"`,
+		})
+		assertStringsNotInHTML(t, body, []string{"Variant of"})
+	})
+
+	t.Run("variant links to its parent", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			ParentsRegistered: map[int64]int64{2: 1, 3: 1},
+			RecipesRegistered: map[int64]models.Recipes{1: {
+				{ID: 1, Name: "Pancakes", Ingredients: []string{"2 eggs", "1 cup milk", "1 cup flour"}, Instructions: []string{"Mix", "Cook"}},
+				{ID: 2, Name: "Vegan pancakes", Ingredients: []string{"1 cup oat milk", "1 cup flour"}, Instructions: []string{"Mix", "Cook"}},
+				{ID: 3, Name: "High-altitude pancakes", Ingredients: []string{"2 eggs", "1 cup milk", "1 cup flour"}, Instructions: []string{"Mix", "Cook"}},
+				{ID: 4, Name: "Waffles", Ingredients: []string{"2 eggs"}, Instructions: []string{"Cook"}},
+			}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, ts.URL+"/recipes/2")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<p class="flex flex-wrap items-baseline gap-1">Variant of<a class="link link-primary" href="/recipes/1" hx-get="/recipes/1" hx-push-url="true" hx-target="#content" hx-swap="innerHTML transition:true">Pancakes</a><a class="link pl-1 text-xs" hx-get="/recipes/2/compare" hx-push-url="true" hx-target="#content" hx-swap="innerHTML transition:true">Compare with it</a></p>`,
+		})
+	})
+
+	t.Run("recipe without variants", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			ParentsRegistered: map[int64]int64{2: 1, 3: 1},
+			RecipesRegistered: map[int64]models.Recipes{1: {
+				{ID: 1, Name: "Pancakes", Ingredients: []string{"2 eggs", "1 cup milk", "1 cup flour"}, Instructions: []string{"Mix", "Cook"}},
+				{ID: 2, Name: "Vegan pancakes", Ingredients: []string{"1 cup oat milk", "1 cup flour"}, Instructions: []string{"Mix", "Cook"}},
+				{ID: 3, Name: "High-altitude pancakes", Ingredients: []string{"2 eggs", "1 cup milk", "1 cup flour"}, Instructions: []string{"Mix", "Cook"}},
+				{ID: 4, Name: "Waffles", Ingredients: []string{"2 eggs"}, Instructions: []string{"Cook"}},
+			}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, ts.URL+"/recipes/4")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsNotInHTML(t, getBodyHTML(rr), []string{`id="recipe_variants"`})
+	})
+
+	t.Run("compare must be logged in", func(t *testing.T) {
+		assertMustBeLoggedIn(t, srv, http.MethodGet, ts.URL+"/recipes/2/compare")
+	})
+
+	t.Run("compare a variant with its parent", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			ParentsRegistered: map[int64]int64{2: 1, 3: 1},
+			RecipesRegistered: map[int64]models.Recipes{1: {
+				{ID: 1, Name: "Pancakes", Ingredients: []string{"2 eggs", "1 cup milk", "1 cup flour"}, Instructions: []string{"Mix", "Cook"}},
+				{ID: 2, Name: "Vegan pancakes", Ingredients: []string{"1 cup oat milk", "1 cup flour"}, Instructions: []string{"Mix", "Cook"}},
+				{ID: 3, Name: "High-altitude pancakes", Ingredients: []string{"2 eggs", "1 cup milk", "1 cup flour"}, Instructions: []string{"Mix", "Cook"}},
+				{ID: 4, Name: "Waffles", Ingredients: []string{"2 eggs"}, Instructions: []string{"Cook"}},
+			}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, ts.URL+"/recipes/2/compare")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		body := getBodyHTML(rr)
+		assertStringsInHTML(t, body, []string{
+			`<title hx-swap-oob="true">Vegan pancakes compared with Pancakes | Recipya</title>`,
+			`<p class="font-semibold">Ingredients</p><ul class="font-mono text-xs"><li class="bg-error/20 px-1 line-through">- 2 eggs</li><li class="bg-error/20 px-1 line-through">- 1 cup milk</li><li class="bg-success/20 px-1">+ 1 cup oat milk</li><li class="px-1">&nbsp; 1 cup flour</li></ul>`,
+		})
+		assertStringsNotInHTML(t, body, []string{`<p class="font-semibold">Instructions</p>`})
+	})
+
+	t.Run("compare an identical variant", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			ParentsRegistered: map[int64]int64{2: 1, 3: 1},
+			RecipesRegistered: map[int64]models.Recipes{1: {
+				{ID: 1, Name: "Pancakes", Ingredients: []string{"2 eggs", "1 cup milk", "1 cup flour"}, Instructions: []string{"Mix", "Cook"}},
+				{ID: 2, Name: "Vegan pancakes", Ingredients: []string{"1 cup oat milk", "1 cup flour"}, Instructions: []string{"Mix", "Cook"}},
+				{ID: 3, Name: "High-altitude pancakes", Ingredients: []string{"2 eggs", "1 cup milk", "1 cup flour"}, Instructions: []string{"Mix", "Cook"}},
+				{ID: 4, Name: "Waffles", Ingredients: []string{"2 eggs"}, Instructions: []string{"Cook"}},
+			}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, ts.URL+"/recipes/3/compare")
+
+		assertStatus(t, rr.Code, http.StatusOK)
+		assertStringsInHTML(t, getBodyHTML(rr), []string{
+			`<p class="text-gray-500">The ingredients and the instructions are the same as those of the parent recipe.</p>`,
+		})
+	})
+
+	t.Run("compare a recipe that is not a variant", func(t *testing.T) {
+		srv.Repository = &mockRepository{
+			ParentsRegistered: map[int64]int64{2: 1, 3: 1},
+			RecipesRegistered: map[int64]models.Recipes{1: {
+				{ID: 1, Name: "Pancakes", Ingredients: []string{"2 eggs", "1 cup milk", "1 cup flour"}, Instructions: []string{"Mix", "Cook"}},
+				{ID: 2, Name: "Vegan pancakes", Ingredients: []string{"1 cup oat milk", "1 cup flour"}, Instructions: []string{"Mix", "Cook"}},
+				{ID: 3, Name: "High-altitude pancakes", Ingredients: []string{"2 eggs", "1 cup milk", "1 cup flour"}, Instructions: []string{"Mix", "Cook"}},
+				{ID: 4, Name: "Waffles", Ingredients: []string{"2 eggs"}, Instructions: []string{"Cook"}},
+			}},
+		}
+
+		rr := sendHxRequestAsLoggedInNoBody(srv, http.MethodGet, ts.URL+"/recipes/4/compare")
+
+		assertStatus(t, rr.Code, http.StatusNotFound)
+		assertWebsocket(t, c, 1, `{"type":"toast","fileName":"","data":"","toast":{"action":"","background":"alert-error","message":"This recipe is not a variant of another recipe.","title":"General Error"}}`)
+	})
+}
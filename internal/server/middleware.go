@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/reaper47/recipya/internal/app"
+	"github.com/reaper47/recipya/internal/auth"
+	"github.com/reaper47/recipya/internal/models"
 	"io"
 	"log/slog"
 	"maps"
@@ -169,6 +171,11 @@ func redirectIfNoSignupsMiddleware(next http.Handler) http.Handler {
 
 func (s *Server) mustBeLoggedInMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok && isAPIRequest(r) {
+			s.serveWithAPIToken(next, w, r, bearer)
+			return
+		}
+
 		if app.Config.Server.IsAutologin {
 			ctx := context.WithValue(r.Context(), UserIDKey, int64(1))
 
@@ -208,7 +215,9 @@ func (s *Server) mustBeLoggedInMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
-		if r.Header.Get("HX-Request") == "true" {
+		if isAPIRequest(r) {
+			writeJSONError(w, http.StatusUnauthorized, "Authentication required.")
+		} else if r.Header.Get("HX-Request") == "true" {
 			w.Header().Set("HX-Redirect", "/auth/login")
 			w.WriteHeader(http.StatusSeeOther)
 		} else {
@@ -216,3 +225,33 @@ func (s *Server) mustBeLoggedInMiddleware(next http.Handler) http.Handler {
 		}
 	})
 }
+
+// serveWithAPIToken authenticates an API request using a personal access token.
+// Safe methods require the read scope, whereas all others require the write scope.
+func (s *Server) serveWithAPIToken(next http.Handler, w http.ResponseWriter, r *http.Request, token string) {
+	selector, validator, err := auth.ParseAPIToken(strings.TrimSpace(token))
+	if err != nil {
+		writeJSONError(w, http.StatusUnauthorized, "Invalid API token.")
+		return
+	}
+
+	apiToken, err := s.Repository.GetAPIToken(selector, validator)
+	if err != nil {
+		writeJSONError(w, http.StatusUnauthorized, "Invalid API token.")
+		return
+	}
+
+	scope := models.APIScopeWrite
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		scope = models.APIScopeRead
+	}
+
+	if !apiToken.HasScope(scope) {
+		writeJSONError(w, http.StatusForbidden, "The API token lacks the '"+string(scope)+"' scope.")
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), UserIDKey, apiToken.UserID)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
@@ -49,6 +49,7 @@ func NewServer(repo services.RepositoryService) *Server {
 			Jar:     jar,
 			Timeout: 30 * time.Second,
 		}),
+		Webhooks: services.NewWebhooksService(nil),
 	}
 	srv.mountHandlers()
 
@@ -73,6 +74,7 @@ type Server struct {
 	Repository   services.RepositoryService
 	Router       *http.ServeMux
 	Scraper      scraper.IScraper
+	Webhooks     services.WebhooksService
 }
 
 func (s *Server) mountHandlers() {
@@ -96,19 +98,44 @@ func (s *Server) mountHandlers() {
 	mux.Handle("GET /update/check", s.mustBeLoggedInMiddleware(s.updateCheckHandler()))
 	mux.Handle("GET /ws", s.mustBeLoggedInMiddleware(s.wsHandler()))
 
+	withLog := func(next http.Handler) http.Handler {
+		return s.mustBeLoggedInMiddleware(s.loggingMiddleware(next))
+	}
+
 	// Admin routes
 	adminMiddleware := func(next http.Handler) http.Handler { return s.mustBeLoggedInMiddleware(s.onlyAdminMiddleware(next)) }
 	mux.Handle("GET /admin", adminMiddleware(s.adminHandler()))
 	mux.Handle("POST /admin/users", adminMiddleware(s.adminUsersPostHandler()))
 	mux.Handle("DELETE /admin/users/{email}", adminMiddleware(s.adminUsersDeleteHandler()))
 
+	// API routes
+	mux.Handle("GET /api/v1/categories", s.mustBeLoggedInMiddleware(s.apiCategoriesHandler()))
+	mux.Handle("POST /api/v1/categories", withLog(s.apiCategoriesPostHandler()))
+	mux.Handle("DELETE /api/v1/categories/{name}", withLog(s.apiCategoriesDeleteHandler()))
+	mux.Handle("GET /api/v1/cookbooks", s.mustBeLoggedInMiddleware(s.apiCookbooksHandler()))
+	mux.Handle("POST /api/v1/cookbooks", withLog(s.apiCookbooksPostHandler()))
+	mux.Handle("GET /api/v1/cookbooks/{id}", s.mustBeLoggedInMiddleware(s.apiCookbookHandler()))
+	mux.Handle("DELETE /api/v1/cookbooks/{id}", withLog(s.apiCookbookDeleteHandler()))
+	mux.Handle("POST /api/v1/cookbooks/{id}/recipes", withLog(s.apiCookbookRecipesPostHandler()))
+	mux.Handle("DELETE /api/v1/cookbooks/{id}/recipes/{recipeID}", withLog(s.apiCookbookRecipeDeleteHandler()))
+	mux.Handle("POST /api/v1/cookbooks/{id}/share", withLog(s.apiCookbookSharePostHandler()))
+	mux.Handle("GET /api/v1/recipes", s.mustBeLoggedInMiddleware(s.apiRecipesHandler()))
+	mux.Handle("POST /api/v1/recipes", withLog(s.apiRecipesPostHandler()))
+	mux.Handle("GET /api/v1/recipes/{id}", s.mustBeLoggedInMiddleware(s.apiRecipeHandler()))
+	mux.Handle("PUT /api/v1/recipes/{id}", withLog(s.apiRecipePutHandler()))
+	mux.Handle("DELETE /api/v1/recipes/{id}", withLog(s.apiRecipeDeleteHandler()))
+	mux.Handle("GET /api/v1/recipes/{id}/scale", s.mustBeLoggedInMiddleware(s.apiRecipeScaleHandler()))
+	mux.Handle("GET /api/v1/recipes/{id}/timers", s.mustBeLoggedInMiddleware(s.apiRecipeTimersHandler()))
+	mux.Handle("POST /api/v1/recipes/{id}/share", withLog(s.apiRecipeSharePostHandler()))
+	mux.Handle("GET /api/v1/recipes/search", s.mustBeLoggedInMiddleware(s.apiRecipesSearchHandler()))
+	mux.HandleFunc("/api/", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSONError(w, http.StatusNotFound, "The requested resource does not exist.")
+	})
+
 	// Auth routes
 	withAuthRegister := func(next http.Handler) http.Handler {
 		return s.redirectIfLoggedInMiddleware(redirectIfNoSignupsMiddleware(next))
 	}
-	withLog := func(next http.Handler) http.Handler {
-		return s.mustBeLoggedInMiddleware(s.loggingMiddleware(next))
-	}
 	mux.Handle("POST /auth/change-password", s.mustBeLoggedInMiddleware(s.changePasswordHandler()))
 	mux.HandleFunc("GET /auth/confirm", s.confirmHandler)
 	mux.HandleFunc("GET /auth/forgot-password", s.forgotPasswordHandler)
@@ -139,6 +166,30 @@ func (s *Server) mountHandlers() {
 	mux.Handle("POST /integrations/import", withLog(s.integrationsImport()))
 	mux.Handle("GET /integrations/test-connection", withLog(s.integrationTestConnectionHandler()))
 
+	// Meal plan routes
+	mux.Handle("GET /plan", s.mustBeLoggedInMiddleware(s.planHandler()))
+	mux.Handle("POST /plan/entries", withLog(s.planEntriesPostHandler()))
+	mux.Handle("PUT /plan/entries/{id}", withLog(s.planEntryPutHandler()))
+	mux.Handle("DELETE /plan/entries/{id}", withLog(s.planEntryDeleteHandler()))
+	mux.Handle("GET /plan/recipes/search", s.mustBeLoggedInMiddleware(s.planRecipesSearchHandler()))
+
+	// Pantry routes
+	mux.Handle("GET /pantry", s.mustBeLoggedInMiddleware(s.pantryHandler()))
+	mux.Handle("POST /pantry", withLog(s.pantryPostHandler()))
+	mux.Handle("DELETE /pantry/{id}", withLog(s.pantryDeleteHandler()))
+
+	// Shopping lists routes
+	mux.Handle("GET /shopping-lists", s.mustBeLoggedInMiddleware(s.shoppingListsHandler()))
+	mux.Handle("POST /shopping-lists", withLog(s.shoppingListsPostHandler()))
+	mux.Handle("GET /shopping-lists/{id}", s.mustBeLoggedInMiddleware(s.shoppingListHandler()))
+	mux.Handle("DELETE /shopping-lists/{id}", withLog(s.shoppingListDeleteHandler()))
+	mux.Handle("PUT /shopping-lists/{id}/items/{itemID}", withLog(s.shoppingListItemPutHandler()))
+	mux.Handle("POST /shopping-lists/{id}/recipes", withLog(s.shoppingListRecipesPostHandler()))
+
+	// Timeline routes
+	mux.Handle("GET /timeline", s.mustBeLoggedInMiddleware(s.timelineHandler()))
+	mux.Handle("GET /timeline/pdf", s.mustBeLoggedInMiddleware(s.timelinePDFHandler()))
+
 	// Recipes routes
 	mux.Handle("GET /recipes", s.mustBeLoggedInMiddleware(s.recipesHandler()))
 	mux.Handle("GET /recipes/{id}", s.mustBeLoggedInMiddleware(s.recipesViewHandler()))
@@ -147,8 +198,22 @@ func (s *Server) mountHandlers() {
 	mux.Handle("POST /recipes/{id}/share", withLog(s.recipeSharePostHandler()))
 	mux.Handle("GET /recipes/{id}/share/add", withLog(s.recipeShareAddHandler()))
 	mux.Handle("GET /recipes/{id}/duplicate", withLog(s.recipeDuplicateHandler()))
+	mux.Handle("GET /recipes/{id}/variant", withLog(s.recipeVariantHandler()))
+	mux.Handle("GET /recipes/{id}/compare", s.mustBeLoggedInMiddleware(s.recipeCompareHandler()))
 	mux.Handle("GET /recipes/{id}/edit", s.mustBeLoggedInMiddleware(s.recipesEditHandler()))
 	mux.Handle("PUT /recipes/{id}/edit", withLog(s.recipesEditPutHandler()))
+	mux.Handle("GET /recipes/{id}/nutrition", s.mustBeLoggedInMiddleware(s.recipeNutritionHandler()))
+	mux.Handle("GET /recipes/{id}/nutrition/foods", s.mustBeLoggedInMiddleware(s.recipeNutritionFoodsHandler()))
+	mux.Handle("PUT /recipes/{id}/nutrition/pins", withLog(s.recipeNutritionPinPutHandler()))
+	mux.Handle("GET /recipes/{id}/cook", s.mustBeLoggedInMiddleware(s.recipeCookModeHandler()))
+	mux.Handle("POST /recipes/{id}/cook/timers", withLog(s.recipeCookModeTimerPostHandler()))
+	mux.Handle("GET /recipes/{id}/cooks", s.mustBeLoggedInMiddleware(s.recipeCookLogHandler()))
+	mux.Handle("POST /recipes/{id}/cooks", withLog(s.recipeCookLogPostHandler()))
+	mux.Handle("DELETE /recipes/{id}/cooks/{entryID}", withLog(s.recipeCookLogDeleteHandler()))
+	mux.Handle("POST /recipes/{id}/notes", withLog(s.recipeNotesPostHandler()))
+	mux.Handle("DELETE /recipes/{id}/notes/{noteID}", withLog(s.recipeNoteDeleteHandler()))
+	mux.Handle("GET /recipes/{id}/history", s.mustBeLoggedInMiddleware(s.recipeHistoryHandler()))
+	mux.Handle("POST /recipes/{id}/revisions/{revisionID}/revert", withLog(s.recipeRevisionRevertHandler()))
 	mux.Handle("GET /recipes/add", s.mustBeLoggedInMiddleware(recipesAddHandler()))
 	mux.Handle("POST /recipes/add/import", withLog(s.recipesAddImportHandler()))
 	mux.Handle("GET /recipes/add/manual", s.mustBeLoggedInMiddleware(s.recipeAddManualHandler()))
@@ -167,12 +232,16 @@ func (s *Server) mountHandlers() {
 
 	// Settings routes
 	mux.Handle("GET /settings", s.mustBeLoggedInMiddleware(s.settingsHandler()))
+	mux.Handle("POST /settings/api-tokens", s.mustBeLoggedInMiddleware(s.settingsAPITokensPostHandler()))
+	mux.Handle("DELETE /settings/api-tokens/{id}", withLog(s.settingsAPITokensDeleteHandler()))
 	mux.Handle("GET /settings/export/recipes", s.mustBeLoggedInMiddleware(s.settingsExportRecipesHandler()))
 	mux.Handle("POST /settings/calculate-nutrition", withLog(s.settingsCalculateNutritionPostHandler()))
 	mux.Handle("PUT /settings/config", withLog(s.onlyAdminMiddleware(s.settingsConfigPutHandler())))
 	mux.Handle("POST /settings/convert-automatically", withLog(s.settingsConvertAutomaticallyPostHandler()))
 	mux.Handle("POST /settings/measurement-system", withLog(s.settingsMeasurementSystemsPostHandler()))
 	mux.Handle("POST /settings/backups/restore", withLog(s.settingsBackupsRestoreHandler()))
+	mux.Handle("POST /settings/webhooks", s.mustBeLoggedInMiddleware(s.settingsWebhooksPostHandler()))
+	mux.Handle("DELETE /settings/webhooks/{id}", withLog(s.settingsWebhooksDeleteHandler()))
 
 	// Share routes
 	mux.HandleFunc("GET /r/{id}", s.recipeShareHandler)
@@ -240,7 +309,7 @@ func (s *Server) Run() {
 		}
 	}
 
-	jobs.ScheduleCronJobs(s.Repository, s.Files, s.Email)
+	jobs.ScheduleCronJobs(s.Repository, s.Files, s.Email, s.emitBackupsCompleted)
 
 	fmt.Println("Serving HTTP server at address", app.Config.Address())
 	err := httpServer.ListenAndServe()
@@ -24,6 +24,7 @@ type header string
 const (
 	formData     header = "multipart/form-data"
 	formHeader   header = "application/x-www-form-urlencoded"
+	jsonHeader   header = "application/json"
 	noHeader     header = ""
 	promptHeader header = "prompt"
 )
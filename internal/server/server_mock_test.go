@@ -7,6 +7,8 @@ import (
 	"io"
 	"log/slog"
 	"mime/multipart"
+	"net"
+	"net/url"
 	"os"
 	"slices"
 	"strings"
@@ -46,30 +48,47 @@ func newServerTest() *server.Server {
 	srv.Files = &mockFiles{}
 	srv.Integrations = &mockIntegrations{}
 	srv.Scraper = &mockScraper{}
+	srv.Webhooks = &mockWebhooks{}
 
 	_ = os.Remove("sessions.csv")
 	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
 	return srv
 }
 
+type mockAPIToken struct {
+	token         models.APIToken
+	selector      string
+	hashValidator string
+}
+
 type mockRepository struct {
+	APITokensRegistered                []mockAPIToken
 	AuthTokens                         []models.AuthToken
 	AddRecipeCategoryFunc              func(name string, userID int64) error
 	AddRecipesFunc                     func(recipes models.Recipes, userID int64, progress chan models.Progress) ([]int64, []models.ReportLog, error)
 	AddShareRecipeFunc                 func(recipeID, userID int64) (int64, error)
 	categories                         map[int64][]string
 	CookbooksFunc                      func(userID int64) ([]models.Cookbook, error)
+	CookLogRegistered                  map[int64]models.CookLog
 	CookbooksRegistered                map[int64][]models.Cookbook
 	DeleteCategoryFunc                 func(name string, userID int64) error
 	DeleteCookbookFunc                 func(id, userID int64) error
+	FoodsFDC                           []models.FoodFDC
+	IngredientsNutritionRegistered     map[int64]models.IngredientsNutrition
 	IsUserPasswordFunc                 func(userID int64, password string) bool
+	MealPlanRegistered                 map[int64][]models.MealPlanEntry
 	MeasurementSystemsFunc             func(userID int64) ([]units.System, models.UserSettings, error)
+	NotesRegistered                    map[int64]models.RecipeNotes
+	PantryRegistered                   map[int64][]models.PantryItem
+	ParentsRegistered                  map[int64]int64
 	RecipeFunc                         func(id, userID int64) (*models.Recipe, error)
 	RecipesRegistered                  map[int64]models.Recipes
 	Reports                            map[int64][]models.Report
 	ReportsFunc                        func(userID int64) ([]models.Report, error)
 	RestoreUserBackupFunc              func(backup *models.UserBackup) error
+	RevisionsRegistered                map[int64][]models.RecipeRevision
 	ShareLinks                         map[string]models.Share
+	ShoppingListsRegistered            map[int64][]models.ShoppingList
 	SwitchMeasurementSystemFunc        func(system units.System, userID int64) error
 	UpdateCookbookImageFunc            func(id int64, image uuid.UUID, userID int64) error
 	UpdateConvertMeasurementSystemFunc func(userID int64, isEnabled bool) error
@@ -77,6 +96,26 @@ type mockRepository struct {
 	UserSettingsRegistered             map[int64]*models.UserSettings
 	UsersRegistered                    []models.User
 	UsersUpdated                       []int64
+	WebhooksRegistered                 []models.Webhook
+}
+
+func (m *mockRepository) AddRecipeNote(note models.RecipeNote, userID int64) (int64, error) {
+	_, err := m.Recipe(note.RecipeID, userID)
+	if err != nil {
+		return 0, sql.ErrNoRows
+	}
+
+	if m.NotesRegistered == nil {
+		m.NotesRegistered = make(map[int64]models.RecipeNotes)
+	}
+
+	var id int64
+	for _, notes := range m.NotesRegistered {
+		id += int64(len(notes))
+	}
+	note.ID = id + 1
+	m.NotesRegistered[note.RecipeID] = append(m.NotesRegistered[note.RecipeID], note)
+	return note.ID, nil
 }
 
 func (m *mockRepository) AddRecipes(xr models.Recipes, userID int64, progress chan models.Progress) ([]int64, []models.ReportLog, error) {
@@ -186,6 +225,85 @@ func (m *mockRepository) AddShareRecipe(recipeID, userID int64) (int64, error) {
 	return 2, nil
 }
 
+func (m *mockRepository) AddShoppingList(list models.ShoppingList, userID int64) (int64, error) {
+	if m.ShoppingListsRegistered == nil {
+		m.ShoppingListsRegistered = make(map[int64][]models.ShoppingList)
+	}
+
+	var id int64
+	for _, lists := range m.ShoppingListsRegistered {
+		id += int64(len(lists))
+	}
+	list.ID = id + 1
+	list.CreatedAt = time.Now()
+	for i := range list.Items {
+		list.Items[i].ID = int64(i + 1)
+	}
+	m.ShoppingListsRegistered[userID] = append(m.ShoppingListsRegistered[userID], list)
+	return list.ID, nil
+}
+
+func (m *mockRepository) AddWebhook(webhook models.Webhook) (models.Webhook, error) {
+	webhook.ID = int64(len(m.WebhooksRegistered) + 1)
+	webhook.CreatedAt = time.Now()
+	m.WebhooksRegistered = append(m.WebhooksRegistered, webhook)
+	return webhook, nil
+}
+
+func (m *mockRepository) AddMealPlanEntry(entry models.MealPlanEntry, userID int64) (int64, error) {
+	recipe, err := m.Recipe(entry.RecipeID, userID)
+	if err != nil {
+		return 0, sql.ErrNoRows
+	}
+
+	if m.MealPlanRegistered == nil {
+		m.MealPlanRegistered = make(map[int64][]models.MealPlanEntry)
+	}
+
+	var id int64
+	for _, entries := range m.MealPlanRegistered {
+		id += int64(len(entries))
+	}
+	entry.ID = id + 1
+	entry.RecipeName = recipe.Name
+	entry.RecipeYield = recipe.Servings()
+	m.MealPlanRegistered[userID] = append(m.MealPlanRegistered[userID], entry)
+	return entry.ID, nil
+}
+
+func (m *mockRepository) AddCookLogEntry(entry models.CookLogEntry, userID int64) (int64, error) {
+	_, err := m.Recipe(entry.RecipeID, userID)
+	if err != nil {
+		return 0, sql.ErrNoRows
+	}
+
+	if m.CookLogRegistered == nil {
+		m.CookLogRegistered = make(map[int64]models.CookLog)
+	}
+
+	var id int64
+	for _, cooks := range m.CookLogRegistered {
+		id += int64(len(cooks))
+	}
+	entry.ID = id + 1
+	m.CookLogRegistered[entry.RecipeID] = append(models.CookLog{entry}, m.CookLogRegistered[entry.RecipeID]...)
+	return entry.ID, nil
+}
+
+func (m *mockRepository) AddPantryItem(item models.PantryItem, userID int64) (int64, error) {
+	if m.PantryRegistered == nil {
+		m.PantryRegistered = make(map[int64][]models.PantryItem)
+	}
+
+	var id int64
+	for _, items := range m.PantryRegistered {
+		id += int64(len(items))
+	}
+	item.ID = id + 1
+	m.PantryRegistered[userID] = append(m.PantryRegistered[userID], item)
+	return item.ID, nil
+}
+
 func (m *mockRepository) AddRecipeCategory(name string, userID int64) error {
 	if m.AddRecipeCategoryFunc != nil {
 		return m.AddRecipeCategoryFunc(name, userID)
@@ -197,7 +315,7 @@ func (m *mockRepository) AddRecipeCategory(name string, userID int64) error {
 	}
 
 	if slices.Contains(categories, name) {
-		return errors.New("category in use")
+		return services.ErrCategoryExists
 	}
 
 	m.categories[userID] = append(m.categories[userID], name)
@@ -221,6 +339,23 @@ func (m *mockRepository) CookbooksUser(_ int64) ([]models.Cookbook, error) {
 	return make([]models.Cookbook, 0), nil
 }
 
+func (m *mockRepository) AddAPIToken(token models.APIToken, selector, hashValidator string) (models.APIToken, error) {
+	token.ID = int64(len(m.APITokensRegistered) + 1)
+	token.CreatedAt = time.Now()
+	m.APITokensRegistered = append(m.APITokensRegistered, mockAPIToken{token: token, selector: selector, hashValidator: hashValidator})
+	return token, nil
+}
+
+func (m *mockRepository) APITokens(userID int64) ([]models.APIToken, error) {
+	tokens := make([]models.APIToken, 0)
+	for _, t := range m.APITokensRegistered {
+		if t.token.UserID == userID {
+			tokens = append(tokens, t.token)
+		}
+	}
+	return tokens, nil
+}
+
 func (m *mockRepository) AddAuthToken(selector, validator string, userID int64) error {
 	token := models.NewAuthToken(int64(len(m.AuthTokens)+1), selector, validator, 10000, userID)
 	m.AuthTokens = append(m.AuthTokens, *token)
@@ -243,7 +378,7 @@ func (m *mockRepository) AddCookbook(title string, userID int64) (int64, error)
 		return cookbook.Title == title
 	})
 	if isExists {
-		return -1, errors.New("cookbook exists")
+		return -1, services.ErrCookbookExists
 	}
 
 	cookbook.ID = int64(len(cookbooks) + 1)
@@ -254,7 +389,7 @@ func (m *mockRepository) AddCookbook(title string, userID int64) (int64, error)
 func (m *mockRepository) AddCookbookRecipe(cookbookID, recipeID, userID int64) error {
 	cookbooks, ok := m.CookbooksRegistered[userID]
 	if !ok {
-		return errors.New("cookbook does not belong to user")
+		return sql.ErrNoRows
 	}
 
 	if cookbooks == nil {
@@ -265,7 +400,7 @@ func (m *mockRepository) AddCookbookRecipe(cookbookID, recipeID, userID int64) e
 		return c.ID == cookbookID
 	})
 	if cookbookIndex == -1 {
-		return errors.New("cookbook not found")
+		return sql.ErrNoRows
 	}
 
 	recipes := m.RecipesRegistered[userID]
@@ -277,7 +412,7 @@ func (m *mockRepository) AddCookbookRecipe(cookbookID, recipeID, userID int64) e
 		return r.ID == recipeID
 	})
 	if recipeIndex == -1 {
-		return errors.New("recipe not found")
+		return sql.ErrNoRows
 	}
 
 	cookbooks[cookbookIndex].Recipes = append(cookbooks[cookbookIndex].Recipes, recipes[recipeID])
@@ -315,14 +450,14 @@ func (m *mockRepository) Confirm(userID int64) error {
 func (m *mockRepository) Cookbook(id, userID int64) (models.Cookbook, error) {
 	cookbooks, ok := m.CookbooksRegistered[userID]
 	if !ok {
-		return models.Cookbook{}, errors.New("user does not have cookbooks")
+		return models.Cookbook{}, sql.ErrNoRows
 	}
 
 	i := slices.IndexFunc(cookbooks, func(c models.Cookbook) bool {
 		return c.ID == id
 	})
 	if i == -1 {
-		return models.Cookbook{}, errors.New("cookbook not found")
+		return models.Cookbook{}, sql.ErrNoRows
 	}
 
 	return cookbooks[i], nil
@@ -374,6 +509,22 @@ func (m *mockRepository) Cookbooks(userID int64, _ uint64) ([]models.Cookbook, e
 	return cookbooks, nil
 }
 
+func (m *mockRepository) CookLog(recipeID, userID int64) (models.CookLog, error) {
+	_, err := m.Recipe(recipeID, userID)
+	if err != nil {
+		return make(models.CookLog, 0), nil
+	}
+	return m.CookLogRegistered[recipeID], nil
+}
+
+func (m *mockRepository) CookLogAll(userID int64) (models.CookLog, error) {
+	cooks := make(models.CookLog, 0)
+	for _, r := range m.RecipesRegistered[userID] {
+		cooks = append(cooks, m.CookLogRegistered[r.ID]...)
+	}
+	return cooks, nil
+}
+
 func (m *mockRepository) Counts(userID int64) (models.Counts, error) {
 	var counts models.Counts
 	recipes, ok := m.RecipesRegistered[userID]
@@ -389,6 +540,15 @@ func (m *mockRepository) Counts(userID int64) (models.Counts, error) {
 	return counts, nil
 }
 
+func (m *mockRepository) DeleteAPIToken(id, userID int64) error {
+	index := slices.IndexFunc(m.APITokensRegistered, func(t mockAPIToken) bool { return t.token.ID == id && t.token.UserID == userID })
+	if index == -1 {
+		return sql.ErrNoRows
+	}
+	m.APITokensRegistered = slices.Delete(m.APITokensRegistered, index, index+1)
+	return nil
+}
+
 func (m *mockRepository) DeleteAuthToken(userID int64) error {
 	index := slices.IndexFunc(m.AuthTokens, func(token models.AuthToken) bool { return token.UserID == userID })
 	if index != -1 {
@@ -403,7 +563,7 @@ func (m *mockRepository) DeleteRecipeCategory(name string, userID int64) error {
 	}
 
 	if name == "uncategorized" {
-		return errors.New("cannot delete 'uncategorized'")
+		return services.ErrCategoryInvalid
 	}
 
 	recipes, ok := m.RecipesRegistered[userID]
@@ -433,6 +593,41 @@ func (m *mockRepository) DeleteCookbook(id, userID int64) error {
 	return nil
 }
 
+func (m *mockRepository) DeleteCookLogEntry(id, recipeID, userID int64) error {
+	_, err := m.Recipe(recipeID, userID)
+	if err != nil {
+		return sql.ErrNoRows
+	}
+
+	cooks := m.CookLogRegistered[recipeID]
+	index := slices.IndexFunc(cooks, func(e models.CookLogEntry) bool { return e.ID == id })
+	if index == -1 {
+		return sql.ErrNoRows
+	}
+	m.CookLogRegistered[recipeID] = slices.Delete(cooks, index, index+1)
+	return nil
+}
+
+func (m *mockRepository) DeleteMealPlanEntry(id, userID int64) error {
+	entries := m.MealPlanRegistered[userID]
+	index := slices.IndexFunc(entries, func(e models.MealPlanEntry) bool { return e.ID == id })
+	if index == -1 {
+		return sql.ErrNoRows
+	}
+	m.MealPlanRegistered[userID] = slices.Delete(entries, index, index+1)
+	return nil
+}
+
+func (m *mockRepository) DeletePantryItem(id, userID int64) error {
+	items := m.PantryRegistered[userID]
+	index := slices.IndexFunc(items, func(item models.PantryItem) bool { return item.ID == id })
+	if index == -1 {
+		return sql.ErrNoRows
+	}
+	m.PantryRegistered[userID] = slices.Delete(items, index, index+1)
+	return nil
+}
+
 func (m *mockRepository) DeleteRecipe(id, userID int64) error {
 	recipes, ok := m.RecipesRegistered[userID]
 	if !ok {
@@ -443,13 +638,28 @@ func (m *mockRepository) DeleteRecipe(id, userID int64) error {
 		return r.ID == id
 	})
 	if i == -1 {
-		return nil
+		return sql.ErrNoRows
 	}
 
 	m.RecipesRegistered[userID] = slices.Delete(recipes, i, i+1)
 	return nil
 }
 
+func (m *mockRepository) DeleteRecipeNote(id, recipeID, userID int64) error {
+	_, err := m.Recipe(recipeID, userID)
+	if err != nil {
+		return sql.ErrNoRows
+	}
+
+	notes := m.NotesRegistered[recipeID]
+	index := slices.IndexFunc(notes, func(n models.RecipeNote) bool { return n.ID == id })
+	if index == -1 {
+		return sql.ErrNoRows
+	}
+	m.NotesRegistered[recipeID] = slices.Delete(notes, index, index+1)
+	return nil
+}
+
 func (m *mockRepository) DeleteRecipeFromCookbook(recipeID, cookbookID int64, userID int64) (int64, error) {
 	cookbooks, ok := m.CookbooksRegistered[userID]
 	if !ok {
@@ -476,6 +686,16 @@ func (m *mockRepository) DeleteRecipeFromCookbook(recipeID, cookbookID int64, us
 	return int64(len(cookbook.Recipes)), nil
 }
 
+func (m *mockRepository) DeleteShoppingList(id, userID int64) error {
+	lists := m.ShoppingListsRegistered[userID]
+	index := slices.IndexFunc(lists, func(l models.ShoppingList) bool { return l.ID == id })
+	if index == -1 {
+		return sql.ErrNoRows
+	}
+	m.ShoppingListsRegistered[userID] = slices.Delete(lists, index, index+1)
+	return nil
+}
+
 func (m *mockRepository) DeleteUser(id int64) error {
 	m.UsersRegistered = slices.DeleteFunc(m.UsersRegistered, func(user models.User) bool {
 		return user.ID == id
@@ -483,6 +703,24 @@ func (m *mockRepository) DeleteUser(id int64) error {
 	return nil
 }
 
+func (m *mockRepository) DeleteWebhook(id, userID int64) error {
+	index := slices.IndexFunc(m.WebhooksRegistered, func(w models.Webhook) bool { return w.ID == id && w.UserID == userID })
+	if index == -1 {
+		return sql.ErrNoRows
+	}
+	m.WebhooksRegistered = slices.Delete(m.WebhooksRegistered, index, index+1)
+	return nil
+}
+
+func (m *mockRepository) GetAPIToken(selector, validator string) (models.APIToken, error) {
+	for _, t := range m.APITokensRegistered {
+		if t.selector == selector && t.hashValidator == auth.HashAPITokenValidator(validator) && !t.token.IsExpired() {
+			return t.token, nil
+		}
+	}
+	return models.APIToken{}, sql.ErrNoRows
+}
+
 func (m *mockRepository) GetAuthToken(_, _ string) (models.AuthToken, error) {
 	return models.AuthToken{UserID: 1, Expires: time.Now().Add(1 * time.Hour)}, nil
 }
@@ -491,6 +729,13 @@ func (m *mockRepository) Media() (images, videos []string) {
 	return make([]string, 0), make([]string, 0)
 }
 
+func (m *mockRepository) IngredientsNutrition(recipeID, userID int64) (models.IngredientsNutrition, error) {
+	if !slices.ContainsFunc(m.RecipesRegistered[userID], func(r models.Recipe) bool { return r.ID == recipeID }) {
+		return nil, sql.ErrNoRows
+	}
+	return m.IngredientsNutritionRegistered[recipeID], nil
+}
+
 func (m *mockRepository) InitAutologin() error {
 	return nil
 }
@@ -512,6 +757,16 @@ func (m *mockRepository) Keywords() ([]string, error) {
 	return []string{"big"}, nil
 }
 
+func (m *mockRepository) MealPlan(userID int64, from, to time.Time) ([]models.MealPlanEntry, error) {
+	entries := make([]models.MealPlanEntry, 0)
+	for _, e := range m.MealPlanRegistered[userID] {
+		if !e.Date.Before(from) && !e.Date.After(to) {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
 func (m *mockRepository) MeasurementSystems(userID int64) ([]units.System, models.UserSettings, error) {
 	if m.MeasurementSystemsFunc != nil {
 		return m.MeasurementSystemsFunc(userID)
@@ -522,8 +777,12 @@ func (m *mockRepository) MeasurementSystems(userID int64) ([]units.System, model
 	}, nil
 }
 
-func (m *mockRepository) Nutrients(_ []string) (models.NutrientsFDC, float64, error) {
-	return models.NutrientsFDC{}, 0, nil
+func (m *mockRepository) Nutrients(_ []models.Ingredient, _ models.NutritionPins) (models.IngredientsNutrition, error) {
+	return models.IngredientsNutrition{}, nil
+}
+
+func (m *mockRepository) PantryItems(userID int64) ([]models.PantryItem, error) {
+	return m.PantryRegistered[userID], nil
 }
 
 func (m *mockRepository) Recipe(id, userID int64) (*models.Recipe, error) {
@@ -533,11 +792,41 @@ func (m *mockRepository) Recipe(id, userID int64) (*models.Recipe, error) {
 
 	if recipes, ok := m.RecipesRegistered[userID]; ok {
 		if int64(len(recipes)) < id {
-			return nil, errors.New("recipe not found")
+			return nil, sql.ErrNoRows
 		}
 		return &recipes[id-1], nil
 	}
-	return nil, errors.New("recipe not found")
+	return nil, sql.ErrNoRows
+}
+
+func (m *mockRepository) RecipeNotes(recipeID, userID int64) (models.RecipeNotes, error) {
+	_, err := m.Recipe(recipeID, userID)
+	if err != nil {
+		return make(models.RecipeNotes, 0), nil
+	}
+	return m.NotesRegistered[recipeID], nil
+}
+
+func (m *mockRepository) RecipeRevisions(recipeID, userID int64) ([]models.RecipeRevision, error) {
+	_, err := m.Recipe(recipeID, userID)
+	if err != nil {
+		return []models.RecipeRevision{}, nil
+	}
+	return m.RevisionsRegistered[recipeID], nil
+}
+
+func (m *mockRepository) RecipeVariants(recipeID, userID int64) (models.RecipeVariants, error) {
+	var variants models.RecipeVariants
+	for _, r := range m.RecipesRegistered[userID] {
+		if r.ID == m.ParentsRegistered[recipeID] {
+			variants.Parent = &models.RecipeLink{ID: r.ID, Name: r.Name}
+		}
+
+		if m.ParentsRegistered[r.ID] == recipeID {
+			variants.Variants = append(variants.Variants, models.RecipeLink{ID: r.ID, Name: r.Name})
+		}
+	}
+	return variants, nil
 }
 
 func (m *mockRepository) RecipeWithSource(source string, userID int64) (*models.Recipe, error) {
@@ -571,6 +860,32 @@ func (m *mockRepository) RecipesAll(userID int64) models.Recipes {
 	return models.Recipes{}
 }
 
+func (m *mockRepository) RecipesNotes(userID int64) (models.RecipeNotes, error) {
+	notes := make(models.RecipeNotes, 0)
+	for _, r := range m.RecipesRegistered[userID] {
+		notes = append(notes, m.NotesRegistered[r.ID]...)
+	}
+	return notes, nil
+}
+
+func (m *mockRepository) RecipesParents(userID int64) (map[int64]int64, error) {
+	parents := make(map[int64]int64)
+	for _, r := range m.RecipesRegistered[userID] {
+		if parentID, ok := m.ParentsRegistered[r.ID]; ok {
+			parents[r.ID] = parentID
+		}
+	}
+	return parents, nil
+}
+
+func (m *mockRepository) RecipesRevisions(userID int64) ([]models.RecipeRevision, error) {
+	revisions := make([]models.RecipeRevision, 0)
+	for _, r := range m.RecipesRegistered[userID] {
+		revisions = append(revisions, m.RevisionsRegistered[r.ID]...)
+	}
+	return revisions, nil
+}
+
 func (m *mockRepository) RecipeShared(link string) (*models.Share, error) {
 	share, ok := m.ShareLinks[link]
 	if !ok {
@@ -606,8 +921,13 @@ func (m *mockRepository) Register(email string, _ auth.HashedPassword) (int64, e
 	return userID, nil
 }
 
-func (m *mockRepository) ReorderCookbookRecipes(_ int64, _ []uint64, _ int64) error {
-	return nil
+func (m *mockRepository) ReorderCookbookRecipes(cookbookID int64, _ []uint64, updatedAt time.Time, userID int64) (time.Time, error) {
+	for _, c := range m.CookbooksRegistered[userID] {
+		if c.ID == cookbookID && !updatedAt.IsZero() && !updatedAt.Equal(c.UpdatedAt) {
+			return c.UpdatedAt, models.ErrConflict
+		}
+	}
+	return time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC), nil
 }
 
 func (m *mockRepository) RecipesShared(_ int64) ([]models.Share, error) {
@@ -651,6 +971,16 @@ func (m *mockRepository) RestoreUserBackup(backup *models.UserBackup) error {
 	return nil
 }
 
+func (m *mockRepository) SearchFoodsFDC(query string) ([]models.FoodFDC, error) {
+	foods := make([]models.FoodFDC, 0)
+	for _, f := range m.FoodsFDC {
+		if strings.Contains(strings.ToLower(f.Description), strings.ToLower(query)) {
+			foods = append(foods, f)
+		}
+	}
+	return foods, nil
+}
+
 func (m *mockRepository) SearchRecipes(opts models.SearchOptionsRecipes, userID int64) (models.Recipes, uint64, error) {
 	recipes, ok := m.RecipesRegistered[userID]
 	if !ok {
@@ -667,6 +997,28 @@ func (m *mockRepository) SearchRecipes(opts models.SearchOptionsRecipes, userID
 	return results, uint64(len(results)), nil
 }
 
+func (m *mockRepository) SearchRecipesPantry(_ models.SearchOptionsRecipes, userID int64) ([]models.PantryMatch, uint64, error) {
+	matches := models.Pantry(m.PantryRegistered[userID]).Rank(m.RecipesRegistered[userID])
+	return matches, uint64(len(matches)), nil
+}
+
+func (m *mockRepository) ShoppingList(id, userID int64) (models.ShoppingList, error) {
+	lists := m.ShoppingListsRegistered[userID]
+	index := slices.IndexFunc(lists, func(l models.ShoppingList) bool { return l.ID == id })
+	if index == -1 {
+		return models.ShoppingList{}, sql.ErrNoRows
+	}
+	return lists[index], nil
+}
+
+func (m *mockRepository) ShoppingLists(userID int64) ([]models.ShoppingList, error) {
+	lists, ok := m.ShoppingListsRegistered[userID]
+	if !ok {
+		return make([]models.ShoppingList, 0), nil
+	}
+	return lists, nil
+}
+
 func (m *mockRepository) SwitchMeasurementSystem(system units.System, userID int64) error {
 	if m.SwitchMeasurementSystemFunc != nil {
 		return m.SwitchMeasurementSystemFunc(system, userID)
@@ -759,17 +1111,80 @@ func (m *mockRepository) UpdateCookbookImage(id int64, image uuid.UUID, userID i
 	return errors.New("cookbook not found")
 }
 
+func (m *mockRepository) UpdateMealPlanEntry(entry models.MealPlanEntry, userID int64) error {
+	entries := m.MealPlanRegistered[userID]
+	index := slices.IndexFunc(entries, func(e models.MealPlanEntry) bool { return e.ID == entry.ID })
+	if index == -1 {
+		return sql.ErrNoRows
+	}
+	entries[index].Date = entry.Date
+	entries[index].Servings = entry.Servings
+	entries[index].Slot = entry.Slot
+	return nil
+}
+
+func (m *mockRepository) UpdateNutritionPin(recipeID int64, pin models.NutritionPin, userID int64) error {
+	breakdown, err := m.IngredientsNutrition(recipeID, userID)
+	if err != nil {
+		return err
+	}
+
+	index := slices.IndexFunc(breakdown, func(x models.IngredientNutrition) bool {
+		return models.NutritionPinKey(x.Ingredient) == models.NutritionPinKey(pin.Ingredient)
+	})
+	if index == -1 {
+		return nil
+	}
+
+	x := &breakdown[index]
+	x.IsPinned = !pin.IsEmpty()
+	if pin.Grams > 0 {
+		x.Grams = pin.Grams
+	}
+	if i := slices.IndexFunc(m.FoodsFDC, func(f models.FoodFDC) bool { return f.ID == pin.FdcID }); i != -1 {
+		x.Food = m.FoodsFDC[i]
+	}
+	return nil
+}
+
 func (m *mockRepository) UpdatePassword(userID int64, _ auth.HashedPassword) error {
 	m.UsersUpdated = append(m.UsersUpdated, userID)
 	return nil
 }
 
+func (m *mockRepository) UpdateRecipeParent(recipeID, parentID, userID int64) error {
+	_, err := m.Recipe(recipeID, userID)
+	if err != nil {
+		return sql.ErrNoRows
+	}
+
+	if m.ParentsRegistered == nil {
+		m.ParentsRegistered = make(map[int64]int64)
+	}
+
+	if parentID == 0 {
+		delete(m.ParentsRegistered, recipeID)
+	} else {
+		m.ParentsRegistered[recipeID] = parentID
+	}
+	return nil
+}
+
 func (m *mockRepository) UpdateRecipe(updatedRecipe *models.Recipe, userID int64, recipeNum int64) error {
 	oldRecipe, err := m.Recipe(recipeNum, userID)
 	if err != nil {
 		return err
 	}
 
+	if !updatedRecipe.UpdatedAt.IsZero() && !updatedRecipe.UpdatedAt.Equal(oldRecipe.UpdatedAt) {
+		return models.ErrConflict
+	}
+
+	if m.RevisionsRegistered != nil {
+		revision := models.RecipeRevision{ID: int64(len(m.RevisionsRegistered[recipeNum]) + 1), Recipe: oldRecipe.Copy(), RecipeID: recipeNum}
+		m.RevisionsRegistered[recipeNum] = append([]models.RecipeRevision{revision}, m.RevisionsRegistered[recipeNum]...)
+	}
+
 	newRecipe := *oldRecipe
 
 	if oldRecipe.Category != updatedRecipe.Category {
@@ -890,6 +1305,37 @@ func (m *mockRepository) UpdateRecipe(updatedRecipe *models.Recipe, userID int64
 	return nil
 }
 
+func (m *mockRepository) UpdateShoppingList(list models.ShoppingList, userID int64) error {
+	lists := m.ShoppingListsRegistered[userID]
+	index := slices.IndexFunc(lists, func(l models.ShoppingList) bool { return l.ID == list.ID })
+	if index == -1 {
+		return sql.ErrNoRows
+	}
+
+	for i := range list.Items {
+		list.Items[i].ID = int64(i + 1)
+	}
+	list.CreatedAt = lists[index].CreatedAt
+	lists[index] = list
+	return nil
+}
+
+func (m *mockRepository) UpdateShoppingListItem(id, listID int64, isChecked bool, userID int64) error {
+	lists := m.ShoppingListsRegistered[userID]
+	index := slices.IndexFunc(lists, func(l models.ShoppingList) bool { return l.ID == listID })
+	if index == -1 {
+		return sql.ErrNoRows
+	}
+
+	items := lists[index].Items
+	i := slices.IndexFunc(items, func(item models.ShoppingListItem) bool { return item.ID == id })
+	if i == -1 {
+		return sql.ErrNoRows
+	}
+	items[i].IsChecked = isChecked
+	return nil
+}
+
 func (m *mockRepository) UpdateUserSettingsCookbooksViewMode(userID int64, mode models.ViewMode) error {
 	settings, ok := m.UserSettingsRegistered[userID]
 	if !ok {
@@ -904,6 +1350,18 @@ func (m *mockRepository) UpdateVideo(_ uuid.UUID, _ int) error {
 	return nil
 }
 
+func (m *mockRepository) UpdateWebhookDelivery(id int64, delivery models.WebhookDelivery) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	index := slices.IndexFunc(m.WebhooksRegistered, func(w models.Webhook) bool { return w.ID == id })
+	if index == -1 {
+		return sql.ErrNoRows
+	}
+	m.WebhooksRegistered[index].LastDelivery = delivery
+	return nil
+}
+
 func (m *mockRepository) UserInitials(userID int64) string {
 	index := slices.IndexFunc(m.UsersRegistered, func(user models.User) bool {
 		return user.ID == userID
@@ -952,6 +1410,29 @@ func (m *mockRepository) VerifyLogin(email, _ string) int64 {
 	return m.UsersRegistered[index].ID
 }
 
+func (m *mockRepository) Webhooks(userID int64) ([]models.Webhook, error) {
+	webhooks := make([]models.Webhook, 0)
+	for _, w := range m.WebhooksRegistered {
+		if w.UserID == userID {
+			webhooks = append(webhooks, w)
+		}
+	}
+	return webhooks, nil
+}
+
+func (m *mockRepository) WebhooksForEvent(event models.WebhookEvent, userID int64) ([]models.Webhook, error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	webhooks := make([]models.Webhook, 0)
+	for _, w := range m.WebhooksRegistered {
+		if (w.UserID == userID || w.IsGlobal) && w.IsSubscribed(event) {
+			webhooks = append(webhooks, w)
+		}
+	}
+	return webhooks, nil
+}
+
 func (m *mockRepository) Websites() models.Websites {
 	return models.Websites{
 		{ID: 1, Host: "101cookbooks.com", URL: "https://101cookbooks.com"},
@@ -1021,12 +1502,12 @@ func (m *mockFiles) BackupUsersData(_ services.RepositoryService) error {
 	return nil
 }
 
-func (m *mockFiles) ExportCookbook(cookbook models.Cookbook, fileType models.FileType) (string, error) {
+func (m *mockFiles) ExportCookbook(cookbook models.Cookbook, _ map[int64]models.RecipeNotes, fileType models.FileType) (string, error) {
 	m.exportHitCount++
 	return cookbook.Title + fileType.Ext(), nil
 }
 
-func (m *mockFiles) ExportRecipes(recipes models.Recipes, _ []models.Cookbook, _ models.FileType, _ chan int) (*bytes.Buffer, error) {
+func (m *mockFiles) ExportRecipes(recipes models.Recipes, _ []models.Cookbook, _ map[int64]models.RecipeNotes, _ models.FileType, _ chan int) (*bytes.Buffer, error) {
 	var b bytes.Buffer
 	for _, recipe := range recipes {
 		b.WriteString(recipe.Name + "-")
@@ -1035,6 +1516,11 @@ func (m *mockFiles) ExportRecipes(recipes models.Recipes, _ []models.Cookbook, _
 	return &b, nil
 }
 
+func (m *mockFiles) ExportTimeline(_ models.Timeline) (string, error) {
+	m.exportHitCount++
+	return "Batch_cooking.pdf", nil
+}
+
 func (m *mockFiles) ExtractRecipes(fileHeaders []*multipart.FileHeader) models.Recipes {
 	if m.extractRecipesFunc != nil {
 		return m.extractRecipesFunc(fileHeaders)
@@ -1057,6 +1543,10 @@ func (m *mockFiles) MergeImagesToPDF(images []io.Reader) io.ReadWriter {
 	return nil
 }
 
+func (m *mockFiles) RestoreGlobalBackup(_ string) error {
+	return nil
+}
+
 func (m *mockFiles) ScrapeAndStoreImage(_ string) (uuid.UUID, error) {
 	return uuid.New(), nil
 }
@@ -1081,6 +1571,10 @@ func (m *mockFiles) UploadVideo(_ io.ReadCloser, _ services.RepositoryService) (
 	return uuid.New(), nil
 }
 
+func (m *mockFiles) VerifyBackup(_ string, _ int64) (models.BackupVerification, error) {
+	return models.BackupVerification{}, nil
+}
+
 type mockIntegrations struct {
 	importFunc          func(baseURL, username, password string, files services.FilesService) (models.Recipes, error)
 	processImageOCRFunc func(file []io.Reader) (models.Recipes, error)
@@ -1150,3 +1644,31 @@ func (m *mockScraper) Scrape(url string, files services.FilesService) (models.Re
 		URL:       url,
 	}, nil
 }
+
+type mockWebhooks struct {
+	payloads chan models.WebhookPayload
+}
+
+func (m *mockWebhooks) Deliver(_ models.Webhook, payload models.WebhookPayload) models.WebhookDelivery {
+	if m.payloads != nil {
+		m.payloads <- payload
+	}
+	return models.WebhookDelivery{Attempts: 1, DeliveredAt: time.Now(), StatusCode: 200}
+}
+
+func (m *mockWebhooks) Validate(webhook models.Webhook) error {
+	u, err := url.Parse(webhook.URL)
+	if err != nil {
+		return err
+	}
+
+	if webhook.UserID == 1 {
+		return nil
+	}
+
+	ip := net.ParseIP(u.Hostname())
+	if u.Hostname() == "localhost" || (ip != nil && !services.IsPublicAddress(ip)) {
+		return services.ErrWebhookAddressNotAllowed
+	}
+	return nil
+}
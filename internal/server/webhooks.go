@@ -0,0 +1,67 @@
+package server
+
+import (
+	"log/slog"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+// emitWebhookEvent notifies the webhooks subscribed to the event in the background.
+// A userID of 0 denotes an application-wide event which only global webhooks receive.
+func (s *Server) emitWebhookEvent(event models.WebhookEvent, userID int64, data any) {
+	if s.Webhooks == nil {
+		return
+	}
+
+	webhooks, err := s.Repository.WebhooksForEvent(event, userID)
+	if err != nil {
+		slog.Error("Failed to fetch webhooks", "userID", userID, "event", event, "error", err)
+		return
+	}
+
+	if len(webhooks) == 0 {
+		return
+	}
+
+	payload := models.NewWebhookPayload(event, userID, data)
+	for _, webhook := range webhooks {
+		go s.deliverWebhook(webhook, payload)
+	}
+}
+
+// emitCookbookChanged notifies the webhooks of a change to one of the user's cookbooks.
+func (s *Server) emitCookbookChanged(userID, cookbookID int64, change string) {
+	s.emitWebhookEvent(models.WebhookEventCookbookChanged, userID, map[string]any{"change": change, "cookbookID": cookbookID})
+}
+
+// emitBackupsCompleted notifies the webhooks once the scheduled backup of the application and of every user's data is done.
+func (s *Server) emitBackupsCompleted() {
+	s.emitWebhookEvent(models.WebhookEventBackupCompleted, 0, map[string]any{"scope": "global"})
+	for _, user := range s.Repository.Users() {
+		s.emitWebhookEvent(models.WebhookEventBackupCompleted, user.ID, map[string]any{"scope": "user"})
+	}
+}
+
+func (s *Server) deliverWebhook(webhook models.Webhook, payload models.WebhookPayload) {
+	delivery := s.Webhooks.Deliver(webhook, payload)
+
+	attrs := []any{
+		"webhookID", webhook.ID,
+		"userID", payload.UserID,
+		"event", payload.Event,
+		"deliveryID", payload.ID,
+		"attempts", delivery.Attempts,
+		"statusCode", delivery.StatusCode,
+	}
+
+	if delivery.IsSuccess() {
+		slog.Info("Delivered webhook", attrs...)
+	} else {
+		slog.Error("Failed to deliver webhook", append(attrs, "error", delivery.Error)...)
+	}
+
+	err := s.Repository.UpdateWebhookDelivery(webhook.ID, delivery)
+	if err != nil {
+		slog.Error("Failed to record webhook delivery", "webhookID", webhook.ID, "error", err)
+	}
+}
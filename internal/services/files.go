@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,13 +13,16 @@ import (
 	"io"
 	"io/fs"
 	"log/slog"
+	"maps"
 	"math"
 	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"slices"
 	"sort"
@@ -102,9 +106,12 @@ func (f *Files) BackupGlobal() error {
 			return err
 		}
 
+		if path == app.BackupPath {
+			return filepath.SkipDir
+		}
+
 		omitFiles := []string{"fdc.db", app.RecipyaDB + "-wal", app.RecipyaDB + "-shm"}
-		if strings.Contains(path, "Backup") ||
-			slices.Contains(omitFiles, info.Name()) {
+		if slices.Contains(omitFiles, info.Name()) {
 			return nil
 		}
 
@@ -151,6 +158,10 @@ func (f *Files) BackupGlobal() error {
 // Backups gets the list of backup dates sorted in descending order for the given user.
 func (f *Files) Backups(userID int64) []time.Time {
 	root := filepath.Join(app.BackupPath, "users", strconv.FormatInt(userID, 10))
+	if userID == 0 {
+		root = filepath.Join(app.BackupPath, "global")
+	}
+
 	_, err := os.Stat(root)
 	if err != nil {
 		return nil
@@ -224,11 +235,6 @@ func (f *Files) backupUserData(repo RepositoryService, userID int64) error {
 	name := fmt.Sprintf("recipya.%s.zip", time.Now().Format(time.DateOnly))
 	target := filepath.Join(app.BackupPath, "users", userIDStr, name)
 
-	_, err = os.Stat(target)
-	if err != nil {
-		return err
-	}
-
 	err = os.MkdirAll(filepath.Dir(target), os.ModePerm)
 	if err != nil {
 		return fmt.Errorf("could not create backup dir: %q", err)
@@ -262,6 +268,31 @@ func (f *Files) backupUserData(repo RepositoryService, userID int64) error {
 	deleteStatements = append(deleteStatements, deletesSQL...)
 	insertStatements = append(insertStatements, insertsSQL...)
 
+	err = backupUserRevisions(zw, repo, allRecipes, userID)
+	if err != nil {
+		return err
+	}
+
+	err = backupUserCookLog(zw, repo, allRecipes, userID)
+	if err != nil {
+		return err
+	}
+
+	err = backupUserNotes(zw, repo, allRecipes, userID)
+	if err != nil {
+		return err
+	}
+
+	err = backupUserSubRecipes(zw, allRecipes)
+	if err != nil {
+		return err
+	}
+
+	err = backupUserVariants(zw, repo, allRecipes, userID)
+	if err != nil {
+		return err
+	}
+
 	if len(deleteStatements) > 0 {
 		w, err := zw.CreateHeader(&zip.FileHeader{
 			Name:     "backup-deletes.sql",
@@ -319,7 +350,7 @@ func (f *Files) backupUserRecipes(
 			return nil, nil, err
 		}
 
-		buf, err := f.ExportRecipes(recipes, cookbooks, models.JSON, nil)
+		buf, err := f.ExportRecipes(recipes, cookbooks, nil, models.JSON, nil)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -332,6 +363,101 @@ func (f *Files) backupUserRecipes(
 	return deletesSQL, insertsSQL, nil
 }
 
+func backupUserCookLog(zw *zip.Writer, repo RepositoryService, recipes models.Recipes, userID int64) error {
+	cooks, err := repo.CookLogAll(userID)
+	if err != nil {
+		return err
+	}
+
+	if len(cooks) == 0 {
+		return nil
+	}
+	return addJSONToZip(zw, "cooklog.json", groupByRecipePosition(recipes, cooks, func(e models.CookLogEntry) int64 { return e.RecipeID }))
+}
+
+func backupUserNotes(zw *zip.Writer, repo RepositoryService, recipes models.Recipes, userID int64) error {
+	notes, err := repo.RecipesNotes(userID)
+	if err != nil {
+		return err
+	}
+
+	if len(notes) == 0 {
+		return nil
+	}
+	return addJSONToZip(zw, "notes.json", groupByRecipePosition(recipes, notes, func(n models.RecipeNote) int64 { return n.RecipeID }))
+}
+
+func backupUserSubRecipes(zw *zip.Writer, recipes models.Recipes) error {
+	links := recipes.SubRecipeLinks()
+	if len(links) == 0 {
+		return nil
+	}
+	return addJSONToZip(zw, "subrecipes.json", links)
+}
+
+func backupUserVariants(zw *zip.Writer, repo RepositoryService, recipes models.Recipes, userID int64) error {
+	parents, err := repo.RecipesParents(userID)
+	if err != nil {
+		return err
+	}
+
+	links := recipes.VariantLinks(parents)
+	if len(links) == 0 {
+		return nil
+	}
+	return addJSONToZip(zw, "variants.json", links)
+}
+
+func backupUserRevisions(zw *zip.Writer, repo RepositoryService, recipes models.Recipes, userID int64) error {
+	revisions, err := repo.RecipesRevisions(userID)
+	if err != nil {
+		return err
+	}
+
+	if len(revisions) == 0 {
+		return nil
+	}
+	return addJSONToZip(zw, "revisions.json", groupByRecipePosition(recipes, revisions, func(r models.RecipeRevision) int64 { return r.RecipeID }))
+}
+
+// groupByRecipePosition groups the items by the position of their recipe because
+// the IDs change when a backup is restored and the names need not be unique.
+func groupByRecipePosition[S ~[]E, E any](recipes models.Recipes, items S, recipeID func(E) int64) map[int]S {
+	positions := make(map[int64]int, len(recipes))
+	for i, r := range recipes {
+		positions[r.ID] = i
+	}
+
+	groups := make(map[int]S)
+	for _, item := range items {
+		pos, ok := positions[recipeID(item)]
+		if !ok {
+			continue
+		}
+		groups[pos] = append(groups[pos], item)
+	}
+	return groups
+}
+
+func addJSONToZip(zw *zip.Writer, name string, v any) error {
+	xb, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	w, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(xb)
+	return err
+}
+
 func backupUserCookbooks(zw *zip.Writer, repo RepositoryService, userID int64) (deletesSQL []string, insertsSQL []string, err error) {
 	cookbooks, err := repo.CookbooksUser(userID)
 	if err != nil {
@@ -353,13 +479,13 @@ func backupUserCookbooks(zw *zip.Writer, repo RepositoryService, userID int64) (
 			return nil, nil, err
 		}
 
-		stmt := strings.Replace(statements.InsertCookbook, "(?, ?, ?)", fmt.Sprintf("('%s', '%s', %d)", c.Title, c.Image, userID), 1)
+		stmt := strings.Replace(statements.InsertCookbook, "(trim(?), ?, ?)", fmt.Sprintf("(%s, %s, %d)", quoteSQL(c.Title), quoteSQL(c.Image.String()), userID), 1)
 		inserts = append(inserts, strings.Join(strings.Fields(stmt), " "))
 
 		for _, r := range c.Recipes {
-			cookbookIDStmt := fmt.Sprintf("(SELECT id FROM cookbooks WHERE title = '%s' AND user_id = %d)", c.Title, userID)
+			cookbookIDStmt := fmt.Sprintf("(SELECT id FROM cookbooks WHERE title = %s AND user_id = %d)", quoteSQL(c.Title), userID)
 			stmt = strings.Replace(statements.InsertCookbookRecipe, "?", cookbookIDStmt, 1)
-			stmt = strings.Replace(stmt, "?", fmt.Sprintf("(SELECT id FROM recipes WHERE name = '%s')", r.Name), 1)
+			stmt = strings.Replace(stmt, "?", fmt.Sprintf("(SELECT id FROM recipes WHERE name = %s)", quoteSQL(r.Name)), 1)
 			stmt = strings.Replace(stmt, "?", cookbookIDStmt, 1)
 			stmt = strings.Replace(stmt, "?", strconv.FormatInt(userID, 10), 1)
 			inserts = append(inserts, strings.Join(strings.Fields(stmt), " "))
@@ -383,7 +509,7 @@ func backupUserCookbooks(zw *zip.Writer, repo RepositoryService, userID int64) (
 			continue
 		}
 
-		values := fmt.Sprintf("('%s', (SELECT id FROM cookbooks WHERE title = '%s'), %d)", share.Link, cookbooks[i].Title, userID)
+		values := fmt.Sprintf("(%s, (SELECT id FROM cookbooks WHERE title = %s), %d)", quoteSQL(share.Link), quoteSQL(cookbooks[i].Title), userID)
 		stmt := strings.Replace(statements.InsertShareLinkCookbook, "(?, ?, ?)", values, 1)
 		insertsSQL = append(insertsSQL, strings.Join(strings.Fields(stmt), " "))
 	}
@@ -399,7 +525,7 @@ func backupUserCookbooks(zw *zip.Writer, repo RepositoryService, userID int64) (
 			break
 		}
 
-		values := fmt.Sprintf("('%s', (SELECT id FROM recipes WHERE name = '%s'), %d)", share.Link, name, userID)
+		values := fmt.Sprintf("(%s, (SELECT id FROM recipes WHERE name = %s), %d)", quoteSQL(share.Link), quoteSQL(name), userID)
 		stmt := strings.Replace(statements.InsertShareLink, "(?, ?, ?)", values, 1)
 		insertsSQL = append(insertsSQL, strings.Join(strings.Fields(stmt), " "))
 	}
@@ -407,6 +533,11 @@ func backupUserCookbooks(zw *zip.Writer, repo RepositoryService, userID int64) (
 	return deletesSQL, insertsSQL, nil
 }
 
+// quoteSQL returns the string as an SQL literal, escaping the single quotes it contains.
+func quoteSQL(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
 func addImageToZip(zw *zip.Writer, img uuid.UUID) error {
 	if img == uuid.Nil {
 		return nil
@@ -464,7 +595,8 @@ func cleanBackups(root string) {
 }
 
 // ExportRecipes creates a zip containing the recipes to export in the desired file type.
-func (f *Files) ExportRecipes(recipes models.Recipes, cookbooks []models.Cookbook, fileType models.FileType, progress chan int) (*bytes.Buffer, error) {
+// The private notes, keyed by recipe ID, are only written to the PDF files.
+func (f *Files) ExportRecipes(recipes models.Recipes, cookbooks []models.Cookbook, notes map[int64]models.RecipeNotes, fileType models.FileType, progress chan int) (*bytes.Buffer, error) {
 	buf := new(bytes.Buffer)
 	writer := zip.NewWriter(buf)
 
@@ -529,7 +661,7 @@ func (f *Files) ExportRecipes(recipes models.Recipes, cookbooks []models.Cookboo
 		}
 	case models.PDF:
 		processed := make(map[string]struct{})
-		for i, e := range exportRecipesPDF(recipes) {
+		for i, e := range exportRecipesPDF(recipes, notes) {
 			if progress != nil {
 				progress <- i
 			}
@@ -602,18 +734,18 @@ func exportRecipesJSON(recipes models.Recipes) []exportData {
 	return data
 }
 
-func exportRecipesPDF(recipes models.Recipes) []exportData {
+func exportRecipesPDF(recipes models.Recipes, notes map[int64]models.RecipeNotes) []exportData {
 	data := make([]exportData, len(recipes))
 	for i, r := range recipes {
 		data[i] = exportData{
 			recipeName: r.Name,
-			data:       recipeToPDF(&r),
+			data:       recipeToPDF(&r, notes[r.ID]),
 		}
 	}
 	return data
 }
 
-func recipeToPDF(r *models.Recipe) []byte {
+func recipeToPDF(r *models.Recipe, notes models.RecipeNotes) []byte {
 	pdf := gofpdf.New("P", "mm", "Letter", "")
 	pdf.SetAuthor("Recipya user", false)
 	pdf.SetCreator("Recipya", false)
@@ -621,11 +753,11 @@ func recipeToPDF(r *models.Recipe) []byte {
 	pdf.SetSubject(sanitized, true)
 	pdf.SetTitle(sanitized, true)
 	pdf.SetCreationDate(time.Now())
-	addRecipeToPDF(pdf, r)
+	addRecipeToPDF(pdf, r, notes)
 	return pdfToBytes(pdf, r.Name)
 }
 
-func addRecipeToPDF(pdf *gofpdf.Fpdf, r *models.Recipe) *gofpdf.Fpdf {
+func addRecipeToPDF(pdf *gofpdf.Fpdf, r *models.Recipe, notes models.RecipeNotes) *gofpdf.Fpdf {
 	viewData := templates.NewViewRecipeData(1, r, nil, nil, true, false)
 
 	tr := pdf.UnicodeTranslatorFromDescriptor("")
@@ -680,7 +812,7 @@ func addRecipeToPDF(pdf *gofpdf.Fpdf, r *models.Recipe) *gofpdf.Fpdf {
 
 	cols := []string{
 		r.Category,
-		strconv.FormatInt(int64(r.Yield), 10) + " servings",
+		r.YieldText(),
 		"Source: " + source,
 	}
 
@@ -866,7 +998,7 @@ func addRecipeToPDF(pdf *gofpdf.Fpdf, r *models.Recipe) *gofpdf.Fpdf {
 	pdf.SetFont(fontFamily, "", fontSizeSmall)
 
 	onNewPage := true
-	for _, ing := range r.Ingredients {
+	for i, ing := range r.Ingredients {
 		currY := pdf.GetY()
 		pdf.SetX(ingredientsX)
 		if currY > pageHeight-3*marginTop && onNewPage {
@@ -877,7 +1009,15 @@ func addRecipeToPDF(pdf *gofpdf.Fpdf, r *models.Recipe) *gofpdf.Fpdf {
 			pdf.SetFont(fontFamily, "", fontSizeSmall)
 			onNewPage = false
 		}
+
+		if section := r.IngredientSections.At(i); section != "" {
+			pdf.SetFont(fontFamily, "BI", fontSizeSmall)
+			pdf.MultiCell(maxWidthColumn, 6, tr(section), "", "L", false)
+			pdf.SetFont(fontFamily, "", fontSizeSmall)
+			pdf.SetX(ingredientsX)
+		}
 		pdf.MultiCell(maxWidthColumn, 5, tr("-> "+ing), "", "L", false)
+		addNotesToPDF(pdf, tr, notes.Ingredient(i), ingredientsX, maxWidthColumn)
 	}
 
 	// Instructions
@@ -900,7 +1040,34 @@ func addRecipeToPDF(pdf *gofpdf.Fpdf, r *models.Recipe) *gofpdf.Fpdf {
 			pdf.SetFont(fontFamily, "", fontSizeSmall)
 			pdf.SetX(marginLeft + pageWidth/3)
 		}
+
+		if section := r.InstructionSections.At(i); section != "" {
+			x := pdf.GetX()
+			pdf.SetFont(fontFamily, "BI", fontSizeSmall)
+			pdf.MultiCell(maxWidthInstruction-2*marginRight, 6, tr(section), "", "L", false)
+			pdf.SetFont(fontFamily, "", fontSizeSmall)
+			pdf.SetX(x)
+		}
 		pdf.MultiCell(maxWidthInstruction-2*marginRight, 5, tr(strconv.Itoa(i+1)+". "+ins), "", "L", false)
+		addNotesToPDF(pdf, tr, notes.Instruction(i), instructionsX, maxWidthInstruction-2*marginRight)
+	}
+
+	// Notes
+	if general := notes.General(len(r.Ingredients), len(r.Instructions)); len(general) > 0 {
+		pdf.Ln(2)
+		pdf.SetX(marginLeft + cellGap)
+		pdf.SetFont(fontFamily, "B", fontSizeSmall)
+		pdf.CellFormat(0, 6, "My notes", "", 1, "L", false, 0, "")
+		pdf.SetFont(fontFamily, "", fontSizeSmall)
+
+		for _, n := range general {
+			text := n.Text
+			if label := n.Label(); label != "" {
+				text = label + ": " + text
+			}
+			pdf.SetX(marginLeft + cellGap)
+			pdf.MultiCell(pageWidth-2*marginLeft-2*cellGap, 5, tr("- "+text), "", "L", false)
+		}
 	}
 
 	pdf.SetPage(pdf.PageNo())
@@ -908,6 +1075,22 @@ func addRecipeToPDF(pdf *gofpdf.Fpdf, r *models.Recipe) *gofpdf.Fpdf {
 	return pdf
 }
 
+// addNotesToPDF writes the private notes attached to an ingredient or an instruction under it.
+func addNotesToPDF(pdf *gofpdf.Fpdf, tr func(string) string, notes models.RecipeNotes, x, width float64) {
+	if len(notes) == 0 {
+		return
+	}
+
+	pdf.SetFont(fontFamily, "I", fontSizeSmall-1)
+	pdf.SetTextColor(96, 96, 96)
+	for _, n := range notes {
+		pdf.SetX(x + 4)
+		pdf.MultiCell(width-4, 4, tr("Note: "+n.Text), "", "L", false)
+	}
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont(fontFamily, "", fontSizeSmall)
+}
+
 // ExtractRecipes extracts the recipes from the HTTP files.
 func (f *Files) ExtractRecipes(fileHeaders []*multipart.FileHeader) models.Recipes {
 	defer func() {
@@ -963,6 +1146,121 @@ func (f *Files) ExtractRecipes(fileHeaders []*multipart.FileHeader) models.Recip
 	return recipes
 }
 
+// NewFileHeadersFromPaths opens local files as if they had been uploaded through the import form,
+// so that they go through the same detection as ExtractRecipes. Directories are walked recursively.
+// Files whose extension cannot be imported are returned in skipped. The cleanup function removes the
+// temporary files holding the parts too large to be kept in memory.
+func NewFileHeadersFromPaths(paths []string) (headers []*multipart.FileHeader, skipped []string, cleanup func(), err error) {
+	var files []string
+	for _, path := range paths {
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if d.IsDir() {
+				return nil
+			}
+
+			if importContentType(p) == "" {
+				skipped = append(skipped, p)
+			} else {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	var forms []*multipart.Form
+	cleanup = func() {
+		for _, form := range forms {
+			_ = form.RemoveAll()
+		}
+	}
+
+	for batch := range slices.Chunk(files, maxFilesPerForm) {
+		form, err := readFilesForm(batch)
+		if err != nil {
+			cleanup()
+			return nil, nil, nil, err
+		}
+
+		forms = append(forms, form)
+		headers = append(headers, form.File["files"]...)
+	}
+	return headers, skipped, cleanup, nil
+}
+
+// maxFilesPerForm keeps the forms read by NewFileHeadersFromPaths under the
+// limit of 1000 parts the multipart package enforces by default.
+const maxFilesPerForm = 500
+
+// readFilesForm reads the files as the parts of one multipart form.
+func readFilesForm(files []string) (*multipart.Form, error) {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+
+	go func() {
+		for _, file := range files {
+			err := writeFilePart(mw, file)
+			if err != nil {
+				_ = pw.CloseWithError(fmt.Errorf("%s: %w", file, err))
+				return
+			}
+		}
+		_ = pw.CloseWithError(mw.Close())
+	}()
+
+	form, err := multipart.NewReader(pr, mw.Boundary()).ReadForm(32 << 20)
+	if err != nil {
+		_ = pr.CloseWithError(err)
+		return nil, err
+	}
+	return form, nil
+}
+
+func writeFilePart(mw *multipart.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="files"; filename=%q`, filepath.Base(path)))
+	h.Set("Content-Type", importContentType(path))
+
+	part, err := mw.CreatePart(h)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(part, f)
+	return err
+}
+
+// importContentType returns the content type a browser sends when uploading the file to import.
+// An empty string is returned when the file cannot be imported.
+func importContentType(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".zip":
+		return "application/zip"
+	case models.JSON.Ext():
+		return "application/json"
+	case models.CML.Ext(), models.Crumb.Ext(), models.MXP.Ext():
+		return "application/octet-stream"
+	case models.Paprika.Ext():
+		return "application/paprikarecipes"
+	case models.TXT.Ext():
+		return "text/plain"
+	default:
+		return ""
+	}
+}
+
 // IsAppLatest checks whether there is a software update.
 func (f *Files) IsAppLatest(current semver.Version) (bool, *github.RepositoryRelease, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
@@ -1031,19 +1329,20 @@ func (f *Files) ScrapeAndStoreImage(rawURL string) (uuid.UUID, error) {
 }
 
 // ExportCookbook exports the cookbook in the desired file type.
+// The private notes, keyed by recipe ID, are written under their recipe.
 // It returns the name of file in the temporary directory.
-func (f *Files) ExportCookbook(cookbook models.Cookbook, fileType models.FileType) (string, error) {
+func (f *Files) ExportCookbook(cookbook models.Cookbook, notes map[int64]models.RecipeNotes, fileType models.FileType) (string, error) {
 	buf := new(bytes.Buffer)
 
 	var tempFileName string
 	switch fileType {
 	case models.PDF:
-		export := exportCookbookToPDF(&cookbook)
+		export := exportCookbookToPDF(&cookbook, notes)
 		_, err := buf.Write(export.data)
 		if err != nil {
 			return "", err
 		}
-		tempFileName = strings.Join(strings.Split(cookbook.Title, " "), "_") + "_*.pdf"
+		tempFileName = strings.NewReplacer(" ", "_", "/", "_", "\\", "_").Replace(cookbook.Title) + "_*.pdf"
 	default:
 		return "", errors.New("unsupported export file type")
 	}
@@ -1062,14 +1361,14 @@ func (f *Files) ExportCookbook(cookbook models.Cookbook, fileType models.FileTyp
 	return filepath.Base(out.Name()), nil
 }
 
-func exportCookbookToPDF(cookbook *models.Cookbook) exportData {
+func exportCookbookToPDF(cookbook *models.Cookbook, notes map[int64]models.RecipeNotes) exportData {
 	return exportData{
 		recipeName: cookbook.Title,
-		data:       cookbookToPDF(cookbook),
+		data:       cookbookToPDF(cookbook, notes),
 	}
 }
 
-func cookbookToPDF(cookbook *models.Cookbook) []byte {
+func cookbookToPDF(cookbook *models.Cookbook, notes map[int64]models.RecipeNotes) []byte {
 	pdf := gofpdf.New("P", "mm", "Letter", "")
 	pdf.SetAuthor("Recipya user", false)
 	pdf.SetCreator("Recipya", false)
@@ -1121,11 +1420,109 @@ func cookbookToPDF(cookbook *models.Cookbook) []byte {
 	pdf.SetFont(fontFamily, "", fontSizeSmall)
 
 	for _, r := range cookbook.Recipes {
-		addRecipeToPDF(pdf, &r)
+		addRecipeToPDF(pdf, &r, notes[r.ID])
 	}
 	return pdfToBytes(pdf, cookbook.Title)
 }
 
+// ExportTimeline exports the batch-cooking timeline to a PDF.
+// It returns the name of file in the temporary directory.
+func (f *Files) ExportTimeline(timeline models.Timeline) (string, error) {
+	out, err := os.CreateTemp("", "Batch_cooking_*.pdf")
+	if err != nil {
+		return "", err
+	}
+	defer out.Close()
+
+	_, err = out.Write(timelineToPDF(&timeline))
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Base(out.Name()), nil
+}
+
+func timelineToPDF(timeline *models.Timeline) []byte {
+	title := "Batch cooking - ready at " + timeline.ReadyAt.Format("Mon Jan 2, 15:04")
+
+	pdf := gofpdf.New("P", "mm", "Letter", "")
+	pdf.SetAuthor("Recipya user", false)
+	pdf.SetCreator("Recipya", false)
+	pdf.SetSubject(title, true)
+	pdf.SetTitle(title, true)
+	pdf.SetCreationDate(time.Now())
+
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	marginLeft, _, marginRight, _ := pdf.GetMargins()
+	pageWidth, _ := pdf.GetPageSize()
+
+	pdf.AddPage()
+	pdf.SetFont(fontFamily, "B", fontSizeBig)
+	pdf.MultiCell(0, 9, tr(title), "", "C", false)
+
+	names := make([]string, 0, len(timeline.Recipes))
+	for _, r := range timeline.Recipes {
+		names = append(names, r.Name)
+	}
+	pdf.SetFont(fontFamily, "", fontSizeSmall)
+	pdf.MultiCell(0, 5, tr("Start at "+timeline.Start().Format("15:04")+": "+strings.Join(names, ", ")), "", "C", false)
+	pdf.Ln(4)
+
+	var (
+		lineHt = 5.0
+		widths = []float64{24, 40, 0}
+	)
+	widths[2] = pageWidth - marginLeft - marginRight - widths[0] - widths[1]
+
+	pdf.SetFont(fontFamily, "B", fontSizeSmall)
+	for i, header := range []string{"Time", "Recipe", "Step"} {
+		pdf.CellFormat(widths[i], lineHt+2, header, "1", 0, "C", false, 0, "")
+	}
+	pdf.Ln(-1)
+	pdf.SetFont(fontFamily, "", fontSizeSmall)
+
+	for _, step := range timeline.Steps {
+		text := step.Text
+		if step.IsConflict {
+			text = "[" + string(step.Equipment) + " conflict] " + text
+		}
+
+		cols := []string{
+			step.Start.Format("15:04") + " - " + step.End.Format("15:04"),
+			step.RecipeName,
+			strconv.Itoa(step.Step) + ". " + text,
+		}
+
+		numLines := 1
+		for i, col := range cols {
+			numLines = max(numLines, len(pdf.SplitLines([]byte(tr(col)), widths[i]-2)))
+		}
+		height := float64(numLines) * lineHt
+
+		_, pageHeight := pdf.GetPageSize()
+		_, _, _, marginBottom := pdf.GetMargins()
+		if pdf.GetY()+height > pageHeight-marginBottom {
+			pdf.AddPage()
+		}
+
+		if step.IsConflict {
+			pdf.SetTextColor(200, 0, 0)
+		}
+
+		x, y := pdf.GetXY()
+		for i, col := range cols {
+			pdf.Rect(x, y, widths[i], height, "D")
+			pdf.SetXY(x+1, y)
+			pdf.MultiCell(widths[i]-2, lineHt, tr(col), "", "L", false)
+			x += widths[i]
+		}
+		pdf.SetXY(marginLeft, y+height)
+		pdf.SetTextColor(0, 0, 0)
+	}
+
+	return pdfToBytes(pdf, title)
+}
+
 func pdfToBytes(pdf *gofpdf.Fpdf, name string) []byte {
 	buf := &bytes.Buffer{}
 	err := pdf.Output(buf)
@@ -1139,9 +1536,7 @@ func pdfToBytes(pdf *gofpdf.Fpdf, name string) []byte {
 // ExtractUserBackup extracts data from the user backup for restoration.
 func (f *Files) ExtractUserBackup(date string, userID int64) (*models.UserBackup, error) {
 	userIDStr := strconv.FormatInt(userID, 10)
-	src := filepath.Join(app.BackupPath, "users", userIDStr, fmt.Sprintf("recipya.%s.zip", date))
-
-	r, err := zip.OpenReader(src)
+	r, err := zip.OpenReader(userBackupPath(date, userID))
 	if err != nil {
 		return nil, err
 	}
@@ -1157,6 +1552,11 @@ func (f *Files) ExtractUserBackup(date string, userID int64) (*models.UserBackup
 		recipesFile    *zip.File
 		deletesSQLFile *zip.File
 		insertsSQLFile *zip.File
+		revisionsFile  *zip.File
+		cookLogFile    *zip.File
+		notesFile      *zip.File
+		subRecipesFile *zip.File
+		variantsFile   *zip.File
 	)
 
 	for _, file := range r.File {
@@ -1167,6 +1567,16 @@ func (f *Files) ExtractUserBackup(date string, userID int64) (*models.UserBackup
 			deletesSQLFile = file
 		case "backup-inserts.sql":
 			insertsSQLFile = file
+		case "revisions.json":
+			revisionsFile = file
+		case "cooklog.json":
+			cookLogFile = file
+		case "notes.json":
+			notesFile = file
+		case "subrecipes.json":
+			subRecipesFile = file
+		case "variants.json":
+			variantsFile = file
 		default:
 			rc, err := file.Open()
 			if err != nil {
@@ -1215,6 +1625,46 @@ func (f *Files) ExtractUserBackup(date string, userID int64) (*models.UserBackup
 		_ = rc.Close()
 	}
 
+	var revisions map[int][]models.RecipeRevision
+	if revisionsFile != nil {
+		err = readZipJSON(revisionsFile, &revisions)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var cookLog map[int]models.CookLog
+	if cookLogFile != nil {
+		err = readZipJSON(cookLogFile, &cookLog)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var notes map[int]models.RecipeNotes
+	if notesFile != nil {
+		err = readZipJSON(notesFile, &notes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var subRecipes map[int][]models.SubRecipeLink
+	if subRecipesFile != nil {
+		err = readZipJSON(subRecipesFile, &subRecipes)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var variants map[int]int
+	if variantsFile != nil {
+		err = readZipJSON(variantsFile, &variants)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	rc, err = recipesFile.Open()
 	if err != nil {
 		return nil, err
@@ -1232,14 +1682,430 @@ func (f *Files) ExtractUserBackup(date string, userID int64) (*models.UserBackup
 	}
 
 	return &models.UserBackup{
+		CookLog:    cookLog,
 		DeleteSQL:  string(deletes),
 		ImagesPath: imagesPath,
 		InsertSQL:  string(inserts),
+		Notes:      notes,
 		Recipes:    f.processRecipeFiles(zr),
+		Revisions:  revisions,
+		SubRecipes: subRecipes,
 		UserID:     userID,
+		Variants:   variants,
 	}, nil
 }
 
+// RestoreGlobalBackup replaces the database, images and videos with those of the global backup.
+// The current data is backed up beforehand. The server must be stopped.
+func (f *Files) RestoreGlobalBackup(date string) error {
+	r, err := zip.OpenReader(globalBackupPath(date))
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	staging := filepath.Join(app.BackupPath, "restore", "global")
+	_ = os.RemoveAll(staging)
+	defer os.RemoveAll(staging)
+
+	for _, file := range r.File {
+		name := filepath.FromSlash(file.Name)
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("invalid file %q in backup", file.Name)
+		}
+
+		if file.FileInfo().IsDir() || strings.Contains(name, "Logs") {
+			continue
+		}
+
+		err = extractZipFile(file, filepath.Join(staging, name))
+		if err != nil {
+			return err
+		}
+	}
+
+	baseDir := filepath.Dir(app.DBBasePath)
+	staged := filepath.Join(staging, filepath.Base(baseDir))
+	_, err = os.Stat(filepath.Join(staged, filepath.Base(app.DBBasePath), app.RecipyaDB))
+	if err != nil {
+		return fmt.Errorf("backup has no database: %w", err)
+	}
+
+	// The safety backup omits the write-ahead log, so its content must be in the database file.
+	err = checkpointDatabase()
+	if err != nil {
+		return fmt.Errorf("could not checkpoint the database: %w", err)
+	}
+
+	err = f.BackupGlobal()
+	if err != nil {
+		return fmt.Errorf("could not back up the current data: %w", err)
+	}
+
+	for _, name := range []string{app.RecipyaDB + "-wal", app.RecipyaDB + "-shm"} {
+		err = os.Remove(filepath.Join(app.DBBasePath, name))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	return filepath.WalkDir(staged, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(staged, path)
+		if err != nil {
+			return err
+		}
+
+		dest := filepath.Join(baseDir, rel)
+		err = os.MkdirAll(filepath.Dir(dest), os.ModePerm)
+		if err != nil {
+			return err
+		}
+		return copyFile(path, dest)
+	})
+}
+
+// checkpointDatabase moves the content of the write-ahead log into the main database file.
+func checkpointDatabase() error {
+	db, err := sql.Open("sqlite", recipyaDSN())
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	_, err = db.Exec("PRAGMA wal_checkpoint(TRUNCATE)")
+	return err
+}
+
+// VerifyBackup checks whether the SQL and the images of a backup are consistent without restoring it.
+// A userID of 0 verifies the global backup.
+func (f *Files) VerifyBackup(date string, userID int64) (models.BackupVerification, error) {
+	parsed, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return models.BackupVerification{}, err
+	}
+
+	src := globalBackupPath(date)
+	if userID > 0 {
+		src = userBackupPath(date, userID)
+	}
+
+	r, err := zip.OpenReader(src)
+	if err != nil {
+		return models.BackupVerification{}, err
+	}
+	defer r.Close()
+
+	v := models.BackupVerification{Date: parsed}
+	if userID > 0 {
+		err = verifyUserBackup(&r.Reader, &v)
+	} else {
+		err = verifyGlobalBackup(&r.Reader, &v)
+	}
+	return v, err
+}
+
+var (
+	backupCookbookImageRegex = regexp.MustCompile(`INSERT INTO cookbooks \(title, image, user_id\) VALUES \('(?:[^']|'')*', '([^']*)', \d+\)`)
+	backupRecipeNameRegex    = regexp.MustCompile(`FROM recipes WHERE name = '((?:[^']|'')*)'\)`)
+)
+
+func verifyUserBackup(zr *zip.Reader, v *models.BackupVerification) error {
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, file := range zr.File {
+		files[file.Name] = file
+	}
+
+	deletes, err := readZipFile(files["backup-deletes.sql"])
+	if err != nil {
+		v.Problems = append(v.Problems, "backup-deletes.sql is missing or unreadable")
+	} else if strings.TrimSpace(deletes) == "" {
+		v.Problems = append(v.Problems, "backup-deletes.sql is empty")
+	}
+
+	recipesFile, ok := files["recipes.zip"]
+	if !ok {
+		v.Problems = append(v.Problems, "recipes.zip is missing")
+		return nil
+	}
+
+	data, err := readZipFile(recipesFile)
+	if err != nil {
+		return err
+	}
+
+	recipesZip, err := zip.NewReader(strings.NewReader(data), int64(len(data)))
+	if err != nil {
+		v.Problems = append(v.Problems, "recipes.zip is not a valid zip archive")
+		return nil
+	}
+
+	var (
+		names     = make(map[string]struct{})
+		positions int
+	)
+	for _, file := range recipesZip.File {
+		switch filepath.Ext(file.Name) {
+		case models.JSON.Ext():
+			content, err := readZipFile(file)
+			if err != nil {
+				return err
+			}
+
+			positions++
+
+			var rs models.RecipeSchema
+			err = json.Unmarshal([]byte(content), &rs)
+			if err != nil {
+				v.Problems = append(v.Problems, fmt.Sprintf("%s is not a valid recipe: %v", file.Name, err))
+				continue
+			}
+			names[rs.Name] = struct{}{}
+			v.Recipes++
+		case app.ImageExt:
+			v.Images++
+		}
+	}
+
+	err = verifyBackupJSON[[]models.RecipeRevision](files["revisions.json"], positions, v)
+	if err != nil {
+		return err
+	}
+
+	err = verifyBackupJSON[models.CookLog](files["cooklog.json"], positions, v)
+	if err != nil {
+		return err
+	}
+
+	err = verifyBackupJSON[models.RecipeNotes](files["notes.json"], positions, v)
+	if err != nil {
+		return err
+	}
+
+	err = verifyBackupJSON[[]models.SubRecipeLink](files["subrecipes.json"], positions, v)
+	if err != nil {
+		return err
+	}
+
+	err = verifyBackupJSON[int](files["variants.json"], positions, v)
+	if err != nil {
+		return err
+	}
+
+	inserts, err := readZipFile(files["backup-inserts.sql"])
+	if err != nil {
+		return nil
+	}
+
+	if strings.Contains(inserts, "?") {
+		v.Problems = append(v.Problems, "backup-inserts.sql contains unbound parameters")
+	}
+
+	for _, match := range backupRecipeNameRegex.FindAllStringSubmatch(inserts, -1) {
+		name := strings.ReplaceAll(match[1], "''", "'")
+		if _, ok := names[name]; !ok {
+			v.Problems = append(v.Problems, fmt.Sprintf("the SQL references the recipe %q which is not in recipes.zip", name))
+		}
+	}
+
+	for _, match := range backupCookbookImageRegex.FindAllStringSubmatch(inserts, -1) {
+		img, err := uuid.Parse(match[1])
+		if err != nil || img == uuid.Nil {
+			continue
+		}
+
+		if _, ok := files["images/"+img.String()+app.ImageExt]; !ok {
+			v.Problems = append(v.Problems, fmt.Sprintf("the cookbook image %s is missing", img))
+			continue
+		}
+		v.Images++
+	}
+
+	return nil
+}
+
+// verifyBackupJSON verifies that the optional JSON file of the backup, keyed by the position of
+// the recipe in recipes.zip, is valid and only references recipes of the backup.
+func verifyBackupJSON[T any](file *zip.File, numRecipes int, v *models.BackupVerification) error {
+	if file == nil {
+		return nil
+	}
+
+	content, err := readZipFile(file)
+	if err != nil {
+		return err
+	}
+
+	var data map[int]T
+	err = json.Unmarshal([]byte(content), &data)
+	if err != nil {
+		v.Problems = append(v.Problems, fmt.Sprintf("%s is not valid: %v", file.Name, err))
+		return nil
+	}
+
+	for _, pos := range slices.Sorted(maps.Keys(data)) {
+		if pos < 0 || pos >= numRecipes {
+			v.Problems = append(v.Problems, fmt.Sprintf("%s references the recipe #%d which is not in recipes.zip", file.Name, pos+1))
+		}
+	}
+	return nil
+}
+
+func verifyGlobalBackup(zr *zip.Reader, v *models.BackupVerification) error {
+	baseDir := filepath.Dir(app.DBBasePath)
+	prefix := filepath.Base(baseDir) + "/"
+	dbName := prefix + filepath.Base(app.DBBasePath) + "/" + app.RecipyaDB
+	imagesPrefix := prefix + filepath.Base(app.ImagesDir) + "/"
+
+	var (
+		dbFile *zip.File
+		images = make(map[string]struct{})
+	)
+
+	for _, file := range zr.File {
+		name := filepath.ToSlash(file.Name)
+		if name == dbName {
+			dbFile = file
+		} else if strings.HasPrefix(name, imagesPrefix) && filepath.Ext(name) == app.ImageExt {
+			images[strings.TrimSuffix(strings.TrimPrefix(name, imagesPrefix), app.ImageExt)] = struct{}{}
+		}
+	}
+
+	if dbFile == nil {
+		v.Problems = append(v.Problems, dbName+" is missing")
+		return nil
+	}
+
+	tmp, err := os.CreateTemp("", "recipya-verify-*.db")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	err = func() error {
+		defer tmp.Close()
+		rc, err := dbFile.Open()
+		if err != nil {
+			return err
+		}
+		defer rc.Close()
+
+		_, err = io.Copy(tmp, rc)
+		return err
+	}()
+	if err != nil {
+		return err
+	}
+
+	db, err := sql.Open("sqlite", "file:"+tmp.Name()+"?mode=ro")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
+	defer cancel()
+
+	var integrity string
+	err = db.QueryRowContext(ctx, "PRAGMA integrity_check").Scan(&integrity)
+	if err != nil {
+		v.Problems = append(v.Problems, fmt.Sprintf("the database could not be read: %v", err))
+		return nil
+	} else if integrity != "ok" {
+		v.Problems = append(v.Problems, "the database failed the integrity check: "+integrity)
+	}
+
+	err = db.QueryRowContext(ctx, statements.SelectCountRecipesAll).Scan(&v.Recipes)
+	if err != nil {
+		v.Problems = append(v.Problems, fmt.Sprintf("the recipes could not be counted: %v", err))
+	}
+
+	rows, err := db.QueryContext(ctx, statements.SelectDistinctImages)
+	if err != nil {
+		v.Problems = append(v.Problems, fmt.Sprintf("the images could not be listed: %v", err))
+		return nil
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var image sql.NullString
+		err = rows.Scan(&image)
+		if err != nil {
+			return err
+		}
+
+		img, err := uuid.Parse(image.String)
+		if err != nil || img == uuid.Nil {
+			continue
+		}
+
+		if _, ok := images[img.String()]; !ok {
+			v.Problems = append(v.Problems, fmt.Sprintf("the image %s referenced in the database is missing", img))
+			continue
+		}
+		v.Images++
+	}
+	return rows.Err()
+}
+
+func globalBackupPath(date string) string {
+	return filepath.Join(app.BackupPath, "global", fmt.Sprintf("recipya.%s.zip", date))
+}
+
+func userBackupPath(date string, userID int64) string {
+	return filepath.Join(app.BackupPath, "users", strconv.FormatInt(userID, 10), fmt.Sprintf("recipya.%s.zip", date))
+}
+
+func readZipFile(file *zip.File) (string, error) {
+	if file == nil {
+		return "", fs.ErrNotExist
+	}
+
+	rc, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	xb, err := io.ReadAll(rc)
+	return string(xb), err
+}
+
+func readZipJSON(file *zip.File, v any) error {
+	content, err := readZipFile(file)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(content), v)
+}
+
+func extractZipFile(file *zip.File, dest string) error {
+	err := os.MkdirAll(filepath.Dir(dest), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	rc, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(out, rc)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
 // MergeImagesToPDF merges images to a PDF file.
 func (f *Files) MergeImagesToPDF(images []io.Reader) io.ReadWriter {
 	if len(images) == 0 {
@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pressly/goose/v3"
+	"github.com/reaper47/recipya/internal/models"
+)
+
+// NewMigrationsService opens the main database without applying the pending migrations.
+func NewMigrationsService() (*Migrations, error) {
+	db, err := sql.Open("sqlite", recipyaDSN())
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := newMigrationsProvider(db)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &Migrations{db: db, provider: provider}, nil
+}
+
+// Migrations is the entity that manages the schema migrations of the main database.
+type Migrations struct {
+	db       *sql.DB
+	provider *goose.Provider
+}
+
+// Close closes the database.
+func (m *Migrations) Close() error {
+	return m.provider.Close()
+}
+
+// DownTo rolls back the migrations newer than the version. It returns the migrations rolled back.
+func (m *Migrations) DownTo(version int64) ([]models.Migration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
+	defer cancel()
+
+	current, err := m.provider.GetDBVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if version >= current {
+		return nil, fmt.Errorf("the database is at version %d, which is not newer than %d", current, version)
+	}
+
+	if !m.hasVersion(version) {
+		return nil, fmt.Errorf("unknown version %d", version)
+	}
+
+	results, err := m.provider.DownTo(ctx, version)
+	return newMigrationsFromResults(results), err
+}
+
+// Status lists every migration along with whether it has been applied, oldest first.
+func (m *Migrations) Status() ([]models.Migration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	statuses, err := m.provider.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make([]models.Migration, 0, len(statuses))
+	for _, s := range statuses {
+		migrations = append(migrations, models.Migration{
+			AppliedAt: s.AppliedAt,
+			IsApplied: s.State == goose.StateApplied,
+			Name:      filepath.Base(s.Source.Path),
+			Version:   s.Source.Version,
+		})
+	}
+	return migrations, nil
+}
+
+// Up applies the pending migrations. It returns the migrations applied.
+func (m *Migrations) Up() ([]models.Migration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
+	defer cancel()
+
+	results, err := m.provider.Up(ctx)
+	return newMigrationsFromResults(results), err
+}
+
+// Verify compares the database's schema against the schema the applied migrations produce on an
+// empty database. Missing, unexpected and altered tables, indexes, triggers and views are reported,
+// including the full-text search tables, along with pending migrations and integrity errors.
+func (m *Migrations) Verify() ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
+	defer cancel()
+
+	var problems []string
+
+	var integrity string
+	err := m.db.QueryRowContext(ctx, "PRAGMA integrity_check").Scan(&integrity)
+	if err != nil {
+		return nil, err
+	} else if integrity != "ok" {
+		problems = append(problems, "the database failed the integrity check: "+integrity)
+	}
+
+	current, target, err := m.provider.GetVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if current < target {
+		problems = append(problems, fmt.Sprintf("the database is at version %d but the latest migration is %d", current, target))
+	}
+
+	refDB, err := sql.Open("sqlite", "file::memory:")
+	if err != nil {
+		return nil, err
+	}
+	defer refDB.Close()
+	refDB.SetMaxOpenConns(1)
+
+	refProvider, err := newMigrationsProvider(refDB)
+	if err != nil {
+		return nil, err
+	}
+
+	if current > 0 {
+		_, err = refProvider.UpTo(ctx, current)
+		if err != nil {
+			return nil, fmt.Errorf("could not build the reference schema: %w", err)
+		}
+	}
+
+	want, err := selectSchema(ctx, refDB)
+	if err != nil {
+		return nil, err
+	}
+
+	got, err := selectSchema(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(want)) {
+		sqlGot, ok := got[key]
+		if !ok {
+			problems = append(problems, "missing "+key)
+		} else if sqlGot != want[key] {
+			problems = append(problems, "altered "+key)
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(got)) {
+		if _, ok := want[key]; !ok {
+			problems = append(problems, "unexpected "+key)
+		}
+	}
+
+	return problems, nil
+}
+
+func (m *Migrations) hasVersion(version int64) bool {
+	if version == 0 {
+		return true
+	}
+
+	for _, s := range m.provider.ListSources() {
+		if s.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+func newMigrationsProvider(db *sql.DB) (*goose.Provider, error) {
+	fsys, err := fs.Sub(embedMigrations, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return goose.NewProvider(goose.DialectSQLite3, db, fsys, goose.WithDisableGlobalRegistry(true))
+}
+
+func newMigrationsFromResults(results []*goose.MigrationResult) []models.Migration {
+	migrations := make([]models.Migration, 0, len(results))
+	for _, r := range results {
+		if r.Error != nil {
+			continue
+		}
+
+		migrations = append(migrations, models.Migration{
+			IsApplied: r.Direction == "up",
+			Name:      filepath.Base(r.Source.Path),
+			Version:   r.Source.Version,
+		})
+	}
+	return migrations
+}
+
+// selectSchema fetches the normalized definition of every schema object keyed by its type and name.
+// SQLite's internal objects and goose's version table are ignored.
+func selectSchema(ctx context.Context, db *sql.DB) (map[string]string, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT type, name, COALESCE(sql, '')
+		FROM sqlite_master
+		WHERE name NOT LIKE 'sqlite_%'
+		  AND name NOT LIKE 'goose_%'`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	schema := make(map[string]string)
+	for rows.Next() {
+		var kind, name, def string
+		err = rows.Scan(&kind, &name, &def)
+		if err != nil {
+			return nil, err
+		}
+		schema[kind+" "+name] = strings.Join(strings.Fields(def), " ")
+	}
+
+	return schema, rows.Err()
+}
@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE api_tokens
+(
+    id             INTEGER PRIMARY KEY,
+    user_id        INTEGER   NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name           TEXT      NOT NULL,
+    selector       TEXT      NOT NULL UNIQUE,
+    hash_validator TEXT      NOT NULL,
+    scopes         TEXT      NOT NULL DEFAULT 'read',
+    expires_at     TIMESTAMP,
+    last_used_at   TIMESTAMP,
+    created_at     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, name)
+);
+
+CREATE INDEX api_tokens_user_id_idx ON api_tokens (user_id);
+
+-- +goose Down
+DROP INDEX api_tokens_user_id_idx;
+DROP TABLE api_tokens;
@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE webhooks
+(
+    id                INTEGER PRIMARY KEY,
+    user_id           INTEGER   NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    url               TEXT      NOT NULL,
+    secret            TEXT      NOT NULL,
+    events            TEXT      NOT NULL,
+    is_global         INTEGER   NOT NULL DEFAULT 0,
+    last_attempts     INTEGER   NOT NULL DEFAULT 0,
+    last_status_code  INTEGER   NOT NULL DEFAULT 0,
+    last_error        TEXT      NOT NULL DEFAULT '',
+    last_delivered_at TIMESTAMP,
+    created_at        TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX webhooks_user_id_idx ON webhooks (user_id);
+
+-- +goose Down
+DROP INDEX webhooks_user_id_idx;
+DROP TABLE webhooks;
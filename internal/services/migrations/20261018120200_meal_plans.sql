@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE meal_plan_entries
+(
+    id         INTEGER PRIMARY KEY,
+    user_id    INTEGER   NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    recipe_id  INTEGER   NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+    date       TEXT      NOT NULL,
+    slot       TEXT      NOT NULL CHECK (slot IN ('breakfast', 'lunch', 'dinner', 'snack')),
+    servings   INTEGER   NOT NULL CHECK (servings > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX meal_plan_entries_user_id_date_idx ON meal_plan_entries (user_id, date);
+
+-- +goose Down
+DROP INDEX meal_plan_entries_user_id_date_idx;
+DROP TABLE meal_plan_entries;
@@ -0,0 +1,42 @@
+-- +goose Up
+CREATE TABLE shopping_lists
+(
+    id         INTEGER PRIMARY KEY,
+    user_id    INTEGER   NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name       TEXT      NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX shopping_lists_user_id_idx ON shopping_lists (user_id);
+
+CREATE TABLE shopping_list_recipes
+(
+    id               INTEGER PRIMARY KEY,
+    shopping_list_id INTEGER NOT NULL REFERENCES shopping_lists (id) ON DELETE CASCADE,
+    recipe_id        INTEGER REFERENCES recipes (id) ON DELETE SET NULL,
+    name             TEXT    NOT NULL,
+    yield            INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX shopping_list_recipes_shopping_list_id_idx ON shopping_list_recipes (shopping_list_id);
+
+CREATE TABLE shopping_list_items
+(
+    id               INTEGER PRIMARY KEY,
+    shopping_list_id INTEGER NOT NULL REFERENCES shopping_lists (id) ON DELETE CASCADE,
+    name             TEXT    NOT NULL,
+    aisle            TEXT    NOT NULL,
+    quantity         REAL    NOT NULL DEFAULT 0,
+    unit             TEXT    NOT NULL DEFAULT '',
+    is_checked       INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX shopping_list_items_shopping_list_id_idx ON shopping_list_items (shopping_list_id);
+
+-- +goose Down
+DROP INDEX shopping_list_items_shopping_list_id_idx;
+DROP TABLE shopping_list_items;
+DROP INDEX shopping_list_recipes_shopping_list_id_idx;
+DROP TABLE shopping_list_recipes;
+DROP INDEX shopping_lists_user_id_idx;
+DROP TABLE shopping_lists;
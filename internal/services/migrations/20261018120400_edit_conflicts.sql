@@ -0,0 +1,88 @@
+-- +goose Up
+DROP TRIGGER recipes_update_updated_at;
+
+-- +goose StatementBegin
+CREATE TRIGGER recipes_update_updated_at
+    AFTER UPDATE
+    ON recipes
+    FOR EACH ROW
+BEGIN
+    UPDATE recipes
+    SET updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
+    WHERE id = NEW.id;
+END;
+-- +goose StatementEnd
+
+ALTER TABLE cookbooks
+    ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT '1970-01-01 00:00:00';
+
+UPDATE cookbooks
+SET updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now');
+
+-- +goose StatementBegin
+CREATE TRIGGER cookbooks_insert_updated_at
+    AFTER INSERT
+    ON cookbooks
+    FOR EACH ROW
+BEGIN
+    UPDATE cookbooks
+    SET updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
+    WHERE id = NEW.id;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER cookbooks_update_updated_at
+    AFTER UPDATE OF title, image
+    ON cookbooks
+    FOR EACH ROW
+BEGIN
+    UPDATE cookbooks
+    SET updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
+    WHERE id = NEW.id;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER cookbook_recipes_insert_updated_at
+    AFTER INSERT
+    ON cookbook_recipes
+    FOR EACH ROW
+BEGIN
+    UPDATE cookbooks
+    SET updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
+    WHERE id = NEW.cookbook_id;
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER cookbook_recipes_update_updated_at
+    AFTER UPDATE OF order_index
+    ON cookbook_recipes
+    FOR EACH ROW
+BEGIN
+    UPDATE cookbooks
+    SET updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
+    WHERE id = NEW.cookbook_id;
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER cookbook_recipes_update_updated_at;
+DROP TRIGGER cookbook_recipes_insert_updated_at;
+DROP TRIGGER cookbooks_update_updated_at;
+DROP TRIGGER cookbooks_insert_updated_at;
+ALTER TABLE cookbooks DROP COLUMN updated_at;
+DROP TRIGGER recipes_update_updated_at;
+
+-- +goose StatementBegin
+CREATE TRIGGER recipes_update_updated_at
+    AFTER UPDATE
+    ON recipes
+    FOR EACH ROW
+BEGIN
+    UPDATE recipes
+    SET updated_at = CURRENT_TIMESTAMP
+    WHERE id = NEW.id;
+END;
+-- +goose StatementEnd
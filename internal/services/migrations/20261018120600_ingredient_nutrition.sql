@@ -0,0 +1,33 @@
+-- +goose Up
+CREATE TABLE ingredient_nutrition
+(
+    id            INTEGER PRIMARY KEY,
+    recipe_id     INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+    position      INTEGER NOT NULL,
+    ingredient    TEXT    NOT NULL,
+    fdc_id        INTEGER,
+    food          TEXT    NOT NULL DEFAULT '',
+    grams         REAL    NOT NULL DEFAULT 0,
+    calories      REAL    NOT NULL DEFAULT 0,
+    carbohydrates REAL    NOT NULL DEFAULT 0,
+    fat           REAL    NOT NULL DEFAULT 0,
+    protein       REAL    NOT NULL DEFAULT 0,
+    is_pinned     INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX ingredient_nutrition_recipe_id_idx ON ingredient_nutrition (recipe_id);
+
+CREATE TABLE nutrition_pins
+(
+    id         INTEGER PRIMARY KEY,
+    recipe_id  INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+    ingredient TEXT    NOT NULL,
+    fdc_id     INTEGER,
+    grams      REAL,
+    UNIQUE (recipe_id, ingredient)
+);
+
+-- +goose Down
+DROP TABLE nutrition_pins;
+DROP INDEX ingredient_nutrition_recipe_id_idx;
+DROP TABLE ingredient_nutrition;
@@ -0,0 +1,31 @@
+-- +goose Up
+ALTER TABLE ingredient_recipe
+    ADD COLUMN quantity REAL NOT NULL DEFAULT 0;
+ALTER TABLE ingredient_recipe
+    ADD COLUMN quantity_max REAL NOT NULL DEFAULT 0;
+ALTER TABLE ingredient_recipe
+    ADD COLUMN unit TEXT NOT NULL DEFAULT '';
+ALTER TABLE ingredient_recipe
+    ADD COLUMN name TEXT NOT NULL DEFAULT '';
+ALTER TABLE ingredient_recipe
+    ADD COLUMN note TEXT NOT NULL DEFAULT '';
+ALTER TABLE ingredient_recipe
+    ADD COLUMN is_optional INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE ingredient_recipe
+    ADD COLUMN is_parsed INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE ingredient_recipe
+    DROP COLUMN is_parsed;
+ALTER TABLE ingredient_recipe
+    DROP COLUMN is_optional;
+ALTER TABLE ingredient_recipe
+    DROP COLUMN note;
+ALTER TABLE ingredient_recipe
+    DROP COLUMN name;
+ALTER TABLE ingredient_recipe
+    DROP COLUMN unit;
+ALTER TABLE ingredient_recipe
+    DROP COLUMN quantity_max;
+ALTER TABLE ingredient_recipe
+    DROP COLUMN quantity;
@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE recipe_sections
+(
+    id        INTEGER PRIMARY KEY,
+    recipe_id INTEGER NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+    kind      TEXT    NOT NULL CHECK (kind IN ('ingredients', 'instructions')),
+    position  INTEGER NOT NULL,
+    name      TEXT    NOT NULL,
+    UNIQUE (recipe_id, kind, position)
+);
+
+CREATE INDEX recipe_sections_recipe_id_idx ON recipe_sections (recipe_id);
+
+-- +goose Down
+DROP INDEX recipe_sections_recipe_id_idx;
+DROP TABLE recipe_sections;
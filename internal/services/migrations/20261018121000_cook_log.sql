@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE cook_log
+(
+    id         INTEGER PRIMARY KEY,
+    recipe_id  INTEGER   NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+    user_id    INTEGER   NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    cooked_at  TEXT      NOT NULL,
+    rating     INTEGER   NOT NULL DEFAULT 0 CHECK (rating BETWEEN 0 AND 5),
+    notes      TEXT      NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX cook_log_recipe_id_user_id_idx ON cook_log (recipe_id, user_id);
+
+-- +goose Down
+DROP INDEX cook_log_recipe_id_user_id_idx;
+DROP TABLE cook_log;
@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE recipe_notes
+(
+    id         INTEGER PRIMARY KEY,
+    recipe_id  INTEGER   NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+    user_id    INTEGER   NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    anchor     TEXT      NOT NULL DEFAULT 'recipe' CHECK (anchor IN ('recipe', 'ingredient', 'instruction')),
+    position   INTEGER   NOT NULL DEFAULT 0,
+    note       TEXT      NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX recipe_notes_recipe_id_user_id_idx ON recipe_notes (recipe_id, user_id);
+
+-- +goose Down
+DROP INDEX recipe_notes_recipe_id_user_id_idx;
+DROP TABLE recipe_notes;
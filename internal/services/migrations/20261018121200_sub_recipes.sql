@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE ingredient_recipe
+    ADD COLUMN sub_recipe_id INTEGER REFERENCES recipes (id) ON DELETE SET NULL;
+
+-- +goose Down
+ALTER TABLE ingredient_recipe
+    DROP COLUMN sub_recipe_id;
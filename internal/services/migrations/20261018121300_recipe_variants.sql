@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE recipes
+    ADD COLUMN parent_id INTEGER REFERENCES recipes (id) ON DELETE SET NULL;
+
+CREATE INDEX recipes_parent_id_idx ON recipes (parent_id);
+
+-- +goose Down
+DROP INDEX recipes_parent_id_idx;
+
+ALTER TABLE recipes
+    DROP COLUMN parent_id;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE recipes
+    ADD COLUMN yield_unit TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE recipes
+    DROP COLUMN yield_unit;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TRIGGER cookbook_recipes_delete_updated_at
+    AFTER DELETE
+    ON cookbook_recipes
+    FOR EACH ROW
+BEGIN
+    UPDATE cookbooks
+    SET updated_at = strftime('%Y-%m-%d %H:%M:%f', 'now')
+    WHERE id = OLD.cookbook_id;
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER cookbook_recipes_delete_updated_at;
@@ -2,6 +2,7 @@ package services
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"mime/multipart"
 	"net/http"
@@ -16,20 +17,51 @@ import (
 	"github.com/reaper47/recipya/internal/units"
 )
 
+// ErrCategoryExists is the error for when the user already has the category.
+var ErrCategoryExists = errors.New("category is already in use")
+
+// ErrCategoryInvalid is the error for when the category cannot be deleted, e.g. "uncategorized".
+var ErrCategoryInvalid = errors.New("category is invalid")
+
+// ErrCookbookExists is the error for when the user already has a cookbook of the same title.
+var ErrCookbookExists = errors.New("cookbook already exists")
+
+// ErrCookbookRecipeExists is the error for when the recipe is already in the cookbook.
+var ErrCookbookRecipeExists = errors.New("recipe is already in the cookbook")
+
 // RepositoryService is the interface that describes the methods required for managing the main data store.
 type RepositoryService interface {
+	// AddAPIToken stores a personal API access token. Only the selector and the hash of the validator are kept.
+	AddAPIToken(token models.APIToken, selector, hashValidator string) (models.APIToken, error)
+
 	// AddAuthToken adds an authentication token to the database.
 	AddAuthToken(selector, validator string, userID int64) error
 
 	// AddCookbook adds a cookbook to the database.
+	// It fails with ErrCookbookExists when the user already has a cookbook of the same title.
 	AddCookbook(title string, userID int64) (int64, error)
 
-	// AddCookbookRecipe adds a recipe to the cookbook.
+	// AddCookbookRecipe adds a recipe to the cookbook. It fails with sql.ErrNoRows when the recipe
+	// or the cookbook does not belong to the user, and with ErrCookbookRecipeExists when the
+	// recipe is already in the cookbook.
 	AddCookbookRecipe(cookbookID, recipeID, userID int64) error
 
+	// AddCookLogEntry logs a cook of the user's recipe and returns its ID.
+	AddCookLogEntry(entry models.CookLogEntry, userID int64) (int64, error)
+
+	// AddMealPlanEntry plans one of the user's recipes for a meal slot and returns the entry's ID.
+	AddMealPlanEntry(entry models.MealPlanEntry, userID int64) (int64, error)
+
+	// AddPantryItem adds an item to the user's pantry and returns its ID.
+	AddPantryItem(item models.PantryItem, userID int64) (int64, error)
+
 	// AddRecipeCategory adds a custom recipe category for the user.
+	// It fails with ErrCategoryExists when the user already has the category.
 	AddRecipeCategory(name string, userID int64) error
 
+	// AddRecipeNote attaches a private note to the user's recipe and returns its ID.
+	AddRecipeNote(note models.RecipeNote, userID int64) (int64, error)
+
 	// AddRecipes adds recipes to the user's collection.
 	AddRecipes(recipes models.Recipes, userID int64, progress chan models.Progress) ([]int64, []models.ReportLog, error)
 
@@ -42,6 +74,15 @@ type RepositoryService interface {
 	// AddShareRecipe adds a shared recipe to the user's collection.
 	AddShareRecipe(recipeID, userID int64) (int64, error)
 
+	// AddShoppingList stores the shopping list along with its items and returns its ID.
+	AddShoppingList(list models.ShoppingList, userID int64) (int64, error)
+
+	// AddWebhook registers a webhook for the user.
+	AddWebhook(webhook models.Webhook) (models.Webhook, error)
+
+	// APITokens gets the user's personal API access tokens.
+	APITokens(userID int64) ([]models.APIToken, error)
+
 	// Categories gets all user categories from the database.
 	Categories(userID int64) ([]string, error)
 
@@ -52,7 +93,7 @@ type RepositoryService interface {
 	// Confirm confirms the user's account.
 	Confirm(userID int64) error
 
-	// Cookbook gets a cookbook by its ID.
+	// Cookbook gets a cookbook by its ID. It fails with sql.ErrNoRows when the user has no such cookbook.
 	Cookbook(id, userID int64) (models.Cookbook, error)
 
 	// CookbookRecipe gets a recipe from a cookbook.
@@ -71,30 +112,66 @@ type RepositoryService interface {
 	// CookbooksUser gets all the user's cookbooks.
 	CookbooksUser(userID int64) ([]models.Cookbook, error)
 
+	// CookLog gets the cooks of the user's recipe, the most recent first.
+	CookLog(recipeID, userID int64) (models.CookLog, error)
+
+	// CookLogAll gets the cooks of all the user's recipes.
+	CookLogAll(userID int64) (models.CookLog, error)
+
 	// Counts gets the models.Counts for the user.
 	Counts(userID int64) (models.Counts, error)
 
+	// DeleteAPIToken revokes one of the user's personal API access tokens.
+	DeleteAPIToken(id, userID int64) error
+
 	// DeleteAuthToken removes an authentication token from the database.
 	DeleteAuthToken(userID int64) error
 
 	// DeleteCookbook deletes a user's cookbook.
 	DeleteCookbook(id, userID int64) error
 
-	// DeleteRecipe deletes a user's recipe.
+	// DeleteCookLogEntry removes a cook from the log of the user's recipe.
+	DeleteCookLogEntry(id, recipeID, userID int64) error
+
+	// DeleteMealPlanEntry removes a recipe from the user's meal plan.
+	DeleteMealPlanEntry(id, userID int64) error
+
+	// DeletePantryItem removes an item from the user's pantry.
+	DeletePantryItem(id, userID int64) error
+
+	// DeleteRecipe deletes a user's recipe. It fails with sql.ErrNoRows when the user has no such recipe.
 	DeleteRecipe(id, userID int64) error
 
-	// DeleteRecipeCategory deletes a user's recipe category.
+	// DeleteRecipeCategory deletes a user's recipe category. It fails with ErrCategoryInvalid when
+	// the category cannot be deleted and with sql.ErrNoRows when the user has no such category.
 	DeleteRecipeCategory(name string, userID int64) error
 
+	// DeleteRecipeNote removes a private note from the user's recipe.
+	DeleteRecipeNote(id, recipeID, userID int64) error
+
 	// DeleteRecipeFromCookbook deletes a recipe from a cookbook. It returns the number of recipes in the cookbook.
 	DeleteRecipeFromCookbook(recipeID, cookbookID int64, userID int64) (int64, error)
 
+	// DeleteShoppingList deletes one of the user's shopping lists.
+	DeleteShoppingList(id, userID int64) error
+
 	// DeleteUser deletes a user and his or her data.
 	DeleteUser(id int64) error
 
+	// DeleteWebhook removes one of the user's webhooks.
+	DeleteWebhook(id, userID int64) error
+
+	// GetAPIToken gets a non-expired personal API access token from its selector and validator.
+	// The token's last used time is updated on success.
+	GetAPIToken(selector, validator string) (models.APIToken, error)
+
 	// GetAuthToken gets a non-expired auth token by the selector.
 	GetAuthToken(selector, validator string) (models.AuthToken, error)
 
+	// IngredientsNutrition gets the contribution of every ingredient of the user's recipe to its nutrition facts.
+	// It returns sql.ErrNoRows when the recipe is not the user's.
+	IngredientsNutrition(recipeID, userID int64) (models.IngredientsNutrition, error)
+
 	// InitAutologin creates a default user for the autologin feature if no users are present.
 	InitAutologin() error
 
@@ -107,6 +184,9 @@ type RepositoryService interface {
 	// Keywords gets all keywords in the database.
 	Keywords() ([]string, error)
 
+	// MealPlan gets the recipes the user planned between two dates inclusively.
+	MealPlan(userID int64, from, to time.Time) ([]models.MealPlanEntry, error)
+
 	// MeasurementSystems gets the units systems, along with the one the user selected, in the database.
 	MeasurementSystems(userID int64) ([]units.System, models.UserSettings, error)
 
@@ -114,12 +194,25 @@ type RepositoryService interface {
 	// An empty slice is returned when an error occurred.
 	Media() (images, videos []string)
 
-	// Nutrients gets the nutrients for the ingredients from the FDC database, along with the total weight.
-	Nutrients(ingredients []string) (models.NutrientsFDC, float64, error)
+	// Nutrients gets the nutrients of every ingredient from the FDC database. The pins override
+	// the food or the weight matched for an ingredient.
+	Nutrients(ingredients []models.Ingredient, pins models.NutritionPins) (models.IngredientsNutrition, error)
+
+	// PantryItems gets the items of the user's pantry, the ones expiring first at the top.
+	PantryItems(userID int64) ([]models.PantryItem, error)
 
-	// Recipe gets the user's recipe of the given id.
+	// Recipe gets the user's recipe of the given id. It fails with sql.ErrNoRows when the user has no such recipe.
 	Recipe(id, userID int64) (*models.Recipe, error)
 
+	// RecipeNotes gets the private notes of the user's recipe.
+	RecipeNotes(recipeID, userID int64) (models.RecipeNotes, error)
+
+	// RecipeRevisions gets the revisions of the user's recipe, from the most recent to the oldest.
+	RecipeRevisions(recipeID, userID int64) ([]models.RecipeRevision, error)
+
+	// RecipeVariants gets the recipe the user's recipe is a variant of along with its variants.
+	RecipeVariants(recipeID, userID int64) (models.RecipeVariants, error)
+
 	// RecipeWithSource gets the user's recipe with the given source.
 	RecipeWithSource(source string, userID int64) (*models.Recipe, error)
 
@@ -129,6 +222,15 @@ type RepositoryService interface {
 	// RecipesAll gets all the user's recipes.
 	RecipesAll(userID int64) models.Recipes
 
+	// RecipesNotes gets the private notes of all the user's recipes.
+	RecipesNotes(userID int64) (models.RecipeNotes, error)
+
+	// RecipesParents gets the ID of the recipe each of the user's variants derives from, keyed by variant ID.
+	RecipesParents(userID int64) (map[int64]int64, error)
+
+	// RecipesRevisions gets the revisions of all the user's recipes.
+	RecipesRevisions(userID int64) ([]models.RecipeRevision, error)
+
 	// RecipeShared checks whether the recipe is shared.
 	// It returns a models.Share. Otherwise, an error.
 	RecipeShared(id string) (*models.Share, error)
@@ -142,8 +244,9 @@ type RepositoryService interface {
 	// Register adds a new user to the store.
 	Register(email string, hashPassword auth.HashedPassword) (int64, error)
 
-	// ReorderCookbookRecipes reorders the recipe indices of a cookbook.
-	ReorderCookbookRecipes(cookbookID int64, recipeIDs []uint64, userID int64) error
+	// ReorderCookbookRecipes reorders the recipe indices of a cookbook and returns when it was last updated.
+	// It fails with models.ErrConflict when the cookbook was updated after updatedAt, unless updatedAt is zero.
+	ReorderCookbookRecipes(cookbookID int64, recipeIDs []uint64, updatedAt time.Time, userID int64) (time.Time, error)
 
 	// Report gets a report of any type belonging to the user.
 	Report(id, userID int64) ([]models.ReportLog, error)
@@ -154,10 +257,23 @@ type RepositoryService interface {
 	// RestoreUserBackup restores the user's data.
 	RestoreUserBackup(backup *models.UserBackup) error
 
+	// SearchFoodsFDC searches the FDC database for the foods whose description contains the query.
+	SearchFoodsFDC(query string) ([]models.FoodFDC, error)
+
 	// SearchRecipes searches for recipes based on the configuration.
 	// It returns the paginated search recipes, the total number of search results and an error.
 	SearchRecipes(opts models.SearchOptionsRecipes, userID int64) (models.Recipes, uint64, error)
 
+	// SearchRecipesPantry searches for the recipes using ingredients of the user's pantry, ranked by how many
+	// of their ingredients the pantry covers. It returns the paginated matches and the total number of matches.
+	SearchRecipesPantry(opts models.SearchOptionsRecipes, userID int64) ([]models.PantryMatch, uint64, error)
+
+	// ShoppingList gets one of the user's shopping lists along with its items.
+	ShoppingList(id, userID int64) (models.ShoppingList, error)
+
+	// ShoppingLists gets the user's shopping lists along with their items.
+	ShoppingLists(userID int64) ([]models.ShoppingList, error)
+
 	// SwitchMeasurementSystem sets the user's units system to the desired one.
 	SwitchMeasurementSystem(system units.System, userID int64) error
 
@@ -170,18 +286,39 @@ type RepositoryService interface {
 	// UpdateCookbookImage updates the image of a user's cookbook.
 	UpdateCookbookImage(id int64, image uuid.UUID, userID int64) error
 
+	// UpdateMealPlanEntry moves a planned recipe to another day or slot and updates its servings.
+	UpdateMealPlanEntry(entry models.MealPlanEntry, userID int64) error
+
+	// UpdateNutritionPin overrides the food or the weight matched for an ingredient of the user's recipe,
+	// then recalculates the contribution of its ingredients. The recipe's nutrition facts are recalculated
+	// only when the user enabled their calculation. An empty pin restores the automatic match.
+	UpdateNutritionPin(recipeID int64, pin models.NutritionPin, userID int64) error
+
 	// UpdatePassword updates the user's password.
 	UpdatePassword(userID int64, hashedPassword auth.HashedPassword) error
 
+	// UpdateRecipeParent makes the user's recipe a variant of the parent recipe. A parentID of 0 detaches it.
+	UpdateRecipeParent(recipeID, parentID, userID int64) error
+
 	// UpdateRecipe updates the recipe with its new values.
+	// It fails with models.ErrConflict when the recipe was updated after updatedRecipe.UpdatedAt, unless it is zero.
 	UpdateRecipe(updatedRecipe *models.Recipe, userID int64, recipeNum int64) error
 
+	// UpdateShoppingList renames the shopping list and replaces its items and recipes.
+	UpdateShoppingList(list models.ShoppingList, userID int64) error
+
+	// UpdateShoppingListItem checks or unchecks an item of one of the user's shopping lists.
+	UpdateShoppingListItem(id, listID int64, isChecked bool, userID int64) error
+
 	// UpdateUserSettingsCookbooksViewMode updates the user's preferred cookbooks viewing mode.
 	UpdateUserSettingsCookbooksViewMode(userID int64, mode models.ViewMode) error
 
 	// UpdateVideo updates a video.
 	UpdateVideo(video uuid.UUID, duration int) error
 
+	// UpdateWebhookDelivery records the outcome of the latest delivery to the webhook.
+	UpdateWebhookDelivery(id int64, delivery models.WebhookDelivery) error
+
 	// UserID gets the user's id from the email. It returns -1 if user not found.
 	UserID(email string) int64
 
@@ -198,6 +335,12 @@ type RepositoryService interface {
 	// If yes, their user ID will be returned. Otherwise, -1 is returned.
 	VerifyLogin(email, password string) int64
 
+	// Webhooks gets the webhooks registered by the user.
+	Webhooks(userID int64) ([]models.Webhook, error)
+
+	// WebhooksForEvent gets the user's webhooks subscribed to the event along with the global ones.
+	WebhooksForEvent(event models.WebhookEvent, userID int64) ([]models.Webhook, error)
+
 	// Websites gets the list of supported websites from which to extract the recipe.
 	Websites() models.Websites
 }
@@ -223,6 +366,7 @@ type FilesService interface {
 	BackupGlobal() error
 
 	// Backups gets the list of backup dates sorted in descending order for the given user.
+	// A userID of 0 lists the global backups.
 	Backups(userID int64) []time.Time
 
 	// BackupUserData backs up a specific user's data to the backup directory.
@@ -232,11 +376,17 @@ type FilesService interface {
 	BackupUsersData(repo RepositoryService) error
 
 	// ExportCookbook exports the cookbook in the desired file type.
+	// The private notes, keyed by recipe ID, are written under their recipe.
 	// It returns the name of file in the temporary directory.
-	ExportCookbook(cookbook models.Cookbook, fileType models.FileType) (string, error)
+	ExportCookbook(cookbook models.Cookbook, notes map[int64]models.RecipeNotes, fileType models.FileType) (string, error)
 
 	// ExportRecipes creates a zip containing the recipes to export in the desired file type.
-	ExportRecipes(recipes models.Recipes, cookbooks []models.Cookbook, fileType models.FileType, progress chan int) (*bytes.Buffer, error)
+	// The private notes, keyed by recipe ID, are only written to the PDF files.
+	ExportRecipes(recipes models.Recipes, cookbooks []models.Cookbook, notes map[int64]models.RecipeNotes, fileType models.FileType, progress chan int) (*bytes.Buffer, error)
+
+	// ExportTimeline exports the batch-cooking timeline to a PDF.
+	// It returns the name of file in the temporary directory.
+	ExportTimeline(timeline models.Timeline) (string, error)
 
 	// ExtractRecipes extracts the recipes from the HTTP files.
 	ExtractRecipes(fileHeaders []*multipart.FileHeader) models.Recipes
@@ -253,6 +403,10 @@ type FilesService interface {
 	// ReadTempFile gets the content of a file in the temporary directory.
 	ReadTempFile(name string) ([]byte, error)
 
+	// RestoreGlobalBackup replaces the database, images and videos with those of the global backup.
+	// The current data is backed up beforehand. The server must be stopped.
+	RestoreGlobalBackup(date string) error
+
 	// ScrapeAndStoreImage takes a URL as input and will download and store the image, and return a UUID referencing the image's internal ID
 	ScrapeAndStoreImage(rawURL string) (uuid.UUID, error)
 
@@ -264,6 +418,10 @@ type FilesService interface {
 
 	// UploadVideo uploads a video to the server. The video is converted to WebM in the background.
 	UploadVideo(rc io.ReadCloser, repo RepositoryService) (uuid.UUID, error)
+
+	// VerifyBackup checks whether the SQL and the images of a backup are consistent without restoring it.
+	// A userID of 0 verifies the global backup.
+	VerifyBackup(date string, userID int64) (models.BackupVerification, error)
 }
 
 // HTTPService is the interface that describes the methods required for preparing and utilizing https requests and responses.
@@ -296,3 +454,13 @@ type IntegrationsService interface {
 	// TestConnection tests the connection of an integration. No error is returned on success.
 	TestConnection(api string) error
 }
+
+// WebhooksService is the interface that describes the methods required for delivering events to webhooks.
+type WebhooksService interface {
+	// Deliver sends the payload to the webhook. Failed deliveries are retried.
+	Deliver(webhook models.Webhook, payload models.WebhookPayload) models.WebhookDelivery
+
+	// Validate verifies whether the webhook may be notified. Only the administrator's webhooks may target
+	// loopback, private or link-local addresses.
+	Validate(webhook models.Webhook) error
+}
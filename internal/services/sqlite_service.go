@@ -2,8 +2,10 @@ package services
 
 import (
 	"context"
+	"crypto/subtle"
 	"database/sql"
 	"embed"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -24,10 +26,12 @@ import (
 	"github.com/reaper47/recipya/internal/auth"
 	"github.com/reaper47/recipya/internal/models"
 	"github.com/reaper47/recipya/internal/services/statements"
+	"github.com/reaper47/recipya/internal/templates"
 	"github.com/reaper47/recipya/internal/units"
 	"github.com/reaper47/recipya/internal/utils/duration"
 	"github.com/reaper47/recipya/internal/utils/extensions"
-	_ "modernc.org/sqlite" // Blank import to initialize the SQL driver.
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
 )
 
 //go:embed migrations/*.sql
@@ -38,6 +42,12 @@ const (
 	longerCtxTimeout = 5 * time.Minute
 )
 
+// The kinds of items a recipe section groups.
+const (
+	sectionsIngredients  = "ingredients"
+	sectionsInstructions = "instructions"
+)
+
 // SQLiteService represents the Service implemented with SQLite.
 type SQLiteService struct {
 	DB    *sql.DB
@@ -47,13 +57,7 @@ type SQLiteService struct {
 
 // NewSQLiteService creates an SQLiteService object.
 func NewSQLiteService() *SQLiteService {
-	dsnURI := "file:" + filepath.Join(app.DBBasePath, app.RecipyaDB) + "?" +
-		"_pragma=foreign_keys(1)" +
-		"&_pragma=journal_mode(wal)" +
-		"&_pragma=synchronous(normal)" +
-		"&_pragma=temp_store(memory)"
-
-	db, err := sql.Open("sqlite", dsnURI)
+	db, err := sql.Open("sqlite", recipyaDSN())
 	if err != nil {
 		panic(err)
 	}
@@ -82,9 +86,33 @@ func NewSQLiteService() *SQLiteService {
 	}
 }
 
+// isUniqueConstraintError reports whether the error is the violation of a UNIQUE constraint.
+func isUniqueConstraintError(err error) bool {
+	var sqliteErr *sqlite.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE
+}
+
+func recipyaDSN() string {
+	return "file:" + filepath.Join(app.DBBasePath, app.RecipyaDB) + "?" +
+		"_pragma=foreign_keys(1)" +
+		"&_pragma=journal_mode(wal)" +
+		"&_pragma=synchronous(normal)" +
+		"&_pragma=temp_store(memory)"
+}
+
 func openFdcDB() *sql.DB {
 	path := filepath.Join(app.DBBasePath, app.FdcDB)
-	db, err := sql.Open("sqlite", "file:"+path)
+
+	// The database is downloaded when the server is set up. Opening it when the file is missing,
+	// e.g. when a command runs before the first start, would create an empty file the server
+	// would then not replace, so an empty in-memory database stands in for it instead.
+	dsn := "file:" + path
+	_, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		dsn = "file::memory:"
+	}
+
+	db, err := sql.Open("sqlite", dsn)
 	if err != nil {
 		panic(err)
 	}
@@ -97,6 +125,26 @@ func openFdcDB() *sql.DB {
 	return db
 }
 
+// AddAPIToken stores a personal API access token. Only the selector and the hash of the validator are kept.
+func (s *SQLiteService) AddAPIToken(token models.APIToken, selector, hashValidator string) (models.APIToken, error) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var expiresAt sql.NullTime
+	if !token.ExpiresAt.IsZero() {
+		expiresAt = sql.NullTime{Time: token.ExpiresAt.UTC(), Valid: true}
+	}
+
+	err := s.DB.QueryRowContext(ctx, statements.InsertAPIToken, token.UserID, token.Name, selector, hashValidator, token.ScopesString(), expiresAt).Scan(&token.ID, &token.CreatedAt)
+	if err != nil {
+		return models.APIToken{}, err
+	}
+	return token, nil
+}
+
 // AddAuthToken adds an authentication token to the database.
 func (s *SQLiteService) AddAuthToken(selector, validator string, userID int64) error {
 	s.Mutex.Lock()
@@ -110,6 +158,7 @@ func (s *SQLiteService) AddAuthToken(selector, validator string, userID int64) e
 }
 
 // AddCookbook adds a cookbook to the database.
+// It fails with ErrCookbookExists when the user already has a cookbook of the same title.
 func (s *SQLiteService) AddCookbook(title string, userID int64) (int64, error) {
 	s.Mutex.Lock()
 	defer s.Mutex.Unlock()
@@ -119,10 +168,15 @@ func (s *SQLiteService) AddCookbook(title string, userID int64) (int64, error) {
 
 	var id int64
 	err := s.DB.QueryRowContext(ctx, statements.InsertCookbook, title, uuid.Nil, userID).Scan(&id)
+	if isUniqueConstraintError(err) {
+		return -1, ErrCookbookExists
+	}
 	return id, err
 }
 
-// AddCookbookRecipe adds a recipe to the cookbook.
+// AddCookbookRecipe adds a recipe to the cookbook. It fails with sql.ErrNoRows when the recipe
+// or the cookbook does not belong to the user, and with ErrCookbookRecipeExists when the
+// recipe is already in the cookbook.
 func (s *SQLiteService) AddCookbookRecipe(cookbookID, recipeID, userID int64) error {
 	s.Mutex.Lock()
 	defer s.Mutex.Unlock()
@@ -137,13 +191,60 @@ func (s *SQLiteService) AddCookbookRecipe(cookbookID, recipeID, userID int64) er
 	}
 
 	if exists == 0 {
-		return errors.New("recipe or cookbook does not belong to the user")
+		return sql.ErrNoRows
 	}
 
 	_, err = s.DB.ExecContext(ctx, statements.InsertCookbookRecipe, cookbookID, recipeID, cookbookID, userID)
+	if isUniqueConstraintError(err) {
+		return ErrCookbookRecipeExists
+	}
 	return err
 }
 
+// AddCookLogEntry logs a cook of the user's recipe and returns its ID.
+// It fails with sql.ErrNoRows when the recipe does not belong to the user.
+func (s *SQLiteService) AddCookLogEntry(entry models.CookLogEntry, userID int64) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var isOwner bool
+	err := s.DB.QueryRowContext(ctx, statements.SelectRecipeUserExist, entry.RecipeID, userID).Scan(&isOwner)
+	if err != nil {
+		return 0, err
+	} else if !isOwner {
+		return 0, sql.ErrNoRows
+	}
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	var id int64
+	err = s.DB.QueryRowContext(ctx, statements.InsertCookLogEntry, entry.RecipeID, userID, entry.CookedAt.Format(time.DateOnly), entry.Rating, entry.Notes).Scan(&id)
+	return id, err
+}
+
+// AddRecipeNote attaches a private note to the user's recipe and returns its ID.
+// It fails with sql.ErrNoRows when the recipe does not belong to the user.
+func (s *SQLiteService) AddRecipeNote(note models.RecipeNote, userID int64) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var isOwner bool
+	err := s.DB.QueryRowContext(ctx, statements.SelectRecipeUserExist, note.RecipeID, userID).Scan(&isOwner)
+	if err != nil {
+		return 0, err
+	} else if !isOwner {
+		return 0, sql.ErrNoRows
+	}
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	var id int64
+	err = s.DB.QueryRowContext(ctx, statements.InsertRecipeNote, note.RecipeID, userID, note.Anchor, note.Index, note.Text).Scan(&id)
+	return id, err
+}
+
 // AddRecipes adds recipes to the user's collection.
 // It returns the IDs of these that were successful and the error.
 func (s *SQLiteService) AddRecipes(recipes models.Recipes, userID int64, progress chan models.Progress) ([]int64, []models.ReportLog, error) {
@@ -282,7 +383,7 @@ func (s *SQLiteService) addRecipeTx(ctx context.Context, tx *sql.Tx, r models.Re
 	}
 
 	var recipeID int64
-	err := tx.QueryRowContext(ctx, statements.InsertRecipe, r.Name, r.Description, mainImage, r.Yield, r.URL).Scan(&recipeID)
+	err := tx.QueryRowContext(ctx, statements.InsertRecipe, r.Name, r.Description, mainImage, r.Yield, r.YieldUnit, r.URL).Scan(&recipeID)
 	if err != nil {
 		return 0, err
 	}
@@ -378,7 +479,7 @@ func (s *SQLiteService) addRecipeTx(ctx context.Context, tx *sql.Tx, r models.Re
 	}
 
 	// Insert instructions
-	r.Instructions = slices.DeleteFunc(extensions.Unique(r.Instructions), func(s string) bool { return s == "" })
+	r.Instructions, r.InstructionSections = r.InstructionSections.Clean(r.Instructions)
 	for i, instruction := range r.Instructions {
 		var instructionID int64
 		err = tx.QueryRowContext(ctx, statements.InsertInstruction, instruction).Scan(&instructionID)
@@ -393,7 +494,8 @@ func (s *SQLiteService) addRecipeTx(ctx context.Context, tx *sql.Tx, r models.Re
 	}
 
 	// Insert ingredients
-	r.Ingredients = slices.DeleteFunc(extensions.Unique(r.Ingredients), func(s string) bool { return s == "" })
+	r.Ingredients, r.IngredientSections = r.IngredientSections.Clean(r.Ingredients)
+	structured := models.NewIngredients(r.Ingredients, r.StructuredIngredients)
 	for i, ingredient := range r.Ingredients {
 		var ingredientID int64
 		err = tx.QueryRowContext(ctx, statements.InsertIngredient, ingredient).Scan(&ingredientID)
@@ -401,12 +503,23 @@ func (s *SQLiteService) addRecipeTx(ctx context.Context, tx *sql.Tx, r models.Re
 			return 0, err
 		}
 
-		_, err = tx.ExecContext(ctx, statements.InsertRecipeIngredient, ingredientID, recipeID, i)
+		_, err = tx.ExecContext(ctx, statements.InsertRecipeIngredient, recipeIngredientArgs(ingredientID, recipeID, i, structured[i])...)
 		if err != nil {
 			return 0, err
 		}
 	}
 
+	// Insert sections
+	err = replaceRecipeSections(ctx, tx, recipeID, sectionsIngredients, r.IngredientSections)
+	if err != nil {
+		return 0, err
+	}
+
+	err = replaceRecipeSections(ctx, tx, recipeID, sectionsInstructions, r.InstructionSections)
+	if err != nil {
+		return 0, err
+	}
+
 	// Insert tools
 	r.Tools = slices.DeleteFunc(extensions.Unique(r.Tools), func(t models.HowToItem) bool { return t.Text == "" })
 	for i, tool := range r.Tools {
@@ -442,6 +555,42 @@ func (s *SQLiteService) addRecipeTx(ctx context.Context, tx *sql.Tx, r models.Re
 	return recipeID, nil
 }
 
+// AddMealPlanEntry plans one of the user's recipes for a meal slot and returns the entry's ID.
+func (s *SQLiteService) AddMealPlanEntry(entry models.MealPlanEntry, userID int64) (int64, error) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var id int64
+	err := s.DB.QueryRowContext(ctx, statements.InsertMealPlanEntry, entry.Date.Format(time.DateOnly), entry.Slot, entry.Servings, userID, entry.RecipeID).Scan(&id)
+	return id, err
+}
+
+// AddPantryItem adds an item to the user's pantry and returns its ID.
+func (s *SQLiteService) AddPantryItem(item models.PantryItem, userID int64) (int64, error) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var unit string
+	if item.Unit != units.Invalid {
+		unit = item.Unit.String()
+	}
+
+	var expiresAt any
+	if !item.ExpiresAt.IsZero() {
+		expiresAt = item.ExpiresAt.Format(time.DateOnly)
+	}
+
+	var id int64
+	err := s.DB.QueryRowContext(ctx, statements.InsertPantryItem, userID, item.Name, item.Quantity, unit, expiresAt).Scan(&id)
+	return id, err
+}
+
 // AddRecipeCategory adds a custom recipe category for the user.
 func (s *SQLiteService) AddRecipeCategory(name string, userID int64) error {
 	// 1. Verify whether category is ok.
@@ -456,7 +605,7 @@ func (s *SQLiteService) AddRecipeCategory(name string, userID int64) error {
 	}
 
 	if slices.Contains(categories, name) {
-		return errors.New("category is already in use")
+		return ErrCategoryExists
 	}
 
 	// 2. Add new category
@@ -615,6 +764,48 @@ func (s *SQLiteService) AddShareRecipe(recipeID, userID int64) (int64, error) {
 	return newRecipeID, tx.Commit()
 }
 
+// AddShoppingList stores the shopping list along with its items and returns its ID.
+func (s *SQLiteService) AddShoppingList(list models.ShoppingList, userID int64) (int64, error) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, statements.InsertShoppingList, userID, list.Name).Scan(&list.ID, &list.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+
+	err = insertShoppingListContent(ctx, tx, list)
+	if err != nil {
+		return 0, err
+	}
+
+	return list.ID, tx.Commit()
+}
+
+// AddWebhook registers a webhook for the user.
+func (s *SQLiteService) AddWebhook(webhook models.Webhook) (models.Webhook, error) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	err := s.DB.QueryRowContext(ctx, statements.InsertWebhook, webhook.UserID, webhook.URL, webhook.Secret, webhook.EventsString(), webhook.IsGlobal).Scan(&webhook.ID, &webhook.CreatedAt)
+	if err != nil {
+		return models.Webhook{}, err
+	}
+	return webhook, nil
+}
+
 // AppInfo gets general information on the application.
 func (s *SQLiteService) AppInfo() (models.AppInfo, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
@@ -639,39 +830,111 @@ func (s *SQLiteService) calculateNutrition(userID int64, recipes []int64, settin
 	}
 
 	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(len(recipes))*longerCtxTimeout)
-		defer cancel()
-
 		for _, id := range recipes {
-			s.Mutex.Lock()
-			recipe, err := s.Recipe(id, userID)
+			err := s.calculateRecipeNutrition(id, userID, force)
 			if err != nil {
-				slog.Error("CalculateNutrition.Recipe failed", "error", err)
-				continue
+				slog.Error("CalculateNutrition failed", "recipeID", id, "error", err)
 			}
-			s.Mutex.Unlock()
+		}
+	}()
+}
 
-			if !force && !recipe.Nutrition.Equal(models.Nutrition{}) {
-				continue
-			}
+// calculateRecipeNutrition calculates the nutrition facts of a recipe and stores the contribution
+// of each of its ingredients, those of its sub-recipes included. The facts already set are kept unless forced.
+func (s *SQLiteService) calculateRecipeNutrition(id, userID int64, force bool) error {
+	recipe, err := s.Recipe(id, userID)
+	if err != nil {
+		return err
+	}
+	return s.storeRecipeNutrition(recipe, userID, force || recipe.Nutrition.Equal(models.Nutrition{}))
+}
 
-			nutrients, weight, err := s.Nutrients(recipe.Ingredients)
-			if err != nil {
-				slog.Error("CalculateNutrition.Nutrients failed", "error", err)
-				continue
-			}
+// storeRecipeNutrition stores the contribution of each ingredient of the recipe, those of its sub-recipes included.
+// The recipe's nutrition facts are replaced by the total of the contributions when isUpdateFacts is true.
+func (s *SQLiteService) storeRecipeNutrition(recipe *models.Recipe, userID int64, isUpdateFacts bool) error {
+	id := recipe.ID
+
+	pins, err := s.nutritionPins(id)
+	if err != nil {
+		return err
+	}
 
-			recipe.Nutrition = nutrients.NutritionFact(weight)
-			n := recipe.Nutrition
+	expanded, err := recipe.ExpandSubRecipes(func(id int64) (*models.Recipe, error) { return s.Recipe(id, userID) })
+	if err != nil {
+		return err
+	}
 
-			s.Mutex.Lock()
-			_, err = s.DB.ExecContext(ctx, statements.UpdateNutrition, n.Calories, n.TotalCarbohydrates, n.Sugars, n.Protein, n.TotalFat, n.SaturatedFat, n.UnsaturatedFat, n.TransFat, n.Cholesterol, n.Sodium, n.Fiber, n.IsPerServing, id)
-			if err != nil {
-				slog.Error("CalculateNutrition.UpdateNutrition failed", "error", err)
-			}
-			s.Mutex.Unlock()
+	breakdown, err := s.Nutrients(expanded.FoodIngredients(), pins)
+	if err != nil {
+		return err
+	}
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if isUpdateFacts {
+		n := breakdown.NutritionFact()
+		_, err = tx.ExecContext(ctx, statements.UpdateNutrition, n.Calories, n.TotalCarbohydrates, n.Sugars, n.Protein, n.TotalFat, n.SaturatedFat, n.UnsaturatedFat, n.TransFat, n.Cholesterol, n.Sodium, n.Fiber, n.IsPerServing, id)
+		if err != nil {
+			return err
 		}
-	}()
+	}
+
+	_, err = tx.ExecContext(ctx, statements.DeleteIngredientsNutrition, id)
+	if err != nil {
+		return err
+	}
+
+	for i, x := range breakdown {
+		_, err = tx.ExecContext(ctx, statements.InsertIngredientNutrition, id, i, x.Ingredient, x.Food.ID, x.Food.Description, x.Grams, x.Calories, x.Carbohydrates, x.Fat, x.Protein, x.IsPinned)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// APITokens gets the user's personal API access tokens.
+func (s *SQLiteService) APITokens(userID int64) ([]models.APIToken, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectAPITokens, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := make([]models.APIToken, 0)
+	for rows.Next() {
+		var (
+			t                   models.APIToken
+			scopes              string
+			expiresAt, lastUsed sql.NullTime
+		)
+
+		err = rows.Scan(&t.ID, &t.UserID, &t.Name, &scopes, &expiresAt, &lastUsed, &t.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		t.Scopes = models.NewAPIScopes(scopes)
+		t.ExpiresAt = expiresAt.Time
+		t.LastUsedAt = lastUsed.Time
+		tokens = append(tokens, t)
+	}
+
+	return tokens, rows.Err()
 }
 
 // Categories gets all user categories from the database.
@@ -759,7 +1022,7 @@ func (s *SQLiteService) Cookbook(id, userID int64) (models.Cookbook, error) {
 	defer cancel()
 
 	var c models.Cookbook
-	err := s.DB.QueryRowContext(ctx, statements.SelectCookbook, id, userID).Scan(&c.ID, &c.Title, &c.Image, &c.Count)
+	err := s.DB.QueryRowContext(ctx, statements.SelectCookbook, id, userID).Scan(&c.ID, &c.Title, &c.Image, &c.Count, &c.UpdatedAt)
 	if err != nil {
 		return c, err
 	}
@@ -778,7 +1041,7 @@ func (s *SQLiteService) CookbookByID(id, userID int64) (models.Cookbook, error)
 	defer cancel()
 
 	var c models.Cookbook
-	err := s.DB.QueryRowContext(ctx, statements.SelectCookbook, id, userID).Scan(&c.ID, &c.Title, &c.Image, &c.Count)
+	err := s.DB.QueryRowContext(ctx, statements.SelectCookbook, id, userID).Scan(&c.ID, &c.Title, &c.Image, &c.Count, &c.UpdatedAt)
 	if err != nil {
 		return models.Cookbook{}, err
 	}
@@ -905,6 +1168,30 @@ func (s *SQLiteService) CookbooksUser(userID int64) ([]models.Cookbook, error) {
 	return cookbooks, rows.Err()
 }
 
+// CookLog gets the cooks of the user's recipe, the most recent first.
+func (s *SQLiteService) CookLog(recipeID, userID int64) (models.CookLog, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectCookLog, recipeID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return scanCookLog(rows)
+}
+
+// CookLogAll gets the cooks of all the user's recipes.
+func (s *SQLiteService) CookLogAll(userID int64) (models.CookLog, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectCookLogUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	return scanCookLog(rows)
+}
+
 // Counts gets the models.Counts for the user.
 func (s *SQLiteService) Counts(userID int64) (models.Counts, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
@@ -915,6 +1202,28 @@ func (s *SQLiteService) Counts(userID int64) (models.Counts, error) {
 	return counts, err
 }
 
+// DeleteAPIToken revokes one of the user's personal API access tokens.
+func (s *SQLiteService) DeleteAPIToken(id, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	res, err := s.DB.ExecContext(ctx, statements.DeleteAPIToken, id, userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
 // DeleteAuthToken removes an authentication token from the database.
 func (s *SQLiteService) DeleteAuthToken(userID int64) error {
 	s.Mutex.Lock()
@@ -927,10 +1236,11 @@ func (s *SQLiteService) DeleteAuthToken(userID int64) error {
 	return err
 }
 
-// DeleteRecipeCategory deletes a user's recipe category.
+// DeleteRecipeCategory deletes a user's recipe category. It fails with ErrCategoryInvalid when
+// the category cannot be deleted and with sql.ErrNoRows when the user has no such category.
 func (s *SQLiteService) DeleteRecipeCategory(name string, userID int64) error {
 	if name == "uncategorized" || name == "" {
-		return errors.New("category is invalid")
+		return ErrCategoryInvalid
 	}
 
 	s.Mutex.Lock()
@@ -971,49 +1281,159 @@ func (s *SQLiteService) DeleteCookbook(id, userID int64) error {
 	return err
 }
 
-// DeleteRecipe deletes a user's recipe. It returns the number of rows affected.
-func (s *SQLiteService) DeleteRecipe(id, userID int64) error {
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
-	defer cancel()
-
+// DeleteCookLogEntry removes a cook from the log of the user's recipe.
+func (s *SQLiteService) DeleteCookLogEntry(id, recipeID, userID int64) error {
 	s.Mutex.Lock()
 	defer s.Mutex.Unlock()
 
-	result, err := s.DB.ExecContext(ctx, statements.DeleteRecipe, userID, id)
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	res, err := s.DB.ExecContext(ctx, statements.DeleteCookLogEntry, id, recipeID, userID)
 	if err != nil {
 		return err
 	}
 
-	n, err := result.RowsAffected()
+	n, err := res.RowsAffected()
 	if err != nil {
 		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
 	}
-
-	if n == 0 {
-		return errors.New("recipe not found")
-	}
-
 	return nil
 }
 
-// DeleteRecipeFromCookbook deletes a recipe from a cookbook. It returns the number of recipes in the cookbook.
-func (s *SQLiteService) DeleteRecipeFromCookbook(recipeID, cookbookID int64, userID int64) (int64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
-	defer cancel()
-
+// DeleteMealPlanEntry removes a recipe from the user's meal plan.
+func (s *SQLiteService) DeleteMealPlanEntry(id, userID int64) error {
 	s.Mutex.Lock()
 	defer s.Mutex.Unlock()
 
-	_, err := s.DB.ExecContext(ctx, statements.DeleteCookbookRecipe, cookbookID, userID, recipeID)
-	if err != nil {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	res, err := s.DB.ExecContext(ctx, statements.DeleteMealPlanEntry, id, userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// DeletePantryItem removes an item from the user's pantry.
+func (s *SQLiteService) DeletePantryItem(id, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	res, err := s.DB.ExecContext(ctx, statements.DeletePantryItem, id, userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// DeleteRecipe deletes a user's recipe. It returns the number of rows affected.
+func (s *SQLiteService) DeleteRecipe(id, userID int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	result, err := s.DB.ExecContext(ctx, statements.DeleteRecipe, userID, id)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// DeleteRecipeNote removes a private note from the user's recipe.
+func (s *SQLiteService) DeleteRecipeNote(id, recipeID, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	res, err := s.DB.ExecContext(ctx, statements.DeleteRecipeNote, id, recipeID, userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// DeleteRecipeFromCookbook deletes a recipe from a cookbook. It returns the number of recipes in the cookbook.
+func (s *SQLiteService) DeleteRecipeFromCookbook(recipeID, cookbookID int64, userID int64) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	_, err := s.DB.ExecContext(ctx, statements.DeleteCookbookRecipe, cookbookID, userID, recipeID)
+	if err != nil {
 		return -1, err
 	}
 
 	var c models.Cookbook
-	err = s.DB.QueryRowContext(ctx, statements.SelectCookbook, cookbookID, userID).Scan(&c.ID, &c.Title, &c.Image, &c.Count)
+	err = s.DB.QueryRowContext(ctx, statements.SelectCookbook, cookbookID, userID).Scan(&c.ID, &c.Title, &c.Image, &c.Count, &c.UpdatedAt)
 	return c.Count, err
 }
 
+// DeleteShoppingList deletes one of the user's shopping lists.
+func (s *SQLiteService) DeleteShoppingList(id, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	res, err := s.DB.ExecContext(ctx, statements.DeleteShoppingList, id, userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
 // DeleteUser deletes a user and his or her data.
 func (s *SQLiteService) DeleteUser(id int64) error {
 	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
@@ -1026,6 +1446,69 @@ func (s *SQLiteService) DeleteUser(id int64) error {
 	return err
 }
 
+// DeleteWebhook removes one of the user's webhooks.
+func (s *SQLiteService) DeleteWebhook(id, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	res, err := s.DB.ExecContext(ctx, statements.DeleteWebhook, id, userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// GetAPIToken gets a non-expired personal API access token from its selector and validator.
+// The token's last used time is updated on success.
+func (s *SQLiteService) GetAPIToken(selector, validator string) (models.APIToken, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var (
+		token               models.APIToken
+		hashValidator       string
+		scopes              string
+		expiresAt, lastUsed sql.NullTime
+	)
+
+	err := s.DB.QueryRowContext(ctx, statements.SelectAPIToken, selector).Scan(&token.ID, &token.UserID, &token.Name, &hashValidator, &scopes, &expiresAt, &lastUsed, &token.CreatedAt)
+	if err != nil {
+		return models.APIToken{}, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(auth.HashAPITokenValidator(validator)), []byte(hashValidator)) != 1 {
+		return models.APIToken{}, errors.New("unequal hashes")
+	}
+
+	token.Scopes = models.NewAPIScopes(scopes)
+	token.ExpiresAt = expiresAt.Time
+	token.LastUsedAt = lastUsed.Time
+
+	if token.IsExpired() {
+		return models.APIToken{}, errors.New("token expired")
+	}
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	_, err = s.DB.ExecContext(ctx, statements.UpdateAPITokenLastUsed, token.ID)
+	if err != nil {
+		slog.Error("Could not update the API token's last used time", "tokenID", token.ID, "error", err)
+	}
+
+	return token, nil
+}
+
 // GetAuthToken gets a non-expired auth token by the selector.
 func (s *SQLiteService) GetAuthToken(selector, validator string) (models.AuthToken, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
@@ -1081,6 +1564,45 @@ func (s *SQLiteService) Media() (images, videos []string) {
 	return images, videos
 }
 
+// IngredientsNutrition gets the contribution of every ingredient of the user's recipe to its nutrition facts.
+// It returns sql.ErrNoRows when the recipe is not the user's.
+func (s *SQLiteService) IngredientsNutrition(recipeID, userID int64) (models.IngredientsNutrition, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var isOwner bool
+	err := s.DB.QueryRowContext(ctx, statements.SelectRecipeUserExist, recipeID, userID).Scan(&isOwner)
+	if err != nil {
+		return nil, err
+	} else if !isOwner {
+		return nil, sql.ErrNoRows
+	}
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectIngredientsNutrition, recipeID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	breakdown := make(models.IngredientsNutrition, 0)
+	for rows.Next() {
+		var (
+			x     models.IngredientNutrition
+			fdcID sql.NullInt64
+		)
+
+		err = rows.Scan(&x.Ingredient, &fdcID, &x.Food.Description, &x.Grams, &x.Calories, &x.Carbohydrates, &x.Fat, &x.Protein, &x.IsPinned)
+		if err != nil {
+			return nil, err
+		}
+
+		x.Food.ID = fdcID.Int64
+		breakdown = append(breakdown, x)
+	}
+
+	return breakdown, rows.Err()
+}
+
 // InitAutologin creates a default user for the autologin feature if no users are present.
 func (s *SQLiteService) InitAutologin() error {
 	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
@@ -1161,6 +1683,39 @@ func (s *SQLiteService) Keywords() ([]string, error) {
 	return xk, nil
 }
 
+// MealPlan gets the recipes the user planned between two dates inclusively.
+func (s *SQLiteService) MealPlan(userID int64, from, to time.Time) ([]models.MealPlanEntry, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectMealPlan, userID, from.Format(time.DateOnly), to.Format(time.DateOnly))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]models.MealPlanEntry, 0)
+	for rows.Next() {
+		var (
+			e    models.MealPlanEntry
+			date string
+		)
+
+		err = rows.Scan(&e.ID, &date, &e.Slot, &e.Servings, &e.RecipeID, &e.RecipeName, &e.RecipeYield)
+		if err != nil {
+			return nil, err
+		}
+
+		e.Date, err = time.Parse(time.DateOnly, date)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, rows.Err()
+}
+
 // MeasurementSystems gets the units systems, along with the one the user selected, in the database.
 func (s *SQLiteService) MeasurementSystems(userID int64) ([]units.System, models.UserSettings, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
@@ -1189,8 +1744,9 @@ func (s *SQLiteService) MeasurementSystems(userID int64) ([]units.System, models
 	}, nil
 }
 
-// Nutrients gets the nutrients for the ingredients from the FDC database, along with the total weight.
-func (s *SQLiteService) Nutrients(ingredients []string) (models.NutrientsFDC, float64, error) {
+// Nutrients gets the nutrients of every ingredient from the FDC database. The pins override
+// the food or the weight matched for an ingredient.
+func (s *SQLiteService) Nutrients(ingredients []models.Ingredient, pins models.NutritionPins) (models.IngredientsNutrition, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
 	defer cancel()
 
@@ -1198,105 +1754,328 @@ func (s *SQLiteService) Nutrients(ingredients []string) (models.NutrientsFDC, fl
 	wg.Add(len(ingredients))
 	tokens := make([]units.TokenizedIngredient, len(ingredients))
 	for i, ing := range ingredients {
-		go func(s string, index int) {
+		go func(in models.Ingredient, index int) {
 			defer wg.Done()
-			tokens[index] = units.NewTokenizedIngredientFromText(s)
+			tokens[index] = units.NewTokenizedIngredientFromText(in.Name)
+			if in.Unit != units.Invalid {
+				tokens[index].Measurement = in.Measurement()
+			} else {
+				tokens[index].Measurement, _ = units.NewMeasurementFromString(in.Text)
+			}
 		}(ing, i)
 	}
 	wg.Wait()
 
-	var weight float64
-	var nutrients models.NutrientsFDC
-	for _, token := range tokens {
-		if len(token.Ingredients) == 0 {
+	breakdown := make(models.IngredientsNutrition, 0, len(ingredients))
+	for i, token := range tokens {
+		text := ingredients[i].Text
+		pin, isPinned := pins.Get(text)
+		if len(token.Ingredients) == 0 && !isPinned {
+			breakdown = append(breakdown, models.IngredientNutrition{Ingredient: text})
 			continue
 		}
 
-		m, err := token.Measurement.Convert(units.Gram)
-		if err != nil {
-			m, err = token.Measurement.Convert(units.Millilitre)
-			if err == nil {
-				weight += m.Quantity
-			}
-		} else {
-			weight += m.Quantity
+		reference := token.Measurement
+		if pin.Grams > 0 {
+			reference = units.Measurement{Quantity: pin.Grams, Unit: units.Gram}
 		}
 
-		stmt := statements.BuildSelectNutrientFDC(token.Ingredients)
-		rows, err := s.FdcDB.QueryContext(ctx, stmt)
+		var (
+			food      models.FoodFDC
+			nutrients models.NutrientsFDC
+			err       error
+		)
+		if pin.FdcID > 0 {
+			food, nutrients, err = s.nutrientsFDC(ctx, statements.SelectNutrientsFDC, pin.FdcID)
+		} else if len(token.Ingredients) > 0 {
+			food, nutrients, err = s.nutrientsFDC(ctx, statements.BuildSelectNutrientFDC(token.Ingredients))
+		}
 		if err != nil {
-			return nil, 0, err
+			return nil, err
 		}
 
-		for rows.Next() {
-			var n models.NutrientFDC
-			err = rows.Scan(&n.ID, &n.Name, &n.Amount, &n.UnitName)
-			if err != nil {
-				return nil, 0, err
-			}
-			n.Reference = token.Measurement
-			nutrients = append(nutrients, n)
-		}
-		_ = rows.Close()
+		in := models.NewIngredientNutrition(text, food, reference, nutrients)
+		in.IsPinned = isPinned
+		breakdown = append(breakdown, in)
+	}
+
+	return breakdown, nil
+}
+
+func (s *SQLiteService) nutrientsFDC(ctx context.Context, query string, args ...any) (models.FoodFDC, models.NutrientsFDC, error) {
+	rows, err := s.FdcDB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return models.FoodFDC{}, nil, err
+	}
+	defer rows.Close()
 
-		err = rows.Err()
+	var (
+		food      models.FoodFDC
+		nutrients models.NutrientsFDC
+	)
+	for rows.Next() {
+		var n models.NutrientFDC
+		err = rows.Scan(&n.ID, &food.Description, &n.Name, &n.Amount, &n.UnitName)
 		if err != nil {
-			return nil, 0, err
+			return models.FoodFDC{}, nil, err
 		}
+		food.ID = n.ID
+		nutrients = append(nutrients, n)
 	}
 
-	return nutrients, weight, nil
+	return food, nutrients, rows.Err()
 }
 
-// Recipe gets the user's recipe of the given id.
-func (s *SQLiteService) Recipe(id, userID int64) (*models.Recipe, error) {
+func (s *SQLiteService) nutritionPins(recipeID int64) (models.NutritionPins, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
 	defer cancel()
 
-	row := s.DB.QueryRowContext(ctx, statements.SelectRecipe, id, userID)
-	r, err := scanRecipe(row, false)
+	rows, err := s.DB.QueryContext(ctx, statements.SelectNutritionPins, recipeID)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
+
+	var pins []models.NutritionPin
+	for rows.Next() {
+		var p models.NutritionPin
+		err = rows.Scan(&p.Ingredient, &p.FdcID, &p.Grams)
+		if err != nil {
+			return nil, err
+		}
+		pins = append(pins, p)
+	}
 
-	return r, nil
+	return models.NewNutritionPins(pins), rows.Err()
 }
 
-// RecipeWithSource gets the user's recipe with the given source.
-func (s *SQLiteService) RecipeWithSource(source string, userID int64) (*models.Recipe, error) {
+// PantryItems gets the items of the user's pantry, the ones expiring first at the top.
+func (s *SQLiteService) PantryItems(userID int64) ([]models.PantryItem, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
 	defer cancel()
 
-	row := s.DB.QueryRowContext(ctx, statements.SelectRecipeWithSource, source, userID)
-	r, err := scanRecipe(row, false)
+	rows, err := s.DB.QueryContext(ctx, statements.SelectPantryItems, userID)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	return r, nil
-}
-
-// Recipes gets the user's recipes.
-func (s *SQLiteService) Recipes(userID int64, opts models.SearchOptionsRecipes) models.Recipes {
-	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
-	defer cancel()
+	items := make([]models.PantryItem, 0)
+	for rows.Next() {
+		var (
+			item      models.PantryItem
+			unit      string
+			expiresAt string
+		)
 
-	params := []any{userID, opts.Page, opts.Page}
-	stmt := statements.SelectRecipes
-	if !opts.Sort.IsDefault {
-		params = []any{userID}
+		err = rows.Scan(&item.ID, &item.Name, &item.Quantity, &unit, &expiresAt)
+		if err != nil {
+			return nil, err
+		}
 
-		values := url.Values{}
-		values.Add("page", strconv.FormatUint(opts.Page, 10))
-		values.Add("sort", opts.Sort.String())
+		if expiresAt != "" {
+			item.ExpiresAt, err = time.Parse(time.DateOnly, expiresAt)
+			if err != nil {
+				return nil, err
+			}
+		}
 
-		stmt = statements.BuildSelectPaginatedResults(models.NewSearchOptionsRecipe(values))
-		stmt = strings.Replace(stmt, "WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? ORDER BY rank)", "WHERE user_id = ?", 1)
+		item.Unit = units.NewUnit(unit)
+		items = append(items, item)
 	}
 
-	rows, err := s.DB.QueryContext(ctx, stmt, params...)
-	if err != nil {
-		return models.Recipes{}
+	return items, rows.Err()
+}
+
+// Recipe gets the user's recipe of the given id. It fails with sql.ErrNoRows when the user has no such recipe.
+func (s *SQLiteService) Recipe(id, userID int64) (*models.Recipe, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	row := s.DB.QueryRowContext(ctx, statements.SelectRecipe, id, userID)
+	r, err := scanRecipe(row, false)
+	if err != nil {
+		return nil, err
+	}
+
+	r.StructuredIngredients, err = s.recipeIngredients(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectRecipeSections, r.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanRecipeSections(rows, map[int64]*models.Recipe{r.ID: r})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// recipeIngredients fetches the parsed parts of the recipe's ingredients. The ingredients
+// stored before they were parsed on save are parsed on the fly.
+func (s *SQLiteService) recipeIngredients(ctx context.Context, r *models.Recipe) ([]models.Ingredient, error) {
+	rows, err := s.DB.QueryContext(ctx, statements.SelectRecipeIngredients, r.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = scanRecipeIngredients(rows, map[int64]*models.Recipe{r.ID: r})
+	if err != nil {
+		return nil, err
+	}
+	return r.StructuredIngredients, nil
+}
+
+// remapRecipeNotes moves the notes attached to the ingredients or the instructions of a recipe
+// along with their item when the recipe is edited. See models.RecipeNotes.Remap.
+func remapRecipeNotes(ctx context.Context, tx *sql.Tx, recipeID int64, anchor models.NoteAnchor, old, updated []string) error {
+	rows, err := tx.QueryContext(ctx, statements.SelectRecipeNotesAll, recipeID)
+	if err != nil {
+		return err
+	}
+
+	notes, err := scanRecipeNotes(rows)
+	if err != nil {
+		return err
+	}
+
+	for _, note := range notes.Remap(anchor, old, updated) {
+		_, err = tx.ExecContext(ctx, statements.UpdateRecipeNoteAnchor, note.Anchor, note.Index, note.ID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replaceRecipeSections replaces the sections of either the ingredients or the instructions of a recipe.
+func replaceRecipeSections(ctx context.Context, tx *sql.Tx, recipeID int64, kind string, sections models.Sections) error {
+	_, err := tx.ExecContext(ctx, statements.DeleteRecipeSections, recipeID, kind)
+	if err != nil {
+		return err
+	}
+
+	for _, sect := range sections {
+		_, err = tx.ExecContext(ctx, statements.InsertRecipeSection, recipeID, kind, sect.Index, sect.Name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recipeIngredientArgs are the arguments of the statements.InsertRecipeIngredient query.
+func recipeIngredientArgs(ingredientID, recipeID int64, order int, in models.Ingredient) []any {
+	var unit string
+	if in.Unit != units.Invalid {
+		unit = in.Unit.String()
+	}
+	var subRecipeID sql.NullInt64
+	if in.RecipeID > 0 {
+		subRecipeID = sql.NullInt64{Int64: in.RecipeID, Valid: true}
+	}
+	return []any{ingredientID, recipeID, order, in.Quantity, in.QuantityMax, unit, in.Name, in.Note, in.IsOptional, subRecipeID}
+}
+
+// RecipeNotes gets the private notes of the user's recipe.
+func (s *SQLiteService) RecipeNotes(recipeID, userID int64) (models.RecipeNotes, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectRecipeNotes, recipeID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return scanRecipeNotes(rows)
+}
+
+// RecipeRevisions gets the revisions of the user's recipe, from the most recent to the oldest.
+func (s *SQLiteService) RecipeRevisions(recipeID, userID int64) ([]models.RecipeRevision, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectRecipeRevisions, recipeID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return scanRecipeRevisions(rows)
+}
+
+// RecipeVariants gets the recipe the user's recipe is a variant of along with its variants.
+func (s *SQLiteService) RecipeVariants(recipeID, userID int64) (models.RecipeVariants, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var (
+		variants models.RecipeVariants
+		parent   models.RecipeLink
+	)
+
+	err := s.DB.QueryRowContext(ctx, statements.SelectRecipeParent, recipeID, userID).Scan(&parent.ID, &parent.Name)
+	if err == nil {
+		variants.Parent = &parent
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return models.RecipeVariants{}, err
+	}
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectRecipeVariants, recipeID, userID)
+	if err != nil {
+		return models.RecipeVariants{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var link models.RecipeLink
+		err = rows.Scan(&link.ID, &link.Name)
+		if err != nil {
+			return models.RecipeVariants{}, err
+		}
+		variants.Variants = append(variants.Variants, link)
+	}
+
+	return variants, rows.Err()
+}
+
+// RecipeWithSource gets the user's recipe with the given source.
+func (s *SQLiteService) RecipeWithSource(source string, userID int64) (*models.Recipe, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	row := s.DB.QueryRowContext(ctx, statements.SelectRecipeWithSource, source, userID)
+	r, err := scanRecipe(row, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Recipes gets the user's recipes.
+func (s *SQLiteService) Recipes(userID int64, opts models.SearchOptionsRecipes) models.Recipes {
+	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
+	defer cancel()
+
+	params := []any{userID, opts.Page, opts.Page}
+	stmt := statements.SelectRecipes
+	if !opts.Sort.IsDefault {
+		params = []any{userID}
+
+		values := url.Values{}
+		values.Add("page", strconv.FormatUint(opts.Page, 10))
+		values.Add("sort", opts.Sort.String())
+
+		stmt = statements.BuildSelectPaginatedResults(models.NewSearchOptionsRecipe(values))
+		stmt = strings.Replace(stmt, "WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? ORDER BY rank)", "WHERE user_id = ?", 1)
+	}
+
+	rows, err := s.DB.QueryContext(ctx, stmt, params...)
+	if err != nil {
+		return models.Recipes{}
 	}
 
 	recipes, err := scanRecipes(rows, true)
@@ -1324,9 +2103,84 @@ func (s *SQLiteService) RecipesAll(userID int64) models.Recipes {
 		return nil
 	}
 
+	byID := make(map[int64]*models.Recipe, len(recipes))
+	for i := range recipes {
+		byID[recipes[i].ID] = &recipes[i]
+	}
+
+	rows, err = s.DB.QueryContext(ctx, statements.SelectRecipesSections, userID)
+	if err != nil {
+		slog.Error("Failed to select the sections of the recipes", "error", err, "userID", userID)
+		return recipes
+	}
+
+	err = scanRecipeSections(rows, byID)
+	if err != nil {
+		slog.Error("Failed to scan the sections of the recipes", "error", err, "userID", userID)
+	}
+
+	rows, err = s.DB.QueryContext(ctx, statements.SelectRecipesIngredients, userID)
+	if err != nil {
+		slog.Error("Failed to select the ingredients of the recipes", "error", err, "userID", userID)
+		return recipes
+	}
+
+	err = scanRecipeIngredients(rows, byID)
+	if err != nil {
+		slog.Error("Failed to scan the ingredients of the recipes", "error", err, "userID", userID)
+	}
+
 	return recipes
 }
 
+// RecipesNotes gets the private notes of all the user's recipes.
+func (s *SQLiteService) RecipesNotes(userID int64) (models.RecipeNotes, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectRecipesNotes, userID)
+	if err != nil {
+		return nil, err
+	}
+	return scanRecipeNotes(rows)
+}
+
+// RecipesParents gets the ID of the recipe each of the user's variants derives from, keyed by variant ID.
+func (s *SQLiteService) RecipesParents(userID int64) (map[int64]int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectRecipesParents, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	parents := make(map[int64]int64)
+	for rows.Next() {
+		var id, parentID int64
+		err = rows.Scan(&id, &parentID)
+		if err != nil {
+			return nil, err
+		}
+		parents[id] = parentID
+	}
+
+	return parents, rows.Err()
+}
+
+// RecipesRevisions gets the revisions of all the user's recipes.
+func (s *SQLiteService) RecipesRevisions(userID int64) ([]models.RecipeRevision, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectRecipesRevisions, userID)
+	if err != nil {
+		return nil, err
+	}
+	return scanRecipeRevisions(rows)
+}
+
 // RecipeShared checks whether the recipe is shared.
 // It returns a models.Share. Otherwise, an error.
 func (s *SQLiteService) RecipeShared(link string) (*models.Share, error) {
@@ -1392,8 +2246,10 @@ func (s *SQLiteService) Register(email string, hashedPassword auth.HashedPasswor
 	return userID, err
 }
 
-// ReorderCookbookRecipes reorders the recipe indices of a cookbook.
-func (s *SQLiteService) ReorderCookbookRecipes(cookbookID int64, recipeIDs []uint64, userID int64) error {
+// ReorderCookbookRecipes reorders the recipe indices of a cookbook. It returns when the cookbook was
+// last updated. The models.ErrConflict error is returned when the cookbook was updated after updatedAt,
+// unless updatedAt is zero.
+func (s *SQLiteService) ReorderCookbookRecipes(cookbookID int64, recipeIDs []uint64, updatedAt time.Time, userID int64) (time.Time, error) {
 	s.Mutex.Lock()
 	defer s.Mutex.Unlock()
 
@@ -1402,25 +2258,42 @@ func (s *SQLiteService) ReorderCookbookRecipes(cookbookID int64, recipeIDs []uin
 
 	tx, err := s.DB.BeginTx(ctx, &sql.TxOptions{})
 	if err != nil {
-		return err
+		return time.Time{}, err
 	}
 	defer tx.Rollback()
 
+	if !updatedAt.IsZero() {
+		var current time.Time
+		err = tx.QueryRowContext(ctx, statements.SelectCookbookUpdatedAt, cookbookID, userID).Scan(&current)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if !current.Equal(updatedAt) {
+			return current, models.ErrConflict
+		}
+	}
+
 	for i, recipeID := range recipeIDs {
 		var exists int64
-		err = s.DB.QueryRowContext(ctx, statements.SelectCookbookRecipeExists, cookbookID, userID, recipeID).Scan(&exists)
+		err = tx.QueryRowContext(ctx, statements.SelectCookbookRecipeExists, cookbookID, userID, recipeID).Scan(&exists)
 		if err != nil {
-			return err
+			return time.Time{}, err
 		}
 
 		if exists == 1 {
 			_, err = tx.ExecContext(ctx, statements.UpdateCookbookRecipesReorder, i, cookbookID, recipeID)
 			if err != nil {
-				return err
+				return time.Time{}, err
 			}
 		}
 	}
-	return tx.Commit()
+
+	err = tx.QueryRowContext(ctx, statements.SelectCookbookUpdatedAt, cookbookID, userID).Scan(&updatedAt)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return updatedAt, tx.Commit()
 }
 
 // Report gets a report of any type belonging to the user.
@@ -1527,8 +2400,62 @@ func (s *SQLiteService) RestoreUserBackup(backup *models.UserBackup) error {
 		return err
 	}
 
-	for _, r := range backup.Recipes {
-		_, err = s.addRecipeTx(ctx, tx, r, backup.UserID)
+	ids := make([]int64, 0, len(backup.Recipes))
+	for pos, r := range backup.Recipes {
+		recipeID, err := s.addRecipeTx(ctx, tx, r, backup.UserID)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, recipeID)
+
+		for _, rev := range slices.Backward(backup.Revisions[pos]) {
+			snapshot, err := json.Marshal(rev.Recipe)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.ExecContext(ctx, statements.InsertRecipeRevisionRestore, recipeID, rev.ReplacedBy, string(snapshot), rev.CreatedAt)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, e := range backup.CookLog[pos] {
+			_, err = tx.ExecContext(ctx, statements.InsertCookLogEntry, recipeID, backup.UserID, e.CookedAt.Format(time.DateOnly), e.Rating, e.Notes)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, n := range backup.Notes[pos] {
+			_, err = tx.ExecContext(ctx, statements.InsertRecipeNote, recipeID, backup.UserID, n.Anchor, n.Index, n.Text)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	isRestored := func(pos int) bool { return pos >= 0 && pos < len(ids) }
+
+	for pos, links := range backup.SubRecipes {
+		for _, link := range links {
+			if !isRestored(pos) || !isRestored(link.Recipe) {
+				continue
+			}
+
+			_, err = tx.ExecContext(ctx, statements.UpdateRecipeSubRecipe, ids[link.Recipe], ids[pos], link.Index)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for pos, parent := range backup.Variants {
+		if !isRestored(pos) || !isRestored(parent) {
+			continue
+		}
+
+		_, err = tx.ExecContext(ctx, statements.UpdateRecipeParent, ids[parent], ids[pos], backup.UserID)
 		if err != nil {
 			return err
 		}
@@ -1552,7 +2479,7 @@ func (s *SQLiteService) RestoreUserBackup(backup *models.UserBackup) error {
 		}
 		defer src.Close()
 
-		dest, err := os.Open(destPath)
+		dest, err := os.Create(destPath)
 		if err != nil {
 			return err
 		}
@@ -1569,14 +2496,54 @@ func (s *SQLiteService) RestoreUserBackup(backup *models.UserBackup) error {
 
 	for _, file := range files {
 		err = copyImage(file.Name())
+		if err != nil {
+			return err
+		}
 	}
 
 	return tx.Commit()
 }
 
+// SearchFoodsFDC searches the FDC database for the foods whose description contains the query.
+func (s *SQLiteService) SearchFoodsFDC(query string) ([]models.FoodFDC, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
+	defer cancel()
+
+	rows, err := s.FdcDB.QueryContext(ctx, statements.SelectFoodsFDC, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	foods := make([]models.FoodFDC, 0)
+	for rows.Next() {
+		var f models.FoodFDC
+		err = rows.Scan(&f.ID, &f.Description)
+		if err != nil {
+			return nil, err
+		}
+		foods = append(foods, f)
+	}
+
+	return foods, rows.Err()
+}
+
 // SearchRecipes searches for recipes based on the configuration.
 // It returns the paginated search recipes, the total number of search results and an error.
 func (s *SQLiteService) SearchRecipes(opts models.SearchOptionsRecipes, userID int64) (models.Recipes, uint64, error) {
+	if opts.Advanced.IsPantry {
+		matches, total, err := s.SearchRecipesPantry(opts, userID)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		recipes := make(models.Recipes, 0, len(matches))
+		for _, m := range matches {
+			recipes = append(recipes, m.Recipe)
+		}
+		return recipes, total, nil
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
 	defer cancel()
 
@@ -1593,61 +2560,375 @@ func (s *SQLiteService) SearchRecipes(opts models.SearchOptionsRecipes, userID i
 		if opts.Query != "" {
 			args = append(args, strings.Join(strings.Fields(opts.Query), " *")+"*")
 		}
-	}
+	}
+
+	if opts.CookbookID > 0 {
+		args = append(args, opts.CookbookID)
+	}
+
+	rows, err := s.DB.QueryContext(ctx, statements.BuildSelectPaginatedResults(opts), args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var recipes models.Recipes
+	for rows.Next() {
+		var (
+			r        models.Recipe
+			img      uuid.UUID
+			count    int64
+			keywords sql.NullString
+		)
+		err = rows.Scan(&r.ID, &r.Name, &r.Description, &img, &r.CreatedAt, &r.Category, &keywords, &count)
+		if err != nil {
+			return models.Recipes{}, 0, err
+		}
+
+		if img != uuid.Nil {
+			r.Images = []uuid.UUID{img}
+		}
+
+		if keywords.Valid && keywords.String != "" {
+			xk := strings.Split(keywords.String, ",")
+			slices.Sort(xk)
+			r.Keywords = xk
+		}
+
+		recipes = append(recipes, r)
+	}
+
+	var totalCount uint64
+	err = s.DB.QueryRowContext(ctx, statements.BuildSelectSearchResultsCount(opts), args...).Scan(&totalCount)
+	return recipes, totalCount, err
+}
+
+// SearchRecipesPantry searches for the recipes using ingredients of the user's pantry, ranked by how
+// many of their ingredients the pantry covers. It returns the paginated matches and the total number of matches.
+func (s *SQLiteService) SearchRecipesPantry(opts models.SearchOptionsRecipes, userID int64) ([]models.PantryMatch, uint64, error) {
+	items, err := s.PantryItems(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	pantry := models.Pantry(items)
+
+	match := pantry.SearchTerms()
+	if match == "" {
+		return make([]models.PantryMatch, 0), 0, nil
+	}
+
+	if opts.Query != "" {
+		match += " AND " + opts.Query + "*"
+	}
+
+	if arg := opts.Arg(); arg != "" {
+		match += " AND " + arg
+	}
+
+	args := []any{userID, match}
+	if opts.CookbookID > 0 {
+		args = append(args, opts.CookbookID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), longerCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, statements.BuildSelectRecipesPantry(opts), args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var recipes models.Recipes
+	for rows.Next() {
+		var (
+			r           models.Recipe
+			img         uuid.UUID
+			keywords    sql.NullString
+			ingredients sql.NullString
+		)
+
+		err = rows.Scan(&r.ID, &r.Name, &r.Description, &img, &r.CreatedAt, &r.Category, &keywords, &ingredients)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if img != uuid.Nil {
+			r.Images = []uuid.UUID{img}
+		}
+
+		if keywords.Valid && keywords.String != "" {
+			xk := strings.Split(keywords.String, ",")
+			slices.Sort(xk)
+			r.Keywords = xk
+		}
+
+		if ingredients.Valid && ingredients.String != "" {
+			r.Ingredients = strings.Split(ingredients.String, "<!---->")
+		}
+
+		recipes = append(recipes, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	matches := pantry.Rank(recipes)
+	total := uint64(len(matches))
+	start := min((max(opts.Page, 1)-1)*templates.ResultsPerPage, total)
+	end := min(start+templates.ResultsPerPage, total)
+	return matches[start:end], total, nil
+}
+
+// ShoppingList gets one of the user's shopping lists along with its items.
+func (s *SQLiteService) ShoppingList(id, userID int64) (models.ShoppingList, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var list models.ShoppingList
+	err := s.DB.QueryRowContext(ctx, statements.SelectShoppingList, id, userID).Scan(&list.ID, &list.Name, &list.CreatedAt)
+	if err != nil {
+		return models.ShoppingList{}, err
+	}
+
+	err = s.selectShoppingListContent(ctx, &list)
+	if err != nil {
+		return models.ShoppingList{}, err
+	}
+	return list, nil
+}
+
+// ShoppingLists gets the user's shopping lists along with their items.
+func (s *SQLiteService) ShoppingLists(userID int64) ([]models.ShoppingList, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, statements.SelectShoppingLists, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lists := make([]models.ShoppingList, 0)
+	for rows.Next() {
+		var list models.ShoppingList
+		err = rows.Scan(&list.ID, &list.Name, &list.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, list)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range lists {
+		err = s.selectShoppingListContent(ctx, &lists[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return lists, nil
+}
+
+func (s *SQLiteService) selectShoppingListContent(ctx context.Context, list *models.ShoppingList) error {
+	rows, err := s.DB.QueryContext(ctx, statements.SelectShoppingListItems, list.ID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	list.Items = make([]models.ShoppingListItem, 0)
+	for rows.Next() {
+		var (
+			item  models.ShoppingListItem
+			aisle string
+			unit  string
+		)
+
+		err = rows.Scan(&item.ID, &item.Name, &aisle, &item.Quantity, &unit, &item.IsChecked)
+		if err != nil {
+			return err
+		}
+
+		item.Aisle = models.Aisle(aisle)
+		item.Unit = units.NewUnit(unit)
+		list.Items = append(list.Items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	recipeRows, err := s.DB.QueryContext(ctx, statements.SelectShoppingListRecipes, list.ID)
+	if err != nil {
+		return err
+	}
+	defer recipeRows.Close()
+
+	list.Recipes = make([]models.ShoppingListRecipe, 0)
+	for recipeRows.Next() {
+		var r models.ShoppingListRecipe
+		err = recipeRows.Scan(&r.RecipeID, &r.Name, &r.Yield)
+		if err != nil {
+			return err
+		}
+		list.Recipes = append(list.Recipes, r)
+	}
+	return recipeRows.Err()
+}
+
+func scanRecipes(rows *sql.Rows, isSearch bool) (models.Recipes, error) {
+	defer rows.Close()
+
+	var recipes models.Recipes
+	for rows.Next() {
+		r, err := scanRecipe(rows, isSearch)
+		if err != nil {
+			return nil, err
+		}
+		recipes = append(recipes, *r)
+	}
+
+	return recipes, rows.Err()
+}
+
+// scanCookLog scans the cooks of recipes.
+func scanCookLog(rows *sql.Rows) (models.CookLog, error) {
+	defer rows.Close()
+
+	cooks := make(models.CookLog, 0)
+	for rows.Next() {
+		var (
+			e        models.CookLogEntry
+			cookedAt string
+		)
+
+		err := rows.Scan(&e.ID, &e.RecipeID, &cookedAt, &e.Rating, &e.Notes)
+		if err != nil {
+			return nil, err
+		}
+
+		e.CookedAt, err = time.Parse(time.DateOnly, cookedAt)
+		if err != nil {
+			return nil, err
+		}
+		cooks = append(cooks, e)
+	}
+
+	return cooks, rows.Err()
+}
+
+// scanRecipeNotes scans the private notes of recipes.
+func scanRecipeNotes(rows *sql.Rows) (models.RecipeNotes, error) {
+	defer rows.Close()
+
+	notes := make(models.RecipeNotes, 0)
+	for rows.Next() {
+		var n models.RecipeNote
+		err := rows.Scan(&n.ID, &n.RecipeID, &n.Anchor, &n.Index, &n.Text)
+		if err != nil {
+			return nil, err
+		}
+		notes = append(notes, n)
+	}
+
+	return notes, rows.Err()
+}
+
+// scanRecipeRevisions scans the revisions of recipes along with their snapshot.
+func scanRecipeRevisions(rows *sql.Rows) ([]models.RecipeRevision, error) {
+	defer rows.Close()
+
+	revisions := make([]models.RecipeRevision, 0)
+	for rows.Next() {
+		var (
+			rev  models.RecipeRevision
+			data string
+		)
+
+		err := rows.Scan(&rev.ID, &rev.RecipeID, &rev.ReplacedBy, &data, &rev.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
 
-	if opts.CookbookID > 0 {
-		args = append(args, opts.CookbookID)
+		err = json.Unmarshal([]byte(data), &rev.Recipe)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, rev)
 	}
 
-	rows, err := s.DB.QueryContext(ctx, statements.BuildSelectPaginatedResults(opts), args...)
-	if err != nil {
-		return nil, 0, err
-	}
+	return revisions, rows.Err()
+}
+
+// scanRecipeSections scans the section headings and assigns them to their recipe.
+func scanRecipeSections(rows *sql.Rows, recipes map[int64]*models.Recipe) error {
+	defer rows.Close()
 
-	var recipes models.Recipes
 	for rows.Next() {
 		var (
-			r        models.Recipe
-			img      uuid.UUID
-			count    int64
-			keywords sql.NullString
+			recipeID int64
+			kind     string
+			sect     models.Section
 		)
-		err = rows.Scan(&r.ID, &r.Name, &r.Description, &img, &r.CreatedAt, &r.Category, &keywords, &count)
+
+		err := rows.Scan(&recipeID, &kind, &sect.Index, &sect.Name)
 		if err != nil {
-			return models.Recipes{}, 0, err
+			return err
 		}
 
-		if img != uuid.Nil {
-			r.Images = []uuid.UUID{img}
+		r, ok := recipes[recipeID]
+		if !ok {
+			continue
 		}
 
-		if keywords.Valid && keywords.String != "" {
-			xk := strings.Split(keywords.String, ",")
-			slices.Sort(xk)
-			r.Keywords = xk
+		switch kind {
+		case sectionsIngredients:
+			r.IngredientSections = append(r.IngredientSections, sect)
+		case sectionsInstructions:
+			r.InstructionSections = append(r.InstructionSections, sect)
 		}
-
-		recipes = append(recipes, r)
 	}
 
-	var totalCount uint64
-	err = s.DB.QueryRowContext(ctx, statements.BuildSelectSearchResultsCount(opts), args...).Scan(&totalCount)
-	return recipes, totalCount, err
+	return rows.Err()
 }
 
-func scanRecipes(rows *sql.Rows, isSearch bool) (models.Recipes, error) {
+// scanRecipeIngredients scans the parsed parts of the ingredients of the recipes. The ingredients
+// stored before they were parsed on save are parsed on the fly.
+func scanRecipeIngredients(rows *sql.Rows, recipes map[int64]*models.Recipe) error {
 	defer rows.Close()
 
-	var recipes models.Recipes
+	parsed := make(map[int64][]models.Ingredient, len(recipes))
 	for rows.Next() {
-		r, err := scanRecipe(rows, isSearch)
+		var (
+			recipeID int64
+			in       models.Ingredient
+			unit     string
+			isParsed bool
+		)
+
+		err := rows.Scan(&recipeID, &in.Text, &in.Quantity, &in.QuantityMax, &unit, &in.Name, &in.Note, &in.IsOptional, &in.RecipeID, &isParsed)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		recipes = append(recipes, *r)
+
+		if !isParsed {
+			continue
+		}
+		in.Unit = units.NewUnit(unit)
+		parsed[recipeID] = append(parsed[recipeID], in)
 	}
 
-	return recipes, rows.Err()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for id, r := range recipes {
+		r.StructuredIngredients = models.NewIngredients(r.Ingredients, parsed[id])
+	}
+	return nil
 }
 
 type scanner interface {
@@ -1677,7 +2958,7 @@ func scanRecipe(sc scanner, isSearch bool) (*models.Recipe, error) {
 		}
 	} else {
 		err = sc.Scan(
-			&r.ID, &r.Name, &r.Description, &mainImage, &otherImagesStr, &r.URL, &r.Yield, &r.CreatedAt, &r.UpdatedAt, &r.Category, &r.Cuisine,
+			&r.ID, &r.Name, &r.Description, &mainImage, &otherImagesStr, &r.URL, &r.Yield, &r.YieldUnit, &r.CreatedAt, &r.UpdatedAt, &r.Category, &r.Cuisine,
 			&ingredients, &instructions, &keywords, &tools, &r.Nutrition.Calories, &r.Nutrition.TotalCarbohydrates,
 			&r.Nutrition.Sugars, &r.Nutrition.Protein, &r.Nutrition.TotalFat, &r.Nutrition.SaturatedFat, &r.Nutrition.UnsaturatedFat, &transFat,
 			&r.Nutrition.Cholesterol, &r.Nutrition.Sodium, &r.Nutrition.Fiber, &isPerServing, &r.Times.Prep, &r.Times.Cook, &r.Times.Total,
@@ -1779,6 +3060,28 @@ func scanRecipe(sc scanner, isSearch bool) (*models.Recipe, error) {
 	return &r, err
 }
 
+func insertShoppingListContent(ctx context.Context, tx *sql.Tx, list models.ShoppingList) error {
+	for _, r := range list.Recipes {
+		_, err := tx.ExecContext(ctx, statements.InsertShoppingListRecipe, list.ID, r.RecipeID, r.Name, r.Yield)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, item := range list.Items {
+		var unit string
+		if item.Unit != units.Invalid {
+			unit = item.Unit.String()
+		}
+
+		_, err := tx.ExecContext(ctx, statements.InsertShoppingListItem, list.ID, item.Name, item.Aisle, item.Quantity, unit, item.IsChecked)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // SwitchMeasurementSystem sets the user's units system to the desired one.
 func (s *SQLiteService) SwitchMeasurementSystem(system units.System, userID int64) error {
 	s.Mutex.Lock()
@@ -1869,6 +3172,67 @@ func (s *SQLiteService) UpdateCookbookImage(id int64, image uuid.UUID, userID in
 	return err
 }
 
+// UpdateMealPlanEntry moves a planned recipe to another day or slot and updates its servings.
+func (s *SQLiteService) UpdateMealPlanEntry(entry models.MealPlanEntry, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	res, err := s.DB.ExecContext(ctx, statements.UpdateMealPlanEntry, entry.Date.Format(time.DateOnly), entry.Slot, entry.Servings, entry.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// UpdateNutritionPin overrides the food or the weight matched for an ingredient of the user's recipe,
+// then recalculates the contribution of its ingredients. The recipe's nutrition facts are recalculated
+// only when the user enabled their calculation. An empty pin restores the automatic match.
+func (s *SQLiteService) UpdateNutritionPin(recipeID int64, pin models.NutritionPin, userID int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var isOwner bool
+	err := s.DB.QueryRowContext(ctx, statements.SelectRecipeUserExist, recipeID, userID).Scan(&isOwner)
+	if err != nil {
+		return err
+	} else if !isOwner {
+		return sql.ErrNoRows
+	}
+
+	s.Mutex.Lock()
+	ingredient := models.NutritionPinKey(pin.Ingredient)
+	if pin.IsEmpty() {
+		_, err = s.DB.ExecContext(ctx, statements.DeleteNutritionPin, recipeID, ingredient)
+	} else {
+		_, err = s.DB.ExecContext(ctx, statements.InsertNutritionPin, recipeID, ingredient, pin.FdcID, pin.Grams)
+	}
+	s.Mutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	settings, err := s.UserSettings(userID)
+	if err != nil {
+		return err
+	}
+
+	recipe, err := s.Recipe(recipeID, userID)
+	if err != nil {
+		return err
+	}
+	return s.storeRecipeNutrition(recipe, userID, settings.CalculateNutritionFact)
+}
+
 // UpdatePassword updates the user's password.
 func (s *SQLiteService) UpdatePassword(userID int64, password auth.HashedPassword) error {
 	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
@@ -1882,6 +3246,7 @@ func (s *SQLiteService) UpdatePassword(userID int64, password auth.HashedPasswor
 }
 
 // UpdateRecipe updates the recipe with its new values.
+// It fails with models.ErrConflict when the recipe was updated after updatedRecipe.UpdatedAt, unless it is zero.
 func (s *SQLiteService) UpdateRecipe(updatedRecipe *models.Recipe, userID int64, recipeNum int64) error {
 	oldRecipe, err := s.Recipe(recipeNum, userID)
 	if err != nil {
@@ -1902,6 +3267,30 @@ func (s *SQLiteService) UpdateRecipe(updatedRecipe *models.Recipe, userID int64,
 
 	recipeID := oldRecipe.ID
 
+	if !updatedRecipe.UpdatedAt.IsZero() {
+		var updatedAt time.Time
+		err = tx.QueryRowContext(ctx, statements.SelectRecipeUpdatedAt, recipeID).Scan(&updatedAt)
+		if err != nil {
+			return err
+		}
+
+		if !updatedAt.Equal(updatedRecipe.UpdatedAt) {
+			return models.ErrConflict
+		}
+	}
+
+	if oldRecipe.IsRevisedBy(updatedRecipe) {
+		snapshot, err := json.Marshal(oldRecipe)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, statements.InsertRecipeRevision, recipeID, userID, string(snapshot))
+		if err != nil {
+			return err
+		}
+	}
+
 	if updatedRecipe.Category != oldRecipe.Category {
 		if updatedRecipe.Category == "" {
 			updatedRecipe.Category = "uncategorized"
@@ -1937,8 +3326,14 @@ func (s *SQLiteService) UpdateRecipe(updatedRecipe *models.Recipe, userID int64,
 	}
 
 	isIngredientsUpdated := !slices.Equal(updatedRecipe.Ingredients, oldRecipe.Ingredients)
-	if isIngredientsUpdated {
-		updatedRecipe.Ingredients = slices.DeleteFunc(extensions.Unique(updatedRecipe.Ingredients), func(s string) bool { return s == "" })
+	if updatedRecipe.StructuredIngredients == nil {
+		updatedRecipe.StructuredIngredients = oldRecipe.StructuredIngredients
+	}
+
+	isIngredientSectionsUpdated := !slices.Equal(updatedRecipe.IngredientSections, oldRecipe.IngredientSections)
+
+	if isIngredientsUpdated || isIngredientSectionsUpdated || !slices.Equal(updatedRecipe.StructuredIngredients, oldRecipe.StructuredIngredients) {
+		updatedRecipe.Ingredients, updatedRecipe.IngredientSections = updatedRecipe.IngredientSections.Clean(updatedRecipe.Ingredients)
 
 		if len(updatedRecipe.Ingredients) == 0 {
 			return errors.New("missing ingredients")
@@ -1959,16 +3354,27 @@ func (s *SQLiteService) UpdateRecipe(updatedRecipe *models.Recipe, userID int64,
 			return err
 		}
 
+		structured := models.NewIngredients(updatedRecipe.Ingredients, updatedRecipe.StructuredIngredients)
 		for i, id := range ids {
-			_, err = tx.ExecContext(ctx, statements.InsertRecipeIngredient, id, recipeID, i)
+			_, err = tx.ExecContext(ctx, statements.InsertRecipeIngredient, recipeIngredientArgs(id, recipeID, i, structured[i])...)
 			if err != nil {
 				return err
 			}
 		}
+
+		err = replaceRecipeSections(ctx, tx, recipeID, sectionsIngredients, updatedRecipe.IngredientSections)
+		if err != nil {
+			return err
+		}
+
+		err = remapRecipeNotes(ctx, tx, recipeID, models.NoteAnchorIngredient, oldRecipe.Ingredients, updatedRecipe.Ingredients)
+		if err != nil {
+			return err
+		}
 	}
 
-	if !slices.Equal(updatedRecipe.Instructions, oldRecipe.Instructions) {
-		updatedRecipe.Instructions = slices.DeleteFunc(extensions.Unique(updatedRecipe.Instructions), func(s string) bool { return s == "" })
+	if !slices.Equal(updatedRecipe.Instructions, oldRecipe.Instructions) || !slices.Equal(updatedRecipe.InstructionSections, oldRecipe.InstructionSections) {
+		updatedRecipe.Instructions, updatedRecipe.InstructionSections = updatedRecipe.InstructionSections.Clean(updatedRecipe.Instructions)
 
 		if len(updatedRecipe.Instructions) == 0 {
 			return errors.New("missing instructions")
@@ -1995,6 +3401,16 @@ func (s *SQLiteService) UpdateRecipe(updatedRecipe *models.Recipe, userID int64,
 				return err
 			}
 		}
+
+		err = replaceRecipeSections(ctx, tx, recipeID, sectionsInstructions, updatedRecipe.InstructionSections)
+		if err != nil {
+			return err
+		}
+
+		err = remapRecipeNotes(ctx, tx, recipeID, models.NoteAnchorInstruction, oldRecipe.Instructions, updatedRecipe.Instructions)
+		if err != nil {
+			return err
+		}
 	}
 
 	if !slices.Equal(updatedRecipe.Keywords, oldRecipe.Keywords) {
@@ -2124,7 +3540,11 @@ func (s *SQLiteService) UpdateRecipe(updatedRecipe *models.Recipe, userID int64,
 		updateFields["yield"] = updatedRecipe.Yield
 	}
 
-	fields := []string{"name", "description", "image", "yield", "url"}
+	if updatedRecipe.YieldUnit != oldRecipe.YieldUnit {
+		updateFields["yield_unit"] = updatedRecipe.YieldUnit
+	}
+
+	fields := []string{"name", "description", "image", "yield", "yield_unit", "url"}
 	for _, field := range fields {
 		if _, ok := updateFields[field]; ok {
 			var xs []string
@@ -2247,6 +3667,98 @@ func (s *SQLiteService) UpdateRecipe(updatedRecipe *models.Recipe, userID int64,
 	return nil
 }
 
+// UpdateRecipeParent makes the user's recipe a variant of the parent recipe. A parentID of 0 detaches it.
+func (s *SQLiteService) UpdateRecipeParent(recipeID, parentID, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	var parent sql.NullInt64
+	if parentID > 0 {
+		parent = sql.NullInt64{Int64: parentID, Valid: true}
+	}
+
+	res, err := s.DB.ExecContext(ctx, statements.UpdateRecipeParent, parent, recipeID, userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// UpdateShoppingList renames the shopping list and replaces its items and recipes.
+func (s *SQLiteService) UpdateShoppingList(list models.ShoppingList, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, statements.UpdateShoppingList, list.Name, list.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	_, err = tx.ExecContext(ctx, statements.DeleteShoppingListItems, list.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, statements.DeleteShoppingListRecipes, list.ID)
+	if err != nil {
+		return err
+	}
+
+	err = insertShoppingListContent(ctx, tx, list)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UpdateShoppingListItem checks or unchecks an item of one of the user's shopping lists.
+func (s *SQLiteService) UpdateShoppingListItem(id, listID int64, isChecked bool, userID int64) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	res, err := s.DB.ExecContext(ctx, statements.UpdateShoppingListItemChecked, isChecked, id, listID, userID)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
 // UpdateUserSettingsCookbooksViewMode updates the user's preferred cookbooks viewing mode.
 func (s *SQLiteService) UpdateUserSettingsCookbooksViewMode(userID int64, mode models.ViewMode) error {
 	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
@@ -2271,6 +3783,18 @@ func (s *SQLiteService) UpdateVideo(video uuid.UUID, durationSecs int) error {
 	return err
 }
 
+// UpdateWebhookDelivery records the outcome of the latest delivery to the webhook.
+func (s *SQLiteService) UpdateWebhookDelivery(id int64, delivery models.WebhookDelivery) error {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	_, err := s.DB.ExecContext(ctx, statements.UpdateWebhookDelivery, delivery.Attempts, delivery.StatusCode, delivery.Error, delivery.DeliveredAt.UTC(), id)
+	return err
+}
+
 // UserID gets the user's id from the email. It returns -1 if user not found.
 func (s *SQLiteService) UserID(email string) int64 {
 	_, err := mail.ParseAddress(email)
@@ -2370,6 +3894,54 @@ func (s *SQLiteService) VerifyLogin(email, password string) int64 {
 	return id
 }
 
+// Webhooks gets the webhooks registered by the user.
+func (s *SQLiteService) Webhooks(userID int64) ([]models.Webhook, error) {
+	return s.queryWebhooks(statements.SelectWebhooks, userID)
+}
+
+// WebhooksForEvent gets the user's webhooks subscribed to the event along with the global ones.
+func (s *SQLiteService) WebhooksForEvent(event models.WebhookEvent, userID int64) ([]models.Webhook, error) {
+	webhooks, err := s.queryWebhooks(statements.SelectWebhooksForEvent, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(webhooks, func(w models.Webhook) bool {
+		return !w.IsSubscribed(event)
+	}), nil
+}
+
+func (s *SQLiteService) queryWebhooks(query string, userID int64) ([]models.Webhook, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := make([]models.Webhook, 0)
+	for rows.Next() {
+		var (
+			w           models.Webhook
+			events      string
+			deliveredAt sql.NullTime
+		)
+
+		err = rows.Scan(&w.ID, &w.UserID, &w.URL, &w.Secret, &events, &w.IsGlobal, &w.LastDelivery.Attempts, &w.LastDelivery.StatusCode, &w.LastDelivery.Error, &deliveredAt, &w.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		w.Events = models.NewWebhookEvents(events)
+		w.LastDelivery.DeliveredAt = deliveredAt.Time
+		webhooks = append(webhooks, w)
+	}
+
+	return webhooks, rows.Err()
+}
+
 // Websites gets the list of supported websites from which to extract the recipe.
 func (s *SQLiteService) Websites() models.Websites {
 	ctx, cancel := context.WithTimeout(context.Background(), shortCtxTimeout)
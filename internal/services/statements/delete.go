@@ -1,5 +1,12 @@
 package statements
 
+// DeleteAPIToken revokes a user's personal API access token.
+const DeleteAPIToken = `
+	DELETE
+	FROM api_tokens
+	WHERE id = ?
+	  AND user_id = ?`
+
 // DeleteAuthToken removes the authentication token associated with the user id from the database.
 const DeleteAuthToken = `
 	DELETE
@@ -26,6 +33,41 @@ const DeleteCookbooks = `
 	FROM cookbooks
 	WHERE user_id = ?`
 
+// DeleteCookLogEntry is the query to remove a cook from the user's cook log.
+const DeleteCookLogEntry = `
+	DELETE
+	FROM cook_log
+	WHERE id = ?
+	  AND recipe_id = ?
+	  AND user_id = ?`
+
+// DeleteIngredientsNutrition is the query to delete the nutrition breakdown of a recipe's ingredients.
+const DeleteIngredientsNutrition = `
+	DELETE
+	FROM ingredient_nutrition
+	WHERE recipe_id = ?`
+
+// DeleteMealPlanEntry is the query to remove a recipe from the user's meal plan.
+const DeleteMealPlanEntry = `
+	DELETE
+	FROM meal_plan_entries
+	WHERE id = ?
+	  AND user_id = ?`
+
+// DeleteNutritionPin is the query to remove the nutrition override of an ingredient of a recipe.
+const DeleteNutritionPin = `
+	DELETE
+	FROM nutrition_pins
+	WHERE recipe_id = ?
+	  AND ingredient = ?`
+
+// DeletePantryItem is the query to remove an item from the user's pantry.
+const DeletePantryItem = `
+	DELETE
+	FROM pantry_items
+	WHERE id = ?
+	  AND user_id = ?`
+
 // DeleteRecipe deletes a user's recipe and the recipe itself.
 const DeleteRecipe = `
 	DELETE
@@ -35,6 +77,14 @@ const DeleteRecipe = `
 						WHERE user_id = ?
 							AND recipe_id = ?)`
 
+// DeleteRecipeNote is the query to remove a private note from the user's recipe.
+const DeleteRecipeNote = `
+	DELETE
+	FROM recipe_notes
+	WHERE id = ?
+	  AND recipe_id = ?
+	  AND user_id = ?`
+
 // DeleteRecipeIngredients deletes all ingredients from a recipe.
 const DeleteRecipeIngredients = `
 	DELETE
@@ -59,6 +109,13 @@ const DeleteRecipeKeywords = `
 	FROM keyword_recipe
 	WHERE recipe_id = ?`
 
+// DeleteRecipeSections deletes the sections of either the ingredients or the instructions of a recipe.
+const DeleteRecipeSections = `
+	DELETE
+	FROM recipe_sections
+	WHERE recipe_id = ?
+	  AND kind = ?`
+
 // DeleteRecipeTools deletes all tools associated with a recipe.
 const DeleteRecipeTools = `
 	DELETE
@@ -73,6 +130,25 @@ const DeleteRecipesUser = `
 				 FROM user_recipe
 				 WHERE user_id = ?)`
 
+// DeleteShoppingList is the query to delete one of the user's shopping lists.
+const DeleteShoppingList = `
+	DELETE
+	FROM shopping_lists
+	WHERE id = ?
+	  AND user_id = ?`
+
+// DeleteShoppingListItems is the query to delete the items of a shopping list.
+const DeleteShoppingListItems = `
+	DELETE
+	FROM shopping_list_items
+	WHERE shopping_list_id = ?`
+
+// DeleteShoppingListRecipes is the query to delete the recipes a shopping list was generated from.
+const DeleteShoppingListRecipes = `
+	DELETE
+	FROM shopping_list_recipes
+	WHERE shopping_list_id = ?`
+
 // DeleteUser deletes a user from the users table.
 const DeleteUser = `
 	DELETE
@@ -87,6 +163,13 @@ const DeleteUserCategory = `
 	  AND category_id = (SELECT id FROM categories WHERE name = ?)
 	RETURNING category_id`
 
+// DeleteWebhook is the query to remove one of the user's webhooks.
+const DeleteWebhook = `
+	DELETE
+	FROM webhooks
+	WHERE id = ?
+	  AND user_id = ?`
+
 // DeleteRecipeVideos deletes a recipe's user-uploaded videos.
 const DeleteRecipeVideos = `
 	DELETE
@@ -5,6 +5,12 @@ const InsertAdditionalImageRecipe = `
 	INSERT INTO additional_images_recipe (recipe_id, image)
 	VALUES (?, ?)`
 
+// InsertAPIToken is the query to add a personal API access token to the database.
+const InsertAPIToken = `
+	INSERT INTO api_tokens (user_id, name, selector, hash_validator, scopes, expires_at)
+	VALUES (?, ?, ?, ?, ?, ?)
+	RETURNING id, created_at`
+
 // InsertAuthToken is the query to add an authentication token to the database.
 const InsertAuthToken = `
 	INSERT INTO auth_tokens (selector, hash_validator, user_id)
@@ -32,6 +38,12 @@ const InsertCookbookRecipe = `
 				   WHERE c.id = ?
 					 AND c.user_id = ?))`
 
+// InsertCookLogEntry is the query to log a cook of a recipe.
+const InsertCookLogEntry = `
+	INSERT INTO cook_log (recipe_id, user_id, cooked_at, rating, notes)
+	VALUES (?, ?, ?, ?, ?)
+	RETURNING id`
+
 // InsertCuisine is the query to add a cuisine to the database
 const InsertCuisine = `
 	INSERT OR IGNORE INTO cuisines (name)
@@ -44,6 +56,11 @@ const InsertIngredient = `
 	ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
 	RETURNING id`
 
+// InsertIngredientNutrition is the query to add the contribution of an ingredient to its recipe's nutrition.
+const InsertIngredientNutrition = `
+	INSERT INTO ingredient_nutrition (recipe_id, position, ingredient, fdc_id, food, grams, calories, carbohydrates, fat, protein, is_pinned)
+	VALUES (?, ?, ?, NULLIF(?, 0), ?, ?, ?, ?, ?, ?, ?)`
+
 // InsertInstruction is the query to add an instruction.
 const InsertInstruction = `
 	INSERT INTO instructions (name)
@@ -60,15 +77,37 @@ const InsertKeyword = `
 		DO UPDATE SET name = EXCLUDED.name
 	RETURNING id`
 
+// InsertMealPlanEntry is the query to plan one of the user's recipes for a meal.
+const InsertMealPlanEntry = `
+	INSERT INTO meal_plan_entries (user_id, recipe_id, date, slot, servings)
+	SELECT ur.user_id, ur.recipe_id, ?, ?, ?
+	FROM user_recipe AS ur
+	WHERE ur.user_id = ?
+	  AND ur.recipe_id = ?
+	RETURNING id`
+
 // InsertNutrition is the query to add a nutrition facts.
 const InsertNutrition = `
 	INSERT INTO nutrition (recipe_id, calories, total_carbohydrates, sugars, protein, total_fat, saturated_fat, unsaturated_fat, trans_fat, cholesterol, sodium, fiber, is_per_serving)
 	VALUES (?, trim(?), trim(?), trim(?), trim(?), trim(?), trim(?), trim(?), trim(?), trim(?), trim(?), trim(?), ?)`
 
+// InsertNutritionPin is the query to override the food or the weight matched for an ingredient of a recipe.
+const InsertNutritionPin = `
+	INSERT INTO nutrition_pins (recipe_id, ingredient, fdc_id, grams)
+	VALUES (?, ?, NULLIF(?, 0), NULLIF(?, 0))
+	ON CONFLICT (recipe_id, ingredient) DO UPDATE SET fdc_id = excluded.fdc_id,
+													  grams  = excluded.grams`
+
+// InsertPantryItem is the query to add an item to the user's pantry.
+const InsertPantryItem = `
+	INSERT INTO pantry_items (user_id, name, quantity, unit, expires_at)
+	VALUES (?, ?, ?, ?, ?)
+	RETURNING id`
+
 // InsertRecipe is the query to add a recipe to the database.
 const InsertRecipe = `
-	INSERT INTO recipes (name, description, image, yield, url)
-	VALUES (trim(?), trim(?), ?, ?, trim(?))
+	INSERT INTO recipes (name, description, image, yield, yield_unit, url)
+	VALUES (trim(?), trim(?), ?, ?, trim(?), trim(?))
 	RETURNING id`
 
 // InsertRecipeCategory associates a recipe with a category.
@@ -86,10 +125,10 @@ const InsertRecipeImage = `
 	INSERT INTO additional_images_recipe (recipe_id, image)
 	VALUES (?, ?)`
 
-// InsertRecipeIngredient is the query to associate a recipe with an ingredient.
+// InsertRecipeIngredient is the query to associate a recipe with an ingredient along with its parsed parts.
 const InsertRecipeIngredient = `
-	INSERT INTO ingredient_recipe (ingredient_id, recipe_id, ingredient_order)
-	VALUES (?, ?, ?)`
+	INSERT INTO ingredient_recipe (ingredient_id, recipe_id, ingredient_order, quantity, quantity_max, unit, name, note, is_optional, sub_recipe_id, is_parsed)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 1)`
 
 // InsertRecipeInstruction is the query to associate a recipe with an instruction.
 const InsertRecipeInstruction = `
@@ -102,6 +141,27 @@ const InsertRecipeKeyword = `
 	VALUES (?, ?)
 	ON CONFLICT (keyword_id, recipe_id) DO NOTHING`
 
+// InsertRecipeNote is the query to attach a private note to a recipe.
+const InsertRecipeNote = `
+	INSERT INTO recipe_notes (recipe_id, user_id, anchor, position, note)
+	VALUES (?, ?, ?, ?, ?)
+	RETURNING id`
+
+// InsertRecipeRevision is the query to snapshot a recipe before it is updated.
+const InsertRecipeRevision = `
+	INSERT INTO recipe_revisions (recipe_id, user_id, data)
+	VALUES (?, ?, ?)`
+
+// InsertRecipeRevisionRestore is the query to restore the revision of a recipe from a backup.
+const InsertRecipeRevisionRestore = `
+	INSERT INTO recipe_revisions (recipe_id, user_id, data, created_at)
+	VALUES (?, (SELECT id FROM users WHERE email = ?), ?, ?)`
+
+// InsertRecipeSection is the query to add a section heading to the ingredients or the instructions of a recipe.
+const InsertRecipeSection = `
+	INSERT INTO recipe_sections (recipe_id, kind, position, name)
+	VALUES (?, ?, ?, ?)`
+
 // InsertRecipeShadow is the query to insert a recipe into the shadow table.
 const InsertRecipeShadow = `
 	INSERT OR REPLACE INTO shadow_last_inserted_recipe (row, id, name, description, source)
@@ -140,6 +200,22 @@ const InsertShareLinkCookbook = `
 	VALUES (?, ?, ?)
 	ON CONFLICT (link, cookbook_id) DO NOTHING`
 
+// InsertShoppingList is the query to add a shopping list.
+const InsertShoppingList = `
+	INSERT INTO shopping_lists (user_id, name)
+	VALUES (?, trim(?))
+	RETURNING id, created_at`
+
+// InsertShoppingListItem is the query to add an item to a shopping list.
+const InsertShoppingListItem = `
+	INSERT INTO shopping_list_items (shopping_list_id, name, aisle, quantity, unit, is_checked)
+	VALUES (?, ?, ?, ?, ?, ?)`
+
+// InsertShoppingListRecipe is the query to record a recipe a shopping list was generated from.
+const InsertShoppingListRecipe = `
+	INSERT INTO shopping_list_recipes (shopping_list_id, recipe_id, name, yield)
+	VALUES (?, NULLIF(?, 0), ?, ?)`
+
 // InsertTimes is the query to add kitchen times.
 const InsertTimes = `
 	INSERT INTO times (prep_seconds, cook_seconds)
@@ -178,3 +254,9 @@ const InsertUserRecipe = `
 const InsertVideoRecipe = `
 	INSERT INTO video_recipe (video, recipe_id, content_url, embed_url)
 	VALUES (?, ?, trim(?), trim(?))`
+
+// InsertWebhook is the query to register a webhook.
+const InsertWebhook = `
+	INSERT INTO webhooks (user_id, url, secret, events, is_global)
+	VALUES (?, ?, ?, ?, ?)
+	RETURNING id, created_at`
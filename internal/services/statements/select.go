@@ -40,13 +40,19 @@ func buildSearchRecipeQuery(opts models.SearchOptionsRecipes) string {
 	var sb strings.Builder
 
 	sb.WriteString("SELECT recipe_id, name, description, image, created_at, category, keywords, row_num FROM (" + BuildBaseSelectRecipe(opts.Sort))
-	sb.WriteString(" WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ?")
+	if opts.Advanced.IsCollapseVariants {
+		sb.WriteString(" WHERE recipes.id IN (SELECT COALESCE(variants.parent_id, recipes_fts.id) FROM recipes_fts")
+		sb.WriteString(" LEFT JOIN recipes AS variants ON variants.id = recipes_fts.id WHERE user_id = ?")
+	} else {
+		sb.WriteString(" WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ?")
+	}
 
-	if opts.Query != "" || !opts.IsBasic() {
+	if opts.Query != "" || opts.Arg() != "" {
 		sb.WriteString(" AND recipes_fts MATCH ?")
 	}
 
 	sb.WriteString(" ORDER BY rank)")
+	sb.WriteString(buildCookFilter(opts.Advanced))
 	if opts.CookbookID > 0 {
 		sb.WriteString(" AND recipes.id NOT IN (SELECT recipe_id FROM cookbook_recipes WHERE cookbook_id = ?)")
 	}
@@ -54,6 +60,39 @@ func buildSearchRecipeQuery(opts models.SearchOptionsRecipes) string {
 	return sb.String()
 }
 
+// BuildSelectRecipesPantry builds the query to fetch the user's recipes matching the search,
+// along with the ingredients stored in the full-text search table.
+func BuildSelectRecipesPantry(opts models.SearchOptionsRecipes) string {
+	var sb strings.Builder
+	sb.WriteString("SELECT r.recipe_id, r.name, r.description, r.image, r.created_at, r.category, r.keywords, fts.ingredients FROM (")
+	sb.WriteString(baseSelectSearchRecipe)
+	sb.WriteString(" WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? AND recipes_fts MATCH ?)")
+	sb.WriteString(buildCookFilter(opts.Advanced))
+	if opts.CookbookID > 0 {
+		sb.WriteString(" AND recipes.id NOT IN (SELECT recipe_id FROM cookbook_recipes WHERE cookbook_id = ?)")
+	}
+	sb.WriteString(" GROUP BY recipes.id) AS r JOIN recipes_fts AS fts ON fts.id = r.recipe_id")
+	return sb.String()
+}
+
+// buildCookFilter builds the conditions restricting the search to the recipes the user never cooked
+// or did not cook recently.
+func buildCookFilter(a models.AdvancedSearch) string {
+	var sb strings.Builder
+	if a.IsNeverCooked {
+		sb.WriteString(" AND NOT EXISTS (SELECT 1 FROM cook_log AS cl WHERE " + cookLogOfRecipe + ")")
+	}
+
+	if a.NotCookedDays > 0 {
+		sb.WriteString(" AND NOT EXISTS (SELECT 1 FROM cook_log AS cl WHERE " + cookLogOfRecipe)
+		sb.WriteString(" AND cl.cooked_at >= date('now', '-" + strconv.Itoa(a.NotCookedDays) + " days'))")
+	}
+	return sb.String()
+}
+
+// cookLogOfRecipe matches the cooks of a recipe logged by the user who owns it.
+const cookLogOfRecipe = "cl.recipe_id = recipes.id AND cl.user_id = user_recipe.user_id"
+
 // BuildSelectNutrientFDC builds the query to fetch a nutrient from the FDC database.
 func BuildSelectNutrientFDC(ingredients []string) string {
 	var sb strings.Builder
@@ -68,6 +107,7 @@ func BuildSelectNutrientFDC(ingredients []string) string {
 
 	return `
 		SELECT food.fdc_id,
+			   food.description,
 			   nutrient.name,
 			   food_nutrient.amount,
 			   nutrient.unit_name
@@ -77,26 +117,32 @@ func BuildSelectNutrientFDC(ingredients []string) string {
 		WHERE food.fdc_id = (SELECT fdc_id
 							 FROM food
 							 WHERE ` + sb.String() + `
-								 AND ((data_type = 'sr_legacy_food' AND (food_category_id = 11 OR food_category_id = 2 OR food_category_id = 18)) OR data_type = 'survey_fndds_food' OR data_type = 'branded_food')
+								 AND ` + foodDataTypesFDC + `
 							 ORDER BY
 								 food_category_id DESC,
 								 data_type DESC,
 								 description ASC
 							 LIMIT 1)
-		  AND nutrient.name IN (
-								'Energy',
-								'Cholesterol',
-								'Carbohydrate, by difference',
-								'Fiber, total dietary',
-								'Protein',
-								'Fatty acids, total monounsaturated',
-								'Fatty acids, total polyunsaturated',
-								'Fatty acids, total trans',
-								'Fatty acids, total saturated',
-								'Sodium, Na',
-								'Sugars, total including NLEA')`
+		  AND nutrient.name IN ` + nutrientNamesFDC
 }
 
+// foodDataTypesFDC restricts the foods of the FDC database to the ones relevant to recipes.
+const foodDataTypesFDC = `((data_type = 'sr_legacy_food' AND (food_category_id = 11 OR food_category_id = 2 OR food_category_id = 18)) OR data_type = 'survey_fndds_food' OR data_type = 'branded_food')`
+
+// nutrientNamesFDC lists the nutrients of the FDC database used in the nutrition facts.
+const nutrientNamesFDC = `(
+	'Energy',
+	'Cholesterol',
+	'Carbohydrate, by difference',
+	'Fiber, total dietary',
+	'Protein',
+	'Fatty acids, total monounsaturated',
+	'Fatty acids, total polyunsaturated',
+	'Fatty acids, total trans',
+	'Fatty acids, total saturated',
+	'Sodium, Na',
+	'Sugars, total including NLEA')`
+
 // IsRecipeForUserExist checks whether the recipe belongs to the given user.
 const IsRecipeForUserExist = `
 	SELECT EXISTS(
@@ -111,6 +157,19 @@ const IsRecipeForUserExist = `
 									 	AND url = ?)
 			   )`
 
+// SelectAPIToken fetches a personal API access token by its selector.
+const SelectAPIToken = `
+	SELECT id, user_id, name, hash_validator, scopes, expires_at, last_used_at, created_at
+	FROM api_tokens
+	WHERE selector = ?`
+
+// SelectAPITokens fetches the user's personal API access tokens.
+const SelectAPITokens = `
+	SELECT id, user_id, name, scopes, expires_at, last_used_at, created_at
+	FROM api_tokens
+	WHERE user_id = ?
+	ORDER BY created_at DESC, id DESC`
+
 // SelectAppInfo fetches general information on the application.
 const SelectAppInfo = `
 	SELECT is_update_available, updated_at, update_last_checked_at
@@ -134,7 +193,7 @@ const SelectCategories = `
 
 // SelectCookbook gets a user's cookbook by cookbook ID.
 const SelectCookbook = `
-	SELECT c.id, c.title, c.image, c.count
+	SELECT c.id, c.title, c.image, c.count, c.updated_at
 	FROM cookbooks AS c
 	WHERE id = ?
 		AND user_id = ?`
@@ -188,12 +247,34 @@ const SelectCookbooksShared = `
 	FROM share_cookbooks
 	WHERE user_id = ?`
 
+// SelectCookbookUpdatedAt gets when the user's cookbook was last updated.
+const SelectCookbookUpdatedAt = `
+	SELECT updated_at
+	FROM cookbooks
+	WHERE id = ?
+		AND user_id = ?`
+
 // SelectCookbookUser gets the ID of the user who has the cookbook ID.
 const SelectCookbookUser = `
 	SELECT user_id
 	FROM cookbooks
 	WHERE id = ?`
 
+// SelectCookLog fetches the cooks of the user's recipe, the most recent first.
+const SelectCookLog = `
+	SELECT id, recipe_id, cooked_at, rating, notes
+	FROM cook_log
+	WHERE recipe_id = ?
+	  AND user_id = ?
+	ORDER BY cooked_at DESC, id DESC`
+
+// SelectCookLogUser fetches the cooks of all the user's recipes.
+const SelectCookLogUser = `
+	SELECT id, recipe_id, cooked_at, rating, notes
+	FROM cook_log
+	WHERE user_id = ?
+	ORDER BY recipe_id, cooked_at DESC, id DESC`
+
 // SelectCookbooks gets a limited number of cookbooks belonging to the user.
 var SelectCookbooks = `
 	SELECT id, image, title, count
@@ -218,6 +299,11 @@ const SelectCounts = `
 	FROM counts
 	WHERE user_id = ?`
 
+// SelectCountRecipesAll gets the number of recipes of every user.
+const SelectCountRecipesAll = `
+	SELECT COUNT(*)
+	FROM recipes`
+
 // SelectCountWebsites fetches the number of supported websites.
 const SelectCountWebsites = `
 	SELECT COUNT(id)
@@ -245,12 +331,38 @@ const SelectDistinctVideos = `
 	SELECT DISTINCT video
 	FROM video_recipe`
 
+// SelectFoodsFDC searches the foods of the FDC database whose description contains the query.
+const SelectFoodsFDC = `
+	SELECT fdc_id, description
+	FROM food
+	WHERE description LIKE '%' || ? || '%'
+		AND ` + foodDataTypesFDC + `
+	ORDER BY length(description), description
+	LIMIT 20`
+
+// SelectIngredientsNutrition fetches the contribution of every ingredient of the user's recipe to its nutrition.
+const SelectIngredientsNutrition = `
+	SELECT ingredient, fdc_id, food, grams, calories, carbohydrates, fat, protein, is_pinned
+	FROM ingredient_nutrition
+	WHERE recipe_id = ?
+		AND recipe_id IN (SELECT recipe_id FROM user_recipe WHERE user_id = ?)
+	ORDER BY position`
+
 // SelectKeywords fetches all keywords.
 const SelectKeywords = `
 	SELECT name
 	FROM keywords
 	ORDER BY name`
 
+// SelectMealPlan fetches the recipes planned by the user between two dates inclusively.
+const SelectMealPlan = `
+	SELECT mpe.id, mpe.date, mpe.slot, mpe.servings, r.id, r.name, CAST(ROUND(COALESCE(r.yield, 0)) AS INTEGER)
+	FROM meal_plan_entries AS mpe
+			 JOIN recipes AS r ON mpe.recipe_id = r.id
+	WHERE mpe.user_id = ?
+	  AND mpe.date BETWEEN ? AND ?
+	ORDER BY mpe.date, mpe.id`
+
 // SelectMeasurementSystems fetches the units systems along with the user's selected system and settings.
 const SelectMeasurementSystems = `
 	SELECT ms.name,
@@ -262,6 +374,32 @@ const SelectMeasurementSystems = `
 			 JOIN user_settings AS us ON measurement_system_id = ms.id
 	WHERE user_id = ?`
 
+// SelectNutrientsFDC fetches the nutrients of a food of the FDC database.
+const SelectNutrientsFDC = `
+	SELECT food.fdc_id,
+		   food.description,
+		   nutrient.name,
+		   food_nutrient.amount,
+		   nutrient.unit_name
+	FROM food_nutrient
+			 INNER JOIN food ON food_nutrient.fdc_id = food.fdc_id
+			 INNER JOIN nutrient ON food_nutrient.nutrient_id = nutrient.id
+	WHERE food.fdc_id = ?
+	  AND nutrient.name IN ` + nutrientNamesFDC
+
+// SelectNutritionPins fetches the nutrition overrides of the ingredients of a recipe.
+const SelectNutritionPins = `
+	SELECT ingredient, COALESCE(fdc_id, 0), COALESCE(grams, 0)
+	FROM nutrition_pins
+	WHERE recipe_id = ?`
+
+// SelectPantryItems fetches the items of the user's pantry, the ones expiring first at the top.
+const SelectPantryItems = `
+	SELECT id, name, quantity, unit, COALESCE(expires_at, '')
+	FROM pantry_items
+	WHERE user_id = ?
+	ORDER BY expires_at IS NULL, expires_at, name`
+
 // BuildBaseSelectRecipe builds from the options.
 func BuildBaseSelectRecipe(sorts models.Sort) string {
 	var s string
@@ -275,6 +413,12 @@ func BuildBaseSelectRecipe(sorts models.Sort) string {
 		s = "recipes.created_at ASC"
 	} else if sorts.IsRandom {
 		s = "RANDOM()"
+	} else if sorts.IsHighestRated {
+		s = "(SELECT AVG(NULLIF(cl.rating, 0)) FROM cook_log AS cl WHERE " + cookLogOfRecipe + ") DESC"
+	} else if sorts.IsRecentlyCooked {
+		s = "(SELECT MAX(cl.cooked_at) FROM cook_log AS cl WHERE " + cookLogOfRecipe + ") DESC"
+	} else if sorts.IsMostCooked {
+		s = "(SELECT COUNT(*) FROM cook_log AS cl WHERE " + cookLogOfRecipe + ") DESC"
 	} else {
 		return baseSelectSearchRecipe
 	}
@@ -299,6 +443,7 @@ const baseSelectRecipe = `
 					'')                             AS other_images,
 		   recipes.url                              AS url,
 		   recipes.yield                            AS yield,
+		   recipes.yield_unit                       AS yield_unit,
 		   recipes.created_at                       AS created_at,
 		   recipes.updated_at                       AS updated_at,
 		   categories.name                          AS category,
@@ -386,8 +531,76 @@ const SelectRecipe = baseSelectRecipe + `
 	INNER JOIN user_recipe AS ur ON ur.recipe_id = recipes.id
 	WHERE recipes.id = ?
 		AND ur.user_id = ?
+	GROUP BY recipes.id
 	LIMIT 1`
 
+// SelectRecipeIngredients fetches the parsed parts of the ingredients of a recipe.
+const SelectRecipeIngredients = `
+	SELECT ir.recipe_id,
+		   ingredients.name,
+		   ir.quantity,
+		   ir.quantity_max,
+		   ir.unit,
+		   ir.name,
+		   ir.note,
+		   ir.is_optional,
+		   COALESCE(ir.sub_recipe_id, 0),
+		   ir.is_parsed
+	FROM ingredient_recipe AS ir
+			 JOIN ingredients ON ingredients.id = ir.ingredient_id
+	WHERE ir.recipe_id = ?
+	ORDER BY ir.ingredient_order`
+
+// SelectRecipeNotes fetches the private notes of the user's recipe, the oldest first.
+const SelectRecipeNotes = `
+	SELECT id, recipe_id, anchor, position, note
+	FROM recipe_notes
+	WHERE recipe_id = ?
+	  AND user_id = ?
+	ORDER BY id`
+
+// SelectRecipeNotesAll fetches the private notes every user attached to the recipe.
+const SelectRecipeNotesAll = `
+	SELECT id, recipe_id, anchor, position, note
+	FROM recipe_notes
+	WHERE recipe_id = ?
+	ORDER BY id`
+
+// SelectRecipeParent fetches the recipe the user's recipe is a variant of.
+const SelectRecipeParent = `
+	SELECT p.id, p.name
+	FROM recipes AS r
+			 JOIN recipes AS p ON p.id = r.parent_id
+			 JOIN user_recipe AS ur ON ur.recipe_id = p.id
+	WHERE r.id = ?
+	  AND ur.user_id = ?`
+
+// SelectRecipeRevisions fetches the revisions of the user's recipe, from the most recent to the oldest.
+const SelectRecipeRevisions = `
+	SELECT rr.id, rr.recipe_id, COALESCE(u.email, ''), rr.data, rr.created_at
+	FROM recipe_revisions AS rr
+			 JOIN user_recipe AS ur ON ur.recipe_id = rr.recipe_id
+			 LEFT JOIN users AS u ON u.id = rr.user_id
+	WHERE rr.recipe_id = ?
+	  AND ur.user_id = ?
+	ORDER BY rr.id DESC`
+
+// SelectRecipeSections fetches the section headings of the ingredients and the instructions of a recipe.
+const SelectRecipeSections = `
+	SELECT recipe_id, kind, position, name
+	FROM recipe_sections
+	WHERE recipe_id = ?
+	ORDER BY kind, position`
+
+// SelectRecipeVariants fetches the variants of the user's recipe.
+const SelectRecipeVariants = `
+	SELECT r.id, r.name
+	FROM recipes AS r
+			 JOIN user_recipe AS ur ON ur.recipe_id = r.id
+	WHERE r.parent_id = ?
+	  AND ur.user_id = ?
+	ORDER BY r.name`
+
 // SelectRecipeWithSource fetches a user's recipe based on the source.
 const SelectRecipeWithSource = baseSelectRecipe + `
 	INNER JOIN user_recipe AS ur ON ur.recipe_id = recipes.id
@@ -400,6 +613,48 @@ const SelectRecipesAll = baseSelectRecipe + `
 	WHERE recipes.id IN (SELECT recipe_id FROM user_recipe WHERE user_id = ?)
 	GROUP BY recipes.id`
 
+// SelectRecipesIngredients fetches the parsed parts of the ingredients of all the user's recipes.
+const SelectRecipesIngredients = `
+	SELECT ir.recipe_id,
+		   ingredients.name,
+		   ir.quantity,
+		   ir.quantity_max,
+		   ir.unit,
+		   ir.name,
+		   ir.note,
+		   ir.is_optional,
+		   COALESCE(ir.sub_recipe_id, 0),
+		   ir.is_parsed
+	FROM ingredient_recipe AS ir
+			 JOIN ingredients ON ingredients.id = ir.ingredient_id
+			 JOIN user_recipe AS ur ON ur.recipe_id = ir.recipe_id
+	WHERE ur.user_id = ?
+	ORDER BY ir.recipe_id, ir.ingredient_order`
+
+// SelectRecipesNotes fetches the private notes of all the user's recipes.
+const SelectRecipesNotes = `
+	SELECT id, recipe_id, anchor, position, note
+	FROM recipe_notes
+	WHERE user_id = ?
+	ORDER BY recipe_id, id`
+
+// SelectRecipesParents fetches the recipe each variant of the user derives from.
+const SelectRecipesParents = `
+	SELECT r.id, r.parent_id
+	FROM recipes AS r
+			 JOIN user_recipe AS ur ON ur.recipe_id = r.id
+	WHERE ur.user_id = ?
+	  AND r.parent_id IS NOT NULL`
+
+// SelectRecipesRevisions fetches the revisions of all the user's recipes.
+const SelectRecipesRevisions = `
+	SELECT rr.id, rr.recipe_id, COALESCE(u.email, ''), rr.data, rr.created_at
+	FROM recipe_revisions AS rr
+			 JOIN user_recipe AS ur ON ur.recipe_id = rr.recipe_id
+			 LEFT JOIN users AS u ON u.id = rr.user_id
+	WHERE ur.user_id = ?
+	ORDER BY rr.recipe_id, rr.id DESC`
+
 // SelectRecipes fetches a chunk of the user's recipes.
 const SelectRecipes = `
 	WITh results AS (
@@ -410,6 +665,14 @@ const SelectRecipes = `
 		)
 	) SELECT * FROM results WHERE row_num BETWEEN (?-1)*` + templates.ResultsPerPageStr + `+1 AND (?-1)*` + templates.ResultsPerPageStr + `+` + templates.ResultsPerPageStr
 
+// SelectRecipesSections fetches the section headings of the ingredients and the instructions of all the user's recipes.
+const SelectRecipesSections = `
+	SELECT rs.recipe_id, rs.kind, rs.position, rs.name
+	FROM recipe_sections AS rs
+			 JOIN user_recipe AS ur ON ur.recipe_id = rs.recipe_id
+	WHERE ur.user_id = ?
+	ORDER BY rs.recipe_id, rs.kind, rs.position`
+
 // SelectRecipeShared checks whether the recipe is shared.
 const SelectRecipeShared = `
 	SELECT recipe_id, user_id
@@ -428,6 +691,12 @@ const SelectRecipesShared = `
 	FROM share_recipes
 	WHERE user_id = ?`
 
+// SelectRecipeUpdatedAt gets when the recipe was last updated.
+const SelectRecipeUpdatedAt = `
+	SELECT updated_at
+	FROM recipes
+	WHERE id = ?`
+
 // SelectRecipeUser fetches the user whose recipe belongs to.
 const SelectRecipeUser = `
 	SELECT user_id
@@ -463,6 +732,34 @@ LEFT JOIN
 WHERE r.report_type = ? AND r.user_id = ?
 GROUP BY r.id`
 
+const baseSelectShoppingList = `
+	SELECT id, name, created_at
+	FROM shopping_lists`
+
+// SelectShoppingList fetches one of the user's shopping lists.
+const SelectShoppingList = baseSelectShoppingList + `
+	WHERE id = ?
+	  AND user_id = ?`
+
+// SelectShoppingListItems fetches the items of a shopping list.
+const SelectShoppingListItems = `
+	SELECT id, name, aisle, quantity, unit, is_checked
+	FROM shopping_list_items
+	WHERE shopping_list_id = ?
+	ORDER BY id`
+
+// SelectShoppingListRecipes fetches the recipes a shopping list was generated from.
+const SelectShoppingListRecipes = `
+	SELECT COALESCE(recipe_id, 0), name, yield
+	FROM shopping_list_recipes
+	WHERE shopping_list_id = ?
+	ORDER BY id`
+
+// SelectShoppingLists fetches the user's shopping lists.
+const SelectShoppingLists = baseSelectShoppingList + `
+	WHERE user_id = ?
+	ORDER BY created_at DESC, id DESC`
+
 // SelectUserExist checks whether the user is present.
 const SelectUserExist = `
 	SELECT EXISTS(
@@ -514,6 +811,20 @@ const SelectUsers = `
 	FROM users
 	ORDER BY id`
 
+const baseSelectWebhook = `
+	SELECT id, user_id, url, secret, events, is_global, last_attempts, last_status_code, last_error, last_delivered_at, created_at
+	FROM webhooks`
+
+// SelectWebhooks fetches the webhooks registered by the user.
+const SelectWebhooks = baseSelectWebhook + `
+	WHERE user_id = ?
+	ORDER BY created_at DESC, id DESC`
+
+// SelectWebhooksForEvent fetches the user's webhooks along with the global ones. The events are filtered by the caller.
+const SelectWebhooksForEvent = baseSelectWebhook + `
+	WHERE user_id = ?
+	   OR is_global = 1`
+
 // SelectWebsites fetches all websites from the database.
 const SelectWebsites = `
 	SELECT id, host, url
@@ -47,6 +47,21 @@ func TestBuildBaseSelectRecipe(t *testing.T) {
 			in:   models.Sort{IsOldestToNewest: true},
 			want: "ROW_NUMBER() OVER (ORDER BY recipes.created_at ASC) AS row_num",
 		},
+		{
+			name: "highest rated",
+			in:   models.Sort{IsHighestRated: true},
+			want: "ROW_NUMBER() OVER (ORDER BY (SELECT AVG(NULLIF(cl.rating, 0)) FROM cook_log AS cl WHERE cl.recipe_id = recipes.id AND cl.user_id = user_recipe.user_id) DESC) AS row_num",
+		},
+		{
+			name: "recently cooked",
+			in:   models.Sort{IsRecentlyCooked: true},
+			want: "ROW_NUMBER() OVER (ORDER BY (SELECT MAX(cl.cooked_at) FROM cook_log AS cl WHERE cl.recipe_id = recipes.id AND cl.user_id = user_recipe.user_id) DESC) AS row_num",
+		},
+		{
+			name: "most cooked",
+			in:   models.Sort{IsMostCooked: true},
+			want: "ROW_NUMBER() OVER (ORDER BY (SELECT COUNT(*) FROM cook_log AS cl WHERE cl.recipe_id = recipes.id AND cl.user_id = user_recipe.user_id) DESC) AS row_num",
+		},
 		{
 			name: "default",
 			in:   models.Sort{IsDefault: true},
@@ -144,6 +159,23 @@ func TestSelectSearchRecipe(t *testing.T) {
 			},
 			want: "SELECT recipe_id, name, description, image, created_at, category, keywords, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? AND recipes_fts MATCH ? ORDER BY rank) GROUP BY recipes.id)",
 		},
+		{
+			name:    "never cooked",
+			options: models.SearchOptionsRecipes{Advanced: models.AdvancedSearch{IsNeverCooked: true}},
+			want:    "SELECT recipe_id, name, description, image, created_at, category, keywords, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? ORDER BY rank) AND NOT EXISTS (SELECT 1 FROM cook_log AS cl WHERE cl.recipe_id = recipes.id AND cl.user_id = user_recipe.user_id) GROUP BY recipes.id)",
+		},
+		{
+			name: "not cooked recently with advanced search",
+			options: models.SearchOptionsRecipes{
+				Advanced: models.AdvancedSearch{Category: "breakfast", NotCookedDays: 180},
+			},
+			want: "SELECT recipe_id, name, description, image, created_at, category, keywords, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT id FROM recipes_fts WHERE user_id = ? AND recipes_fts MATCH ? ORDER BY rank) AND NOT EXISTS (SELECT 1 FROM cook_log AS cl WHERE cl.recipe_id = recipes.id AND cl.user_id = user_recipe.user_id AND cl.cooked_at >= date('now', '-180 days')) GROUP BY recipes.id)",
+		},
+		{
+			name:    "variants collapsed under their parent",
+			options: models.SearchOptionsRecipes{Query: "pancakes", Advanced: models.AdvancedSearch{IsCollapseVariants: true}},
+			want:    "SELECT recipe_id, name, description, image, created_at, category, keywords, row_num FROM ( SELECT recipes.id AS recipe_id, recipes.name AS name, recipes.description AS description, recipes.image AS image, recipes.created_at AS created_at, categories.name AS category, GROUP_CONCAT(DISTINCT keywords.name) AS keywords, user_id, ROW_NUMBER() OVER (ORDER BY recipes.id) AS row_num FROM recipes LEFT JOIN category_recipe ON recipes.id = category_recipe.recipe_id LEFT JOIN categories ON category_recipe.category_id = categories.id LEFT JOIN keyword_recipe ON recipes.id = keyword_recipe.recipe_id LEFT JOIN keywords ON keyword_recipe.keyword_id = keywords.id LEFT JOIN user_recipe ON recipes.id = user_recipe.recipe_id WHERE recipes.id IN (SELECT COALESCE(variants.parent_id, recipes_fts.id) FROM recipes_fts LEFT JOIN recipes AS variants ON variants.id = recipes_fts.id WHERE user_id = ? AND recipes_fts MATCH ? ORDER BY rank) GROUP BY recipes.id)",
+		},
 		{
 			name:    "cookbook search",
 			options: models.SearchOptionsRecipes{Query: "choco", CookbookID: 1},
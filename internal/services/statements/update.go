@@ -1,5 +1,11 @@
 package statements
 
+// UpdateAPITokenLastUsed is the query to record when a personal API access token was last used.
+const UpdateAPITokenLastUsed = `
+	UPDATE api_tokens
+	SET last_used_at = CURRENT_TIMESTAMP
+	WHERE id = ?`
+
 // UpdateCalculateNutrition is the query to update the user's calculate nutrition setting.
 const UpdateCalculateNutrition = `
 	UPDATE user_settings
@@ -32,6 +38,15 @@ const UpdateIsConfirmed = `
 	SET is_confirmed = 1
 	WHERE id = ?`
 
+// UpdateMealPlanEntry is the query to move a planned recipe or change its servings.
+const UpdateMealPlanEntry = `
+	UPDATE meal_plan_entries
+	SET date     = ?,
+		slot     = ?,
+		servings = ?
+	WHERE id = ?
+	  AND user_id = ?`
+
 // UpdateMeasurementSystem is the query to update the user's preferred measurement system.
 const UpdateMeasurementSystem = `
 	UPDATE user_settings
@@ -96,12 +111,50 @@ const UpdateRecipeImage = `
 	SET image = '00000000-0000-0000-0000-000000000000'
 	WHERE id = ?`
 
+// UpdateRecipeNoteAnchor is the query to move a private note to another part of its recipe.
+const UpdateRecipeNoteAnchor = `
+	UPDATE recipe_notes
+	SET anchor   = ?,
+		position = ?
+	WHERE id = ?`
+
+// UpdateRecipeParent is the query to set the recipe the user's recipe is a variant of.
+const UpdateRecipeParent = `
+	UPDATE recipes
+	SET parent_id = ?
+	WHERE id = ?
+	  AND id IN (SELECT recipe_id FROM user_recipe WHERE user_id = ?)`
+
+// UpdateRecipeSubRecipe is the query to set the recipe an ingredient of a recipe refers to.
+const UpdateRecipeSubRecipe = `
+	UPDATE ingredient_recipe
+	SET sub_recipe_id = ?
+	WHERE recipe_id = ?
+	  AND ingredient_order = ?`
+
 // UpdateRecipeTimes is the query to update a recipe's times.
 const UpdateRecipeTimes = `
 	UPDATE time_recipe
 	SET time_id = ?
 	WHERE recipe_id = ?`
 
+// UpdateShoppingList is the query to rename one of the user's shopping lists.
+const UpdateShoppingList = `
+	UPDATE shopping_lists
+	SET name = trim(?)
+	WHERE id = ?
+	  AND user_id = ?`
+
+// UpdateShoppingListItemChecked is the query to check or uncheck an item of one of the user's shopping lists.
+const UpdateShoppingListItemChecked = `
+	UPDATE shopping_list_items
+	SET is_checked = ?
+	WHERE id = ?
+	  AND shopping_list_id = (SELECT id
+							  FROM shopping_lists
+							  WHERE id = ?
+								AND user_id = ?)`
+
 // UpdateUserSettingsCookbooksViewMode is the query to update the cookbooks_view column of a user's settings.
 const UpdateUserSettingsCookbooksViewMode = `
 	UPDATE user_settings
@@ -120,3 +173,12 @@ const UpdateVideo = `
 	UPDATE video_recipe
 	SET duration = ?
 	WHERE video = ?`
+
+// UpdateWebhookDelivery is the query to record the outcome of the latest delivery to a webhook.
+const UpdateWebhookDelivery = `
+	UPDATE webhooks
+	SET last_attempts     = ?,
+	    last_status_code  = ?,
+	    last_error        = ?,
+	    last_delivered_at = ?
+	WHERE id = ?`
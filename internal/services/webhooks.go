@@ -0,0 +1,200 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+)
+
+// These constants define the HTTP headers sent along every webhook payload.
+const (
+	WebhookHeaderDelivery  = "X-Recipya-Delivery"
+	WebhookHeaderEvent     = "X-Recipya-Event"
+	WebhookHeaderSignature = "X-Recipya-Signature"
+)
+
+// ErrWebhookAddressNotAllowed is the error for when a webhook targets a loopback, private or link-local address.
+// Only the administrator's webhooks may target such addresses.
+var ErrWebhookAddressNotAllowed = errors.New("the webhook's address is not public")
+
+type webhookRestrictedKey struct{}
+
+// GenerateWebhookSecret creates the secret used to sign the payloads sent to a webhook.
+func GenerateWebhookSecret() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return "whsec_" + hex.EncodeToString(b)
+}
+
+// IsPublicAddress verifies whether the IP address is reachable from the internet, i.e. it is
+// neither a loopback, private, link-local, multicast nor unspecified address.
+func IsPublicAddress(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified()
+}
+
+// NewWebhooksService creates a new Webhooks that satisfies the WebhooksService interface.
+// A payload is sent up to three times, doubling the wait between attempts.
+// The default client refuses to connect to addresses that are not public unless the webhook is the administrator's.
+func NewWebhooksService(client HTTPClient) *Webhooks {
+	if client == nil {
+		dialer := &net.Dialer{
+			Timeout: 10 * time.Second,
+			ControlContext: func(ctx context.Context, _, address string, _ syscall.RawConn) error {
+				if restricted, _ := ctx.Value(webhookRestrictedKey{}).(bool); !restricted {
+					return nil
+				}
+
+				host, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return err
+				}
+
+				if ip := net.ParseIP(host); ip == nil || !IsPublicAddress(ip) {
+					return ErrWebhookAddressNotAllowed
+				}
+				return nil
+			},
+		}
+
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.DialContext = dialer.DialContext
+		client = &http.Client{Timeout: 10 * time.Second, Transport: transport}
+	}
+
+	return &Webhooks{
+		Backoff:     2 * time.Second,
+		client:      client,
+		MaxAttempts: 3,
+	}
+}
+
+// Webhooks is the entity that delivers event payloads to webhooks.
+type Webhooks struct {
+	Backoff     time.Duration
+	client      HTTPClient
+	MaxAttempts int
+}
+
+// Deliver sends the payload to the webhook, signed with the webhook's secret.
+// Network errors, 429 and 5xx responses are retried.
+func (w *Webhooks) Deliver(webhook models.Webhook, payload models.WebhookPayload) models.WebhookDelivery {
+	delivery := models.WebhookDelivery{DeliveredAt: time.Now()}
+
+	err := w.Validate(webhook)
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+
+	signature := SignWebhookPayload(webhook.Secret, body)
+	wait := w.Backoff
+
+	for delivery.Attempts < max(w.MaxAttempts, 1) {
+		if delivery.Attempts > 0 {
+			time.Sleep(wait)
+			wait *= 2
+		}
+		delivery.Attempts++
+
+		delivery.StatusCode, err = w.send(webhook, body, signature, payload)
+		if err != nil {
+			delivery.Error = err.Error()
+			continue
+		}
+
+		delivery.Error = ""
+		if delivery.StatusCode != http.StatusTooManyRequests && delivery.StatusCode < http.StatusInternalServerError {
+			break
+		}
+	}
+
+	delivery.DeliveredAt = time.Now()
+	if delivery.Error == "" && !delivery.IsSuccess() {
+		delivery.Error = fmt.Sprintf("receiver responded with %d", delivery.StatusCode)
+	}
+	return delivery
+}
+
+func (w *Webhooks) send(webhook models.Webhook, body []byte, signature string, payload models.WebhookPayload) (int, error) {
+	ctx := context.WithValue(context.Background(), webhookRestrictedKey{}, !isAdminWebhook(webhook))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Recipya-Webhooks")
+	req.Header.Set(WebhookHeaderDelivery, payload.ID.String())
+	req.Header.Set(WebhookHeaderEvent, string(payload.Event))
+	req.Header.Set(WebhookHeaderSignature, "sha256="+signature)
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 1<<16))
+
+	return res.StatusCode, nil
+}
+
+// Validate verifies whether the webhook may be notified. The host of a webhook that is not the
+// administrator's is resolved and fails with ErrWebhookAddressNotAllowed when any of its addresses is not public.
+func (w *Webhooks) Validate(webhook models.Webhook) error {
+	u, err := url.Parse(webhook.URL)
+	if err != nil {
+		return err
+	}
+
+	if isAdminWebhook(webhook) {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil {
+		return err
+	}
+
+	for _, addr := range addrs {
+		if !IsPublicAddress(addr.IP) {
+			return ErrWebhookAddressNotAllowed
+		}
+	}
+	return nil
+}
+
+func isAdminWebhook(webhook models.Webhook) bool {
+	return webhook.UserID == 1
+}
+
+// SignWebhookPayload computes the hex-encoded HMAC-SHA256 of the body using the secret.
+// Receivers compare it against the X-Recipya-Signature header to verify the payload's authenticity.
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,165 @@
+package services_test
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/reaper47/recipya/internal/models"
+	"github.com/reaper47/recipya/internal/services"
+)
+
+func TestWebhooks_Deliver(t *testing.T) {
+	newService := func() *services.Webhooks {
+		w := services.NewWebhooksService(nil)
+		w.Backoff = time.Millisecond
+		return w
+	}
+
+	payload := models.NewWebhookPayload(models.WebhookEventRecipeCreated, 1, map[string]any{"recipeIDs": []int64{3}})
+
+	t.Run("payload is signed", func(t *testing.T) {
+		var (
+			gotBody    []byte
+			gotHeaders http.Header
+			gotPayload models.WebhookPayload
+		)
+
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotBody, _ = io.ReadAll(r.Body)
+			gotHeaders = r.Header.Clone()
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer ts.Close()
+
+		delivery := newService().Deliver(models.Webhook{Secret: "shh", URL: ts.URL, UserID: 1}, payload)
+
+		if !delivery.IsSuccess() || delivery.Attempts != 1 || delivery.StatusCode != http.StatusNoContent {
+			t.Fatalf("unexpected delivery %+v", delivery)
+		}
+		if got, want := gotHeaders.Get(services.WebhookHeaderSignature), "sha256="+services.SignWebhookPayload("shh", gotBody); got != want {
+			t.Fatalf("got signature %q but want %q", got, want)
+		}
+		if got := gotHeaders.Get(services.WebhookHeaderEvent); got != "recipe.created" {
+			t.Fatalf("got event header %q", got)
+		}
+		if got := gotHeaders.Get(services.WebhookHeaderDelivery); got != payload.ID.String() {
+			t.Fatalf("got delivery header %q but want %q", got, payload.ID)
+		}
+		if err := json.Unmarshal(gotBody, &gotPayload); err != nil {
+			t.Fatal(err)
+		}
+		if gotPayload.Event != models.WebhookEventRecipeCreated || gotPayload.UserID != 1 {
+			t.Fatalf("unexpected payload %+v", gotPayload)
+		}
+	})
+
+	t.Run("server errors are retried", func(t *testing.T) {
+		var hits atomic.Int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if hits.Add(1) < 3 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer ts.Close()
+
+		delivery := newService().Deliver(models.Webhook{URL: ts.URL, UserID: 1}, payload)
+
+		if !delivery.IsSuccess() || delivery.Attempts != 3 {
+			t.Fatalf("unexpected delivery %+v", delivery)
+		}
+	})
+
+	t.Run("gives up after the maximum number of attempts", func(t *testing.T) {
+		var hits atomic.Int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			hits.Add(1)
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer ts.Close()
+
+		delivery := newService().Deliver(models.Webhook{URL: ts.URL, UserID: 1}, payload)
+
+		if delivery.IsSuccess() || delivery.Attempts != 3 || hits.Load() != 3 || delivery.Error != "receiver responded with 429" {
+			t.Fatalf("unexpected delivery %+v after %d hits", delivery, hits.Load())
+		}
+	})
+
+	t.Run("client errors are not retried", func(t *testing.T) {
+		var hits atomic.Int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			hits.Add(1)
+			w.WriteHeader(http.StatusGone)
+		}))
+		defer ts.Close()
+
+		delivery := newService().Deliver(models.Webhook{URL: ts.URL, UserID: 1}, payload)
+
+		if delivery.IsSuccess() || delivery.Attempts != 1 || hits.Load() != 1 {
+			t.Fatalf("unexpected delivery %+v after %d hits", delivery, hits.Load())
+		}
+	})
+
+	t.Run("webhooks of other users may not target private addresses", func(t *testing.T) {
+		var hits atomic.Int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			hits.Add(1)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer ts.Close()
+
+		for _, u := range []string{ts.URL, strings.Replace(ts.URL, "127.0.0.1", "localhost", 1)} {
+			delivery := newService().Deliver(models.Webhook{URL: u, UserID: 2}, payload)
+
+			if delivery.IsSuccess() || delivery.Attempts != 0 || hits.Load() != 0 {
+				t.Fatalf("unexpected delivery %+v to %s after %d hits", delivery, u, hits.Load())
+			}
+		}
+	})
+
+	t.Run("unreachable receiver", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+		ts.Close()
+
+		delivery := newService().Deliver(models.Webhook{URL: ts.URL, UserID: 1}, payload)
+
+		if delivery.IsSuccess() || delivery.Attempts != 3 || delivery.Error == "" {
+			t.Fatalf("unexpected delivery %+v", delivery)
+		}
+	})
+}
+
+func TestIsPublicAddress(t *testing.T) {
+	testcases := []struct {
+		in   string
+		want bool
+	}{
+		{in: "93.184.216.34", want: true},
+		{in: "2606:2800:220:1:248:1893:25c8:1946", want: true},
+		{in: "127.0.0.1"},
+		{in: "::1"},
+		{in: "10.1.2.3"},
+		{in: "172.16.0.1"},
+		{in: "192.168.0.10"},
+		{in: "169.254.169.254"},
+		{in: "fe80::1"},
+		{in: "fd00::1"},
+		{in: "0.0.0.0"},
+		{in: "::ffff:127.0.0.1"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.in, func(t *testing.T) {
+			if got := services.IsPublicAddress(net.ParseIP(tc.in)); got != tc.want {
+				t.Fatalf("got %t but want %t", got, tc.want)
+			}
+		})
+	}
+}
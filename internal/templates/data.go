@@ -29,12 +29,20 @@ type Data struct {
 	About           AboutData
 	Admin           AdminData
 	CookbookFeature CookbookFeature
+	Comparison      models.VariantComparison
 	Functions       FunctionsData[int64]
+	History         models.RecipeHistory
+	MealPlan        models.MealPlanWeek
 	Pagination      Pagination
+	Pantry          models.Pantry
+	PantryMatches   map[int64]models.PantryMatch
 	Recipes         models.Recipes
 	Reports         ReportsData
 	Searchbar       SearchbarData
 	Settings        SettingsData
+	ShoppingList    models.ShoppingList
+	ShoppingLists   []models.ShoppingList
+	Timeline        models.Timeline
 	View            *ViewRecipeData
 }
 
@@ -86,6 +94,7 @@ func MakeCookbookView(c models.Cookbook, index int64, page uint64) CookbookView
 		PageItemID: index + 1,
 		Recipes:    c.Recipes,
 		Title:      c.Title,
+		UpdatedAt:  c.UpdatedAt,
 	}
 }
 
@@ -99,6 +108,7 @@ type CookbookView struct {
 	PageNumber    uint64
 	PageItemID    int64
 	Title         string
+	UpdatedAt     time.Time
 }
 
 // NewFunctionsData initializes a new FunctionsData.
@@ -143,10 +153,12 @@ type RegisterData struct {
 
 // SettingsData holds template data related to the user settings.
 type SettingsData struct {
+	APITokens          []models.APIToken
 	Backups            []Backup
 	Config             app.ConfigFile
 	MeasurementSystems []units.System
 	UserSettings       models.UserSettings
+	Webhooks           []models.Webhook
 }
 
 // Backup holds data related to backups.
@@ -200,8 +212,11 @@ type ViewRecipeData struct {
 	IsURL          bool
 	IsVideoExist   []bool
 	Keywords       []string
+	Notes          models.RecipeNotes // Notes are the private notes of the user, which are never loaded for shared recipes.
 	Recipe         *models.Recipe
+	Recipes        models.Recipes // Recipes are the user's other recipes the ingredients can refer to.
 	Share          ShareData
+	Variants       models.RecipeVariants // Variants are the relatives of the recipe, which are never loaded for shared recipes.
 }
 
 func newFormattedTimes(times models.Times) formattedTimes {
@@ -567,6 +567,12 @@ func (m Measurement) Convert(to Unit) (Measurement, error) {
 	return Measurement{Quantity: q, Unit: to}, nil
 }
 
+// ConvertSystem converts the measurement to the most readable unit of the System.
+// The measurement is unchanged when its unit is already of the System.
+func (m Measurement) ConvertSystem(to System) Measurement {
+	return convertMeasurement(m, to)
+}
+
 // Scale scales the measurement by the given multiplier.
 func (m Measurement) Scale(multiplier float64) Measurement {
 	q := m.Quantity * multiplier
@@ -89,3 +89,12 @@ func (u Unit) String() string {
 		return "invalid"
 	}
 }
+
+// NewUnit parses a unit from its name or abbreviation. It returns Invalid when the unit is unsupported.
+func NewUnit(s string) Unit {
+	m, err := NewMeasurement(0, s)
+	if err != nil {
+		return Invalid
+	}
+	return m.Unit
+}
@@ -44,3 +44,19 @@ func TestUnit_String(t *testing.T) {
 		})
 	}
 }
+
+func TestNewUnit(t *testing.T) {
+	for u := units.Celsius; u <= units.Yard; u++ {
+		t.Run(u.String(), func(t *testing.T) {
+			if got := units.NewUnit(u.String()); got != u {
+				t.Fatalf("got %v but want %v", got, u)
+			}
+		})
+	}
+
+	t.Run("unsupported unit", func(t *testing.T) {
+		if got := units.NewUnit("handful"); got != units.Invalid {
+			t.Fatalf("got %v but want %v", got, units.Invalid)
+		}
+	})
+}
@@ -80,6 +80,70 @@ func From(s string) time.Duration {
 	return dur
 }
 
+// Span is a duration written in a text, e.g. "25-30 minutes" in "Bake for 25-30 minutes".
+type Span struct {
+	Min  time.Duration
+	Max  time.Duration // Max is greater than Min when the text gives a range.
+	Text string
+}
+
+// FindSpans finds the durations written in a text, such as the instruction of a recipe.
+func FindSpans(s string) []Span {
+	matches := regex.Timer.FindAllStringSubmatch(s, -1)
+	spans := make([]Span, 0, len(matches))
+	for _, match := range matches {
+		unit := time.Second
+		if match[3] != "" {
+			unit = time.Hour
+		} else if strings.HasPrefix(strings.ToLower(match[5]), "m") {
+			unit = time.Minute
+		}
+
+		span := Span{
+			Min:  time.Duration(parseNumber(match[1]) * float64(unit)),
+			Text: match[0],
+		}
+
+		span.Max = span.Min
+		if match[2] != "" {
+			span.Max = max(span.Min, time.Duration(parseNumber(match[2])*float64(unit)))
+		}
+
+		if match[4] != "" {
+			minutes, _ := strconv.Atoi(match[4])
+			span.Min += time.Duration(minutes) * time.Minute
+			span.Max += time.Duration(minutes) * time.Minute
+		}
+
+		if span.Min > 0 {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+// parseNumber parses numbers such as 2, 1.5, 1/2 and 1 1/2.
+func parseNumber(s string) float64 {
+	var total float64
+	for _, part := range strings.Fields(s) {
+		num, den, isFraction := strings.Cut(part, "/")
+		n, err := strconv.ParseFloat(num, 64)
+		if err != nil {
+			return 0
+		}
+
+		if isFraction {
+			d, err := strconv.ParseFloat(den, 64)
+			if err != nil || d == 0 {
+				return 0
+			}
+			n /= d
+		}
+		total += n
+	}
+	return total
+}
+
 // Parse attempts to parse the given duration string into a *Duration
 // if parsing fails an error is returned instead
 func Parse(d string) (*Duration, error) {
@@ -156,6 +156,61 @@ func TestDuration_ToTimeDuration(t *testing.T) {
 	}
 }
 
+func TestFindSpans(t *testing.T) {
+	testcases := []struct {
+		name string
+		in   string
+		want []duration.Span
+	}{
+		{
+			name: "no duration",
+			in:   "Preheat the oven to 350 F",
+			want: []duration.Span{},
+		},
+		{
+			name: "minutes",
+			in:   "Let rest 10 minutes.",
+			want: []duration.Span{{Min: 10 * time.Minute, Max: 10 * time.Minute, Text: "10 minutes"}},
+		},
+		{
+			name: "range",
+			in:   "Bake for 25–30 minutes",
+			want: []duration.Span{{Min: 25 * time.Minute, Max: 30 * time.Minute, Text: "25–30 minutes"}},
+		},
+		{
+			name: "range with words",
+			in:   "Simmer 1 to 2 hrs",
+			want: []duration.Span{{Min: time.Hour, Max: 2 * time.Hour, Text: "1 to 2 hrs"}},
+		},
+		{
+			name: "fraction",
+			in:   "Let rise 1 1/2 hours",
+			want: []duration.Span{{Min: 90 * time.Minute, Max: 90 * time.Minute, Text: "1 1/2 hours"}},
+		},
+		{
+			name: "hours and minutes",
+			in:   "Roast 1 hour and 15 minutes, then rest 1h30min",
+			want: []duration.Span{
+				{Min: 75 * time.Minute, Max: 75 * time.Minute, Text: "1 hour and 15 minutes"},
+				{Min: 90 * time.Minute, Max: 90 * time.Minute, Text: "1h30min"},
+			},
+		},
+		{
+			name: "seconds",
+			in:   "Microwave 45 secs",
+			want: []duration.Span{{Min: 45 * time.Second, Max: 45 * time.Second, Text: "45 secs"}},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := duration.FindSpans(tc.in)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got %+v but want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestISO8601(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -41,6 +41,9 @@ var RangePattern = regexp.MustCompile(`(\d+(?:/\d+)?)\s*-\s*(\d+(?:/\d+)?)`)
 // Time matches time, such as 1h30min.
 var Time = regexp.MustCompile(`(?i)(\d+\s?h\s*)?(\d+\s?(?:m\b|min|minute|minutter|minuten|timer?)s?\b)|(\d+\s?h\s*)(\d+\s?mins?\b)?|(\d+\s?-\s?\d+\s*timer)`)
 
+// Timer matches a duration written in an instruction, such as 25-30 minutes, 1 1/2 hours or 1h30min.
+var Timer = regexp.MustCompile(`(?i)\b(\d+\s+\d+/\d+|\d+/\d+|\d+(?:\.\d+)?)(?:\s*(?:-|–|—|to|or)\s*(\d+\s+\d+/\d+|\d+/\d+|\d+(?:\.\d+)?))?\s*(?:(hours?|hrs?|h)(?:\s*(?:and\s*)?(\d+)\s*(?:minutes?|mins?|m)\b|\b)|(minutes?|mins?|seconds?|secs?)\b)`)
+
 // Unit matches a unit.
 var Unit = regexp.MustCompile(`(?i)((?:\d*\.?\d+\s*to\s*)?(?:\d*\s*\d+/)?(?:\d+-\d*/?)?\d*\.?\d+)-?\s*(centimeters?|centimetres?|cm\b|cups?|deciliters?|decilitres?|dl\b|feet|foot|ft\.?\b|′|fluid\s*ounces|fl\.?\s*oz\.*|fluid\s*oz\.?|gallons?|gals?\b|milliliters?|millilitres?|ml\b|millimeters?|millimetres?|mm\b|grams?|grammes?|\d*g\b|inches?|inch|in\b|["”]|kilograms?|kilogrammes?|kg|milligrams?|milligrammes?|mg\b|meters?|metres?|m\b|ounces?|oz\.?|pints?|fl\.?\s*pt\.?|pt\.?|pounds?|lbs?\.?\b|lb\.?\b|#|quarts?|fl\.?\s*qt\.?|qt\.?\b|liters?|litres?|l\b|tablespoons?|ss|tbsp\.?\w*|teaspoons?|ts\w?\.?|tsp\.?\w*|yards?|degrees?\s*celsius|degrees?\s*c|celsius|°?\s?c\b|degrees?\s*fahrenheit|degrees?\s*f|fahrenheit|°?\s?f\b)`)
 
@@ -274,6 +274,34 @@ func TestRegex_Time(t *testing.T) {
 	}
 }
 
+func TestRegex_Timer(t *testing.T) {
+	valid := []string{
+		"Bake for 25 minutes",
+		"bake 25-30 minutes",
+		"bake 25–30 mins",
+		"simmer 1 to 2 hours",
+		"let rest 1 1/2 hours",
+		"1h30min",
+		"1 hour and 30 minutes",
+		"microwave 45 secs",
+	}
+	assertRegex(t, valid, regex.Timer)
+
+	invalid := []string{
+		"2 minced garlic cloves",
+		"4 mindre",
+		"5 hot dogs",
+		"preheat the oven to 350 F",
+	}
+	for _, s := range invalid {
+		t.Run("regex is invalid "+s, func(t *testing.T) {
+			if regex.Timer.MatchString(s) {
+				t.Error("got true when want false")
+			}
+		})
+	}
+}
+
 func TestRegex_Units(t *testing.T) {
 	testcases := []struct {
 		name string
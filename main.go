@@ -3,6 +3,7 @@ package main
 
 import (
 	"github.com/reaper47/recipya/internal/app"
+	"github.com/reaper47/recipya/internal/commands"
 	"github.com/reaper47/recipya/internal/server"
 	"github.com/reaper47/recipya/internal/services"
 	"github.com/urfave/cli/v2"
@@ -24,6 +25,13 @@ func main() {
 					return nil
 				},
 			},
+			commands.Import(),
+			commands.Export(),
+			commands.Users(),
+			commands.Backup(),
+			commands.Scrape(),
+			commands.DB(),
+			commands.Doctor(),
 		},
 		Usage: "the ultimate recipes manager for you and your family",
 	}